@@ -17,23 +17,26 @@ limitations under the License.
 package app
 
 import (
+	"encoding/json"
 	"io"
 	"os"
+	"time"
 
 	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kind/pkg/cmd"
 	"sigs.k8s.io/kind/pkg/cmd/kind"
+	"sigs.k8s.io/kind/pkg/cmd/kind/history"
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
 	"sigs.k8s.io/kind/pkg/log"
 )
 
 // Main is the kind main(), it will invoke Run(), if an error is returned
-// it will then call os.Exit
+// it will then call os.Exit with the exit code for the error's category
 func Main() {
 	if err := Run(cmd.NewLogger(), cmd.StandardIOStreams(), os.Args[1:]); err != nil {
-		os.Exit(1)
+		os.Exit(errors.ExitCode(err))
 	}
 }
 
@@ -50,13 +53,59 @@ func Run(logger log.Logger, streams cmd.IOStreams, args []string) error {
 	// actually run the command
 	c := kind.NewCommand(logger, streams)
 	c.SetArgs(args)
-	if err := c.Execute(); err != nil {
+	start := time.Now()
+	executed, err := c.ExecuteC()
+	history.Record(logger, executed, start, err)
+	if err != nil {
 		logError(logger, err)
+		if checkErrorFormat(args) == "json" {
+			writeJSONError(streams.ErrOut, err)
+		}
 		return err
 	}
 	return nil
 }
 
+// checkErrorFormat returns the value of --error-format in args
+func checkErrorFormat(args []string) string {
+	flags := pflag.NewFlagSet("persistent-error-format", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	errorFormat := "text"
+	flags.StringVar(
+		&errorFormat,
+		"error-format",
+		"text",
+		"format of the final error on failure, one of: text, json",
+	)
+	// see checkQuiet
+	flags.Usage = func() {}
+	_ = flags.Parse(args)
+	return errorFormat
+}
+
+// jsonError is the machine readable representation of a failure
+type jsonError struct {
+	Code     errors.Category `json:"code"`
+	ExitCode int             `json:"exitCode"`
+	Phase    string          `json:"phase,omitempty"`
+	Node     string          `json:"node,omitempty"`
+	Message  string          `json:"message"`
+	Hint     string          `json:"hint,omitempty"`
+}
+
+// writeJSONError writes err and its details as a single line JSON object
+func writeJSONError(w io.Writer, err error) {
+	details := errors.DetailsOf(err)
+	_ = json.NewEncoder(w).Encode(jsonError{
+		Code:     details.Category,
+		ExitCode: details.Category.ExitCode(),
+		Phase:    details.Phase,
+		Node:     details.Node,
+		Message:  err.Error(),
+		Hint:     details.Hint,
+	})
+}
+
 // checkQuiet returns true if -q / --quiet was set in args
 func checkQuiet(args []string) bool {
 	flags := pflag.NewFlagSet("persistent-quiet", pflag.ContinueOnError)
@@ -17,9 +17,11 @@ limitations under the License.
 package app
 
 import (
+	"bytes"
 	"testing"
 
 	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
 )
 
 func TestCheckQuiet(t *testing.T) {
@@ -81,6 +83,51 @@ func TestCheckQuiet(t *testing.T) {
 	}
 }
 
+func TestCheckErrorFormat(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Args     []string
+		Expected string
+	}{
+		{Args: []string{"create", "cluster"}, Expected: "text"},
+		{Args: []string{"create", "cluster", "--error-format", "json"}, Expected: "json"},
+		{Args: []string{"--error-format=json", "--help"}, Expected: "json"},
+	}
+	for _, tc := range cases {
+		if result := checkErrorFormat(tc.Args); result != tc.Expected {
+			t.Errorf("checkErrorFormat(%v) = %q, expected %q", tc.Args, result, tc.Expected)
+		}
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	t.Parallel()
+	t.Run("with details", func(t *testing.T) {
+		t.Parallel()
+		err := errors.WithDetails(errors.New("failed to init node with kubeadm"), errors.Details{
+			Category: errors.KubeadmCategory,
+			Phase:    "kubeadm-init",
+			Node:     "kind-control-plane",
+			Hint:     "use --retain",
+		})
+		var buff bytes.Buffer
+		writeJSONError(&buff, err)
+		expected := `{"code":"KubeadmFailed","exitCode":5,"phase":"kubeadm-init","node":"kind-control-plane","message":"failed to init node with kubeadm","hint":"use --retain"}` + "\n"
+		if buff.String() != expected {
+			t.Errorf("expected %q but got %q", expected, buff.String())
+		}
+	})
+	t.Run("without details", func(t *testing.T) {
+		t.Parallel()
+		var buff bytes.Buffer
+		writeJSONError(&buff, errors.New("oops"))
+		expected := `{"code":"Unknown","exitCode":1,"message":"oops"}` + "\n"
+		if buff.String() != expected {
+			t.Errorf("expected %q but got %q", expected, buff.String())
+		}
+	})
+}
+
 func Test_CommandErrReturn(t *testing.T) {
 	t.Parallel()
 	cases := []struct {
@@ -72,10 +72,103 @@ func SetDefaultsCluster(obj *Cluster) {
 			obj.Networking.ServiceSubnet = "10.96.0.0/16,fd00:10:96::/112"
 		}
 	}
+	// default the cluster DNS domain using the kubeadm default
+	if obj.Networking.ClusterDomain == "" {
+		obj.Networking.ClusterDomain = "cluster.local"
+	}
+	// the CNI defaults to kindnet, and none is the same as disableDefaultCNI
+	if obj.Networking.CNI == "" {
+		obj.Networking.CNI = KindnetCNI
+		if obj.Networking.DisableDefaultCNI {
+			obj.Networking.CNI = NoCNI
+		}
+	}
+	if obj.Networking.CNI == NoCNI {
+		obj.Networking.DisableDefaultCNI = true
+	}
 	// default the KubeProxyMode using iptables as it's already the default
 	if obj.Networking.KubeProxyMode == "" {
 		obj.Networking.KubeProxyMode = IPTablesProxyMode
 	}
+	// default the local registry port to the port used by the local registry guide
+	if obj.LocalRegistry.Port == 0 {
+		obj.LocalRegistry.Port = 5001
+	}
+	// the dex provider run by kind has a static client and users with emails
+	if obj.OIDC.Provider == DexOIDCProvider {
+		if obj.OIDC.ClientID == "" {
+			obj.OIDC.ClientID = "kind"
+		}
+		if obj.OIDC.UsernameClaim == "" {
+			obj.OIDC.UsernameClaim = "email"
+		}
+	}
+	// phase timeouts default to the historical kind behavior
+	for _, t := range []struct {
+		value    *string
+		fallback string
+	}{
+		{&obj.Timeouts.ImagePull, "0s"},
+		{&obj.Timeouts.NodeProvision, "30s"},
+		{&obj.Timeouts.KubeadmInit, "0s"},
+		{&obj.Timeouts.Join, "0s"},
+		{&obj.Timeouts.CNIReady, "0s"},
+		{&obj.Timeouts.DevicePluginReady, "2m"},
+		{&obj.Timeouts.IngressReady, "3m"},
+		{&obj.Timeouts.ServiceLoadBalancerReady, "3m"},
+		{&obj.Timeouts.ControlPlaneEndpointReady, "1m"},
+	} {
+		if *t.value == "" {
+			*t.value = t.fallback
+		}
+	}
+
+	// default the StorageClass name to the historical kind default
+	if obj.StorageClass.Name == "" {
+		obj.StorageClass.Name = "standard"
+	}
+	// extra load balancer backends default to the same port on the control planes
+	for i := range obj.LoadBalancer.ExtraBackends {
+		b := &obj.LoadBalancer.ExtraBackends[i]
+		if b.BackendPort == 0 {
+			b.BackendPort = b.Port
+		}
+		if b.Role == "" {
+			b.Role = ControlPlaneRole
+		}
+	}
+	// load balancer tuning defaults to the historical kind haproxy config
+	if obj.LoadBalancer.Timeouts.Connect == "" {
+		obj.LoadBalancer.Timeouts.Connect = "5s"
+	}
+	if obj.LoadBalancer.Timeouts.Client == "" {
+		obj.LoadBalancer.Timeouts.Client = "50s"
+	}
+	if obj.LoadBalancer.Timeouts.Server == "" {
+		obj.LoadBalancer.Timeouts.Server = "50s"
+	}
+	if obj.LoadBalancer.MaxConnections == 0 {
+		obj.LoadBalancer.MaxConnections = 100000
+	}
+	if obj.LoadBalancer.HealthCheck.Interval == "" {
+		obj.LoadBalancer.HealthCheck.Interval = "2s"
+	}
+	if obj.LoadBalancer.HealthCheck.Rise == 0 {
+		obj.LoadBalancer.HealthCheck.Rise = 2
+	}
+	if obj.LoadBalancer.HealthCheck.Fall == 0 {
+		obj.LoadBalancer.HealthCheck.Fall = 3
+	}
+	if obj.LoadBalancer.SessionAffinity == "" {
+		obj.LoadBalancer.SessionAffinity = NoSessionAffinity
+	}
+	if obj.LoadBalancer.Algorithm == "" {
+		obj.LoadBalancer.Algorithm = RoundRobinAlgorithm
+	}
+	// kubeadm deploys 2 CoreDNS replicas
+	if obj.DNS.MinReplicas == 0 {
+		obj.DNS.MinReplicas = 2
+	}
 }
 
 // SetDefaultsNode sets uninitialized fields to their default value.
@@ -21,7 +21,9 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"strings"
 
+	"sigs.k8s.io/kind/pkg/build/nodeimage/internal/container/docker"
 	"sigs.k8s.io/kind/pkg/build/nodeimage/internal/kube"
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/internal/version"
@@ -45,17 +47,104 @@ func Build(options ...Option) error {
 		}
 	}
 
-	// verify that we're using a supported arch
-	if !supportedArch(ctx.arch) {
-		ctx.logger.Warnf("unsupported architecture %q", ctx.arch)
-	}
-
 	if ctx.buildType == "" {
 		ctx.buildType = detectBuildType(ctx.kubeParam)
 		if ctx.buildType != "" {
 			ctx.logger.V(0).Infof("Detected build type: %q", ctx.buildType)
 		}
 	}
+	if err := validateArches(ctx.buildType, ctx.arches); err != nil {
+		return err
+	}
+
+	// a single architecture is built as the image itself
+	if len(ctx.arches) <= 1 {
+		if len(ctx.arches) == 1 {
+			ctx.arch = ctx.arches[0]
+		}
+		if err := buildForArch(ctx); err != nil {
+			return err
+		}
+		if ctx.push {
+			ctx.logger.V(0).Infof("Pushing %s", ctx.image)
+			return errors.Wrap(docker.Push(ctx.image), "failed to push image")
+		}
+		return nil
+	}
+
+	// multiple architectures are each built as image-arch and then
+	// combined in a manifest list, which requires pushing them
+	images, err := archImages(ctx.image, ctx.arches)
+	if err != nil {
+		return err
+	}
+	for i, arch := range ctx.arches {
+		archCtx := *ctx
+		archCtx.arch = arch
+		archCtx.image = images[i]
+		if err := buildForArch(&archCtx); err != nil {
+			return err
+		}
+	}
+	if !ctx.push {
+		ctx.logger.V(0).Infof("Built %s, push these with a manifest list to use them as %s", strings.Join(images, ", "), ctx.image)
+		return nil
+	}
+	for _, image := range images {
+		ctx.logger.V(0).Infof("Pushing %s", image)
+		if err := docker.Push(image); err != nil {
+			return errors.Wrapf(err, "failed to push image %s", image)
+		}
+	}
+	ctx.logger.V(0).Infof("Pushing manifest list %s", ctx.image)
+	return errors.Wrap(docker.PushManifestList(ctx.image, images), "failed to push manifest list")
+}
+
+// validateArches checks that buildType can produce a node image for each of
+// arches, url and file builds provide binaries for a single arch only
+func validateArches(buildType string, arches []string) error {
+	if len(arches) > 1 && (buildType == "url" || buildType == "file") {
+		return errors.Errorf("the %s build type provides binaries for a single architecture and cannot build %s", buildType, strings.Join(arches, ", "))
+	}
+	return nil
+}
+
+// archImages returns the arch specific image for each of arches
+func archImages(image string, arches []string) ([]string, error) {
+	images := make([]string, 0, len(arches))
+	for _, arch := range arches {
+		archImage, err := archImage(image, arch)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, archImage)
+	}
+	return images, nil
+}
+
+// archImage returns the name:tag for the arch specific image of image
+func archImage(image, arch string) (string, error) {
+	if strings.Contains(image, "@") {
+		return "", errors.Errorf("image %q must not have a digest when building multiple architectures", image)
+	}
+	// the tag follows the last colon after the last slash, an earlier colon
+	// is a registry port
+	repository, tag := image, "latest"
+	if i := strings.LastIndexByte(image, ':'); i > strings.LastIndexByte(image, '/') {
+		repository, tag = image[:i], image[i+1:]
+	}
+	if repository == "" || tag == "" {
+		return "", errors.Errorf("unexpected image: %q", image)
+	}
+	return repository + ":" + tag + "-" + arch, nil
+}
+
+// buildForArch builds the node image for ctx.arch
+func buildForArch(ctx *buildContext) error {
+	// verify that we're using a supported arch
+	if !supportedArch(ctx.arch) {
+		ctx.logger.Warnf("unsupported architecture %q", ctx.arch)
+	}
 
 	if ctx.buildType == "url" {
 		ctx.logger.V(0).Infof("Building using URL: %q", ctx.kubeParam)
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeimage
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestArchImage(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		Image       string
+		Arch        string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:     "tagged image",
+			Image:    "kindest/node:v1.25.0",
+			Arch:     "arm64",
+			Expected: "kindest/node:v1.25.0-arm64",
+		},
+		{
+			Name:     "untagged image",
+			Image:    "kindest/node",
+			Arch:     "amd64",
+			Expected: "kindest/node:latest-amd64",
+		},
+		{
+			Name:     "registry with port",
+			Image:    "localhost:5000/node:v1",
+			Arch:     "arm64",
+			Expected: "localhost:5000/node:v1-arm64",
+		},
+		{
+			Name:     "untagged image in registry with port",
+			Image:    "localhost:5000/node",
+			Arch:     "arm64",
+			Expected: "localhost:5000/node:latest-arm64",
+		},
+		{
+			Name:        "digest",
+			Image:       "kindest/node:v1@sha256:28ef97b8686a0b5399129e9b763d5b7e5ff03576aa5580d6f4182a49c5fe1913",
+			Arch:        "amd64",
+			ExpectError: true,
+		},
+		{
+			Name:        "empty tag",
+			Image:       "kindest/node:",
+			Arch:        "amd64",
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			image, err := archImage(tc.Image, tc.Arch)
+			assert.ExpectError(t, tc.ExpectError, err)
+			assert.StringEqual(t, tc.Expected, image)
+		})
+	}
+}
+
+func TestArchImages(t *testing.T) {
+	t.Parallel()
+	images, err := archImages("kindest/node:v1.25.0", []string{"amd64", "arm64"})
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []string{"kindest/node:v1.25.0-amd64", "kindest/node:v1.25.0-arm64"}, images)
+
+	_, err = archImages("kindest/node@sha256:28ef97b8686a0b5399129e9b763d5b7e5ff03576aa5580d6f4182a49c5fe1913", []string{"amd64", "arm64"})
+	assert.ExpectError(t, true, err)
+}
+
+func TestValidateArches(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		BuildType   string
+		Arches      []string
+		ExpectError bool
+	}{
+		{
+			Name:      "source with multiple arches",
+			BuildType: "source",
+			Arches:    []string{"amd64", "arm64"},
+		},
+		{
+			Name:      "release with multiple arches",
+			BuildType: "release",
+			Arches:    []string{"amd64", "arm64"},
+		},
+		{
+			Name:      "url with a single arch",
+			BuildType: "url",
+			Arches:    []string{"arm64"},
+		},
+		{
+			Name:      "file without arches",
+			BuildType: "file",
+		},
+		{
+			Name:        "url with multiple arches",
+			BuildType:   "url",
+			Arches:      []string{"amd64", "arm64"},
+			ExpectError: true,
+		},
+		{
+			Name:        "file with multiple arches",
+			BuildType:   "file",
+			Arches:      []string{"amd64", "arm64"},
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.ExpectError(t, tc.ExpectError, validateArches(tc.BuildType, tc.Arches))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeimage
+
+import (
+	"os"
+	"regexp"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// BuildConfig is the node image build config file, it customizes the
+// node image beyond the Kubernetes artifacts
+//
+// In yaml this looks like:
+//
+//	images:
+//	- registry.k8s.io/e2e-test-images/agnhost:2.39
+//	packages:
+//	- nfs-common
+type BuildConfig struct {
+	// Images are extra images to pre-pull into the node image, so clusters
+	// can use them without pulling
+	Images []string `json:"images,omitempty"`
+	// Packages are extra debian packages to install in the node image
+	Packages []string `json:"packages,omitempty"`
+}
+
+// validPackageRE matches debian package names, with an optional =version
+var validPackageRE = regexp.MustCompile(`^[a-z0-9][a-z0-9+.-]+(=[A-Za-z0-9.+~:-]+)?$`)
+
+// LoadBuildConfig reads and validates the build config file at path
+func LoadBuildConfig(path string) (*BuildConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read build config")
+	}
+	cfg := &BuildConfig{}
+	if err := yaml.UnmarshalStrict(raw, cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse build config %s", path)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid build config %s", path)
+	}
+	return cfg, nil
+}
+
+// Validate returns an error for each problem with the build config
+func (c *BuildConfig) Validate() error {
+	errs := []error{}
+	for _, image := range c.Images {
+		if image == "" {
+			errs = append(errs, errors.New("images must not be empty"))
+		}
+	}
+	for _, pkg := range c.Packages {
+		if !validPackageRE.MatchString(pkg) {
+			errs = append(errs, errors.Errorf("invalid package: %q", pkg))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewAggregate(errs)
+	}
+	return nil
+}
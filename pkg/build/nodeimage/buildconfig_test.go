@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeimage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestLoadBuildConfig(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		Contents    string
+		Expected    *BuildConfig
+		ExpectError bool
+	}{
+		{
+			Name: "images and packages",
+			Contents: `images:
+- registry.k8s.io/e2e-test-images/agnhost:2.39
+packages:
+- nfs-common
+- open-iscsi=2.1.8-1
+`,
+			Expected: &BuildConfig{
+				Images:   []string{"registry.k8s.io/e2e-test-images/agnhost:2.39"},
+				Packages: []string{"nfs-common", "open-iscsi=2.1.8-1"},
+			},
+		},
+		{
+			Name:     "empty",
+			Contents: "",
+			Expected: &BuildConfig{},
+		},
+		{
+			Name:        "unknown field",
+			Contents:    "image:\n- registry.k8s.io/pause:3.9\n",
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid package",
+			Contents:    "packages:\n- nfs-common; rm -rf /\n",
+			ExpectError: true,
+		},
+		{
+			Name:        "empty image",
+			Contents:    "images:\n- \"\"\n",
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "build-config.yaml")
+			if err := os.WriteFile(path, []byte(tc.Contents), 0600); err != nil {
+				t.Fatalf("failed to write build config: %v", err)
+			}
+			cfg, err := LoadBuildConfig(path)
+			assert.ExpectError(t, tc.ExpectError, err)
+			if err == nil {
+				assert.DeepEqual(t, tc.Expected, cfg)
+			}
+		})
+	}
+
+	_, err := LoadBuildConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ExpectError(t, true, err)
+}
+
+func TestBuildConfigValidate(t *testing.T) {
+	t.Parallel()
+	cfg := &BuildConfig{
+		Images:   []string{"", "registry.k8s.io/pause:3.9", ""},
+		Packages: []string{"nfs-common", "Bad"},
+	}
+	err := cfg.Validate()
+	assert.ExpectError(t, true, err)
+	// one error per empty image and per invalid package
+	if errs := errors.Errors(err); len(errs) != 3 {
+		t.Errorf("expected 3 errors, got %v", errs)
+	}
+	assert.ExpectError(t, false, (&BuildConfig{}).Validate())
+}
+
+func TestValidPackageRE(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Package  string
+		Expected bool
+	}{
+		{Package: "nfs-common", Expected: true},
+		{Package: "libstdc++6", Expected: true},
+		{Package: "python3.11", Expected: true},
+		{Package: "open-iscsi=2.1.8-1", Expected: true},
+		{Package: "tzdata=2024a-0+deb12u1", Expected: true},
+		{Package: "systemd=1:252.22-1~deb12u1", Expected: true},
+		{Package: "a", Expected: false},
+		{Package: "Upper", Expected: false},
+		{Package: "-flag", Expected: false},
+		{Package: "nfs-common=", Expected: false},
+		{Package: "nfs-common nfs-kernel-server", Expected: false},
+		{Package: "$(id)", Expected: false},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Package, func(t *testing.T) {
+			t.Parallel()
+			assert.BoolEqual(t, tc.Expected, validPackageRE.MatchString(tc.Package))
+		})
+	}
+}
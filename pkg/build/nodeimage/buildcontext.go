@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"sigs.k8s.io/kind/pkg/cluster/constants"
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
 	"sigs.k8s.io/kind/pkg/log"
@@ -53,6 +54,12 @@ type buildContext struct {
 	arch      string
 	buildType string
 	kubeParam string
+	// extraImages and extraPackages are from the build config
+	extraImages   []string
+	extraPackages []string
+	// arches and push are only used by Build, which builds for each arch
+	arches []string
+	push   bool
 	// non-option fields
 	builder kube.Builder
 }
@@ -113,6 +120,12 @@ func (c *buildContext) buildImage(bits kube.Bits) error {
 		}
 	}
 
+	// install extra packages from the build config
+	if err := c.installPackages(cmder); err != nil {
+		c.logger.Errorf("Image build Failed! Failed to install packages: %v", err)
+		return err
+	}
+
 	// write version
 	// TODO: support grabbing version from a binary instead?
 	// This may or may not be a good idea ...
@@ -127,10 +140,16 @@ func (c *buildContext) buildImage(bits kube.Bits) error {
 
 	// pre-pull images that were not part of the build and write CNI / storage
 	// manifests
-	if _, err = c.prePullImagesAndWriteManifests(bits, parsedVersion, containerID); err != nil {
+	preloadedImages, err := c.prePullImagesAndWriteManifests(bits, parsedVersion, containerID)
+	if err != nil {
 		c.logger.Errorf("Image build Failed! Failed to pull Images: %v", err)
 		return err
 	}
+	// record the preloaded images, e.g. for kind prune images to keep them
+	if err := createFile(cmder, constants.NodeImageManifestPath, strings.Join(preloadedImages, "\n")+"\n"); err != nil {
+		c.logger.Errorf("Image build Failed! Failed to write the image manifest: %v", err)
+		return err
+	}
 
 	// Save the image changes to a new image
 	if err = exec.Command(
@@ -252,6 +271,10 @@ func (c *buildContext) prePullImagesAndWriteManifests(bits kube.Bits, parsedVers
 	// all builds should install the default storage driver images currently
 	requiredImages = append(requiredImages, defaultStorageImages...)
 
+	// extra images from the build config, these must be pulled
+	extraImages := sets.NewString(c.extraImages...)
+	requiredImages = append(requiredImages, c.extraImages...)
+
 	// setup image importer
 	importer := newContainerdImporter(cmder)
 	if err := importer.Prepare(); err != nil {
@@ -271,7 +294,10 @@ func (c *buildContext) prePullImagesAndWriteManifests(bits kube.Bits, parsedVers
 		image := image // https://golang.org/doc/faq#closures_and_goroutines
 		fns = append(fns, func() error {
 			if !builtImages.Has(image) {
-				if err = importer.Pull(image, dockerBuildOsAndArch(c.arch)); err != nil {
+				if err := importer.Pull(image, dockerBuildOsAndArch(c.arch)); err != nil {
+					if extraImages.Has(image) {
+						return errors.Wrapf(err, "failed to pull extra image %s", image)
+					}
 					c.logger.Warnf("Failed to pull %s with error: %v", image, err)
 					runE := exec.RunErrorForError(err)
 					c.logger.Warn(string(runE.Output))
@@ -325,6 +351,20 @@ func (c *buildContext) prePullImagesAndWriteManifests(bits kube.Bits, parsedVers
 	return importer.ListImported()
 }
 
+// installPackages installs the extra packages in the build container,
+// without keeping the package lists in the image
+func (c *buildContext) installPackages(cmder exec.Cmder) error {
+	if len(c.extraPackages) == 0 {
+		return nil
+	}
+	c.logger.V(0).Infof("Installing packages: %s", strings.Join(c.extraPackages, " "))
+	args := []string{"-c",
+		`apt-get update && DEBIAN_FRONTEND=noninteractive apt-get install -y --no-install-recommends "$@" && apt-get clean && rm -rf /var/lib/apt/lists/*`,
+		"--",
+	}
+	return cmder.Command("bash", append(args, c.extraPackages...)...).SetStdout(os.Stdout).SetStderr(os.Stderr).Run()
+}
+
 func (c *buildContext) createBuildContainer() (id string, err error) {
 	// attempt to explicitly pull the image if it doesn't exist locally
 	// errors here are non-critical; we'll proceed with execution, which includes a pull operation
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// Push pushes image to its registry, as in `docker push`
+func Push(image string) error {
+	return exec.Command("docker", "push", image).Run()
+}
+
+// PushManifestList creates and pushes a manifest list named image from the
+// already pushed images, as in `docker manifest create` and `docker manifest push`
+func PushManifestList(image string, images []string) error {
+	args := append([]string{"manifest", "create", "--amend", image}, images...)
+	if err := exec.Command("docker", args...).Run(); err != nil {
+		return err
+	}
+	return exec.Command("docker", "manifest", "push", "--purge", image).Run()
+}
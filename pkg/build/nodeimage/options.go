@@ -17,6 +17,9 @@ limitations under the License.
 package nodeimage
 
 import (
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/log"
 )
 
@@ -73,7 +76,43 @@ func WithArch(arch string) Option {
 	})
 }
 
-// WithArch sets the architecture to build for
+// WithArches sets multiple architectures to build for, each is tagged as
+// the image tag with an -arch suffix, e.g. kindest/node:latest-arm64
+func WithArches(arches []string) Option {
+	return optionAdapter(func(b *buildContext) error {
+		for _, arch := range arches {
+			if arch == "" {
+				return errors.Errorf("invalid architectures %q, they must not be empty", strings.Join(arches, ","))
+			}
+		}
+		b.arches = arches
+		return nil
+	})
+}
+
+// WithPush configures a build to push the built image, and with multiple
+// architectures a manifest list for them named as the image
+func WithPush(push bool) Option {
+	return optionAdapter(func(b *buildContext) error {
+		b.push = push
+		return nil
+	})
+}
+
+// WithBuildConfig customizes the image with extra images and packages from
+// cfg, in addition to any from previous options
+func WithBuildConfig(cfg *BuildConfig) Option {
+	return optionAdapter(func(b *buildContext) error {
+		if err := cfg.Validate(); err != nil {
+			return err
+		}
+		b.extraImages = append(b.extraImages, cfg.Images...)
+		b.extraPackages = append(b.extraPackages, cfg.Packages...)
+		return nil
+	})
+}
+
+// WithBuildType sets the type of build, one of url, file, release or source
 func WithBuildType(buildType string) Option {
 	return optionAdapter(func(b *buildContext) error {
 		if buildType != "" {
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/apply"
+	internalcreate "sigs.k8s.io/kind/pkg/cluster/internal/create"
+	internalencoding "sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/cli"
+)
+
+// NodeChange is a change to a node for reconciling a cluster with its config
+type NodeChange struct {
+	Node string
+	// Action is one of add, recreate or remove
+	Action string
+	Reason string
+}
+
+// String implements fmt.Stringer
+func (c NodeChange) String() string {
+	return apply.Change{Node: c.Node, Action: apply.Action(c.Action), Reason: c.Reason}.String()
+}
+
+// PlanApply returns the changes Apply would make, without making them
+func (p *Provider) PlanApply(name string, rawConfig []byte, options ...CreateOption) ([]NodeChange, error) {
+	cfg, err := applyConfig(name, rawConfig, options)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := apply.Plan(p.provider, cfg)
+	return nodeChanges(changes), err
+}
+
+// Apply reconciles an existing cluster with the config rawConfig: worker
+// nodes are added, removed, or recreated if their config changed
+// Changes to control-plane nodes or cluster-wide settings are an error, as
+// these require recreating the cluster
+// If name is empty the name in the config is used
+// If wait is positive Apply waits up to wait for new nodes to be Ready
+// options are the Create options the cluster was created with, such as
+// CreateWithNodeImage, the config is resolved the same way as by Create
+func (p *Provider) Apply(name string, rawConfig []byte, wait time.Duration, options ...CreateOption) ([]NodeChange, error) {
+	cfg, err := applyConfig(name, rawConfig, options)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := apply.Apply(p.logger, cli.StatusForLogger(p.logger), p.provider, cfg, wait)
+	return nodeChanges(changes), err
+}
+
+func applyConfig(name string, rawConfig []byte, options []CreateOption) (*config.Cluster, error) {
+	cfg, err := internalencoding.Parse(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+	opts := &internalcreate.ClusterOptions{
+		NameOverride: name,
+	}
+	for _, o := range options {
+		if err := o.apply(opts); err != nil {
+			return nil, err
+		}
+	}
+	// the config file always wins over config options
+	opts.Config = cfg
+	if err := internalcreate.FixupOptions(opts); err != nil {
+		return nil, err
+	}
+	if err := opts.Config.Validate(); err != nil {
+		return nil, err
+	}
+	return opts.Config, nil
+}
+
+func nodeChanges(changes []apply.Change) []NodeChange {
+	ret := make([]NodeChange, 0, len(changes))
+	for _, c := range changes {
+		ret = append(ret, NodeChange{Node: c.Node, Action: string(c.Action), Reason: c.Reason})
+	}
+	return ret
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+)
+
+// CacheRegistries returns the registries a pull-through cache can be started for
+func CacheRegistries() []string {
+	return common.CacheRegistries()
+}
+
+// StartCaches ensures a pull-through cache container is running for each of
+// registries, or for all of CacheRegistries if none are given
+// Clusters created while a cache is running pull the registry's images
+// through it, unless they configure registryMirrors for the registry
+func (p *Provider) StartCaches(registries ...string) error {
+	if len(registries) == 0 {
+		registries = CacheRegistries()
+	}
+	return p.provider.EnsureCaches(registries)
+}
+
+// ListCaches returns the registries with a running pull-through cache,
+// mapped to the endpoint nodes reach the cache at
+func (p *Provider) ListCaches() (map[string]string, error) {
+	return p.provider.ListCaches()
+}
+
+// StopCaches deletes all pull-through cache containers and their contents
+// Existing clusters using the caches fall back to pulling from the registries
+func (p *Provider) StopCaches() error {
+	return p.provider.DeleteCaches()
+}
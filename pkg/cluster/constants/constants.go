@@ -20,6 +20,10 @@ package constants
 // DefaultClusterName is the default cluster Context name
 const DefaultClusterName = "kind"
 
+// LocalRegistryName is the name of the local registry container, it is
+// shared by all clusters with localRegistry enabled
+const LocalRegistryName = "kind-registry"
+
 /* node role value constants */
 const (
 	// ControlPlaneNodeRoleValue identifies a node that hosts a Kubernetes
@@ -38,6 +42,13 @@ const (
 	// kubernetes nodes
 	ExternalLoadBalancerNodeRoleValue string = "external-load-balancer"
 
+	// APIServerSocketRelayNodeRoleValue identifies a node that relays a host
+	// unix socket to the API server, see networking.apiServerSocket.
+	//
+	// Please note that `kind` nodes hosting the socket relay are not
+	// kubernetes nodes
+	APIServerSocketRelayNodeRoleValue string = "api-server-socket-relay"
+
 	// ExternalEtcdNodeRoleValue identifies a node that hosts an external-etcd
 	// instance.
 	//
@@ -47,3 +58,68 @@ const (
 	// kubernetes nodes
 	ExternalEtcdNodeRoleValue string = "external-etcd"
 )
+
+/* node container label key constants, stable for tools inspecting containers */
+const (
+	// ClusterLabelKey is the label on every node container, valued with the
+	// name of the cluster it belongs to
+	ClusterLabelKey = "io.x-k8s.kind.cluster"
+
+	// NodeRoleLabelKey is the label on every node container, valued with
+	// one of the node role values above
+	NodeRoleLabelKey = "io.x-k8s.kind.role"
+
+	// NodeImageLabelKey records the image reference a node was created from
+	NodeImageLabelKey = "io.x-k8s.kind.image"
+
+	// NodeImageDigestLabelKey records the exact digest of the image a node
+	// was created from
+	NodeImageDigestLabelKey = "io.x-k8s.kind.image-digest"
+
+	// NodeNetworkLabelKey records the primary network of a node container
+	NodeNetworkLabelKey = "io.x-k8s.kind.network"
+
+	// NodeNetworkSubnetsLabelKey records the comma separated subnets of the
+	// primary network when the node was created
+	NodeNetworkSubnetsLabelKey = "io.x-k8s.kind.network-subnets"
+
+	// ClusterConfigHashLabelKey records the hash of the cluster-wide config
+	// on all node containers
+	ClusterConfigHashLabelKey = "io.x-k8s.kind.cluster-config"
+
+	// NodeConfigHashLabelKey records the hash of a node's config on its container
+	NodeConfigHashLabelKey = "io.x-k8s.kind.node-config"
+)
+
+/* Kubernetes Node object label key constants, set by kubelet when it registers */
+const (
+	// VersionNodeLabelKey is valued with the version of kind that created the node
+	VersionNodeLabelKey = "kind.x-k8s.io/version"
+
+	// RoleNodeLabelKey is valued with the node's kind role
+	RoleNodeLabelKey = "kind.x-k8s.io/role"
+)
+
+/* Kubernetes Node object annotation key constants, set by kind after the node joins */
+const (
+	// ImageDigestNodeAnnotationKey is valued with the node image digest
+	ImageDigestNodeAnnotationKey = "kind.x-k8s.io/image-digest"
+)
+
+/* port constants */
+const (
+	// APIServerInternalPort is the port the API server listens on inside the
+	// node network, the external load balancer listens on the same port
+	APIServerInternalPort = 6443
+
+	// LocalRegistryPort is the port the local registry listens on inside the
+	// node network
+	LocalRegistryPort = 5000
+)
+
+/* node image path constants */
+const (
+	// NodeImageManifestPath lists the images preloaded in the node image, one
+	// per line, node images built by older versions of kind do not have it
+	NodeImageManifestPath = "/kind/images/manifest"
+)
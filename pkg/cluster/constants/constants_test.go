@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constants
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+// these values are read by tools inspecting kind clusters, changing one
+// breaks them and must be deliberate
+func TestStableValues(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name     string
+		Value    string
+		Expected string
+	}{
+		{Name: "ClusterLabelKey", Value: ClusterLabelKey, Expected: "io.x-k8s.kind.cluster"},
+		{Name: "NodeRoleLabelKey", Value: NodeRoleLabelKey, Expected: "io.x-k8s.kind.role"},
+		{Name: "NodeImageLabelKey", Value: NodeImageLabelKey, Expected: "io.x-k8s.kind.image"},
+		{Name: "NodeImageDigestLabelKey", Value: NodeImageDigestLabelKey, Expected: "io.x-k8s.kind.image-digest"},
+		{Name: "NodeNetworkLabelKey", Value: NodeNetworkLabelKey, Expected: "io.x-k8s.kind.network"},
+		{Name: "NodeNetworkSubnetsLabelKey", Value: NodeNetworkSubnetsLabelKey, Expected: "io.x-k8s.kind.network-subnets"},
+		{Name: "ClusterConfigHashLabelKey", Value: ClusterConfigHashLabelKey, Expected: "io.x-k8s.kind.cluster-config"},
+		{Name: "NodeConfigHashLabelKey", Value: NodeConfigHashLabelKey, Expected: "io.x-k8s.kind.node-config"},
+		{Name: "VersionNodeLabelKey", Value: VersionNodeLabelKey, Expected: "kind.x-k8s.io/version"},
+		{Name: "RoleNodeLabelKey", Value: RoleNodeLabelKey, Expected: "kind.x-k8s.io/role"},
+		{Name: "ImageDigestNodeAnnotationKey", Value: ImageDigestNodeAnnotationKey, Expected: "kind.x-k8s.io/image-digest"},
+		{Name: "NodeImageManifestPath", Value: NodeImageManifestPath, Expected: "/kind/images/manifest"},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.StringEqual(t, tc.Expected, tc.Value)
+		})
+	}
+	if APIServerInternalPort != 6443 {
+		t.Errorf("expected APIServerInternalPort 6443, got %d", APIServerInternalPort)
+	}
+	if LocalRegistryPort != 5000 {
+		t.Errorf("expected LocalRegistryPort 5000, got %d", LocalRegistryPort)
+	}
+}
+
+func TestContainerLabelKeysUnique(t *testing.T) {
+	t.Parallel()
+	seen := map[string]bool{}
+	for _, key := range []string{
+		ClusterLabelKey, NodeRoleLabelKey, NodeImageLabelKey, NodeImageDigestLabelKey,
+		NodeNetworkLabelKey, NodeNetworkSubnetsLabelKey, ClusterConfigHashLabelKey, NodeConfigHashLabelKey,
+	} {
+		if !strings.HasPrefix(key, "io.x-k8s.kind.") {
+			t.Errorf("container label key %q does not start with io.x-k8s.kind.", key)
+		}
+		if seen[key] {
+			t.Errorf("duplicate container label key %q", key)
+		}
+		seen[key] = true
+	}
+}
@@ -70,6 +70,50 @@ func CreateWithNodeImage(nodeImage string) CreateOption {
 	})
 }
 
+// CreateWithLabels records labels on the cluster, which may later be used to
+// select clusters with ListWithSelector
+func CreateWithLabels(labels map[string]string) CreateOption {
+	return createOptionAdapter(func(o *internalcreate.ClusterOptions) error {
+		o.Labels = labels
+		return nil
+	})
+}
+
+// CreateWithAnnotations records annotations on the cluster
+func CreateWithAnnotations(annotations map[string]string) CreateOption {
+	return createOptionAdapter(func(o *internalcreate.ClusterOptions) error {
+		o.Annotations = annotations
+		return nil
+	})
+}
+
+// CreateWithAllowMixedNodeImages allows the nodes to run images with
+// different digests, which is otherwise refused
+func CreateWithAllowMixedNodeImages(allow bool) CreateOption {
+	return createOptionAdapter(func(o *internalcreate.ClusterOptions) error {
+		o.AllowMixedNodeImages = allow
+		return nil
+	})
+}
+
+// CreateWithImageBundle loads the images in the archive at path into every
+// node before starting Kubernetes, overriding imageBundle in the config
+func CreateWithImageBundle(path string) CreateOption {
+	return createOptionAdapter(func(o *internalcreate.ClusterOptions) error {
+		o.ImageBundle = path
+		return nil
+	})
+}
+
+// CreateWithIngress installs the ingress controller, e.g. "nginx", serving
+// on ports 80 and 443 of the host, overriding ingress.controller in the config
+func CreateWithIngress(controller string) CreateOption {
+	return createOptionAdapter(func(o *internalcreate.ClusterOptions) error {
+		o.Ingress = controller
+		return nil
+	})
+}
+
 // CreateWithRetain disables deletion of nodes and any other cleanup
 // that would normally occur after a failure to create
 // This is mainly used for debugging purposes
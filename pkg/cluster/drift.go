@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+)
+
+// NodeImageDrift compares the image a node was created from with the image
+// the same reference resolves to now
+type NodeImageDrift struct {
+	// Node is the node name
+	Node string
+	// Image is the image reference the node was created from
+	Image string
+	// Digest is the digest of the image the node was created from
+	Digest string
+	// Current is the digest the image reference resolves to now
+	Current string
+	// Drifted is true if Current differs from Digest
+	Drifted bool
+}
+
+// CheckImageDrift reports, for each Kubernetes node in the cluster, whether its
+// image reference (e.g. kindest/node:v1.29.0) now resolves to a different image
+// This pulls the latest image for each reference not pinned by digest.
+// Nodes created by versions of kind not recording their image are omitted.
+func (p *Provider) CheckImageDrift(name string) ([]NodeImageDrift, error) {
+	name = defaultName(name)
+	n, err := p.ListInternalNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("unknown cluster %q", name)
+	}
+	current := map[string]string{}
+	ret := []NodeImageDrift{}
+	for _, node := range n {
+		containerLabels, err := p.provider.GetNodeLabels(node)
+		if err != nil {
+			return nil, err
+		}
+		drift := NodeImageDrift{
+			Node:   node.String(),
+			Image:  containerLabels[common.NodeImageLabelKey],
+			Digest: containerLabels[common.NodeImageDigestLabelKey],
+		}
+		if drift.Image == "" || drift.Digest == "" {
+			continue
+		}
+		if _, resolved := current[drift.Image]; !resolved {
+			// references pinned by digest cannot drift
+			if strings.Contains(drift.Image, "@sha256:") {
+				current[drift.Image] = drift.Digest
+			} else {
+				p.logger.V(1).Infof("Resolving image %s ...", drift.Image)
+				digest, err := p.provider.PullImageDigest(drift.Image)
+				if err != nil {
+					return nil, err
+				}
+				current[drift.Image] = digest
+			}
+		}
+		drift.Current = current[drift.Image]
+		drift.Drifted = drift.Current != drift.Digest
+		ret = append(ret, drift)
+	}
+	return ret, nil
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sigs.k8s.io/kind/pkg/cluster/internal/diagnostics"
+)
+
+// DefaultKubeletLines is the default number of kubelet journal lines Events
+// reports for each node
+const DefaultKubeletLines = diagnostics.DefaultKubeletLines
+
+// Events returns a report of the cluster's events, its pods that are not
+// running and the last kubeletLines lines of the kubelet journal of each node,
+// the same report kind logs when creating a cluster fails after kubeadm init
+func (p *Provider) Events(name string, kubeletLines int) (string, error) {
+	n, err := p.clusterNodes(name)
+	if err != nil {
+		return "", err
+	}
+	return diagnostics.Collect(n, kubeletLines), nil
+}
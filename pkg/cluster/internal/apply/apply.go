@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apply implements reconciling an existing cluster with a config
+package apply
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/coredns"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/loadbalancer"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+	"sigs.k8s.io/kind/pkg/cluster/internal/scale"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/cli"
+)
+
+// Action is the kind of change to a node
+type Action string
+
+const (
+	// AddAction creates and joins a node
+	AddAction Action = "add"
+	// RecreateAction replaces a node whose config changed
+	RecreateAction Action = "recreate"
+	// RemoveAction drains and deletes a node
+	RemoveAction Action = "remove"
+)
+
+// Change is a change to a node needed to reconcile the cluster with a config
+type Change struct {
+	Node   string
+	Action Action
+	Reason string
+}
+
+// String implements fmt.Stringer
+func (c Change) String() string {
+	if c.Reason == "" {
+		return fmt.Sprintf("%s %s", c.Action, c.Node)
+	}
+	return fmt.Sprintf("%s %s: %s", c.Action, c.Node, c.Reason)
+}
+
+// observedNode is the recorded state of an existing node
+type observedNode struct {
+	node nodes.Node
+	role string
+	// hash is the recorded config hash, if any
+	hash string
+}
+
+// Plan computes the changes to reconcile the cluster cfg.Name with cfg
+func Plan(p providers.Provider, cfg *config.Cluster) ([]Change, error) {
+	_, changes, err := plan(p, cfg)
+	return changes, err
+}
+
+// Apply reconciles the cluster cfg.Name with cfg and returns the changes made
+// If wait is positive Apply waits up to wait for new nodes to be Ready
+func Apply(logger log.Logger, status *cli.Status, p providers.Provider, cfg *config.Cluster, wait time.Duration) ([]Change, error) {
+	observed, changes, err := plan(p, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	allNodes, err := p.ListNodes(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+	controlPlane, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	// remove nodes first so recreated nodes are provisioned again
+	removed := map[string]bool{}
+	toRemove := []nodes.Node{}
+	adding := false
+	for _, c := range changes {
+		if c.Action == RemoveAction || c.Action == RecreateAction {
+			removed[c.Node] = true
+			toRemove = append(toRemove, observed[c.Node].node)
+		}
+		if c.Action == AddAction || c.Action == RecreateAction {
+			adding = true
+		}
+	}
+	if len(toRemove) > 0 {
+		if err := scale.RemoveNodes(status, p, controlPlane, toRemove); err != nil {
+			return nil, err
+		}
+	}
+	if adding {
+		remaining := []nodes.Node{}
+		for _, n := range allNodes {
+			if !removed[n.String()] {
+				remaining = append(remaining, n)
+			}
+		}
+		if err := scale.AddNodes(logger, status, p, cfg, controlPlane, remaining, wait); err != nil {
+			return nil, err
+		}
+	}
+
+	// the rest of the cluster may depend on the workers
+	allNodes, err = p.ListNodes(cfg.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.LoadBalancer.ExtraBackends) > 0 {
+		if err := loadbalancer.NewAction().Execute(actions.NewActionContextForNodes(logger, status, p, cfg, allNodes)); err != nil {
+			return nil, err
+		}
+	}
+	if coredns.Enabled(&cfg.DNS) {
+		internalNodes, err := nodeutils.InternalNodes(allNodes)
+		if err != nil {
+			return nil, err
+		}
+		if err := coredns.Apply(controlPlane, &cfg.DNS, len(internalNodes)); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+func plan(p providers.Provider, cfg *config.Cluster) (map[string]observedNode, []Change, error) {
+	allNodes, err := p.ListNodes(cfg.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	internalNodes, err := nodeutils.InternalNodes(allNodes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(internalNodes) == 0 {
+		return nil, nil, errors.Errorf("unknown cluster %q, create it with `kind create cluster`", cfg.Name)
+	}
+	observed := map[string]observedNode{}
+	clusterHash := ""
+	for _, n := range internalNodes {
+		role, err := n.Role()
+		if err != nil {
+			return nil, nil, err
+		}
+		labels, err := p.GetNodeLabels(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		observed[n.String()] = observedNode{node: n, role: role, hash: labels[common.NodeConfigHashLabelKey]}
+		if h := labels[common.ClusterConfigHashLabelKey]; h != "" {
+			clusterHash = h
+		}
+	}
+	changes, err := diff(cfg, clusterHash, observed)
+	return observed, changes, err
+}
+
+// diff computes the changes from the observed nodes to cfg
+// Only workers can change, other differences require recreating the cluster
+func diff(cfg *config.Cluster, clusterHash string, observed map[string]observedNode) ([]Change, error) {
+	const recreate = ", recreate the cluster to apply it"
+	if clusterHash != "" && clusterHash != common.ClusterConfigHash(cfg) {
+		return nil, errors.New("cluster-wide settings changed" + recreate)
+	}
+
+	changes := []Change{}
+	desired := map[string]bool{}
+	namer := common.MakeNodeNamer(cfg.Name)
+	for i := range cfg.Nodes {
+		node := &cfg.Nodes[i]
+		name := namer(string(node.Role))
+		desired[name] = true
+		existing, exists := observed[name]
+		if node.Role == config.ControlPlaneRole {
+			if !exists {
+				return nil, errors.Errorf("control-plane node %s would be added"+recreate, name)
+			}
+			if existing.hash != "" && existing.hash != common.NodeConfigHash(node) {
+				return nil, errors.Errorf("control-plane node %s config changed"+recreate, name)
+			}
+			continue
+		}
+		switch {
+		case !exists:
+			changes = append(changes, Change{Node: name, Action: AddAction})
+		case existing.hash != "" && existing.hash != common.NodeConfigHash(node):
+			changes = append(changes, Change{Node: name, Action: RecreateAction, Reason: "config changed"})
+		}
+	}
+
+	// report removals in a stable order
+	names := make([]string, 0, len(observed))
+	for name := range observed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if desired[name] {
+			continue
+		}
+		if observed[name].role == constants.ControlPlaneNodeRoleValue {
+			return nil, errors.Errorf("control-plane node %s would be removed"+recreate, name)
+		}
+		changes = append(changes, Change{Node: name, Action: RemoveAction})
+	}
+	return changes, nil
+}
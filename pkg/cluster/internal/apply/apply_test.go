@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	newConfig := func(workers int) *config.Cluster {
+		cfg := &config.Cluster{Name: "foo", Nodes: []config.Node{{Role: config.ControlPlaneRole}}}
+		for i := 0; i < workers; i++ {
+			cfg.Nodes = append(cfg.Nodes, config.Node{Role: config.WorkerRole})
+		}
+		config.SetDefaultsCluster(cfg)
+		return cfg
+	}
+	// the cluster as created from newConfig(2)
+	created := newConfig(2)
+	clusterHash := common.ClusterConfigHash(created)
+	observed := map[string]observedNode{
+		"foo-control-plane": {role: "control-plane", hash: common.NodeConfigHash(&created.Nodes[0])},
+		"foo-worker":        {role: "worker", hash: common.NodeConfigHash(&created.Nodes[1])},
+		"foo-worker2":       {role: "worker", hash: common.NodeConfigHash(&created.Nodes[2])},
+	}
+
+	cases := []struct {
+		Name        string
+		Config      func() *config.Cluster
+		Expected    []Change
+		ExpectError bool
+	}{
+		{
+			Name:     "unchanged",
+			Config:   func() *config.Cluster { return newConfig(2) },
+			Expected: []Change{},
+		},
+		{
+			Name:   "more workers",
+			Config: func() *config.Cluster { return newConfig(4) },
+			Expected: []Change{
+				{Node: "foo-worker3", Action: AddAction},
+				{Node: "foo-worker4", Action: AddAction},
+			},
+		},
+		{
+			Name:     "fewer workers",
+			Config:   func() *config.Cluster { return newConfig(1) },
+			Expected: []Change{{Node: "foo-worker2", Action: RemoveAction}},
+		},
+		{
+			Name: "worker mounts changed",
+			Config: func() *config.Cluster {
+				cfg := newConfig(2)
+				cfg.Nodes[2].ExtraMounts = []config.Mount{{HostPath: "/data", ContainerPath: "/data"}}
+				return cfg
+			},
+			Expected: []Change{{Node: "foo-worker2", Action: RecreateAction, Reason: "config changed"}},
+		},
+		{
+			Name: "control-plane port mappings changed",
+			Config: func() *config.Cluster {
+				cfg := newConfig(2)
+				cfg.Nodes[0].ExtraPortMappings = []config.PortMapping{{ContainerPort: 80, HostPort: 80}}
+				return cfg
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "more control-plane nodes",
+			Config: func() *config.Cluster {
+				cfg := newConfig(2)
+				cfg.Nodes = append(cfg.Nodes, cfg.Nodes[0])
+				return cfg
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "cluster-wide settings changed",
+			Config: func() *config.Cluster {
+				cfg := newConfig(2)
+				cfg.FeatureGates = map[string]bool{"Foo": true}
+				return cfg
+			},
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			changes, err := diff(tc.Config(), clusterHash, observed)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.DeepEqual(t, tc.Expected, changes)
+		})
+	}
+}
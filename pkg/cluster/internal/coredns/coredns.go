@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package coredns implements sizing the cluster's CoreDNS deployment
+package coredns
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// Enabled returns true if dns requests any change to the CoreDNS deployment
+func Enabled(dns *config.DNS) bool {
+	return dns.NodesPerReplica > 0 || dns.Resources != config.DNSResources{}
+}
+
+// Replicas returns the number of CoreDNS replicas for a cluster of nodeCount
+// nodes, or 0 if the replicas should not be changed
+func Replicas(dns *config.DNS, nodeCount int) int {
+	if dns.NodesPerReplica <= 0 {
+		return 0
+	}
+	perReplica := int(dns.NodesPerReplica)
+	replicas := (nodeCount + perReplica - 1) / perReplica
+	if replicas < int(dns.MinReplicas) {
+		replicas = int(dns.MinReplicas)
+	}
+	return replicas
+}
+
+// Apply sizes the CoreDNS deployment for a cluster of nodeCount nodes,
+// using controlPlane to reach the API server
+func Apply(controlPlane nodes.Node, dns *config.DNS, nodeCount int) error {
+	if args := resourcesArgs(&dns.Resources); len(args) > 0 {
+		if err := kubectl(controlPlane, append([]string{"set", "resources", "deployment/coredns"}, args...)...); err != nil {
+			return errors.Wrap(err, "failed to set CoreDNS resources")
+		}
+	}
+	if replicas := Replicas(dns, nodeCount); replicas > 0 {
+		if err := kubectl(controlPlane, "scale", "deployment/coredns", fmt.Sprintf("--replicas=%d", replicas)); err != nil {
+			return errors.Wrap(err, "failed to scale CoreDNS")
+		}
+	}
+	return nil
+}
+
+func kubectl(controlPlane nodes.Node, args ...string) error {
+	return controlPlane.Command(
+		"kubectl", append([]string{"--kubeconfig=/etc/kubernetes/admin.conf", "-n", "kube-system"}, args...)...,
+	).Run()
+}
+
+// resourcesArgs returns the `kubectl set resources` flags for r
+func resourcesArgs(r *config.DNSResources) []string {
+	requests := []string{}
+	if r.CPURequest != "" {
+		requests = append(requests, "cpu="+r.CPURequest)
+	}
+	if r.MemoryRequest != "" {
+		requests = append(requests, "memory="+r.MemoryRequest)
+	}
+	args := []string{}
+	if len(requests) > 0 {
+		args = append(args, "--requests="+strings.Join(requests, ","))
+	}
+	if r.MemoryLimit != "" {
+		args = append(args, "--limits=memory="+r.MemoryLimit)
+	}
+	return args
+}
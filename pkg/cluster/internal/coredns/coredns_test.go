@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coredns
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestReplicas(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name      string
+		DNS       config.DNS
+		NodeCount int
+		Expected  int
+	}{
+		{Name: "unset", DNS: config.DNS{MinReplicas: 2}, NodeCount: 100, Expected: 0},
+		{Name: "small cluster", DNS: config.DNS{NodesPerReplica: 16, MinReplicas: 2}, NodeCount: 3, Expected: 2},
+		{Name: "exact", DNS: config.DNS{NodesPerReplica: 16, MinReplicas: 2}, NodeCount: 64, Expected: 4},
+		{Name: "rounds up", DNS: config.DNS{NodesPerReplica: 16, MinReplicas: 2}, NodeCount: 65, Expected: 5},
+		{Name: "single replica", DNS: config.DNS{NodesPerReplica: 4, MinReplicas: 1}, NodeCount: 1, Expected: 1},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			if r := Replicas(&tc.DNS, tc.NodeCount); r != tc.Expected {
+				t.Errorf("expected %d replicas but got %d", tc.Expected, r)
+			}
+		})
+	}
+}
+
+func TestResourcesArgs(t *testing.T) {
+	t.Parallel()
+	assert.DeepEqual(t, []string{}, resourcesArgs(&config.DNSResources{}))
+	assert.DeepEqual(t, []string{"--limits=memory=340Mi"}, resourcesArgs(&config.DNSResources{MemoryLimit: "340Mi"}))
+	assert.DeepEqual(t,
+		[]string{"--requests=cpu=200m,memory=140Mi", "--limits=memory=340Mi"},
+		resourcesArgs(&config.DNSResources{CPURequest: "200m", MemoryRequest: "140Mi", MemoryLimit: "340Mi"}),
+	)
+}
@@ -17,14 +17,20 @@ limitations under the License.
 package actions
 
 import (
+	"strings"
 	"sync"
 
+	"sigs.k8s.io/kind/pkg/cluster/constants"
 	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/internal/apis/config"
 	"sigs.k8s.io/kind/pkg/internal/cli"
 	"sigs.k8s.io/kind/pkg/log"
 
+	"sigs.k8s.io/kind/pkg/cluster/internal/kubectl"
 	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 )
 
 // Action defines a step of bringing up a kind cluster after initial node
@@ -58,9 +64,40 @@ func NewActionContext(
 	}
 }
 
+// NewActionContextForNodes returns a new ActionContext whose Nodes are
+// limited to n, for running actions against some nodes of an existing cluster
+func NewActionContextForNodes(
+	logger log.Logger,
+	status *cli.Status,
+	provider providers.Provider,
+	cfg *config.Cluster,
+	n []nodes.Node,
+) *ActionContext {
+	ctx := NewActionContext(logger, status, provider, cfg)
+	ctx.cache.setNodes(n)
+	return ctx
+}
+
+// Artifact is something an action installed into the cluster, recorded in
+// the cluster inventory
+type Artifact struct {
+	// Name identifies the artifact, e.g. "ingress"
+	Name string
+	// Source is where the artifact came from, e.g. the URL of a manifest or
+	// the image of a container, if any
+	Source string
+	// Contents is the applied manifest, if known
+	Contents []byte
+	// Container is true if the artifact is a container next to the nodes
+	// rather than a manifest applied to the cluster
+	Container bool
+}
+
 type cachedData struct {
-	mu    sync.RWMutex
-	nodes []nodes.Node
+	mu        sync.RWMutex
+	nodes     []nodes.Node
+	client    *kubectl.Client
+	artifacts []Artifact
 }
 
 func (cd *cachedData) getNodes() []nodes.Node {
@@ -75,6 +112,32 @@ func (cd *cachedData) setNodes(n []nodes.Node) {
 	cd.nodes = n
 }
 
+func (cd *cachedData) getClient() *kubectl.Client {
+	cd.mu.RLock()
+	defer cd.mu.RUnlock()
+	return cd.client
+}
+
+func (cd *cachedData) setClient(c *kubectl.Client) {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	cd.client = c
+}
+
+// RecordArtifact records an artifact the action installed, see Artifacts
+func (ac *ActionContext) RecordArtifact(a Artifact) {
+	ac.cache.mu.Lock()
+	defer ac.cache.mu.Unlock()
+	ac.cache.artifacts = append(ac.cache.artifacts, a)
+}
+
+// Artifacts returns the artifacts recorded by earlier actions, in order
+func (ac *ActionContext) Artifacts() []Artifact {
+	ac.cache.mu.RLock()
+	defer ac.cache.mu.RUnlock()
+	return append([]Artifact{}, ac.cache.artifacts...)
+}
+
 // Nodes returns the list of cluster nodes, this is a cached call
 func (ac *ActionContext) Nodes() ([]nodes.Node, error) {
 	cachedNodes := ac.cache.getNodes()
@@ -88,3 +151,65 @@ func (ac *ActionContext) Nodes() ([]nodes.Node, error) {
 	ac.cache.setNodes(n)
 	return n, nil
 }
+
+// Client returns a client for the cluster's API server, this is a cached call
+// It may only be used once kubeadm init has run on the bootstrap control plane
+func (ac *ActionContext) Client() (*kubectl.Client, error) {
+	if c := ac.cache.getClient(); c != nil {
+		return c, nil
+	}
+	// Nodes may be limited to nodes that do not include the control plane
+	allNodes, err := ac.Provider.ListNodes(ac.Config.Name)
+	if err != nil {
+		return nil, err
+	}
+	controlPlane, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+	if err != nil {
+		return nil, err
+	}
+	c := kubectl.NewClient(controlPlane)
+	ac.cache.setClient(c)
+	return c, nil
+}
+
+// ConfigNodeFor returns the node in cfg that node was created from
+func ConfigNodeFor(cfg *config.Cluster, node nodes.Node) (*config.Node, error) {
+	// TODO: gross hack!
+	// identify node in config by matching name (since these are named in order)
+	// we should really just streamline the bootstrap code and maintain
+	// this mapping ... something for the next major refactor
+	var configNode *config.Node
+	namer := common.MakeNodeNamer("")
+	for i := range cfg.Nodes {
+		n := &cfg.Nodes[i]
+		nodeSuffix := namer(string(n.Role))
+		if strings.HasSuffix(node.String(), nodeSuffix) {
+			configNode = n
+		}
+	}
+	if configNode == nil {
+		return nil, errors.Errorf("failed to match node %q to config", node.String())
+	}
+	return configNode, nil
+}
+
+// AnnotateNodeImageDigest annotates the Node object of node with the digest of
+// its node image, if known, which is too long for a label value
+func AnnotateNodeImageDigest(ctx *ActionContext, node nodes.Node) error {
+	containerLabels, err := ctx.Provider.GetNodeLabels(node)
+	if err != nil {
+		return errors.Wrap(err, "failed to get node labels")
+	}
+	digest := containerLabels[common.NodeImageDigestLabelKey]
+	if digest == "" {
+		return nil
+	}
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	if err := client.Run("annotate", "--overwrite", "node", node.String(), constants.ImageDigestNodeAnnotationKey+"="+digest); err != nil {
+		return errors.Wrapf(err, "failed to annotate node %s with its image digest", node.String())
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// defaultAuditPolicy logs the metadata of every request, skipping the
+// RequestReceived stage and the high volume health checks and leader
+// election lease renewals that would otherwise dominate the log
+const defaultAuditPolicy = `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+- RequestReceived
+rules:
+- level: None
+  nonResourceURLs:
+  - /healthz*
+  - /livez*
+  - /readyz*
+  - /version
+- level: None
+  verbs: ["get", "update"]
+  resources:
+  - group: coordination.k8s.io
+    resources: ["leases"]
+- level: Metadata
+`
+
+// auditPolicy returns the audit policy of cfg
+func auditPolicy(cfg *config.Cluster) string {
+	if cfg.Audit.Policy != "" {
+		return cfg.Audit.Policy
+	}
+	return defaultAuditPolicy
+}
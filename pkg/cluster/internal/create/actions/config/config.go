@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 
 	"sigs.k8s.io/kind/pkg/cluster/constants"
@@ -77,10 +78,13 @@ func (a *Action) Execute(ctx *actions.ActionContext) error {
 		PodSubnet:            ctx.Config.Networking.PodSubnet,
 		KubeProxyMode:        string(ctx.Config.Networking.KubeProxyMode),
 		ServiceSubnet:        ctx.Config.Networking.ServiceSubnet,
+		ClusterDomain:        ctx.Config.Networking.ClusterDomain,
 		ControlPlane:         true,
 		IPFamily:             ctx.Config.Networking.IPFamily,
 		FeatureGates:         ctx.Config.FeatureGates,
 		RuntimeConfig:        ctx.Config.RuntimeConfig,
+		Audit:                ctx.Config.Audit.Enabled,
+		OIDC:                 ctx.Config.OIDC,
 		RootlessProvider:     providerInfo.Rootless,
 	}
 
@@ -94,7 +98,10 @@ func (a *Action) Execute(ctx *actions.ActionContext) error {
 			}
 
 			ctx.Logger.V(2).Infof("Using the following kubeadm config for node %s:\n%s", node.String(), kubeadmConfig)
-			return writeKubeadmConfig(kubeadmConfig, node)
+			if err := writeKubeadmConfig(kubeadmConfig, node); err != nil {
+				return err
+			}
+			return writeControlPlaneFiles(ctx.Config, node)
 		}
 	}
 
@@ -115,8 +122,47 @@ func (a *Action) Execute(ctx *actions.ActionContext) error {
 		return err
 	}
 
+	// registry mirrors need containerd to read hosts.toml from registryConfigDir
+	// running pull-through caches are used by every new cluster
+	caches, err := ctx.Provider.ListCaches()
+	if err != nil {
+		return err
+	}
+	mirrors := registryMirrors(ctx.Config, caches)
+	hasMirrors := len(mirrors) > 0
+	for _, n := range ctx.Config.Nodes {
+		hasMirrors = hasMirrors || len(n.RegistryMirrors) > 0
+	}
+	containerdConfigPatches := ctx.Config.ContainerdConfigPatches
+	if hasMirrors {
+		containerdConfigPatches = append([]string{registryConfigPathPatch}, containerdConfigPatches...)
+	}
+
+	// the OIDC provider was started with the nodes
+	if ctx.Config.OIDC.Provider != "" {
+		ctx.RecordArtifact(actions.Artifact{
+			Name:      common.OIDCProviderName(ctx.Config.Name),
+			Source:    common.OIDCProviderImageFor(ctx.Config),
+			Container: true,
+		})
+	}
+
+	// the NVIDIA device plugin needs the NVIDIA runtime on the GPU nodes
+	nvidiaNodes := map[string]bool{}
+	if ctx.Config.DevicePlugin.Type == config.NVIDIADevicePlugin {
+		if nvidiaNodes, err = nvidiaRuntimeNodes(kubeNodes); err != nil {
+			return err
+		}
+	}
+
+	// nodes with a containerd log level get it patched into their config
+	hasContainerdLogging := false
+	for _, n := range ctx.Config.Nodes {
+		hasContainerdLogging = hasContainerdLogging || n.Logging.ContainerdLevel != ""
+	}
+
 	// if we have containerd config, patch all the nodes concurrently
-	if len(ctx.Config.ContainerdConfigPatches) > 0 || len(ctx.Config.ContainerdConfigPatchesJSON6902) > 0 {
+	if len(containerdConfigPatches) > 0 || len(ctx.Config.ContainerdConfigPatchesJSON6902) > 0 || len(nvidiaNodes) > 0 || hasContainerdLogging {
 		fns := make([]func() error, len(kubeNodes))
 		for i, node := range kubeNodes {
 			node := node // capture loop variable
@@ -127,13 +173,27 @@ func (a *Action) Execute(ctx *actions.ActionContext) error {
 				if err := node.Command("cat", containerdConfigPath).SetStdout(&buff).Run(); err != nil {
 					return errors.Wrap(err, "failed to read containerd config from node")
 				}
-				patched, err := patch.TOML(buff.String(), ctx.Config.ContainerdConfigPatches, ctx.Config.ContainerdConfigPatchesJSON6902)
+				configNode, err := actions.ConfigNodeFor(ctx.Config, node)
+				if err != nil {
+					return err
+				}
+				nodePatches := containerdConfigPatches
+				if nvidiaNodes[node.String()] {
+					nodePatches = append([]string{nvidiaRuntimePatch}, nodePatches...)
+				}
+				if configNode.Logging.ContainerdLevel != "" {
+					nodePatches = append([]string{containerdLoggingPatch(configNode.Logging.ContainerdLevel)}, nodePatches...)
+				}
+				patched, err := patch.TOML(buff.String(), nodePatches, ctx.Config.ContainerdConfigPatchesJSON6902)
 				if err != nil {
 					return errors.Wrap(err, "failed to patch containerd config")
 				}
 				if err := nodeutils.WriteFile(node, containerdConfigPath, patched); err != nil {
 					return errors.Wrap(err, "failed to write patched containerd config")
 				}
+				if err := writeRegistryMirrors(node, mergeRegistryMirrors(mirrors, configNode.RegistryMirrors)); err != nil {
+					return err
+				}
 				// restart containerd now that we've re-configured it
 				// skip if containerd is not running
 				if err := node.Command("bash", "-c", `! pgrep --exact containerd || systemctl restart containerd`).Run(); err != nil {
@@ -147,6 +207,48 @@ func (a *Action) Execute(ctx *actions.ActionContext) error {
 		}
 	}
 
+	// if we have an image bundle, load it on all the nodes concurrently
+	if ctx.Config.ImageBundle != "" {
+		fns := make([]func() error, len(kubeNodes))
+		for i, node := range kubeNodes {
+			node := node // capture loop variable
+			fns[i] = func() error {
+				return loadImageBundle(node, ctx.Config.ImageBundle)
+			}
+		}
+		if err := errors.UntilErrorConcurrent(fns); err != nil {
+			return err
+		}
+	}
+
+	// if we have a shared OCI layout, import it on all the nodes concurrently
+	if ctx.Config.SharedOCILayout != "" {
+		fns := make([]func() error, len(kubeNodes))
+		for i, node := range kubeNodes {
+			node := node // capture loop variable
+			fns[i] = func() error {
+				return importSharedOCILayout(node)
+			}
+		}
+		if err := errors.UntilErrorConcurrent(fns); err != nil {
+			return err
+		}
+	}
+
+	// if crash capture is enabled, configure all the nodes concurrently
+	if ctx.Config.CrashCapture {
+		fns := make([]func() error, len(kubeNodes))
+		for i, node := range kubeNodes {
+			node := node // capture loop variable
+			fns[i] = func() error {
+				return configureCrashCapture(node)
+			}
+		}
+		if err := errors.UntilErrorConcurrent(fns); err != nil {
+			return err
+		}
+	}
+
 	// mark success
 	ctx.Status.End(true)
 	return nil
@@ -162,21 +264,9 @@ func getKubeadmConfig(cfg *config.Cluster, data kubeadm.ConfigData, node nodes.N
 	}
 	data.KubernetesVersion = kubeVersion
 
-	// TODO: gross hack!
-	// identify node in config by matching name (since these are named in order)
-	// we should really just streamline the bootstrap code and maintain
-	// this mapping ... something for the next major refactor
-	var configNode *config.Node
-	namer := common.MakeNodeNamer("")
-	for i := range cfg.Nodes {
-		n := &cfg.Nodes[i]
-		nodeSuffix := namer(string(n.Role))
-		if strings.HasSuffix(node.String(), nodeSuffix) {
-			configNode = n
-		}
-	}
-	if configNode == nil {
-		return "", errors.Errorf("failed to match node %q to config", node.String())
+	configNode, err := actions.ConfigNodeFor(cfg, node)
+	if err != nil {
+		return "", err
 	}
 
 	// get the node ip address
@@ -209,9 +299,10 @@ func getKubeadmConfig(cfg *config.Cluster, data kubeadm.ConfigData, node nodes.N
 	}
 
 	// configure the node labels
-	if len(configNode.Labels) > 0 {
-		data.NodeLabels = hashMapLabelsToCommaSeparatedLabels(configNode.Labels)
-	}
+	data.NodeLabels = hashMapLabelsToCommaSeparatedLabels(nodeLabels(configNode))
+
+	// configure the kubelet log verbosity
+	data.KubeletVerbosity = configNode.Logging.KubeletVerbosity
 
 	// set the node role
 	data.ControlPlane = string(configNode.Role) == constants.ControlPlaneNodeRoleValue
@@ -222,7 +313,10 @@ func getKubeadmConfig(cfg *config.Cluster, data kubeadm.ConfigData, node nodes.N
 		return "", err
 	}
 
-	clusterPatches, clusterJSONPatches := allPatchesFromConfig(cfg)
+	clusterPatches, clusterJSONPatches, err := allPatchesFromConfig(cfg)
+	if err != nil {
+		return "", err
+	}
 	// apply cluster-level patches first
 	patchedConfig, err := patch.KubeYAML(cf, clusterPatches, clusterJSONPatches)
 	if err != nil {
@@ -254,8 +348,14 @@ func removeMetadata(kustomized string) string {
 	)
 }
 
-func allPatchesFromConfig(cfg *config.Cluster) (patches []string, jsonPatches []config.PatchJSON6902) {
-	return cfg.KubeadmConfigPatches, cfg.KubeadmConfigPatchesJSON6902
+func allPatchesFromConfig(cfg *config.Cluster) (patches []string, jsonPatches []config.PatchJSON6902, err error) {
+	dirPatches, dirJSONPatches, err := patchesFromDirectories(cfg.KubeadmConfigPatchDirectories)
+	if err != nil {
+		return nil, nil, err
+	}
+	patches = append(append([]string{}, cfg.KubeadmConfigPatches...), dirPatches...)
+	jsonPatches = append(append([]config.PatchJSON6902{}, cfg.KubeadmConfigPatchesJSON6902...), dirJSONPatches...)
+	return patches, jsonPatches, nil
 }
 
 // writeKubeadmConfig writes the kubeadm configuration in the specified node
@@ -269,11 +369,45 @@ func writeKubeadmConfig(kubeadmConfig string, node nodes.Node) error {
 	return nil
 }
 
+// writeControlPlaneFiles writes the files kube-apiserver is configured to
+// read to node if it is a control-plane node, other nodes do not run it
+func writeControlPlaneFiles(cfg *config.Cluster, node nodes.Node) error {
+	files := [][2]string{}
+	if cfg.Audit.Enabled {
+		files = append(files, [2]string{config.AuditPolicyPath, auditPolicy(cfg)})
+	}
+	if cfg.OIDC.CertificateAuthority != "" {
+		files = append(files, [2]string{config.OIDCCertificateAuthorityPath, cfg.OIDC.CertificateAuthority})
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	role, err := node.Role()
+	if err != nil {
+		return err
+	}
+	if role != constants.ControlPlaneNodeRoleValue {
+		return nil
+	}
+	for _, f := range files {
+		if err := nodeutils.WriteFile(node, f[0], f[1]); err != nil {
+			return errors.Wrapf(err, "failed to copy %s to node", f[0])
+		}
+	}
+	return nil
+}
+
 // hashMapLabelsToCommaSeparatedLabels converts labels in hashmap form to labels in a comma-separated string form like "key1=value1,key2=value2"
 func hashMapLabelsToCommaSeparatedLabels(labels map[string]string) string {
+	// sort the keys for a stable config
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 	output := ""
-	for key, value := range labels {
-		output += fmt.Sprintf("%s=%s,", key, value)
+	for _, key := range keys {
+		output += fmt.Sprintf("%s=%s,", key, labels[key])
 	}
 	return strings.TrimSuffix(output, ",") // remove the last character (comma) in the output string
 }
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// crashDir is where crash data is persisted on the node, /var is a volume
+// so this survives node restarts, and /var/log is collected by export logs
+const crashDir = "/var/log/kind/crash"
+
+// crashCaptureScript follows the kernel log and the kubelet / containerd
+// journal and appends OOM events and panics to files under crashDir
+// Either pipeline ending fails the script so systemd restarts it
+const crashCaptureScript = `#!/bin/bash
+set -o nounset -o pipefail
+mkdir -p ` + crashDir + `
+# core_pattern is shared with the host, record it to help locate core dumps
+cat /proc/sys/kernel/core_pattern > ` + crashDir + `/core_pattern
+dmesg --follow --time-format=iso \
+  | grep --line-buffered -iE 'out of memory|oom-kill|oom_reaper|invoked oom-killer' \
+  >> ` + crashDir + `/oom.log &
+journalctl --follow --no-tail --output=short-iso -u kubelet.service -u containerd.service \
+  | grep --line-buffered -A 100 -E 'panic: |fatal error: ' \
+  >> ` + crashDir + `/panics.log &
+wait -n
+exit 1
+`
+
+// crashCaptureService restarts the capture when it fails, but gives up if it
+// keeps failing rather than respawning it forever
+const crashCaptureService = `[Unit]
+Description=kind crash capture
+After=systemd-journald.service
+StartLimitIntervalSec=300
+StartLimitBurst=5
+
+[Service]
+ExecStart=/kind/bin/crash-capture.sh
+Restart=on-failure
+RestartSec=10s
+
+[Install]
+WantedBy=multi-user.target
+`
+
+// crashCaptureDropIn is installed for kubelet and containerd, so they and their
+// children may dump core, and the Go runtime aborts with a core dump on panic.
+// With a relative core_pattern dumps are written to the working directory
+const crashCaptureDropIn = `[Service]
+LimitCORE=infinity
+Environment=GOTRACEBACK=crash
+WorkingDirectory=-` + crashDir + `
+`
+
+// configureCrashCapture installs and starts crash capture on node
+func configureCrashCapture(node nodes.Node) error {
+	files := map[string]string{
+		"/kind/bin/crash-capture.sh":                                          crashCaptureScript,
+		"/etc/systemd/system/kind-crash-capture.service":                      crashCaptureService,
+		"/etc/systemd/system/kubelet.service.d/20-kind-crash-capture.conf":    crashCaptureDropIn,
+		"/etc/systemd/system/containerd.service.d/20-kind-crash-capture.conf": crashCaptureDropIn,
+	}
+	for path, contents := range files {
+		if err := nodeutils.WriteFile(node, path, contents); err != nil {
+			return errors.Wrapf(err, "failed to write %s", path)
+		}
+	}
+	if err := node.Command("chmod", "+x", "/kind/bin/crash-capture.sh").Run(); err != nil {
+		return errors.Wrap(err, "failed to make crash capture script executable")
+	}
+	if err := node.Command("mkdir", "-p", crashDir).Run(); err != nil {
+		return errors.Wrap(err, "failed to create crash directory")
+	}
+	// reload units and apply the drop-ins, kubelet is not configured yet so
+	// restarting it is harmless, containerd is only restarted if running
+	if err := node.Command("bash", "-c",
+		`systemctl daemon-reload && systemctl enable --now kind-crash-capture.service && `+
+			`(! pgrep --exact containerd || systemctl restart containerd) && systemctl restart kubelet`,
+	).Run(); err != nil {
+		return errors.Wrap(err, "failed to start crash capture")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	osexec "os/exec"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestCrashCaptureService(t *testing.T) {
+	t.Parallel()
+	unit := parseUnit(crashCaptureService)
+	cases := []struct {
+		Section  string
+		Key      string
+		Expected string
+	}{
+		{Section: "Unit", Key: "StartLimitIntervalSec", Expected: "300"},
+		{Section: "Unit", Key: "StartLimitBurst", Expected: "5"},
+		{Section: "Service", Key: "ExecStart", Expected: "/kind/bin/crash-capture.sh"},
+		{Section: "Service", Key: "Restart", Expected: "on-failure"},
+		{Section: "Service", Key: "RestartSec", Expected: "10s"},
+		{Section: "Install", Key: "WantedBy", Expected: "multi-user.target"},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Section+"/"+tc.Key, func(t *testing.T) {
+			t.Parallel()
+			assert.StringEqual(t, tc.Expected, unit[tc.Section][tc.Key])
+		})
+	}
+}
+
+func TestCrashCaptureScript(t *testing.T) {
+	t.Parallel()
+	if _, err := osexec.LookPath("bash"); err != nil {
+		t.Skip("bash is not available")
+	}
+	cmd := exec.Command("bash", "-n")
+	cmd.SetStdin(strings.NewReader(crashCaptureScript))
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("crash capture script is not valid bash: %v", err)
+	}
+	// the script must fail once a pipeline ends so systemd restarts it
+	lines := strings.Split(strings.TrimSpace(crashCaptureScript), "\n")
+	assert.DeepEqual(t, []string{"wait -n", "exit 1"}, lines[len(lines)-2:])
+}
+
+// parseUnit returns the directives of a systemd unit by section and key
+func parseUnit(unit string) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	section := ""
+	for _, line := range strings.Split(unit, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(line, "[]")
+			sections[section] = map[string]string{}
+			continue
+		}
+		if parts := strings.SplitN(line, "=", 2); len(parts) == 2 && section != "" {
+			sections[section][parts[0]] = parts[1]
+		}
+	}
+	return sections
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// loadImageBundle loads the images in the archive at bundle on the host
+// into containerd on node
+func loadImageBundle(node nodes.Node, bundle string) error {
+	f, err := os.Open(bundle)
+	if err != nil {
+		return errors.Wrap(err, "failed to open image bundle")
+	}
+	defer f.Close()
+	if err := nodeutils.LoadImageArchive(node, f); err != nil {
+		return errors.Wrap(err, "failed to load image bundle")
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/patch"
+)
+
+func TestContainerdLoggingPatch(t *testing.T) {
+	t.Parallel()
+	// a subset of a kind node containerd config
+	const containerdConfig = `version = 2
+
+[plugins."io.containerd.grpc.v1.cri".containerd]
+  snapshotter = "overlayfs"
+`
+	patched, err := patch.TOML(containerdConfig, []string{containerdLoggingPatch("debug")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error patching: %v", err)
+	}
+	for _, expected := range []string{
+		"[debug]",
+		`level = "debug"`,
+		// the rest of the config is kept
+		`snapshotter = "overlayfs"`,
+	} {
+		if !strings.Contains(patched, expected) {
+			t.Errorf("expected the patched config to contain %q, got:\n%s", expected, patched)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/version"
+)
+
+// metadata labels set on every Node object by kubelet when it registers,
+// so in-cluster tooling can identify the kind environment
+// The node image digest does not fit in a label value, it is recorded in an
+// annotation once the node joined, see actions.AnnotateNodeImageDigest
+const (
+	versionNodeLabelKey = constants.VersionNodeLabelKey
+	roleNodeLabelKey    = constants.RoleNodeLabelKey
+)
+
+// nodeLabels returns the kubelet node labels for configNode, the kind
+// metadata labels plus the user's labels, which take precedence
+func nodeLabels(configNode *config.Node) map[string]string {
+	labels := map[string]string{
+		versionNodeLabelKey: labelValue(version.KindVersion()),
+		roleNodeLabelKey:    string(configNode.Role),
+	}
+	for k, v := range configNode.Labels {
+		labels[k] = v
+	}
+	return labels
+}
+
+var invalidLabelValueCharsRE = regexp.MustCompile(`[^-A-Za-z0-9_.]`)
+
+// labelValue converts s to a valid label value, replacing invalid characters
+// with "_" and truncating it to 63 characters
+func labelValue(s string) string {
+	s = invalidLabelValueCharsRE.ReplaceAllString(s, "_")
+	if len(s) > 63 {
+		s = s[:63]
+	}
+	// values must begin and end with an alphanumeric character
+	return strings.Trim(s, "-_.")
+}
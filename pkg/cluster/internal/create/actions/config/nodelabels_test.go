@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+	"sigs.k8s.io/kind/pkg/internal/version"
+)
+
+func TestNodeLabels(t *testing.T) {
+	t.Parallel()
+	node := &config.Node{
+		Role:   config.WorkerRole,
+		Labels: map[string]string{"tier": "frontend", roleNodeLabelKey: "custom"},
+	}
+	assert.DeepEqual(t, map[string]string{
+		versionNodeLabelKey: labelValue(version.KindVersion()),
+		roleNodeLabelKey:    "custom",
+		"tier":              "frontend",
+	}, nodeLabels(node))
+	assert.DeepEqual(t, map[string]string{
+		versionNodeLabelKey: labelValue(version.KindVersion()),
+		roleNodeLabelKey:    "control-plane",
+	}, nodeLabels(&config.Node{Role: config.ControlPlaneRole}))
+}
+
+func TestLabelValue(t *testing.T) {
+	t.Parallel()
+	assert.StringEqual(t, "0.25.0-alpha.12_0123456789abcd", labelValue("0.25.0-alpha.12+0123456789abcd"))
+	assert.StringEqual(t, "abc", labelValue("_abc."))
+	assert.StringEqual(t, "", labelValue(""))
+}
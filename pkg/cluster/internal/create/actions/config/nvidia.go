@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// nvidiaRuntimePath is where the nvidia-container-toolkit installs the
+// NVIDIA container runtime, which the NVIDIA device plugin pods need
+const nvidiaRuntimePath = "/usr/bin/nvidia-container-runtime"
+
+// nvidiaRuntimePatch makes the NVIDIA container runtime the default containerd
+// runtime, as `nvidia-ctk runtime configure --set-as-default` does
+const nvidiaRuntimePatch = `[plugins."io.containerd.grpc.v1.cri".containerd]
+  default_runtime_name = "nvidia"
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia]
+  runtime_type = "io.containerd.runc.v2"
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.nvidia.options]
+  BinaryName = "` + nvidiaRuntimePath + `"
+  SystemdCgroup = true
+`
+
+// nvidiaRuntimeNodes returns the names of the nodes with the NVIDIA container
+// runtime installed, it is an error if there are none
+func nvidiaRuntimeNodes(kubeNodes []nodes.Node) (map[string]bool, error) {
+	found := map[string]bool{}
+	for _, n := range kubeNodes {
+		if n.Command("test", "-x", nvidiaRuntimePath).Run() == nil {
+			found[n.String()] = true
+		}
+	}
+	if len(found) == 0 {
+		return nil, errors.WithDetails(
+			errors.Errorf("devicePlugin type nvidia needs the NVIDIA container runtime, but no node has %s", nvidiaRuntimePath),
+			errors.Details{
+				Category: errors.ConfigCategory,
+				Hint:     "use a node image with the nvidia-container-toolkit installed, or mount it into the nodes with extraMounts",
+			},
+		)
+	}
+	return found, nil
+}
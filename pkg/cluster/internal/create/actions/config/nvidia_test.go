@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/patch"
+)
+
+func TestNVIDIARuntimePatch(t *testing.T) {
+	t.Parallel()
+	// a subset of a kind node containerd config
+	const containerdConfig = `version = 2
+
+[plugins."io.containerd.grpc.v1.cri".containerd]
+  default_runtime_name = "runc"
+  snapshotter = "overlayfs"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+  runtime_type = "io.containerd.runc.v2"
+`
+	patched, err := patch.TOML(containerdConfig, []string{nvidiaRuntimePatch}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error patching: %v", err)
+	}
+	for _, expected := range []string{
+		`default_runtime_name = "nvidia"`,
+		`BinaryName = "/usr/bin/nvidia-container-runtime"`,
+		// the rest of the config is kept
+		`snapshotter = "overlayfs"`,
+		`[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]`,
+	} {
+		if !strings.Contains(patched, expected) {
+			t.Errorf("expected the patched config to contain %q, got:\n%s", expected, patched)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kind/pkg/apis/config/v1alpha4"
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// patchesFromDirectories reads the kubeadm config patch files in dirs, in the
+// order listed and by file name within each directory
+//
+// Files ending in .json6902.yaml or .json6902.json hold a JSON 6902 patch and
+// its target, in the format of kubeadmConfigPatchesJSON6902 entries. Other
+// .yaml, .yml and .json files hold a merge patch, other files are ignored
+func patchesFromDirectories(dirs []string) (patches []string, jsonPatches []config.PatchJSON6902, err error) {
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read kubeadm config patch directory %q", dir)
+		}
+		names := []string{}
+		for _, e := range entries {
+			if !e.IsDir() {
+				names = append(names, e.Name())
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			switch {
+			case strings.HasSuffix(name, ".json6902.yaml"), strings.HasSuffix(name, ".json6902.json"):
+				raw, err := os.ReadFile(path)
+				if err != nil {
+					return nil, nil, errors.Wrap(err, "failed to read kubeadm config patch")
+				}
+				p := v1alpha4.PatchJSON6902{}
+				if err := yaml.UnmarshalStrict(raw, &p); err != nil {
+					return nil, nil, errors.Wrapf(err, "invalid JSON 6902 kubeadm config patch %q", path)
+				}
+				if p.Kind == "" || p.Patch == "" {
+					return nil, nil, errors.Errorf("invalid JSON 6902 kubeadm config patch %q: kind and patch are required", path)
+				}
+				jsonPatches = append(jsonPatches, config.PatchJSON6902{
+					Group:   p.Group,
+					Version: p.Version,
+					Kind:    p.Kind,
+					Patch:   p.Patch,
+				})
+			case strings.HasSuffix(name, ".yaml"), strings.HasSuffix(name, ".yml"), strings.HasSuffix(name, ".json"):
+				raw, err := os.ReadFile(path)
+				if err != nil {
+					return nil, nil, errors.Wrap(err, "failed to read kubeadm config patch")
+				}
+				patches = append(patches, string(raw))
+			}
+		}
+	}
+	return patches, jsonPatches, nil
+}
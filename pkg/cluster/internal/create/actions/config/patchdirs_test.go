@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestPatchesFromDirectories(t *testing.T) {
+	t.Parallel()
+	first, second := t.TempDir(), t.TempDir()
+	files := map[string]string{
+		filepath.Join(first, "20-kubelet.yaml"):               "kind: KubeletConfiguration\n",
+		filepath.Join(first, "10-apiserver.yml"):              "kind: ClusterConfiguration\n",
+		filepath.Join(first, "30-etcd.json6902.yaml"):         "kind: ClusterConfiguration\npatch: |\n  - op: add\n    path: /etcd/local\n    value: {}\n",
+		filepath.Join(first, "README.md"):                     "ignored",
+		filepath.Join(first, "nested", "ignored.yaml"):        "kind: InitConfiguration\n",
+		filepath.Join(second, "join.json"):                    `{"kind": "JoinConfiguration"}`,
+		filepath.Join(second, "join.json6902.json"):           `{"group": "kubeadm.k8s.io", "version": "v1beta3", "kind": "JoinConfiguration", "patch": "[]"}`,
+		filepath.Join(second, "kubeadm.k8s.io.json6902.yaml"): "kind: InitConfiguration\npatch: '[]'\n",
+	}
+	for path, contents := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	patches, jsonPatches, err := patchesFromDirectories([]string{first, second})
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []string{
+		"kind: ClusterConfiguration\n",
+		"kind: KubeletConfiguration\n",
+		`{"kind": "JoinConfiguration"}`,
+	}, patches)
+	assert.DeepEqual(t, []config.PatchJSON6902{
+		{Kind: "ClusterConfiguration", Patch: "- op: add\n  path: /etcd/local\n  value: {}\n"},
+		{Group: "kubeadm.k8s.io", Version: "v1beta3", Kind: "JoinConfiguration", Patch: "[]"},
+		{Kind: "InitConfiguration", Patch: "[]"},
+	}, jsonPatches)
+
+	// JSON 6902 patches must have a target and unknown fields are rejected
+	bogus := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bogus, "bogus.json6902.yaml"), []byte("kinds: InitConfiguration\npatch: '[]'\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = patchesFromDirectories([]string{bogus})
+	assert.ExpectError(t, true, err)
+	_, _, err = patchesFromDirectories([]string{filepath.Join(bogus, "missing")})
+	assert.ExpectError(t, true, err)
+}
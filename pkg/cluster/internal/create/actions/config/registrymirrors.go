@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/sets"
+)
+
+// registryConfigDir is where containerd reads per-registry hosts.toml from
+const registryConfigDir = "/etc/containerd/certs.d"
+
+// registryConfigPathPatch points the containerd CRI plugin at registryConfigDir,
+// it is applied before the user's containerdConfigPatches
+const registryConfigPathPatch = `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "` + registryConfigDir + `"
+`
+
+// registryMirrors returns the configured registry mirrors, plus the local
+// registry, which is pulled from as localhost:<port> like on the host, plus
+// the running pull-through caches for registries that are not mirrored already
+func registryMirrors(cfg *config.Cluster, caches map[string]string) []config.RegistryMirror {
+	mirrors := append([]config.RegistryMirror{}, cfg.RegistryMirrors...)
+	mirrored := sets.NewString()
+	for _, m := range mirrors {
+		mirrored.Insert(m.Registry)
+	}
+	registries := make([]string, 0, len(caches))
+	for r := range caches {
+		registries = append(registries, r)
+	}
+	sort.Strings(registries)
+	for _, r := range registries {
+		if !mirrored.Has(r) {
+			mirrors = append(mirrors, config.RegistryMirror{Registry: r, Endpoints: []string{caches[r]}})
+		}
+	}
+	if cfg.LocalRegistry.Enabled {
+		mirrors = append(mirrors, config.RegistryMirror{
+			Registry:  fmt.Sprintf("localhost:%d", cfg.LocalRegistry.Port),
+			Endpoints: []string{fmt.Sprintf("http://%s:%d", constants.LocalRegistryName, common.RegistryContainerPort)},
+		})
+	}
+	return mirrors
+}
+
+// mergeRegistryMirrors merges a node's registry mirrors over the cluster's,
+// a node entry replaces the cluster entry for the same registry
+func mergeRegistryMirrors(mirrors, nodeMirrors []config.RegistryMirror) []config.RegistryMirror {
+	if len(nodeMirrors) == 0 {
+		return mirrors
+	}
+	merged := append([]config.RegistryMirror{}, mirrors...)
+	for _, nm := range nodeMirrors {
+		replaced := false
+		for i := range merged {
+			if merged[i].Registry == nm.Registry {
+				merged[i] = nm
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, nm)
+		}
+	}
+	return merged
+}
+
+// writeRegistryMirrors writes the hosts.toml for each mirrored registry on node
+func writeRegistryMirrors(node nodes.Node, mirrors []config.RegistryMirror) error {
+	for _, m := range mirrors {
+		hostsPath := path.Join(registryConfigDir, m.Registry, "hosts.toml")
+		if err := nodeutils.WriteFile(node, hostsPath, hostsTOML(m)); err != nil {
+			return errors.Wrapf(err, "failed to write %s", hostsPath)
+		}
+	}
+	return nil
+}
+
+// hostsTOML renders the containerd hosts.toml for a mirrored registry
+// https://github.com/containerd/containerd/blob/main/docs/hosts.md
+func hostsTOML(m config.RegistryMirror) string {
+	var b strings.Builder
+	header := ""
+	if m.Auth != (config.RegistryAuth{}) {
+		token := base64.StdEncoding.EncodeToString([]byte(m.Auth.Username + ":" + m.Auth.Password))
+		header = fmt.Sprintf("  authorization = %q\n", "Basic "+token)
+	}
+	// fall back to the registry itself when every mirror fails
+	switch m.Registry {
+	case "_default":
+	case "docker.io":
+		b.WriteString("server = \"https://registry-1.docker.io\"\n")
+	default:
+		fmt.Fprintf(&b, "server = %q\n", "https://"+m.Registry)
+	}
+	if header != "" && m.Registry != "_default" {
+		b.WriteString("\n[header]\n" + header)
+	}
+	for _, endpoint := range m.Endpoints {
+		fmt.Fprintf(&b, "\n[host.%q]\n  capabilities = [\"pull\", \"resolve\"]\n", endpoint)
+		if header != "" {
+			fmt.Fprintf(&b, "\n[host.%q.header]\n%s", endpoint, header)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestHostsTOML(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name     string
+		Mirror   config.RegistryMirror
+		Expected string
+	}{
+		{
+			Name: "docker hub",
+			Mirror: config.RegistryMirror{
+				Registry:  "docker.io",
+				Endpoints: []string{"http://kind-registry:5000", "https://mirror.gcr.io"},
+			},
+			Expected: `server = "https://registry-1.docker.io"
+
+[host."http://kind-registry:5000"]
+  capabilities = ["pull", "resolve"]
+
+[host."https://mirror.gcr.io"]
+  capabilities = ["pull", "resolve"]
+`,
+		},
+		{
+			Name: "registry with port",
+			Mirror: config.RegistryMirror{
+				Registry:  "registry.example.com:5000",
+				Endpoints: []string{"http://10.0.0.5:5000"},
+			},
+			Expected: `server = "https://registry.example.com:5000"
+
+[host."http://10.0.0.5:5000"]
+  capabilities = ["pull", "resolve"]
+`,
+		},
+		{
+			Name: "default",
+			Mirror: config.RegistryMirror{
+				Registry:  "_default",
+				Endpoints: []string{"https://cache.example.com"},
+			},
+			Expected: `
+[host."https://cache.example.com"]
+  capabilities = ["pull", "resolve"]
+`,
+		},
+		{
+			Name: "auth",
+			Mirror: config.RegistryMirror{
+				Registry:  "registry.example.com",
+				Endpoints: []string{"https://mirror.example.com"},
+				Auth:      config.RegistryAuth{Username: "ci", Password: "secret"},
+			},
+			Expected: `server = "https://registry.example.com"
+
+[header]
+  authorization = "Basic Y2k6c2VjcmV0"
+
+[host."https://mirror.example.com"]
+  capabilities = ["pull", "resolve"]
+
+[host."https://mirror.example.com".header]
+  authorization = "Basic Y2k6c2VjcmV0"
+`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.StringEqual(t, tc.Expected, hostsTOML(tc.Mirror))
+		})
+	}
+}
+
+func TestRegistryMirrors(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Cluster{
+		RegistryMirrors: []config.RegistryMirror{
+			{Registry: "docker.io", Endpoints: []string{"https://mirror.gcr.io"}},
+		},
+	}
+	assert.DeepEqual(t, cfg.RegistryMirrors, registryMirrors(cfg, nil))
+	cfg.LocalRegistry = config.LocalRegistry{Enabled: true, Port: 5001}
+	assert.DeepEqual(t, []config.RegistryMirror{
+		{Registry: "docker.io", Endpoints: []string{"https://mirror.gcr.io"}},
+		{Registry: "localhost:5001", Endpoints: []string{"http://kind-registry:5000"}},
+	}, registryMirrors(cfg, nil))
+	// caches are used for registries without a configured mirror
+	assert.DeepEqual(t, []config.RegistryMirror{
+		{Registry: "docker.io", Endpoints: []string{"https://mirror.gcr.io"}},
+		{Registry: "quay.io", Endpoints: []string{"http://kind-cache-quay-io:5000"}},
+		{Registry: "registry.k8s.io", Endpoints: []string{"http://kind-cache-registry-k8s-io:5000"}},
+		{Registry: "localhost:5001", Endpoints: []string{"http://kind-registry:5000"}},
+	}, registryMirrors(cfg, map[string]string{
+		"docker.io":       "http://kind-cache-docker-io:5000",
+		"registry.k8s.io": "http://kind-cache-registry-k8s-io:5000",
+		"quay.io":         "http://kind-cache-quay-io:5000",
+	}))
+}
+
+func TestMergeRegistryMirrors(t *testing.T) {
+	t.Parallel()
+	mirrors := []config.RegistryMirror{
+		{Registry: "docker.io", Endpoints: []string{"https://mirror.gcr.io"}},
+		{Registry: "registry.example.com", Endpoints: []string{"https://mirror.example.com"}},
+	}
+	assert.DeepEqual(t, mirrors, mergeRegistryMirrors(mirrors, nil))
+	assert.DeepEqual(t, []config.RegistryMirror{
+		{Registry: "docker.io", Endpoints: []string{"https://mirror.gcr.io"}},
+		{Registry: "registry.example.com", Auth: config.RegistryAuth{Username: "ci", Password: "secret"}},
+		{Registry: "quay.io", Endpoints: []string{"https://quay.example.com"}},
+	}, mergeRegistryMirrors(mirrors, []config.RegistryMirror{
+		{Registry: "registry.example.com", Auth: config.RegistryAuth{Username: "ci", Password: "secret"}},
+		{Registry: "quay.io", Endpoints: []string{"https://quay.example.com"}},
+	}))
+	// the cluster mirrors are not modified
+	assert.StringEqual(t, "https://mirror.example.com", mirrors[1].Endpoints[0])
+}
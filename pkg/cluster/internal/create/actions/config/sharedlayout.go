@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// SharedOCILayoutPath is where the shared OCI layout is mounted in the nodes
+const SharedOCILayoutPath = "/kind/oci-layout"
+
+// linkBlobsScript links each blob in the layout into the containerd content
+// store, so containerd reads them from the read-only mount instead of storing
+// a copy. Blobs already in the content store are left alone.
+const linkBlobsScript = `set -o errexit -o nounset
+store=/var/lib/containerd/io.containerd.content.v1.content/blobs/sha256
+mkdir -p "${store}"
+for blob in "$1"/blobs/sha256/*; do
+  digest="$(basename "${blob}")"
+  [ -e "${store}/${digest}" ] || ln -s "${blob}" "${store}/${digest}"
+done
+`
+
+// importSharedOCILayout makes the images in the shared OCI layout available
+// to containerd on node
+func importSharedOCILayout(node nodes.Node) error {
+	if err := node.Command("bash", "-c", linkBlobsScript, "-", SharedOCILayoutPath).Run(); err != nil {
+		return errors.Wrap(err, "failed to link shared OCI layout blobs")
+	}
+	// with the blobs in place this only records the images and unpacks them
+	return nodeutils.ImportOCILayout(node, SharedOCILayoutPath)
+}
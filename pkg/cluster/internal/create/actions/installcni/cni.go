@@ -31,6 +31,9 @@ import (
 	"sigs.k8s.io/kind/pkg/internal/patch"
 )
 
+// DefaultManifestPath is the node image's kindnet manifest
+const DefaultManifestPath = "/kind/manifests/default-cni.yaml"
+
 type action struct{}
 
 // NewAction returns a new action for installing default CNI
@@ -55,9 +58,20 @@ func (a *action) Execute(ctx *actions.ActionContext) error {
 	}
 	node := controlPlanes[0] // kind expects at least one always
 
+	// calico, cilium and flannel are not shipped in the node image
+	switch ctx.Config.Networking.CNI {
+	case "", config.KindnetCNI:
+	default:
+		if err := installThirdParty(ctx, node); err != nil {
+			return err
+		}
+		ctx.Status.End(true)
+		return nil
+	}
+
 	// read the manifest from the node
 	var raw bytes.Buffer
-	if err := node.Command("cat", "/kind/manifests/default-cni.yaml").SetStdout(&raw).Run(); err != nil {
+	if err := node.Command("cat", DefaultManifestPath).SetStdout(&raw).Run(); err != nil {
 		return errors.Wrap(err, "failed to read CNI manifest")
 	}
 	manifest := raw.String()
@@ -121,13 +135,27 @@ func (a *action) Execute(ctx *actions.ActionContext) error {
 	ctx.Logger.V(5).Infof("Using the following Kindnetd config:\n%s", manifest)
 
 	// install the manifest
-	if err := node.Command(
-		"kubectl", "create", "--kubeconfig=/etc/kubernetes/admin.conf",
-		"-f", "-",
-	).SetStdin(strings.NewReader(manifest)).Run(); err != nil {
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	if err := client.Create(manifest); err != nil {
 		return errors.Wrap(err, "failed to apply overlay network")
 	}
 
+	// optionally wait for the default CNI daemonset to be rolled out
+	if timeout := config.TimeoutDuration(ctx.Config.Timeouts.CNIReady); timeout > 0 {
+		if err := client.Run(
+			"rollout", "status", "daemonset/kindnet",
+			"--namespace=kube-system", "--timeout="+timeout.String(),
+		); err != nil {
+			return errors.WithDetails(
+				errors.Wrapf(err, "CNI was not ready after %s", timeout),
+				errors.Details{Category: errors.TimeoutCategory, Node: node.String()},
+			)
+		}
+	}
+
 	// mark success
 	ctx.Status.End(true)
 	return nil
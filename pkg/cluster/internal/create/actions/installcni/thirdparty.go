@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installcni
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+)
+
+// thirdPartyManifestPath is where the manifest of a third party CNI is
+// recorded on the bootstrap control plane once it is applied
+const thirdPartyManifestPath = "/kind/manifests/cni.yaml"
+
+// ManifestPath returns the path of the CNI manifest kind applied from the
+// bootstrap control plane of the cluster configured by cfg
+func ManifestPath(cfg *config.Cluster) string {
+	switch cfg.Networking.CNI {
+	case "", config.KindnetCNI:
+		return DefaultManifestPath
+	}
+	return thirdPartyManifestPath
+}
+
+// thirdPartyCNI describes how to install a CNI not shipped in the node image
+type thirdPartyCNI struct {
+	// defaultVersion is the version installed if cniVersion is unset, it is
+	// tested with kind and supports all the Kubernetes versions kind node
+	// images are published for
+	defaultVersion string
+	// versionPrefix is "v" for CNIs whose release tags have it, cniVersion
+	// may be given with or without it
+	versionPrefix string
+	// url is the format of the manifest URL given the version, or if empty
+	// manifest is the format of the manifest given the version
+	url      string
+	manifest string
+	// namespace and daemonset are the rollout waited on with timeouts.cniReady
+	namespace, daemonset string
+	// job is a Job in namespace the manifest runs to finish the install
+	job string
+}
+
+var thirdPartyCNIs = map[config.CNI]thirdPartyCNI{
+	config.CalicoCNI: {
+		defaultVersion: "v3.27.3",
+		versionPrefix:  "v",
+		url:            "https://raw.githubusercontent.com/projectcalico/calico/%s/manifests/calico.yaml",
+		namespace:      "kube-system",
+		daemonset:      "calico-node",
+	},
+	config.FlannelCNI: {
+		defaultVersion: "v0.25.1",
+		versionPrefix:  "v",
+		url:            "https://github.com/flannel-io/flannel/releases/download/%s/kube-flannel.yml",
+		namespace:      "kube-flannel",
+		daemonset:      "kube-flannel-ds",
+	},
+	// cilium does not publish a static manifest, the cilium CLI renders
+	// and applies it from a host network Job, which needs no CNI
+	config.CiliumCNI: {
+		defaultVersion: "1.15.5",
+		manifest:       ciliumInstallManifest,
+		namespace:      "kube-system",
+		daemonset:      "cilium",
+		job:            "cilium-install",
+	},
+}
+
+const ciliumInstallManifest = `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: cilium-install
+  namespace: kube-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: cilium-install
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: cluster-admin
+subjects:
+- kind: ServiceAccount
+  name: cilium-install
+  namespace: kube-system
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: cilium-install
+  namespace: kube-system
+spec:
+  backoffLimit: 4
+  template:
+    spec:
+      serviceAccountName: cilium-install
+      hostNetwork: true
+      restartPolicy: OnFailure
+      tolerations:
+      - operator: Exists
+      containers:
+      - name: cilium-install
+        image: quay.io/cilium/cilium-cli:v0.16.10
+        command:
+        - cilium
+        - install
+        - --version=%s
+        - --set=ipam.mode=kubernetes
+`
+
+// installThirdParty installs the configured third party CNI from node
+func installThirdParty(ctx *actions.ActionContext, node nodes.Node) error {
+	cni, ok := thirdPartyCNIs[ctx.Config.Networking.CNI]
+	if !ok {
+		return errors.Errorf("unknown cni %q", ctx.Config.Networking.CNI)
+	}
+	manifest, err := thirdPartyManifest(node, cni, cni.version(ctx.Config.Networking.CNIVersion), ctx.Config)
+	if err != nil {
+		return err
+	}
+
+	// pull the images on every node up front, so the CNI rolls out at once
+	// and pull failures are reported here rather than as pods never ready
+	allNodes, err := ctx.Nodes()
+	if err != nil {
+		return err
+	}
+	internalNodes, err := nodeutils.InternalNodes(allNodes)
+	if err != nil {
+		return err
+	}
+	images := manifestImages(manifest)
+	fns := []func() error{}
+	for _, n := range internalNodes {
+		n := n // capture loop variable
+		fns = append(fns, func() error {
+			for _, image := range images {
+				if err := n.Command("crictl", "pull", image).Run(); err != nil {
+					return errors.Wrapf(err, "failed to pull %s on node %s", image, n.String())
+				}
+			}
+			return nil
+		})
+	}
+	if err := errors.UntilErrorConcurrent(fns); err != nil {
+		return err
+	}
+
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	if err := client.Apply(manifest); err != nil {
+		return errors.Wrapf(err, "failed to apply %s", ctx.Config.Networking.CNI)
+	}
+	// record what was applied, e.g. for the inventory
+	if err := nodeutils.WriteFile(node, thirdPartyManifestPath, manifest); err != nil {
+		return err
+	}
+
+	// optionally wait for the CNI to be rolled out
+	if timeout := config.TimeoutDuration(ctx.Config.Timeouts.CNIReady); timeout > 0 {
+		if cni.job != "" {
+			if err := client.Wait("condition=complete", timeout, "job/"+cni.job, "--namespace="+cni.namespace); err != nil {
+				return errors.WithDetails(
+					errors.Wrapf(err, "CNI install job was not complete after %s", timeout),
+					errors.Details{Category: errors.TimeoutCategory, Node: node.String()},
+				)
+			}
+		}
+		if err := client.Run(
+			"rollout", "status", "daemonset/"+cni.daemonset,
+			"--namespace="+cni.namespace, "--timeout="+timeout.String(),
+		); err != nil {
+			return errors.WithDetails(
+				errors.Wrapf(err, "CNI was not ready after %s", timeout),
+				errors.Details{Category: errors.TimeoutCategory, Node: node.String()},
+			)
+		}
+	}
+	return nil
+}
+
+// version returns the version of cni to install given the configured
+// cniVersion, with the prefix the release tags of cni use
+func (cni thirdPartyCNI) version(cniVersion string) string {
+	if cniVersion == "" {
+		return cni.defaultVersion
+	}
+	return cni.versionPrefix + strings.TrimPrefix(cniVersion, "v")
+}
+
+// thirdPartyManifest returns the manifest of cni at version for cfg,
+// downloading it from node so that the node's proxy settings apply
+func thirdPartyManifest(node nodes.Node, cni thirdPartyCNI, version string, cfg *config.Cluster) (string, error) {
+	if cni.url == "" {
+		return fmt.Sprintf(cni.manifest, version), nil
+	}
+	url := fmt.Sprintf(cni.url, version)
+	raw, err := exec.Output(node.Command("curl", "-fsSL", "--retry", "3", url))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to download CNI manifest %s", url)
+	}
+	manifest := string(raw)
+	// flannel's manifest hardcodes the kubeadm default pod subnet
+	if cfg.Networking.CNI == config.FlannelCNI {
+		manifest = strings.ReplaceAll(manifest, `"Network": "10.244.0.0/16"`, fmt.Sprintf("%q: %q", "Network", cfg.Networking.PodSubnet))
+	}
+	return manifest, nil
+}
+
+// imageRE matches the image of a container in a manifest
+var imageRE = regexp.MustCompile(`(?m)^\s*-?\s*image:\s*["']?([^"'\s]+)["']?\s*$`)
+
+// manifestImages returns the unique images of the containers in manifest
+func manifestImages(manifest string) []string {
+	images := []string{}
+	seen := map[string]bool{}
+	for _, m := range imageRE.FindAllStringSubmatch(manifest, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			images = append(images, m[1])
+		}
+	}
+	return images
+}
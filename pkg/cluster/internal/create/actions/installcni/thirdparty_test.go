@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installcni
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestManifestImages(t *testing.T) {
+	t.Parallel()
+	manifest := `spec:
+  initContainers:
+  - name: install-cni
+    image: docker.io/calico/cni:v3.27.3
+  containers:
+  - image: "docker.io/calico/node:v3.27.3"
+    name: calico-node
+  - name: sidecar
+    image: docker.io/calico/cni:v3.27.3
+    # image: commented.example.com/ignored:v1
+`
+	assert.DeepEqual(t, []string{"docker.io/calico/cni:v3.27.3", "docker.io/calico/node:v3.27.3"}, manifestImages(manifest))
+}
+
+func TestManifestPath(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Cluster{}
+	assert.StringEqual(t, DefaultManifestPath, ManifestPath(cfg))
+	cfg.Networking.CNI = config.CalicoCNI
+	assert.StringEqual(t, thirdPartyManifestPath, ManifestPath(cfg))
+}
+
+func TestCiliumManifest(t *testing.T) {
+	t.Parallel()
+	manifest, err := thirdPartyManifest(nil, thirdPartyCNIs[config.CiliumCNI], "1.15.5", &config.Cluster{})
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []string{"quay.io/cilium/cilium-cli:v0.16.10"}, manifestImages(manifest))
+}
+
+func TestThirdPartyCNIVersion(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name       string
+		CNI        config.CNI
+		CNIVersion string
+		Expected   string
+	}{
+		{Name: "calico default", CNI: config.CalicoCNI, Expected: "v3.27.3"},
+		{Name: "calico with prefix", CNI: config.CalicoCNI, CNIVersion: "v3.28.0", Expected: "v3.28.0"},
+		{Name: "calico without prefix", CNI: config.CalicoCNI, CNIVersion: "3.28.0", Expected: "v3.28.0"},
+		{Name: "flannel without prefix", CNI: config.FlannelCNI, CNIVersion: "0.26.0", Expected: "v0.26.0"},
+		{Name: "cilium default", CNI: config.CiliumCNI, Expected: "1.15.5"},
+		{Name: "cilium with prefix", CNI: config.CiliumCNI, CNIVersion: "v1.16.0", Expected: "1.16.0"},
+		{Name: "cilium without prefix", CNI: config.CiliumCNI, CNIVersion: "1.16.0", Expected: "1.16.0"},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.StringEqual(t, tc.Expected, thirdPartyCNIs[tc.CNI].version(tc.CNIVersion))
+		})
+	}
+}
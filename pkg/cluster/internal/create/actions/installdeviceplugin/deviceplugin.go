@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package installdeviceplugin implements the action to install a
+// Kubernetes device plugin, e.g. for GPUs
+package installdeviceplugin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	"sigs.k8s.io/kind/pkg/cluster/internal/kubectl"
+)
+
+// defaultNVIDIAImage is the pinned NVIDIA device plugin image, this version
+// supports all the Kubernetes versions kind node images are published for
+const defaultNVIDIAImage = "nvcr.io/nvidia/k8s-device-plugin:v0.15.0"
+
+// nvidiaResource is the extended resource the NVIDIA device plugin exposes
+const nvidiaResource = "nvidia.com/gpu"
+
+// based on https://github.com/NVIDIA/k8s-device-plugin/blob/v0.15.0/deployments/static/nvidia-device-plugin.yml
+// with a control-plane toleration so single node clusters can use GPUs
+const nvidiaManifest = `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: nvidia-device-plugin-daemonset
+  namespace: kube-system
+spec:
+  selector:
+    matchLabels:
+      name: nvidia-device-plugin-ds
+  updateStrategy:
+    type: RollingUpdate
+  template:
+    metadata:
+      labels:
+        name: nvidia-device-plugin-ds
+    spec:
+      tolerations:
+      - key: nvidia.com/gpu
+        operator: Exists
+        effect: NoSchedule
+      - key: node-role.kubernetes.io/control-plane
+        operator: Exists
+        effect: NoSchedule
+      priorityClassName: system-node-critical
+      containers:
+      - image: %s
+        name: nvidia-device-plugin-ctr
+        env:
+        - name: FAIL_ON_INIT_ERROR
+          value: "false"
+        securityContext:
+          allowPrivilegeEscalation: false
+          capabilities:
+            drop: ["ALL"]
+        volumeMounts:
+        - name: device-plugin
+          mountPath: /var/lib/kubelet/device-plugins
+      volumes:
+      - name: device-plugin
+        hostPath:
+          path: /var/lib/kubelet/device-plugins
+`
+
+type action struct{}
+
+// NewAction returns a new action for installing the configured device plugin
+func NewAction() actions.Action {
+	return &action{}
+}
+
+// Execute runs the action
+func (a *action) Execute(ctx *actions.ActionContext) error {
+	plugin := ctx.Config.DevicePlugin
+	if plugin.Type == "" {
+		return nil
+	}
+	ctx.Status.Start(fmt.Sprintf("Installing %s device plugin 🎮", plugin.Type))
+	defer ctx.Status.End(false)
+
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	manifest, resource := devicePluginManifest(plugin)
+	if err := client.Apply(manifest); err != nil {
+		return errors.Wrap(err, "failed to install device plugin")
+	}
+	ctx.RecordArtifact(actions.Artifact{Name: "device-plugin-" + string(plugin.Type), Contents: []byte(manifest)})
+
+	// wait for the devices, so that pods requesting them schedule immediately
+	// the cluster is usable without them, so this only warns
+	timeout := config.TimeoutDuration(ctx.Config.Timeouts.DevicePluginReady)
+	if timeout > 0 {
+		if err := waitForAllocatable(client, resource, timeout); err != nil {
+			ctx.Status.End(false)
+			ctx.Logger.Warnf("%v, check that the devices are passed through to the nodes, or set timeouts.devicePluginReady to 0s to not wait", err)
+			return nil
+		}
+	}
+
+	// mark success
+	ctx.Status.End(true)
+	return nil
+}
+
+// devicePluginManifest returns the manifest for plugin and the name of the
+// resource it exposes
+func devicePluginManifest(plugin config.DevicePlugin) (string, string) {
+	image := plugin.Image
+	if image == "" {
+		image = defaultNVIDIAImage
+	}
+	return fmt.Sprintf(nvidiaManifest, image), nvidiaResource
+}
+
+// nodeList is the subset of a NodeList we need
+type nodeList struct {
+	Items []struct {
+		Status struct {
+			Allocatable map[string]string `json:"allocatable"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// waitForAllocatable waits until at least one node has resource allocatable
+func waitForAllocatable(client *kubectl.Client, resource string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		var nodes nodeList
+		if err := client.Get(&nodes, "nodes"); err == nil && hasAllocatable(&nodes, resource) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out after %s waiting for %s to be allocatable on any node", timeout, resource)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// hasAllocatable returns true if any node has a non-zero quantity of resource
+func hasAllocatable(nodes *nodeList, resource string) bool {
+	for _, n := range nodes.Items {
+		if q := strings.TrimSpace(n.Status.Allocatable[resource]); q != "" && q != "0" {
+			return true
+		}
+	}
+	return false
+}
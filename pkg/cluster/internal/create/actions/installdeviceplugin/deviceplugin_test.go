@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installdeviceplugin
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestDevicePluginManifest(t *testing.T) {
+	t.Parallel()
+	manifest, resource := devicePluginManifest(config.DevicePlugin{Type: config.NVIDIADevicePlugin})
+	assert.StringEqual(t, "nvidia.com/gpu", resource)
+	assert.BoolEqual(t, true, strings.Contains(manifest, "image: "+defaultNVIDIAImage+"\n"))
+	manifest, _ = devicePluginManifest(config.DevicePlugin{
+		Type:  config.NVIDIADevicePlugin,
+		Image: "registry.example.com/k8s-device-plugin:dev",
+	})
+	assert.BoolEqual(t, true, strings.Contains(manifest, "image: registry.example.com/k8s-device-plugin:dev\n"))
+}
+
+func TestHasAllocatable(t *testing.T) {
+	t.Parallel()
+	var nodes nodeList
+	raw := `{"items": [
+		{"status": {"allocatable": {"cpu": "8", "nvidia.com/gpu": "0"}}},
+		{"status": {"allocatable": {"cpu": "8"}}}
+	]}`
+	assert.ExpectError(t, false, json.Unmarshal([]byte(raw), &nodes))
+	assert.BoolEqual(t, false, hasAllocatable(&nodes, "nvidia.com/gpu"))
+	nodes.Items[1].Status.Allocatable["nvidia.com/gpu"] = "2"
+	assert.BoolEqual(t, true, hasAllocatable(&nodes, "nvidia.com/gpu"))
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package installingress implements the action to install an ingress
+// controller serving on the ingress node's host ports
+package installingress
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	"sigs.k8s.io/kind/pkg/cluster/internal/kubectl"
+)
+
+// controller describes how to install an ingress controller
+type controller struct {
+	// url is an upstream manifest with kind specific patches, applied from
+	// the node, or manifest is applied if url is empty
+	url      string
+	manifest string
+	// patches are strategic merge patches applied after the manifest
+	patches []patch
+	// rollouts are waited on for the controller to be ready
+	rollouts []rollout
+}
+
+type patch struct {
+	namespace, resource, patch string
+}
+
+type rollout struct {
+	namespace, resource string
+}
+
+// these versions support all the Kubernetes versions kind node images are
+// published for, the nginx manifest schedules to the ingress-ready node
+// and tolerates the control-plane taint already
+var controllers = map[config.IngressController]controller{
+	config.NginxIngress: {
+		url: "https://raw.githubusercontent.com/kubernetes/ingress-nginx/controller-v1.10.1/deploy/static/provider/kind/deploy.yaml",
+		rollouts: []rollout{
+			{"ingress-nginx", "deployment/ingress-nginx-controller"},
+		},
+	},
+	// patched as in https://projectcontour.io/docs/1.28/guides/kind/
+	config.ContourIngress: {
+		url: "https://raw.githubusercontent.com/projectcontour/contour/v1.28.2/examples/render/contour.yaml",
+		patches: []patch{{
+			namespace: "projectcontour",
+			resource:  "daemonset/envoy",
+			patch:     `{"spec":{"template":{"spec":{"nodeSelector":{"ingress-ready":"true"},"tolerations":[{"key":"node-role.kubernetes.io/control-plane","operator":"Exists","effect":"NoSchedule"}]}}}}`,
+		}},
+		rollouts: []rollout{
+			{"projectcontour", "deployment/contour"},
+			{"projectcontour", "daemonset/envoy"},
+		},
+	},
+	config.TraefikIngress: {
+		manifest: traefikManifest,
+		rollouts: []rollout{
+			{"traefik", "deployment/traefik"},
+		},
+	},
+}
+
+// traefik does not publish a static manifest, this is based on
+// https://doc.traefik.io/traefik/v2.11/providers/kubernetes-ingress/
+// with host ports and scheduling matching the nginx kind manifest
+const traefikManifest = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: traefik
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: traefik
+  namespace: traefik
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: traefik
+rules:
+- apiGroups: [""]
+  resources: ["services", "endpoints", "secrets"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: ["extensions", "networking.k8s.io"]
+  resources: ["ingresses", "ingressclasses"]
+  verbs: ["get", "list", "watch"]
+- apiGroups: ["extensions", "networking.k8s.io"]
+  resources: ["ingresses/status"]
+  verbs: ["update"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: traefik
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: traefik
+subjects:
+- kind: ServiceAccount
+  name: traefik
+  namespace: traefik
+---
+apiVersion: networking.k8s.io/v1
+kind: IngressClass
+metadata:
+  name: traefik
+spec:
+  controller: traefik.io/ingress-controller
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: traefik
+  namespace: traefik
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: traefik
+  template:
+    metadata:
+      labels:
+        app: traefik
+    spec:
+      serviceAccountName: traefik
+      nodeSelector:
+        ingress-ready: "true"
+      tolerations:
+      - key: node-role.kubernetes.io/control-plane
+        operator: Exists
+        effect: NoSchedule
+      containers:
+      - name: traefik
+        image: docker.io/library/traefik:v2.11
+        args:
+        - --entrypoints.web.address=:8000
+        - --entrypoints.websecure.address=:8443
+        - --providers.kubernetesingress
+        - --ping
+        ports:
+        - name: web
+          containerPort: 8000
+          hostPort: 80
+        - name: websecure
+          containerPort: 8443
+          hostPort: 443
+        readinessProbe:
+          httpGet:
+            path: /ping
+            port: 8080
+`
+
+type action struct{}
+
+// NewAction returns a new action for installing the configured ingress controller
+func NewAction() actions.Action {
+	return &action{}
+}
+
+// Execute runs the action
+func (a *action) Execute(ctx *actions.ActionContext) error {
+	name := ctx.Config.Ingress.Controller
+	if name == "" {
+		return nil
+	}
+	ctx.Status.Start(fmt.Sprintf("Installing %s ingress controller 🚪", name))
+	defer ctx.Status.End(false)
+
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	if err := install(client, controllers[name]); err != nil {
+		return errors.Wrapf(err, "failed to install %s ingress controller", name)
+	}
+	ctx.RecordArtifact(artifact(string(name), controllers[name]))
+
+	// wait for the controller, so that ingresses work once create returns
+	if timeout := config.TimeoutDuration(ctx.Config.Timeouts.IngressReady); timeout > 0 {
+		for _, r := range controllers[name].rollouts {
+			if err := client.Run(
+				"rollout", "status", r.resource,
+				"--namespace="+r.namespace, "--timeout="+timeout.String(),
+			); err != nil {
+				return errors.WithDetails(
+					errors.Wrapf(err, "%s ingress controller was not ready after %s", name, timeout),
+					errors.Details{
+						Category: errors.TimeoutCategory,
+						Hint:     "check that the node can pull the ingress controller images, or set timeouts.ingressReady to 0s to not wait",
+					},
+				)
+			}
+		}
+	}
+
+	// mark success
+	ctx.Status.End(true)
+	return nil
+}
+
+// artifact returns the inventory record of installing c
+func artifact(name string, c controller) actions.Artifact {
+	a := actions.Artifact{Name: "ingress-" + name, Source: c.url}
+	if c.url == "" {
+		a.Contents = []byte(c.manifest)
+	}
+	return a
+}
+
+// install applies the manifest and patches of c
+func install(client *kubectl.Client, c controller) error {
+	if c.url != "" {
+		if err := client.Run("apply", "-f", c.url); err != nil {
+			return err
+		}
+	} else if err := client.Apply(c.manifest); err != nil {
+		return err
+	}
+	for _, p := range c.patches {
+		if err := client.Run(
+			"patch", p.resource, "--namespace="+p.namespace,
+			"--type=strategic", "--patch="+p.patch,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installingress
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestControllers(t *testing.T) {
+	t.Parallel()
+	for _, name := range []config.IngressController{
+		config.NginxIngress,
+		config.ContourIngress,
+		config.TraefikIngress,
+	} {
+		name := name // capture range variable
+		t.Run(string(name), func(t *testing.T) {
+			t.Parallel()
+			c, ok := controllers[name]
+			assert.BoolEqual(t, true, ok)
+			assert.BoolEqual(t, true, (c.url == "") != (c.manifest == ""))
+			assert.BoolEqual(t, true, len(c.rollouts) > 0)
+		})
+	}
+}
+
+func TestTraefikManifest(t *testing.T) {
+	t.Parallel()
+	for _, doc := range strings.Split(traefikManifest, "\n---\n") {
+		var object struct {
+			Kind string `json:"kind"`
+		}
+		assert.ExpectError(t, false, yaml.Unmarshal([]byte(doc), &object))
+		assert.BoolEqual(t, true, object.Kind != "")
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package installservicelb implements the action to install an
+// implementation of Services of type LoadBalancer
+package installservicelb
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+)
+
+// metallbManifestURL is the pinned MetalLB manifest, this version supports
+// all the Kubernetes versions kind node images are published for
+const metallbManifestURL = "https://raw.githubusercontent.com/metallb/metallb/v0.14.5/config/manifests/metallb-native.yaml"
+
+// metallbConfig allocates the addresses and announces them on the node network
+const metallbConfig = `apiVersion: metallb.io/v1beta1
+kind: IPAddressPool
+metadata:
+  name: kind
+  namespace: metallb-system
+spec:
+  addresses:
+%s---
+apiVersion: metallb.io/v1beta1
+kind: L2Advertisement
+metadata:
+  name: kind
+  namespace: metallb-system
+`
+
+// configRetryTimeout bounds waiting for the MetalLB webhook to serve once
+// the controller is rolled out, the webhook rejects the config until then
+const configRetryTimeout = time.Minute
+
+type action struct{}
+
+// NewAction returns a new action for installing the service load balancer
+func NewAction() actions.Action {
+	return &action{}
+}
+
+// Execute runs the action
+func (a *action) Execute(ctx *actions.ActionContext) error {
+	if ctx.Config.ServiceLoadBalancer.Type == "" {
+		return nil
+	}
+	ctx.Status.Start("Installing service load balancer 🔀")
+	defer ctx.Status.End(false)
+
+	addresses := ctx.Config.ServiceLoadBalancer.Addresses
+	if len(addresses) == 0 {
+		allNodes, err := ctx.Nodes()
+		if err != nil {
+			return err
+		}
+		node, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+		if err != nil {
+			return err
+		}
+		addresses, err = defaultAddresses(node, ctx.Config.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	if err := client.Run("apply", "-f", metallbManifestURL); err != nil {
+		return errors.Wrap(err, "failed to install MetalLB")
+	}
+	ctx.RecordArtifact(actions.Artifact{Name: "service-lb", Source: metallbManifestURL})
+	timeout := config.TimeoutDuration(ctx.Config.Timeouts.ServiceLoadBalancerReady)
+	for _, resource := range []string{"deployment/controller", "daemonset/speaker"} {
+		if err := client.Run(
+			"rollout", "status", resource,
+			"--namespace=metallb-system", "--timeout="+timeout.String(),
+		); err != nil {
+			return errors.WithDetails(
+				errors.Wrapf(err, "MetalLB was not ready after %s", timeout),
+				errors.Details{
+					Category: errors.TimeoutCategory,
+					Hint:     "check that the nodes can pull the MetalLB images, or increase timeouts.serviceLoadBalancerReady",
+				},
+			)
+		}
+	}
+
+	manifest := poolManifest(addresses)
+	deadline := time.Now().Add(configRetryTimeout)
+	for {
+		err := client.Apply(manifest)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			return errors.Wrap(err, "failed to configure MetalLB addresses")
+		}
+		time.Sleep(2 * time.Second)
+	}
+	ctx.RecordArtifact(actions.Artifact{Name: "service-lb-config", Contents: []byte(manifest)})
+
+	ctx.Logger.V(0).Infof(" • Services of type LoadBalancer get addresses from: %s", strings.Join(addresses, ", "))
+
+	// mark success
+	ctx.Status.End(true)
+	return nil
+}
+
+// poolManifest returns the MetalLB config allocating addresses
+func poolManifest(addresses []string) string {
+	var b strings.Builder
+	for _, a := range addresses {
+		fmt.Fprintf(&b, "  - %s\n", a)
+	}
+	return fmt.Sprintf(metallbConfig, b.String())
+}
+
+// defaultAddresses returns an address range of the cluster in the upper half
+// of each of the subnets of node's addresses on the node network
+func defaultAddresses(node nodes.Node, cluster string) ([]string, error) {
+	ipv4, ipv6, err := node.IP()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get node IP")
+	}
+	lines, err := exec.OutputLines(node.Command("ip", "-o", "addr", "show"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get node addresses")
+	}
+	subnets := nodeSubnets(lines, ipv4, ipv6)
+	if len(subnets) == 0 {
+		return nil, errors.Errorf("failed to find the subnet of node addresses %q", strings.Trim(ipv4+","+ipv6, ","))
+	}
+	addresses := []string{}
+	for _, subnet := range subnets {
+		r, err := addressRange(subnet, cluster)
+		if err != nil {
+			return nil, errors.WithDetails(err, errors.Details{
+				Hint: "set serviceLoadBalancer.addresses to the ranges to allocate",
+			})
+		}
+		addresses = append(addresses, r)
+	}
+	return addresses, nil
+}
+
+// nodeSubnets returns the subnets of the node IPs, given `ip -o addr show`
+// lines like: 2: eth0    inet 172.18.0.2/16 brd 172.18.255.255 scope global eth0
+func nodeSubnets(lines []string, nodeIPs ...string) []string {
+	subnets := []string{}
+	for _, nodeIP := range nodeIPs {
+		ip := net.ParseIP(nodeIP)
+		if ip == nil {
+			continue
+		}
+		for _, line := range lines {
+			fields := strings.Fields(line)
+			for i := 0; i+1 < len(fields); i++ {
+				if fields[i] != "inet" && fields[i] != "inet6" {
+					continue
+				}
+				addr, subnet, err := net.ParseCIDR(fields[i+1])
+				if err == nil && addr.Equal(ip) {
+					subnets = append(subnets, subnet.String())
+				}
+			}
+		}
+	}
+	return subnets
+}
+
+// addressRange returns the range of 51 addresses for cluster in subnet,
+// ending 5 before the end of the cluster address block, so that clusters
+// sharing the node network do not announce the same addresses, e.g.
+// 172.18.255.200-172.18.255.250 is in the last block of 172.18.0.0/16
+// The addresses after the range are left to the DNS server and broadcast
+func addressRange(subnet, cluster string) (string, error) {
+	first, err := common.ClusterAddress(subnet, cluster, 55)
+	if err != nil {
+		return "", err
+	}
+	last, err := common.ClusterAddress(subnet, cluster, 5)
+	if err != nil {
+		return "", err
+	}
+	return first + "-" + last, nil
+}
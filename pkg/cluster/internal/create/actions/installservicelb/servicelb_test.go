@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installservicelb
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestNodeSubnets(t *testing.T) {
+	t.Parallel()
+	lines := []string{
+		`1: lo    inet 127.0.0.1/8 scope host lo\       valid_lft forever preferred_lft forever`,
+		`5: eth0    inet 172.18.0.2/16 brd 172.18.255.255 scope global eth0\       valid_lft forever preferred_lft forever`,
+		`5: eth0    inet6 fc00:f853:ccd:e793::2/64 scope global nodad \       valid_lft forever preferred_lft forever`,
+		`7: eth1    inet 10.10.0.2/24 brd 10.10.0.255 scope global eth1\       valid_lft forever preferred_lft forever`,
+	}
+	assert.DeepEqual(t, []string{"172.18.0.0/16", "fc00:f853:ccd:e793::/64"}, nodeSubnets(lines, "172.18.0.2", "fc00:f853:ccd:e793::2"))
+	assert.DeepEqual(t, []string{"172.18.0.0/16"}, nodeSubnets(lines, "172.18.0.2", ""))
+	assert.DeepEqual(t, []string{}, nodeSubnets(lines, "192.168.0.2", ""))
+}
+
+func TestAddressRange(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Subnet      string
+		Cluster     string
+		Expected    string
+		ExpectError bool
+	}{
+		{Subnet: "172.18.0.0/16", Cluster: "kind", Expected: "172.18.239.8-172.18.239.58"},
+		// other clusters get other addresses
+		{Subnet: "172.18.0.0/16", Cluster: "foo", Expected: "172.18.202.8-172.18.202.58"},
+		{Subnet: "10.89.0.0/24", Cluster: "kind", Expected: "10.89.0.136-10.89.0.186"},
+		{Subnet: "10.89.0.0/24", Cluster: "bar", Expected: "10.89.0.200-10.89.0.250"},
+		{Subnet: "fc00:f853:ccd:e793::/64", Cluster: "kind", Expected: "fc00:f853:ccd:e793:ffff:ffff:ffc7:6f08-fc00:f853:ccd:e793:ffff:ffff:ffc7:6f3a"},
+		{Subnet: "10.89.0.0/25", Cluster: "kind", ExpectError: true},
+		{Subnet: "bogus", Cluster: "kind", ExpectError: true},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Subnet+"/"+tc.Cluster, func(t *testing.T) {
+			t.Parallel()
+			result, err := addressRange(tc.Subnet, tc.Cluster)
+			assert.ExpectError(t, tc.ExpectError, err)
+			assert.StringEqual(t, tc.Expected, result)
+		})
+	}
+}
+
+func TestPoolManifest(t *testing.T) {
+	t.Parallel()
+	assert.StringEqual(t, `apiVersion: metallb.io/v1beta1
+kind: IPAddressPool
+metadata:
+  name: kind
+  namespace: metallb-system
+spec:
+  addresses:
+  - 172.18.255.200-172.18.255.250
+  - fc00:f853:ccd:e793::ff00/120
+---
+apiVersion: metallb.io/v1beta1
+kind: L2Advertisement
+metadata:
+  name: kind
+  namespace: metallb-system
+`, poolManifest([]string{"172.18.255.200-172.18.255.250", "fc00:f853:ccd:e793::ff00/120"}))
+}
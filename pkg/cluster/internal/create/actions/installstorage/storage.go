@@ -20,6 +20,7 @@ package installstorage
 
 import (
 	"bytes"
+	"regexp"
 	"strings"
 
 	"sigs.k8s.io/kind/pkg/cluster/nodes"
@@ -27,7 +28,9 @@ import (
 	"sigs.k8s.io/kind/pkg/log"
 
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	"sigs.k8s.io/kind/pkg/cluster/internal/kubectl"
 	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
 )
 
 type action struct{}
@@ -54,8 +57,13 @@ func (a *action) Execute(ctx *actions.ActionContext) error {
 	}
 	node := controlPlanes[0] // kind expects at least one always
 
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+
 	// add the default storage class
-	if err := addDefaultStorage(ctx.Logger, node); err != nil {
+	if err := addDefaultStorage(ctx.Logger, node, client, ctx.Config.StorageClass); err != nil {
 		return errors.Wrap(err, "failed to add default storage class")
 	}
 
@@ -77,7 +85,7 @@ metadata:
     storageclass.kubernetes.io/is-default-class: "true"
 provisioner: kubernetes.io/host-path`
 
-func addDefaultStorage(logger log.Logger, controlPlane nodes.Node) error {
+func addDefaultStorage(logger log.Logger, controlPlane nodes.Node, client *kubectl.Client, storageClass config.StorageClass) error {
 	// start with fallback default, and then try to get the newer kind node
 	// storage manifest if present
 	manifest := defaultStorageManifest
@@ -87,13 +95,34 @@ func addDefaultStorage(logger log.Logger, controlPlane nodes.Node) error {
 	} else {
 		manifest = raw.String()
 	}
+	manifest = customizeManifest(manifest, storageClass)
 
 	// apply the manifest
-	in := strings.NewReader(manifest)
-	cmd := controlPlane.Command(
-		"kubectl",
-		"--kubeconfig=/etc/kubernetes/admin.conf", "apply", "-f", "-",
-	)
-	cmd.SetStdin(in)
-	return cmd.Run()
+	return client.Apply(manifest)
+}
+
+var provisionerImageRE = regexp.MustCompile(`(?m)^([ \t]*image:[ \t]*)\S*local-path-provisioner\S*[ \t]*$`)
+var nameRE = regexp.MustCompile(`(?m)^  name: .*$`)
+
+// customizeManifest applies the StorageClass name and provisioner image
+// overrides from the config to the storage manifest
+func customizeManifest(manifest string, storageClass config.StorageClass) string {
+	if storageClass.ProvisionerImage != "" {
+		manifest = provisionerImageRE.ReplaceAllString(manifest, "${1}"+storageClass.ProvisionerImage)
+	}
+	if storageClass.Name == "" {
+		return manifest
+	}
+	// rename the StorageClass object
+	docs := strings.Split(manifest, "\n---")
+	for i, doc := range docs {
+		if !strings.Contains("\n"+doc, "\nkind: StorageClass") {
+			continue
+		}
+		// the first top level name field is metadata.name
+		if loc := nameRE.FindStringIndex(doc); loc != nil {
+			docs[i] = doc[:loc[0]] + "  name: " + storageClass.Name + doc[loc[1]:]
+		}
+	}
+	return strings.Join(docs, "\n---")
 }
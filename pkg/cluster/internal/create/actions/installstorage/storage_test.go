@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installstorage
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+const testManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: local-path-provisioner
+  namespace: local-path-storage
+spec:
+  template:
+    spec:
+      containers:
+        - name: local-path-provisioner
+          image: docker.io/kindest/local-path-provisioner:v20240813-c6f155d6
+          command:
+            - --helper-image
+            - docker.io/kindest/local-path-helper:v20230510-486859a6
+
+---
+apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  name: standard
+  namespace: kube-system
+  annotations:
+    storageclass.kubernetes.io/is-default-class: "true"
+provisioner: rancher.io/local-path
+`
+
+func TestCustomizeManifest(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name         string
+		Manifest     string
+		StorageClass config.StorageClass
+		Expected     string
+	}{
+		{
+			Name:         "defaults",
+			Manifest:     testManifest,
+			StorageClass: config.StorageClass{Name: "standard"},
+			Expected:     testManifest,
+		},
+		{
+			Name:     "custom name and image",
+			Manifest: testManifest,
+			StorageClass: config.StorageClass{
+				Name:             "local",
+				ProvisionerImage: "registry.example.com/local-path-provisioner:dev",
+			},
+			Expected: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: local-path-provisioner
+  namespace: local-path-storage
+spec:
+  template:
+    spec:
+      containers:
+        - name: local-path-provisioner
+          image: registry.example.com/local-path-provisioner:dev
+          command:
+            - --helper-image
+            - docker.io/kindest/local-path-helper:v20230510-486859a6
+
+---
+apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  name: local
+  namespace: kube-system
+  annotations:
+    storageclass.kubernetes.io/is-default-class: "true"
+provisioner: rancher.io/local-path
+`,
+		},
+		{
+			Name:         "legacy manifest",
+			Manifest:     defaultStorageManifest,
+			StorageClass: config.StorageClass{Name: "local"},
+			Expected: `# host-path based default storage class
+apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  namespace: kube-system
+  name: local
+  annotations:
+    storageclass.kubernetes.io/is-default-class: "true"
+provisioner: kubernetes.io/host-path`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.StringEqual(t, tc.Expected, customizeManifest(tc.Manifest, tc.StorageClass))
+		})
+	}
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory implements an action to record a manifest of the
+// software kind installed into the cluster
+package inventory
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/version"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/installcni"
+	"sigs.k8s.io/kind/pkg/cluster/internal/loadbalancer"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+)
+
+// Path is the location of the inventory on the bootstrap control plane node
+const Path = "/kind/inventory.json"
+
+// ConfigMapName is the name of the kube-system ConfigMap holding the inventory
+const ConfigMapName = "kind-inventory"
+
+// Inventory is a manifest of everything kind installed into a cluster
+type Inventory struct {
+	// KindVersion is the version of kind that created the cluster
+	KindVersion string `json:"kindVersion"`
+	// Nodes are the node containers and their component versions
+	Nodes []Node `json:"nodes"`
+	// Images are the container images present on the nodes
+	Images []Image `json:"images"`
+	// Manifests are the manifests kind applied to the cluster
+	Manifests []Manifest `json:"manifests"`
+	// Containers are the containers kind runs next to the nodes for the
+	// cluster, e.g. the OIDC provider
+	Containers []Container `json:"containers,omitempty"`
+}
+
+// Node describes a node container
+type Node struct {
+	Name        string `json:"name"`
+	Role        string `json:"role"`
+	Image       string `json:"image"`
+	ImageDigest string `json:"imageDigest,omitempty"`
+	// Binaries maps binary names to their reported versions
+	Binaries map[string]string `json:"binaries,omitempty"`
+}
+
+// Image describes a container image and the nodes it is present on
+type Image struct {
+	Name   string   `json:"name"`
+	Digest string   `json:"digest"`
+	Nodes  []string `json:"nodes"`
+}
+
+// Manifest describes a manifest kind applied to the cluster
+type Manifest struct {
+	Name string `json:"name"`
+	// Path is the path on the node or the URL the manifest was applied from,
+	// if any, built-in manifests have none
+	Path   string `json:"path,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// Container describes a container kind runs for the cluster
+type Container struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+// versionCommands are the binaries recorded for each kubernetes node
+var versionCommands = map[string][]string{
+	"kubernetes": {"cat", "/kind/version"},
+	"kubeadm":    {"kubeadm", "version", "-o", "short"},
+	"kubelet":    {"kubelet", "--version"},
+	"containerd": {"containerd", "--version"},
+	"crictl":     {"crictl", "--version"},
+}
+
+type action struct{}
+
+// NewAction returns a new action for recording the cluster inventory
+func NewAction() actions.Action {
+	return &action{}
+}
+
+// Execute runs the action
+// The inventory is informational, failing to record it only warns
+func (a *action) Execute(ctx *actions.ActionContext) error {
+	ctx.Status.Start("Recording software inventory 🧾")
+	if err := record(ctx); err != nil {
+		ctx.Status.End(false)
+		ctx.Logger.Warnf("Failed to record the software inventory: %v", err)
+		return nil
+	}
+	ctx.Status.End(true)
+	return nil
+}
+
+// record collects the inventory and stores it on the node and in the cluster
+func record(ctx *actions.ActionContext) error {
+	allNodes, err := ctx.Nodes()
+	if err != nil {
+		return err
+	}
+	node, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+	if err != nil {
+		return err
+	}
+
+	inv := &Inventory{
+		KindVersion: version.KindVersion(),
+	}
+
+	// collect the node containers
+	images := map[string]*Image{}
+	var mu sync.Mutex
+	fns := []func() error{}
+	for _, n := range allNodes {
+		n := n // capture loop variable
+		fns = append(fns, func() error {
+			ni, nodeImages, err := collectNode(ctx, n)
+			if err != nil {
+				return errors.Wrapf(err, "failed to collect inventory for node %s", n.String())
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			inv.Nodes = append(inv.Nodes, ni)
+			for _, image := range nodeImages {
+				key := image.Name + "@" + image.Digest
+				if images[key] == nil {
+					images[key] = &Image{Name: image.Name, Digest: image.Digest}
+				}
+				images[key].Nodes = append(images[key].Nodes, n.String())
+			}
+			return nil
+		})
+	}
+	if err := errors.UntilErrorConcurrent(fns); err != nil {
+		return err
+	}
+	sort.Slice(inv.Nodes, func(i, j int) bool { return inv.Nodes[i].Name < inv.Nodes[j].Name })
+	inv.Images = []Image{}
+	for _, image := range images {
+		sort.Strings(image.Nodes)
+		inv.Images = append(inv.Images, *image)
+	}
+	sort.Slice(inv.Images, func(i, j int) bool {
+		if inv.Images[i].Name != inv.Images[j].Name {
+			return inv.Images[i].Name < inv.Images[j].Name
+		}
+		return inv.Images[i].Digest < inv.Images[j].Digest
+	})
+
+	// collect the manifests kind applied
+	inv.Manifests = []Manifest{}
+	if !ctx.Config.Networking.DisableDefaultCNI {
+		m, err := collectManifest(node, "cni", installcni.ManifestPath(ctx.Config))
+		if err != nil {
+			return err
+		}
+		inv.Manifests = append(inv.Manifests, m)
+	}
+	if !ctx.Config.StorageClass.DisableDefault {
+		m, err := collectManifest(node, "storage", "/kind/manifests/default-storage.yaml")
+		if err != nil {
+			return err
+		}
+		inv.Manifests = append(inv.Manifests, m)
+	}
+	// and the artifacts recorded by the other actions
+	for _, a := range ctx.Artifacts() {
+		if a.Container {
+			inv.Containers = append(inv.Containers, Container{Name: a.Name, Image: a.Source})
+			continue
+		}
+		inv.Manifests = append(inv.Manifests, artifactManifest(a))
+	}
+
+	raw, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to encode inventory")
+	}
+
+	// store the inventory on the node and in the cluster
+	if err := nodeutils.WriteFile(node, Path, string(raw)); err != nil {
+		return errors.Wrap(err, "failed to write inventory")
+	}
+	if err := node.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"create", "configmap", "-n", "kube-system", ConfigMapName,
+		"--from-file=inventory.json="+Path,
+	).Run(); err != nil {
+		return errors.Wrap(err, "failed to create inventory ConfigMap")
+	}
+	return nil
+}
+
+func collectNode(ctx *actions.ActionContext, n nodes.Node) (Node, []Image, error) {
+	role, err := n.Role()
+	if err != nil {
+		return Node{}, nil, err
+	}
+	ni := Node{
+		Name: n.String(),
+		Role: role,
+	}
+	// the load balancer is not a kubernetes node
+	if role == constants.ExternalLoadBalancerNodeRoleValue {
+		ni.Image = loadbalancer.ImageFor(ctx.Config)
+		return ni, nil, nil
+	}
+	labels, err := ctx.Provider.GetNodeLabels(n)
+	if err != nil {
+		return Node{}, nil, err
+	}
+	ni.Image = labels[common.NodeImageLabelKey]
+	ni.ImageDigest = labels[common.NodeImageDigestLabelKey]
+	ni.Binaries = map[string]string{}
+	for name, args := range versionCommands {
+		out, err := exec.Output(n.Command(args[0], args[1:]...))
+		if err != nil {
+			// not every node image ships every binary
+			continue
+		}
+		ni.Binaries[name] = strings.TrimSpace(string(out))
+	}
+	out, err := exec.Output(n.Command("crictl", "images", "-o", "json"))
+	if err != nil {
+		return Node{}, nil, errors.Wrap(err, "failed to list images")
+	}
+	images, err := parseCrictlImages(out)
+	if err != nil {
+		return Node{}, nil, err
+	}
+	return ni, images, nil
+}
+
+// parseCrictlImages parses `crictl images -o json` into one Image per tag
+func parseCrictlImages(raw []byte) ([]Image, error) {
+	var list struct {
+		Images []struct {
+			ID          string   `json:"id"`
+			RepoTags    []string `json:"repoTags"`
+			RepoDigests []string `json:"repoDigests"`
+		} `json:"images"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, errors.Wrap(err, "failed to parse image list")
+	}
+	images := []Image{}
+	for _, i := range list.Images {
+		// prefer the registry digest, fall back to the image ID for
+		// images that were loaded rather than pulled
+		digest := i.ID
+		for _, d := range i.RepoDigests {
+			if parts := strings.SplitN(d, "@", 2); len(parts) == 2 {
+				digest = parts[1]
+				break
+			}
+		}
+		for _, tag := range i.RepoTags {
+			images = append(images, Image{Name: tag, Digest: digest})
+		}
+	}
+	return images, nil
+}
+
+// artifactManifest returns the inventory entry of an applied manifest
+func artifactManifest(a actions.Artifact) Manifest {
+	m := Manifest{Name: a.Name, Path: a.Source}
+	if a.Contents != nil {
+		m.SHA256 = fmt.Sprintf("%x", sha256.Sum256(a.Contents))
+	}
+	return m
+}
+
+func collectManifest(node nodes.Node, name, path string) (Manifest, error) {
+	var raw bytes.Buffer
+	if err := node.Command("cat", path).SetStdout(&raw).Run(); err != nil {
+		// older node images do not ship manifests, kind applied a built-in
+		// default instead
+		return Manifest{Name: name, Path: path}, nil
+	}
+	return Manifest{
+		Name:   name,
+		Path:   path,
+		SHA256: fmt.Sprintf("%x", sha256.Sum256(raw.Bytes())),
+	}, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+)
+
+func TestParseCrictlImages(t *testing.T) {
+	t.Parallel()
+	raw := []byte(`{
+  "images": [
+    {
+      "id": "sha256:5185b96f0becf59032b8e3646e99f84d9655dff3ac9e2605e0dc77f9c441ae4a",
+      "repoTags": ["registry.k8s.io/pause:3.7"],
+      "repoDigests": ["registry.k8s.io/pause@sha256:bb6ed397957e9ca7c65ada0db5c5d1c707c9c8afc80a94acbe69f3ae76988f0c"]
+    },
+    {
+      "id": "sha256:1f831d9a4a9b21bf21c1a5cb0e7bbb2d3e0d40e1d92ba5b1ad1b1d0fba1167d4",
+      "repoTags": ["docker.io/library/app:dev", "docker.io/library/app:latest"],
+      "repoDigests": []
+    },
+    {
+      "id": "sha256:dangling",
+      "repoTags": [],
+      "repoDigests": []
+    }
+  ]
+}`)
+	images, err := parseCrictlImages(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.DeepEqual(t, []Image{
+		{
+			Name:   "registry.k8s.io/pause:3.7",
+			Digest: "sha256:bb6ed397957e9ca7c65ada0db5c5d1c707c9c8afc80a94acbe69f3ae76988f0c",
+		},
+		{
+			Name:   "docker.io/library/app:dev",
+			Digest: "sha256:1f831d9a4a9b21bf21c1a5cb0e7bbb2d3e0d40e1d92ba5b1ad1b1d0fba1167d4",
+		},
+		{
+			Name:   "docker.io/library/app:latest",
+			Digest: "sha256:1f831d9a4a9b21bf21c1a5cb0e7bbb2d3e0d40e1d92ba5b1ad1b1d0fba1167d4",
+		},
+	}, images)
+
+	if _, err := parseCrictlImages([]byte("not json")); err == nil {
+		t.Errorf("expected an error for invalid output")
+	}
+}
+
+func TestArtifactManifest(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name     string
+		Artifact actions.Artifact
+		Expected Manifest
+	}{
+		{
+			Name:     "applied from a URL",
+			Artifact: actions.Artifact{Name: "service-lb", Source: "https://example.com/metallb.yaml"},
+			Expected: Manifest{Name: "service-lb", Path: "https://example.com/metallb.yaml"},
+		},
+		{
+			Name:     "built-in manifest",
+			Artifact: actions.Artifact{Name: "ingress-traefik", Contents: []byte("kind: Namespace\n")},
+			Expected: Manifest{Name: "ingress-traefik", SHA256: "e25209b6e6c1f782b78132b008b475de169b9153540f2d59a52d4c0e44d15571"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, tc.Expected, artifactManifest(tc.Artifact))
+		})
+	}
+}
@@ -19,13 +19,17 @@ package kubeadminit
 
 import (
 	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/kind/pkg/errors"
-	"sigs.k8s.io/kind/pkg/exec"
 
 	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
 
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	"sigs.k8s.io/kind/pkg/cluster/internal/kubeadm"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 	"sigs.k8s.io/kind/pkg/internal/apis/config"
 	"sigs.k8s.io/kind/pkg/internal/version"
 )
@@ -35,11 +39,15 @@ import (
 // CNI network plugin.
 type action struct {
 	skipKubeProxy bool
+	timeout       time.Duration
 }
 
 // NewAction returns a new action for kubeadm init
 func NewAction(cfg *config.Cluster) actions.Action {
-	return &action{skipKubeProxy: cfg.Networking.KubeProxyMode == config.NoneProxyMode}
+	return &action{
+		skipKubeProxy: cfg.Networking.KubeProxyMode == config.NoneProxyMode,
+		timeout:       config.TimeoutDuration(cfg.Timeouts.KubeadmInit),
+	}
 }
 
 // Execute runs the action
@@ -91,11 +99,22 @@ func (a *action) Execute(ctx *actions.ActionContext) error {
 	}
 
 	// run kubeadm
-	cmd := node.Command("kubeadm", args...)
-	lines, err := exec.CombinedOutputLines(cmd)
+	initCtx, cancel := common.TimeoutContext(a.timeout)
+	defer cancel()
+	cmd := kubeadm.Command(initCtx, node, a.timeout, args...)
+	lines, err := kubeadm.RunWithPhases(ctx.Status, "", cmd)
 	ctx.Logger.V(3).Info(strings.Join(lines, "\n"))
 	if err != nil {
-		return errors.Wrap(err, "failed to init node with kubeadm")
+		category := errors.KubeadmCategory
+		if common.TimedOut(initCtx) {
+			category = errors.TimeoutCategory
+			err = errors.Wrapf(err, "timed out after %s", a.timeout)
+		}
+		return errors.WithDetails(errors.Wrap(err, "failed to init node with kubeadm"), errors.Details{
+			Category: category,
+			Node:     node.String(),
+			Hint:     "use --retain to keep the nodes, then inspect the kubelet and control plane logs with `kind export logs`",
+		})
 	}
 
 	// copy some files to the other control plane nodes
@@ -121,6 +140,14 @@ func (a *action) Execute(ctx *actions.ActionContext) error {
 		}
 	}
 
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	if err := actions.AnnotateNodeImageDigest(ctx, node); err != nil {
+		return err
+	}
+
 	// if we are only provisioning one node, remove the control plane taint
 	// https://kubernetes.io/docs/setup/independent/create-cluster-kubeadm/#master-isolation
 	if len(allNodes) == 1 {
@@ -146,12 +173,7 @@ func (a *action) Execute(ctx *actions.ActionContext) error {
 			// for any newer version only remove the new taint
 			taints = []string{"node-role.kubernetes.io/control-plane-"}
 		}
-		taintArgs := []string{"--kubeconfig=/etc/kubernetes/admin.conf", "taint", "nodes", "--all"}
-		taintArgs = append(taintArgs, taints...)
-
-		if err := node.Command(
-			"kubectl", taintArgs...,
-		).Run(); err != nil {
+		if err := client.Run(append([]string{"taint", "nodes", "--all"}, taints...)...); err != nil {
 			return errors.Wrap(err, "failed to remove control plane taint")
 		}
 	}
@@ -159,15 +181,57 @@ func (a *action) Execute(ctx *actions.ActionContext) error {
 	// Kubeadm will add `node.kubernetes.io/exclude-from-external-load-balancers` on control plane nodes.
 	// For single node clusters, this means we cannot have a load balancer at all (MetalLB, etc), so remove the label.
 	if len(allNodes) == 1 {
-		labelArgs := []string{"--kubeconfig=/etc/kubernetes/admin.conf", "label", "nodes", "--all", "node.kubernetes.io/exclude-from-external-load-balancers-"}
-		if err := node.Command(
-			"kubectl", labelArgs...,
-		).Run(); err != nil {
+		if err := client.Run("label", "nodes", "--all", "node.kubernetes.io/exclude-from-external-load-balancers-"); err != nil {
 			return errors.Wrap(err, "failed to remove control plane load balancer label")
 		}
 	}
 
+	// record the cluster metadata in-cluster for tools running in the cluster
+	clusterInfo, err := clusterInfoManifest(ctx.Config)
+	if err != nil {
+		return err
+	}
+	if err := client.Apply(string(clusterInfo)); err != nil {
+		return errors.Wrap(err, "failed to create cluster info ConfigMap")
+	}
+
 	// mark success
 	ctx.Status.End(true)
 	return nil
 }
+
+// ClusterInfoConfigMap is the name of the ConfigMap in the kube-public
+// namespace recording the cluster name, labels and annotations
+const ClusterInfoConfigMap = "kind-cluster-info"
+
+// clusterInfoManifest returns the ConfigMap manifest recording cfg's metadata
+func clusterInfoManifest(cfg *config.Cluster) ([]byte, error) {
+	type metadata struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Labels      map[string]string `json:"labels,omitempty"`
+		Annotations map[string]string `json:"annotations,omitempty"`
+	}
+	manifest, err := yaml.Marshal(struct {
+		APIVersion string            `json:"apiVersion"`
+		Kind       string            `json:"kind"`
+		Metadata   metadata          `json:"metadata"`
+		Data       map[string]string `json:"data"`
+	}{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata: metadata{
+			Name:        ClusterInfoConfigMap,
+			Namespace:   "kube-public",
+			Labels:      cfg.Labels,
+			Annotations: cfg.Annotations,
+		},
+		Data: map[string]string{
+			"name": cfg.Name,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode cluster info ConfigMap")
+	}
+	return manifest, nil
+}
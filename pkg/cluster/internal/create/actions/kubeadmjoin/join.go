@@ -19,17 +19,21 @@ package kubeadmjoin
 
 import (
 	"strings"
+	"time"
 
 	"sigs.k8s.io/kind/pkg/cluster/constants"
 	"sigs.k8s.io/kind/pkg/cluster/nodes"
 	"sigs.k8s.io/kind/pkg/errors"
-	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/cli"
 	"sigs.k8s.io/kind/pkg/internal/version"
 	"sigs.k8s.io/kind/pkg/log"
 
 	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
 
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	"sigs.k8s.io/kind/pkg/cluster/internal/kubeadm"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
 )
 
 // Action implements action for creating the kubeadm join
@@ -84,7 +88,7 @@ func joinSecondaryControlPlanes(
 	// (this is not safe currently)
 	for _, node := range secondaryControlPlanes {
 		node := node // capture loop variable
-		if err := runKubeadmJoin(ctx.Logger, node); err != nil {
+		if err := joinNode(ctx, node); err != nil {
 			return err
 		}
 	}
@@ -105,7 +109,7 @@ func joinWorkers(
 	for _, node := range workers {
 		node := node // capture loop variable
 		fns = append(fns, func() error {
-			return runKubeadmJoin(ctx.Logger, node)
+			return joinNode(ctx, node)
 		})
 	}
 	if err := errors.UntilErrorConcurrent(fns); err != nil {
@@ -116,8 +120,49 @@ func joinWorkers(
 	return nil
 }
 
-// runKubeadmJoin executes kubeadm join command
-func runKubeadmJoin(logger log.Logger, node nodes.Node) error {
+// joinNode joins node to the cluster, annotates it with its image digest and
+// then applies its node-level KubeletConfiguration patches, which kubeadm join
+// does not use
+// With a join timeout it then waits for the Node to be Ready within the rest
+// of that timeout
+func joinNode(ctx *actions.ActionContext, node nodes.Node) error {
+	configNode, err := actions.ConfigNodeFor(ctx.Config, node)
+	if err != nil {
+		return err
+	}
+	timeout := config.TimeoutDuration(ctx.Config.Timeouts.Join)
+	deadline := time.Now().Add(timeout)
+	if err := runKubeadmJoin(ctx.Logger, ctx.Status, node, timeout); err != nil {
+		return err
+	}
+	if err := actions.AnnotateNodeImageDigest(ctx, node); err != nil {
+		return err
+	}
+	if err := patchKubeletConfig(node, configNode); err != nil {
+		return err
+	}
+	// without a limit or a CNI the wait-for-ready action decides whether to wait
+	if timeout <= 0 || ctx.Config.Networking.DisableDefaultCNI {
+		return nil
+	}
+	allNodes, err := ctx.Nodes()
+	if err != nil {
+		return err
+	}
+	controlPlane, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+	if err != nil {
+		return err
+	}
+	if _, err := nodeutils.WaitForNodeReady(controlPlane, node, time.Until(deadline)); err != nil {
+		return errors.Wrap(err, "joined node did not become Ready")
+	}
+	return nil
+}
+
+// runKubeadmJoin executes kubeadm join command,
+// for at most timeout when it is not zero
+// The kubeadm phases are shown as phases of status prefixed with the node name
+func runKubeadmJoin(logger log.Logger, status *cli.Status, node nodes.Node, timeout time.Duration) error {
 	kubeVersionStr, err := nodeutils.KubeVersion(node)
 	if err != nil {
 		return errors.Wrap(err, "failed to get kubernetes version from node")
@@ -142,11 +187,22 @@ func runKubeadmJoin(logger log.Logger, node nodes.Node) error {
 	}
 
 	// run kubeadm join
-	cmd := node.Command("kubeadm", args...)
-	lines, err := exec.CombinedOutputLines(cmd)
+	joinCtx, cancel := common.TimeoutContext(timeout)
+	defer cancel()
+	cmd := kubeadm.Command(joinCtx, node, timeout, args...)
+	lines, err := kubeadm.RunWithPhases(status, node.String()+": ", cmd)
 	logger.V(3).Info(strings.Join(lines, "\n"))
 	if err != nil {
-		return errors.Wrap(err, "failed to join node with kubeadm")
+		category := errors.KubeadmCategory
+		if common.TimedOut(joinCtx) {
+			category = errors.TimeoutCategory
+			err = errors.Wrapf(err, "timed out after %s", timeout)
+		}
+		return errors.WithDetails(errors.Wrap(err, "failed to join node with kubeadm"), errors.Details{
+			Category: category,
+			Node:     node.String(),
+			Hint:     "use --retain to keep the nodes, then inspect the kubelet logs with `kind export logs`",
+		})
 	}
 
 	return nil
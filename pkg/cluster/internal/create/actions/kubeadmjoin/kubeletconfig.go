@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadmjoin
+
+import (
+	"bytes"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/patch"
+)
+
+// kubeletConfigPath is where kubeadm join writes the kubelet config it
+// downloads from the cluster's kubelet-config ConfigMap
+const kubeletConfigPath = "/var/lib/kubelet/config.yaml"
+
+// kubeletConfigPatches returns the node-level patches targeting the
+// KubeletConfiguration, these are not used by kubeadm join
+func kubeletConfigPatches(node *config.Node) ([]string, []config.PatchJSON6902, error) {
+	patches := []string{}
+	for _, p := range node.KubeadmConfigPatches {
+		typeMeta := struct {
+			Kind string `json:"kind"`
+		}{}
+		if err := yaml.Unmarshal([]byte(p), &typeMeta); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to parse kubeadmConfigPatches")
+		}
+		if typeMeta.Kind == "KubeletConfiguration" {
+			patches = append(patches, p)
+		}
+	}
+	patches6902 := []config.PatchJSON6902{}
+	for _, p := range node.KubeadmConfigPatchesJSON6902 {
+		if p.Kind == "KubeletConfiguration" {
+			patches6902 = append(patches6902, p)
+		}
+	}
+	return patches, patches6902, nil
+}
+
+// patchKubeletConfig applies the node-level KubeletConfiguration patches to
+// the kubelet config of a joined node and restarts the kubelet to use it
+func patchKubeletConfig(node nodes.Node, configNode *config.Node) error {
+	patches, patches6902, err := kubeletConfigPatches(configNode)
+	if err != nil {
+		return err
+	}
+	if len(patches) == 0 && len(patches6902) == 0 {
+		return nil
+	}
+	var buff bytes.Buffer
+	if err := node.Command("cat", kubeletConfigPath).SetStdout(&buff).Run(); err != nil {
+		return errors.Wrap(err, "failed to read kubelet config from node")
+	}
+	patched, err := patch.KubeYAML(buff.String(), patches, patches6902)
+	if err != nil {
+		return errors.Wrap(err, "failed to patch kubelet config")
+	}
+	if err := nodeutils.WriteFile(node, kubeletConfigPath, patched); err != nil {
+		return errors.Wrap(err, "failed to write patched kubelet config")
+	}
+	// the kubelet refuses to start if the checkpointed CPU or memory manager
+	// policy differs from the config, nothing is pinned yet so drop them
+	if err := node.Command("bash", "-c",
+		`rm -f /var/lib/kubelet/cpu_manager_state /var/lib/kubelet/memory_manager_state && systemctl restart kubelet`,
+	).Run(); err != nil {
+		return errors.Wrap(err, "failed to restart kubelet after patching config")
+	}
+	return nil
+}
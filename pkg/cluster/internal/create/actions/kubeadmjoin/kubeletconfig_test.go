@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadmjoin
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestKubeletConfigPatches(t *testing.T) {
+	t.Parallel()
+	kubeletPatch := "kind: KubeletConfiguration\ncpuManagerPolicy: static\n"
+	kubeletPatch6902 := config.PatchJSON6902{
+		Version: "v1beta1",
+		Kind:    "KubeletConfiguration",
+		Patch:   "- op: add\n  path: /evictionHard/memory.available\n  value: 500Mi\n",
+	}
+	node := &config.Node{
+		KubeadmConfigPatches: []string{
+			"kind: JoinConfiguration\nnodeRegistration:\n  name: foo\n",
+			kubeletPatch,
+		},
+		KubeadmConfigPatchesJSON6902: []config.PatchJSON6902{
+			{Kind: "ClusterConfiguration", Patch: "[]"},
+			kubeletPatch6902,
+		},
+	}
+	patches, patches6902, err := kubeletConfigPatches(node)
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []string{kubeletPatch}, patches)
+	assert.DeepEqual(t, []config.PatchJSON6902{kubeletPatch6902}, patches6902)
+
+	_, _, err = kubeletConfigPatches(&config.Node{KubeadmConfigPatches: []string{"kind: [bogus"}})
+	assert.ExpectError(t, true, err)
+}
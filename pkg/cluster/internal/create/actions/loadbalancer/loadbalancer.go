@@ -19,6 +19,7 @@ package loadbalancer
 
 import (
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/kind/pkg/cluster/constants"
 	"sigs.k8s.io/kind/pkg/errors"
@@ -74,11 +75,37 @@ func (a *Action) Execute(ctx *actions.ActionContext) error {
 		backendServers[n.String()] = fmt.Sprintf("%s:%d", n.String(), common.APIServerInternalPort)
 	}
 
+	// collect the extra backends
+	extraBackends := []loadbalancer.ExtraBackend{}
+	for _, b := range ctx.Config.LoadBalancer.ExtraBackends {
+		backendNodes, err := nodeutils.SelectNodesByRole(allNodes, string(b.Role))
+		if err != nil {
+			return err
+		}
+		servers := map[string]string{}
+		for _, n := range backendNodes {
+			servers[n.String()] = fmt.Sprintf("%s:%d", n.String(), b.BackendPort)
+		}
+		extraBackends = append(extraBackends, loadbalancer.ExtraBackend{
+			Name:    b.Name,
+			Port:    int(b.Port),
+			Servers: servers,
+		})
+	}
+
+	tuning, err := tuningFromConfig(&ctx.Config.LoadBalancer)
+	if err != nil {
+		return err
+	}
+
 	// create loadbalancer config data
 	loadbalancerConfig, err := loadbalancer.Config(&loadbalancer.ConfigData{
 		ControlPlanePort: common.APIServerInternalPort,
 		BackendServers:   backendServers,
+		ExtraBackends:    extraBackends,
 		IPv6:             ctx.Config.Networking.IPFamily == config.IPv6Family,
+		StatsPort:        int(ctx.Config.LoadBalancer.Stats.Port),
+		Tuning:           tuning,
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to generate loadbalancer config data")
@@ -98,3 +125,30 @@ func (a *Action) Execute(ctx *actions.ActionContext) error {
 	ctx.Status.End(true)
 	return nil
 }
+
+// tuningFromConfig converts the validated load balancer config to haproxy tuning
+func tuningFromConfig(lb *config.LoadBalancer) (*loadbalancer.Tuning, error) {
+	t := &loadbalancer.Tuning{
+		MaxConnections:   int(lb.MaxConnections),
+		HealthCheckRise:  int(lb.HealthCheck.Rise),
+		HealthCheckFall:  int(lb.HealthCheck.Fall),
+		ClientIPAffinity: lb.SessionAffinity == config.ClientIPSessionAffinity,
+		Algorithm:        string(lb.Algorithm),
+	}
+	for _, d := range []struct {
+		value string
+		out   *time.Duration
+	}{
+		{lb.Timeouts.Connect, &t.ConnectTimeout},
+		{lb.Timeouts.Client, &t.ClientTimeout},
+		{lb.Timeouts.Server, &t.ServerTimeout},
+		{lb.HealthCheck.Interval, &t.HealthCheckInterval},
+	} {
+		parsed, err := time.ParseDuration(d.value)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid loadBalancer config")
+		}
+		*d.out = parsed
+	}
+	return t, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package localregistry implements the action to start the local registry
+// and advertise it to the cluster
+package localregistry
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+)
+
+type action struct{}
+
+// NewAction returns a new action for starting the local registry
+func NewAction() actions.Action {
+	return &action{}
+}
+
+// Execute runs the action
+func (a *action) Execute(ctx *actions.ActionContext) error {
+	if !ctx.Config.LocalRegistry.Enabled {
+		return nil
+	}
+	ctx.Status.Start("Starting local registry 🗄")
+	defer ctx.Status.End(false)
+
+	port := ctx.Config.LocalRegistry.Port
+	if err := ctx.Provider.EnsureRegistry(constants.LocalRegistryName, port); err != nil {
+		return err
+	}
+
+	client, err := ctx.Client()
+	if err != nil {
+		return err
+	}
+	if err := client.Apply(fmt.Sprintf(hostingConfigMap, port)); err != nil {
+		return errors.Wrap(err, "failed to publish local-registry-hosting ConfigMap")
+	}
+
+	// mark success
+	ctx.Status.End(true)
+	return nil
+}
+
+// hostingConfigMap documents the local registry for tools per KEP-1755
+// https://github.com/kubernetes/enhancements/tree/master/keps/sig-cluster-lifecycle/generic/1755-communicating-a-local-registry
+const hostingConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: local-registry-hosting
+  namespace: kube-public
+data:
+  localRegistryHosting.v1: |
+    host: "localhost:%d"
+    help: "https://kind.sigs.k8s.io/docs/user/local-registry/"
+`
@@ -25,9 +25,7 @@ import (
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
 	"sigs.k8s.io/kind/pkg/cluster/nodes"
 	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
-	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
-	"sigs.k8s.io/kind/pkg/internal/version"
 )
 
 // Action implements an action for waiting for the cluster to be ready
@@ -48,6 +46,12 @@ func (a *Action) Execute(ctx *actions.ActionContext) error {
 	if a.waitTime == time.Duration(0) {
 		return nil
 	}
+	// without a CNI the nodes cannot become Ready before the user has
+	// the kubeconfig to install one, so waiting would always time out
+	if ctx.Config.Networking.DisableDefaultCNI {
+		ctx.Logger.V(0).Info(" • Skipping wait for Ready, no CNI is installed ⏭️")
+		return nil
+	}
 	ctx.Status.Start(
 		fmt.Sprintf(
 			"Waiting ≤ %s for control-plane = Ready ⏳",
@@ -68,62 +72,63 @@ func (a *Action) Execute(ctx *actions.ActionContext) error {
 
 	// Wait for the nodes to reach Ready status.
 	startTime := time.Now()
-
-	// TODO: Remove the below handling once kubeadm 1.23 is no longer supported.
-	// https://github.com/kubernetes-sigs/kind/issues/1699
-	rawVersion, err := nodeutils.KubeVersion(node)
-	if err != nil {
-		return errors.Wrap(err, "failed to get Kubernetes version from node")
-	}
-	kubeVersion, err := version.ParseSemantic(rawVersion)
-	if err != nil {
-		return errors.Wrap(err, "could not parse Kubernetes version")
-	}
-	selectorLabel := "node-role.kubernetes.io/control-plane"
-	if kubeVersion.LessThan(version.MustParseSemantic("v1.24.0-alpha.1.591+a3d5e5598290df")) {
-		selectorLabel = "node-role.kubernetes.io/master"
-	}
-
-	isReady := waitForReady(node, startTime.Add(a.waitTime), selectorLabel)
-	if !isReady {
-		ctx.Status.End(false)
-		ctx.Logger.V(0).Info(" • WARNING: Timed out waiting for Ready ⚠️")
-		return nil
+	for _, cp := range controlPlanes {
+		readiness, err := nodeutils.WaitForNodeReady(node, cp, time.Until(startTime.Add(a.waitTime)))
+		if err != nil {
+			ctx.Status.End(false)
+			ctx.Logger.V(0).Info(" • WARNING: Timed out waiting for Ready ⚠️")
+			if readiness.Reason != "" {
+				ctx.Logger.V(0).Infof(" • %s: %s: %s", cp.String(), readiness.Reason, readiness.Message)
+			}
+			return nil
+		}
 	}
 
 	// mark success
 	ctx.Status.End(true)
 	ctx.Logger.V(0).Infof(" • Ready after %s 💚", formatDuration(time.Since(startTime)))
+
+	// wait for the default storage provisioner with the remaining time, so
+	// workloads using the default StorageClass can be deployed immediately
+	if !ctx.Config.StorageClass.DisableDefault {
+		ctx.Status.Start(
+			fmt.Sprintf(
+				"Waiting ≤ %s for storage provisioner = Ready ⏳",
+				formatDuration(time.Until(startTime.Add(a.waitTime))),
+			),
+		)
+		if !waitForStorage(node, startTime.Add(a.waitTime)) {
+			ctx.Status.End(false)
+			ctx.Logger.V(0).Info(" • WARNING: Timed out waiting for storage provisioner ⚠️")
+			return nil
+		}
+		ctx.Status.End(true)
+	}
 	return nil
 }
 
-// WaitForReady uses kubectl inside the "node" container to check if the
-// control plane nodes are "Ready".
-func waitForReady(node nodes.Node, until time.Time, selectorLabel string) bool {
+// waitForStorage uses kubectl inside the "node" container to check if the
+// default storage provisioner is available
+// Images with the legacy in-tree host-path StorageClass have no provisioner
+// deployment, which is treated as available
+func waitForStorage(node nodes.Node, until time.Time) bool {
 	return tryUntil(until, func() bool {
 		cmd := node.Command(
 			"kubectl",
 			"--kubeconfig=/etc/kubernetes/admin.conf",
 			"get",
-			"nodes",
-			"--selector="+selectorLabel,
-			// When the node reaches status ready, the status field will be set
-			// to true.
-			"-o=jsonpath='{.items..status.conditions[-1:].status}'",
+			"deployments",
+			"--namespace=local-path-storage",
+			`-o=jsonpath={range .items[*]}{.metadata.name}={.status.availableReplicas}{"\n"}{end}`,
 		)
 		lines, err := exec.OutputLines(cmd)
 		if err != nil {
 			return false
 		}
-
-		// 'lines' will return the status of all nodes labeled as master. For
-		// example, if we have three control plane nodes, and all are ready,
-		// then the status will have the following format: `True True True'.
-		status := strings.Fields(lines[0])
-		for _, s := range status {
-			// Check node status. If node is ready then this will be 'True',
-			// 'False' or 'Unknown' otherwise.
-			if !strings.Contains(s, "True") {
+		// each line is name=availableReplicas, which is empty when none are
+		for _, line := range lines {
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 || parts[1] == "" || parts[1] == "0" {
 				return false
 			}
 		}
@@ -19,12 +19,18 @@ package create
 import (
 	"fmt"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alessio/shellescape"
 
 	"sigs.k8s.io/kind/pkg/cluster/internal/delete"
+	"sigs.k8s.io/kind/pkg/cluster/internal/diagnostics"
 	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/internal/apis/config"
 	"sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
@@ -33,11 +39,17 @@ import (
 
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
 	configaction "sigs.k8s.io/kind/pkg/cluster/internal/create/actions/config"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/configuredns"
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/installcni"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/installdeviceplugin"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/installingress"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/installservicelb"
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/installstorage"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/inventory"
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/kubeadminit"
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/kubeadmjoin"
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/loadbalancer"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/localregistry"
 	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/waitforready"
 	"sigs.k8s.io/kind/pkg/cluster/internal/kubeconfig"
 )
@@ -53,12 +65,21 @@ type ClusterOptions struct {
 	Config       *config.Cluster
 	NameOverride string // overrides config.Name
 	// NodeImage overrides the nodes' images in Config if non-zero
-	NodeImage      string
+	NodeImage string
+	// Labels and Annotations are recorded on the cluster, see config.Cluster
+	Labels         map[string]string
+	Annotations    map[string]string
 	Retain         bool
 	WaitForReady   time.Duration
 	KubeconfigPath string
 	// see https://github.com/kubernetes-sigs/kind/issues/324
 	StopBeforeSettingUpKubernetes bool // if false kind should setup kubernetes after creating nodes
+	// AllowMixedNodeImages allows nodes with different image digests
+	AllowMixedNodeImages bool
+	// ImageBundle overrides the image bundle in Config if non-zero
+	ImageBundle string
+	// Ingress overrides the ingress controller in Config if non-zero
+	Ingress string
 	// Options to control output
 	DisplayUsage      bool
 	DisplaySalutation bool
@@ -68,17 +89,31 @@ type ClusterOptions struct {
 func Cluster(logger log.Logger, p providers.Provider, opts *ClusterOptions) error {
 	// validate provider first
 	if err := validateProvider(p); err != nil {
-		return err
+		return errors.WithDetails(err, errors.Details{
+			Category: errors.PreflightCategory,
+			Phase:    "preflight",
+		})
 	}
 
 	// default / process options (namely config)
-	if err := fixupOptions(opts); err != nil {
-		return err
+	if err := FixupOptions(opts); err != nil {
+		return errors.WithDetails(err, errors.Details{
+			Category: errors.ConfigCategory,
+			Phase:    "config",
+			Hint:     configHint,
+		})
 	}
 
 	// Check if the cluster name already exists
 	if err := alreadyExists(p, opts.Config.Name); err != nil {
-		return err
+		return errors.WithDetails(err, errors.Details{
+			Category: errors.PreflightCategory,
+			Phase:    "preflight",
+			Hint: fmt.Sprintf(
+				"delete the existing cluster with `kind delete cluster --name %s` or choose a different name",
+				opts.Config.Name,
+			),
+		})
 	}
 
 	// warn if cluster name might typically be too long
@@ -88,7 +123,20 @@ func Cluster(logger log.Logger, p providers.Provider, opts *ClusterOptions) erro
 
 	// then validate
 	if err := opts.Config.Validate(); err != nil {
-		return err
+		return errors.WithDetails(err, errors.Details{
+			Category: errors.ConfigCategory,
+			Phase:    "config",
+			Hint:     configHint,
+		})
+	}
+
+	// and check the host can fit the requested node resources
+	if err := validateResources(logger, p, opts.Config); err != nil {
+		return errors.WithDetails(err, errors.Details{
+			Category: errors.PreflightCategory,
+			Phase:    "preflight",
+			Hint:     "lower the node resources in the config, or give the container runtime more CPUs and memory",
+		})
 	}
 
 	// setup a status object to show progress to the user
@@ -97,47 +145,100 @@ func Cluster(logger log.Logger, p providers.Provider, opts *ClusterOptions) erro
 	// we're going to start creating now, tell the user
 	logger.V(0).Infof("Creating cluster %q ...\n", opts.Config.Name)
 
+	// load the image bundle first so the node images need not be pulled
+	if opts.Config.ImageBundle != "" {
+		if err := loadImageBundle(status, p, opts.Config.ImageBundle); err != nil {
+			return errors.WithDetails(err, errors.Details{
+				Category: errors.ProvisioningCategory,
+				Phase:    "provision",
+			})
+		}
+	}
+
+	// start the shared caches before the nodes so they are used as mirrors
+	if opts.Config.ImageCache {
+		if err := startImageCaches(status, p); err != nil {
+			return errors.WithDetails(err, errors.Details{
+				Category: errors.ProvisioningCategory,
+				Phase:    "provision",
+			})
+		}
+	}
+
 	// Create node containers implementing defined config Nodes
 	if err := p.Provision(status, opts.Config); err != nil {
 		// In case of errors nodes are deleted (except if retain is explicitly set)
 		if !opts.Retain {
 			_ = delete.Cluster(logger, p, opts.Config.Name, opts.KubeconfigPath)
 		}
-		return err
+		details := errors.Details{
+			Phase: "provision",
+			Hint:  "check that the container runtime is running and the node image is available, use --retain to keep the nodes for debugging",
+		}
+		if errors.DetailsOf(err).Category == errors.UnknownCategory {
+			details.Category = errors.ProvisioningCategory
+		}
+		return errors.WithDetails(err, details)
 	}
 
 	// TODO(bentheelder): make this controllable from the command line?
 	actionsToRun := []actions.Action{
-		loadbalancer.NewAction(), // setup external loadbalancer
-		configaction.NewAction(), // setup kubeadm config
+		withPhase("loadbalancer", loadbalancer.NewAction()),   // setup external loadbalancer
+		withPhase("kubeadm-config", configaction.NewAction()), // setup kubeadm config
 	}
 	if !opts.StopBeforeSettingUpKubernetes {
 		actionsToRun = append(actionsToRun,
-			kubeadminit.NewAction(opts.Config), // run kubeadm init
+			withPhase("kubeadm-init", kubeadminit.NewAction(opts.Config)), // run kubeadm init
+			withPhase("local-registry", localregistry.NewAction()),        // start the local registry
 		)
 		// this step might be skipped, but is next after init
 		if !opts.Config.Networking.DisableDefaultCNI {
 			actionsToRun = append(actionsToRun,
-				installcni.NewAction(), // install CNI
+				withPhase("install-cni", installcni.NewAction()), // install CNI
+			)
+		} else {
+			actionsToRun = append(actionsToRun,
+				skipped("Skipping CNI, disableDefaultCNI is set: nodes stay NotReady until you install one"),
+			)
+		}
+		// this step might be skipped, but is next after CNI
+		if !opts.Config.StorageClass.DisableDefault {
+			actionsToRun = append(actionsToRun,
+				withPhase("install-storage", installstorage.NewAction()), // install StorageClass
+			)
+		} else {
+			actionsToRun = append(actionsToRun,
+				skipped("Skipping StorageClass, storageClass.disableDefault is set"),
 			)
 		}
 		// add remaining steps
 		actionsToRun = append(actionsToRun,
-			installstorage.NewAction(),                // install StorageClass
-			kubeadmjoin.NewAction(),                   // run kubeadm join
-			waitforready.NewAction(opts.WaitForReady), // wait for cluster readiness
+			withPhase("kubeadm-join", kubeadmjoin.NewAction()),                     // run kubeadm join
+			withPhase("configure-dns", configuredns.NewAction()),                   // size CoreDNS for the nodes
+			withPhase("install-device-plugin", installdeviceplugin.NewAction()),    // install the device plugin, e.g. for GPUs
+			withPhase("install-ingress", installingress.NewAction()),               // install the ingress controller
+			withPhase("install-service-lb", installservicelb.NewAction()),          // install the service load balancer
+			withPhase("wait-for-ready", waitforready.NewAction(opts.WaitForReady)), // wait for cluster readiness
+			withPhase("inventory", inventory.NewAction()),                          // record installed software
 		)
 	}
 
 	// run all actions
 	actionsContext := actions.NewActionContext(logger, status, p, opts.Config)
+	initialized := false
 	for _, action := range actionsToRun {
 		if err := action.Execute(actionsContext); err != nil {
+			if initialized {
+				logDiagnostics(logger, p, opts.Config.Name)
+			}
 			if !opts.Retain {
 				_ = delete.Cluster(logger, p, opts.Config.Name, opts.KubeconfigPath)
 			}
 			return err
 		}
+		if a, ok := action.(*phaseAction); ok && a.phase == "kubeadm-init" {
+			initialized = true
+		}
 	}
 
 	// skip the rest if we're not setting up kubernetes
@@ -159,6 +260,12 @@ func Cluster(logger log.Logger, p providers.Provider, opts *ClusterOptions) erro
 		return err
 	}
 
+	// the exported endpoint may lag the API server coming up on the node
+	if err := kubeconfig.WaitForEndpoint(p, opts.Config.Name, config.TimeoutDuration(opts.Config.Timeouts.ControlPlaneEndpointReady)); err != nil {
+		logDiagnostics(logger, p, opts.Config.Name)
+		return errors.WithDetails(err, errors.Details{Phase: "control-plane-endpoint"})
+	}
+
 	// optionally display usage
 	if opts.DisplayUsage {
 		logUsage(logger, opts.Config.Name, opts.KubeconfigPath)
@@ -171,6 +278,48 @@ func Cluster(logger log.Logger, p providers.Provider, opts *ClusterOptions) erro
 	return nil
 }
 
+const configHint = "check the cluster configuration, see https://kind.sigs.k8s.io/docs/user/configuration/"
+
+// skippedAction reports an addon the config disabled, in place of the
+// action that would have installed it
+type skippedAction struct {
+	message string
+}
+
+func skipped(message string) actions.Action {
+	return &skippedAction{message: message}
+}
+
+// Execute runs the action
+func (a *skippedAction) Execute(ctx *actions.ActionContext) error {
+	ctx.Logger.V(0).Infof(" • %s ⏭️", a.message)
+	return nil
+}
+
+// phaseAction wraps an action to annotate the errors it returns with the phase
+// name, and a category if the action did not categorize the error itself
+type phaseAction struct {
+	phase  string
+	action actions.Action
+}
+
+func withPhase(phase string, action actions.Action) actions.Action {
+	return &phaseAction{phase: phase, action: action}
+}
+
+// Execute runs the action
+func (a *phaseAction) Execute(ctx *actions.ActionContext) error {
+	err := a.action.Execute(ctx)
+	if err == nil {
+		return nil
+	}
+	details := errors.Details{Phase: a.phase}
+	if errors.DetailsOf(err).Category == errors.UnknownCategory {
+		details.Category = errors.ProvisioningCategory
+	}
+	return errors.WithDetails(err, details)
+}
+
 // alreadyExists returns an error if the cluster name already exists
 // or if we had an error checking
 func alreadyExists(p providers.Provider, name string) error {
@@ -184,6 +333,17 @@ func alreadyExists(p providers.Provider, name string) error {
 	return nil
 }
 
+// logDiagnostics logs the state of the cluster after an action failed, once
+// the API server is up this explains most failures
+func logDiagnostics(logger log.Logger, p providers.Provider, name string) {
+	allNodes, err := p.ListNodes(name)
+	if err != nil || len(allNodes) == 0 {
+		return
+	}
+	logger.V(0).Infof("Cluster state at the time of the failure, use `kind export logs` for all logs:\n%s",
+		diagnostics.Collect(allNodes, diagnostics.DefaultKubeletLines))
+}
+
 func logUsage(logger log.Logger, name, explicitKubeconfigPath string) {
 	// construct a sample command for interacting with the cluster
 	kctx := kubeconfig.ContextForCluster(name)
@@ -208,7 +368,9 @@ func logSalutation(logger log.Logger) {
 	logger.V(0).Info(s)
 }
 
-func fixupOptions(opts *ClusterOptions) error {
+// FixupOptions defaults opts and resolves the config the cluster is created
+// with, apply uses it too so the config hashes of both match
+func FixupOptions(opts *ClusterOptions) error {
 	// do post processing for options
 	// first ensure we at least have a default cluster config
 	if opts.Config == nil {
@@ -233,6 +395,72 @@ func fixupOptions(opts *ClusterOptions) error {
 		}
 	}
 
+	// merge in cluster metadata
+	for k, v := range opts.Labels {
+		if opts.Config.Labels == nil {
+			opts.Config.Labels = map[string]string{}
+		}
+		opts.Config.Labels[k] = v
+	}
+	for k, v := range opts.Annotations {
+		if opts.Config.Annotations == nil {
+			opts.Config.Annotations = map[string]string{}
+		}
+		opts.Config.Annotations[k] = v
+	}
+
+	opts.Config.AllowMixedNodeImages = opts.AllowMixedNodeImages
+
+	// resolve the image bundle, which is read when creating the nodes
+	if opts.ImageBundle != "" {
+		opts.Config.ImageBundle = opts.ImageBundle
+	}
+	if opts.Config.ImageBundle != "" {
+		bundle, err := filepath.Abs(opts.Config.ImageBundle)
+		if err != nil {
+			return errors.Wrapf(err, "unable to resolve absolute path for imageBundle: %q", opts.Config.ImageBundle)
+		}
+		if _, err := os.Stat(bundle); err != nil {
+			return errors.Wrapf(err, "invalid imageBundle %q", opts.Config.ImageBundle)
+		}
+		opts.Config.ImageBundle = bundle
+	}
+
+	// resolve the kubeadm config patch directories, which are read when
+	// configuring the nodes
+	for i, dir := range opts.Config.KubeadmConfigPatchDirectories {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return errors.Wrapf(err, "unable to resolve absolute path for kubeadmConfigPatchDirectories: %q", dir)
+		}
+		if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+			return errors.Errorf("invalid kubeadmConfigPatchDirectories entry %q: not a directory", dir)
+		}
+		opts.Config.KubeadmConfigPatchDirectories[i] = abs
+	}
+
+	if opts.Ingress != "" {
+		opts.Config.Ingress.Controller = config.IngressController(opts.Ingress)
+	}
+
+	// mount the shared OCI layout into every node
+	if opts.Config.SharedOCILayout != "" {
+		layout, err := filepath.Abs(opts.Config.SharedOCILayout)
+		if err != nil {
+			return errors.Wrapf(err, "unable to resolve absolute path for sharedOCILayout: %q", opts.Config.SharedOCILayout)
+		}
+		if _, err := os.Stat(filepath.Join(layout, "index.json")); err != nil {
+			return errors.Wrapf(err, "sharedOCILayout %q is not an OCI image layout", opts.Config.SharedOCILayout)
+		}
+		for i := range opts.Config.Nodes {
+			opts.Config.Nodes[i].ExtraMounts = append(opts.Config.Nodes[i].ExtraMounts, config.Mount{
+				HostPath:      layout,
+				ContainerPath: configaction.SharedOCILayoutPath,
+				Readonly:      true,
+			})
+		}
+	}
+
 	// default config fields (important for usage as a library, where the config
 	// may be constructed in memory rather than from disk)
 	config.SetDefaultsCluster(opts.Config)
@@ -240,18 +468,118 @@ func fixupOptions(opts *ClusterOptions) error {
 	return nil
 }
 
+// loadImageBundle loads the images in the bundle into the host container runtime
+func loadImageBundle(status *cli.Status, p providers.Provider, bundle string) (err error) {
+	status.Start("Loading image bundle 🗃")
+	defer func() { status.End(err == nil) }()
+	f, err := os.Open(bundle)
+	if err != nil {
+		return errors.Wrap(err, "failed to open image bundle")
+	}
+	defer f.Close()
+	return p.LoadImages(f)
+}
+
+// startImageCaches ensures the shared pull-through caches are running
+func startImageCaches(status *cli.Status, p providers.Provider) (err error) {
+	status.Start("Starting image caches 🗄")
+	defer func() { status.End(err == nil) }()
+	return p.EnsureCaches(common.CacheRegistries())
+}
+
 func validateProvider(p providers.Provider) error {
 	info, err := p.Info()
 	if err != nil {
 		return err
 	}
-	if info.Rootless {
-		if !info.Cgroup2 {
-			return errors.New("running kind with rootless provider requires cgroup v2, see https://kind.sigs.k8s.io/docs/user/rootless/")
+	return validateRootless(info)
+}
+
+func validateResources(logger log.Logger, p providers.Provider, cfg *config.Cluster) error {
+	info, err := p.Info()
+	if err != nil {
+		return err
+	}
+	return validateNodeResources(logger, info, cfg)
+}
+
+// validateNodeResources checks that the provider supports the requested node
+// resource limits and that no node asks for more than the host has.
+// Limits are not reserved, so nodes may add up to more than the host has,
+// which is only warned about
+func validateNodeResources(logger log.Logger, info *providers.ProviderInfo, cfg *config.Cluster) error {
+	errs := []error{}
+	var totalMemory int64
+	for i := range cfg.Nodes {
+		r := &cfg.Nodes[i].Resources
+		name := fmt.Sprintf("node %d (%s)", i, cfg.Nodes[i].Role)
+		if r.CPUs != "" {
+			// validated already
+			cpus, _ := strconv.ParseFloat(r.CPUs, 64)
+			if !info.SupportsCPUShares {
+				errs = append(errs, errors.Errorf("%s requests cpus, but the provider does not support CPU limits", name))
+			} else if info.NCPU > 0 && cpus > float64(info.NCPU) {
+				errs = append(errs, errors.Errorf("%s requests %s cpus, but the host only has %d", name, r.CPUs, info.NCPU))
+			}
+		}
+		if r.Memory != "" {
+			// validated already
+			memory, _ := config.ParseMemory(r.Memory)
+			totalMemory += memory
+			if !info.SupportsMemoryLimit {
+				errs = append(errs, errors.Errorf("%s requests memory, but the provider does not support memory limits", name))
+			} else if info.MemTotal > 0 && memory > info.MemTotal {
+				errs = append(errs, errors.Errorf("%s requests %s of memory, but the host only has %d bytes", name, r.Memory, info.MemTotal))
+			}
 		}
-		if !info.SupportsMemoryLimit || !info.SupportsPidsLimit || !info.SupportsCPUShares {
-			return errors.New("running kind with rootless provider requires setting systemd property \"Delegate=yes\", see https://kind.sigs.k8s.io/docs/user/rootless/")
+		if r.PIDs > 0 && !info.SupportsPidsLimit {
+			errs = append(errs, errors.Errorf("%s requests pids, but the provider does not support process limits", name))
 		}
 	}
+	if len(errs) > 0 {
+		return errors.NewAggregate(errs)
+	}
+	if info.MemTotal > 0 && totalMemory > info.MemTotal {
+		logger.Warnf("the nodes request %d bytes of memory in total, more than the %d bytes the host has", totalMemory, info.MemTotal)
+	}
+	return nil
+}
+
+// rootlessHint explains how to delegate cgroup controllers to the user with systemd
+const rootlessHint = "create /etc/systemd/system/user@.service.d/delegate.conf with a [Service] section setting Delegate=yes, " +
+	"run `sudo systemctl daemon-reload` and log in again, see https://kind.sigs.k8s.io/docs/user/rootless/"
+
+// validateRootless checks that a rootless provider has the cgroup v2
+// controllers kind nodes need delegated to it
+func validateRootless(info *providers.ProviderInfo) error {
+	if !info.Rootless {
+		return nil
+	}
+	if !info.Cgroup2 {
+		return errors.WithDetails(
+			errors.New("running kind with rootless provider requires cgroup v2, see https://kind.sigs.k8s.io/docs/user/rootless/"),
+			errors.Details{Hint: "boot the host with systemd.unified_cgroup_hierarchy=1"},
+		)
+	}
+	missing := []string{}
+	if !info.SupportsCPUShares {
+		missing = append(missing, "cpu")
+	}
+	if !info.SupportsMemoryLimit {
+		missing = append(missing, "memory")
+	}
+	if !info.SupportsPidsLimit {
+		missing = append(missing, "pids")
+	}
+	if len(missing) > 0 {
+		return errors.WithDetails(
+			errors.Errorf(
+				"running kind with rootless provider requires the cgroup controllers %s to be delegated to the user, "+
+					"set systemd property \"Delegate=yes\" for user@.service, see https://kind.sigs.k8s.io/docs/user/rootless/",
+				strings.Join(missing, ", "),
+			),
+			errors.Details{Hint: rootlessHint},
+		)
+	}
 	return nil
 }
@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package create
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+	"sigs.k8s.io/kind/pkg/log"
+)
+
+func TestValidateRootless(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name          string
+		Info          providers.ProviderInfo
+		ExpectMissing string
+		ExpectError   bool
+	}{
+		{
+			Name: "rootful",
+			Info: providers.ProviderInfo{},
+		},
+		{
+			Name: "rootless with all controllers",
+			Info: providers.ProviderInfo{
+				Rootless:            true,
+				Cgroup2:             true,
+				SupportsMemoryLimit: true,
+				SupportsPidsLimit:   true,
+				SupportsCPUShares:   true,
+			},
+		},
+		{
+			Name:        "rootless on cgroup v1",
+			Info:        providers.ProviderInfo{Rootless: true},
+			ExpectError: true,
+		},
+		{
+			Name: "rootless without delegation",
+			Info: providers.ProviderInfo{
+				Rootless:          true,
+				Cgroup2:           true,
+				SupportsPidsLimit: true,
+			},
+			ExpectMissing: "cpu, memory",
+			ExpectError:   true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			err := validateRootless(&tc.Info)
+			assert.ExpectError(t, tc.ExpectError, err)
+			if err == nil {
+				return
+			}
+			if errors.DetailsOf(err).Hint == "" {
+				t.Errorf("expected a hint for %v", err)
+			}
+			if tc.ExpectMissing != "" && !strings.Contains(err.Error(), "controllers "+tc.ExpectMissing+" ") {
+				t.Errorf("expected error to name missing controllers %q: %v", tc.ExpectMissing, err)
+			}
+		})
+	}
+}
+
+func TestValidateNodeResources(t *testing.T) {
+	t.Parallel()
+	host := providers.ProviderInfo{
+		SupportsMemoryLimit: true,
+		SupportsPidsLimit:   true,
+		SupportsCPUShares:   true,
+		NCPU:                4,
+		MemTotal:            8 << 30,
+	}
+	cases := []struct {
+		Name           string
+		Info           providers.ProviderInfo
+		Resources      []config.NodeResources
+		ExpectedErrors int
+	}{
+		{
+			Name:      "fits the host",
+			Info:      host,
+			Resources: []config.NodeResources{{CPUs: "4", Memory: "8g", PIDs: 4096}, {}},
+		},
+		{
+			Name: "nodes may add up to more than the host",
+			Info: host,
+			Resources: []config.NodeResources{
+				{CPUs: "3", Memory: "6g"},
+				{CPUs: "3", Memory: "6g"},
+			},
+		},
+		{
+			Name:           "larger than the host",
+			Info:           host,
+			Resources:      []config.NodeResources{{CPUs: "4.5", Memory: "9g"}},
+			ExpectedErrors: 2,
+		},
+		{
+			Name:           "unknown host capacity",
+			Info:           providers.ProviderInfo{SupportsMemoryLimit: true, SupportsCPUShares: true},
+			Resources:      []config.NodeResources{{CPUs: "64", Memory: "1024g"}},
+			ExpectedErrors: 0,
+		},
+		{
+			Name:           "unsupported limits",
+			Info:           providers.ProviderInfo{},
+			Resources:      []config.NodeResources{{CPUs: "1", Memory: "1g", PIDs: 100}},
+			ExpectedErrors: 3,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &config.Cluster{}
+			for _, r := range tc.Resources {
+				cfg.Nodes = append(cfg.Nodes, config.Node{Role: config.WorkerRole, Resources: r})
+			}
+			err := validateNodeResources(log.NoopLogger{}, &tc.Info, cfg)
+			if tc.ExpectedErrors == 0 {
+				assert.ExpectError(t, false, err)
+				return
+			}
+			errs := errors.Errors(err)
+			if errs == nil {
+				errs = []error{err}
+			}
+			if len(errs) != tc.ExpectedErrors {
+				t.Errorf("expected %d errors but got len(%v) = %d", tc.ExpectedErrors, errs, len(errs))
+			}
+		})
+	}
+}
+
+func TestFixupOptionsImageBundle(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	configBundle := filepath.Join(dir, "config.tar")
+	flagBundle := filepath.Join(dir, "flag.tar")
+	for _, path := range []string{configBundle, flagBundle} {
+		if err := os.WriteFile(path, []byte{}, 0600); err != nil {
+			t.Fatalf("failed to write bundle: %v", err)
+		}
+	}
+	cases := []struct {
+		Name         string
+		ConfigBundle string
+		OptionBundle string
+		Expected     string
+		ExpectError  bool
+	}{
+		{
+			Name: "no bundle",
+		},
+		{
+			Name:         "config bundle",
+			ConfigBundle: configBundle,
+			Expected:     configBundle,
+		},
+		{
+			Name:         "option overrides config",
+			ConfigBundle: configBundle,
+			OptionBundle: flagBundle,
+			Expected:     flagBundle,
+		},
+		{
+			Name:         "bundle path is cleaned",
+			ConfigBundle: filepath.Join(dir, ".", "config.tar"),
+			Expected:     configBundle,
+		},
+		{
+			Name:         "missing bundle",
+			ConfigBundle: filepath.Join(dir, "missing.tar"),
+			ExpectError:  true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			opts := &ClusterOptions{
+				Config:      &config.Cluster{ImageBundle: tc.ConfigBundle},
+				ImageBundle: tc.OptionBundle,
+			}
+			err := FixupOptions(opts)
+			assert.ExpectError(t, tc.ExpectError, err)
+			if err == nil {
+				assert.StringEqual(t, tc.Expected, opts.Config.ImageBundle)
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	"sigs.k8s.io/kind/pkg/cluster/internal/kubeconfig"
 	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/cluster/internal/routes"
 )
 
 // Cluster deletes the cluster identified by ctx
@@ -39,11 +40,38 @@ func Cluster(logger log.Logger, p providers.Provider, name, explicitKubeconfigPa
 	}
 
 	if len(n) > 0 {
+		// remove the host routes kind added via the nodes, if any, see:
+		// `kind network routes`
+		if routes.Supported() {
+			if _, err := routes.Remove(logger, n); err != nil {
+				logger.Warnf("failed to remove host routes: %v", err)
+			}
+		}
 		err = p.DeleteNodes(n)
 		if err != nil {
 			return err
 		}
 		logger.V(0).Infof("Deleted nodes: %q", n)
+		// nodes imported from a snapshot run from images named after them
+		if err := p.DeleteSnapshotImages(n); err != nil {
+			logger.Warnf("failed to delete snapshot images: %v", err)
+		}
+	}
+
+	if err := p.DeleteProxyHelper(name); err != nil {
+		return err
+	}
+
+	if err := p.DeleteOIDCProvider(name); err != nil {
+		return err
+	}
+
+	if err := p.DeleteDNSServer(name); err != nil {
+		return err
+	}
+
+	if err := p.DeleteScratchNode(name); err != nil {
+		return err
 	}
 
 	if kerr != nil {
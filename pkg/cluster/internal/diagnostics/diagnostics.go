@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package diagnostics implements collecting the state of a cluster that
+// explains most early failures, without exporting all of its logs
+package diagnostics
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// DefaultKubeletLines is the default number of kubelet journal lines
+// collected from each node
+const DefaultKubeletLines = 30
+
+// Collect returns a report of the cluster's events, its pods that are not
+// running, and the last kubeletLines lines of the kubelet journal of each node
+//
+// Collecting is best effort, sections that cannot be collected, e.g. because
+// the API server is not up, report the error instead
+func Collect(allNodes []nodes.Node, kubeletLines int) string {
+	var b bytes.Buffer
+	node, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+	if err != nil {
+		fmt.Fprintf(&b, "=== Events ===\n%v\n", err)
+	} else {
+		writeSection(&b, "Events", node.Command(
+			"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+			"get", "events", "--all-namespaces", "--sort-by=.lastTimestamp",
+		))
+		writeSection(&b, "Pods not Running", node.Command(
+			"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+			"get", "pods", "--all-namespaces", "--output=wide",
+			"--field-selector=status.phase!=Running,status.phase!=Succeeded",
+		))
+	}
+	internalNodes, err := nodeutils.InternalNodes(allNodes)
+	if err != nil {
+		fmt.Fprintf(&b, "=== kubelet ===\n%v\n", err)
+		return b.String()
+	}
+	for _, n := range internalNodes {
+		writeSection(&b, fmt.Sprintf("kubelet on %s", n.String()), n.Command(
+			"journalctl", "--unit=kubelet.service", "--no-pager", "--output=short-iso",
+			"--lines="+strconv.Itoa(kubeletLines),
+		))
+	}
+	return b.String()
+}
+
+// writeSection writes the output of cmd under title to b,
+// or the error running it
+func writeSection(b *bytes.Buffer, title string, cmd exec.Cmd) {
+	fmt.Fprintf(b, "=== %s ===\n", title)
+	var out bytes.Buffer
+	if err := cmd.SetStdout(&out).SetStderr(&out).Run(); err != nil {
+		fmt.Fprintf(b, "%s%v\n", out.String(), err)
+		return
+	}
+	if out.Len() == 0 {
+		b.WriteString("(none)\n")
+		return
+	}
+	b.Write(out.Bytes())
+	if out.Bytes()[out.Len()-1] != '\n' {
+		b.WriteString("\n")
+	}
+}
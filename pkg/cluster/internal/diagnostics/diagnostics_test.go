@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+// fakeNode answers commands by the name of the binary run
+type fakeNode struct {
+	name    string
+	role    string
+	outputs map[string]string
+}
+
+var _ nodes.Node = &fakeNode{}
+
+func (n *fakeNode) String() string              { return n.name }
+func (n *fakeNode) Role() (string, error)       { return n.role, nil }
+func (n *fakeNode) IP() (string, string, error) { return "", "", nil }
+func (n *fakeNode) SerialLogs(io.Writer) error  { return nil }
+func (n *fakeNode) Command(command string, args ...string) exec.Cmd {
+	return &fakeCmd{output: n.outputs[command+" "+args[len(args)-1]]}
+}
+func (n *fakeNode) CommandContext(_ context.Context, command string, args ...string) exec.Cmd {
+	return n.Command(command, args...)
+}
+
+type fakeCmd struct {
+	output string
+	stdout io.Writer
+}
+
+func (c *fakeCmd) Run() error {
+	if c.output == "fail" {
+		_, _ = io.WriteString(c.stdout, "connection refused\n")
+		return errors.New("exit status 1")
+	}
+	_, _ = io.WriteString(c.stdout, c.output)
+	return nil
+}
+func (c *fakeCmd) SetEnv(...string) exec.Cmd      { return c }
+func (c *fakeCmd) SetStdin(io.Reader) exec.Cmd    { return c }
+func (c *fakeCmd) SetStdout(w io.Writer) exec.Cmd { c.stdout = w; return c }
+func (c *fakeCmd) SetStderr(io.Writer) exec.Cmd   { return c }
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+	allNodes := []nodes.Node{
+		&fakeNode{name: "kind-worker", role: "worker", outputs: map[string]string{
+			"journalctl --lines=5": "kubelet started",
+		}},
+		&fakeNode{name: "kind-control-plane", role: "control-plane", outputs: map[string]string{
+			"kubectl --sort-by=.lastTimestamp":                                       "LAST SEEN   TYPE      REASON\n1s          Warning   FailedScheduling\n",
+			"kubectl --field-selector=status.phase!=Running,status.phase!=Succeeded": "",
+			"journalctl --lines=5":                                                   "fail",
+		}},
+		&fakeNode{name: "kind-external-load-balancer", role: "external-load-balancer"},
+	}
+	assert.StringEqual(t, strings.Join([]string{
+		"=== Events ===",
+		"LAST SEEN   TYPE      REASON",
+		"1s          Warning   FailedScheduling",
+		"=== Pods not Running ===",
+		"(none)",
+		"=== kubelet on kind-worker ===",
+		"kubelet started",
+		"=== kubelet on kind-control-plane ===",
+		"connection refused",
+		"exit status 1",
+		"",
+	}, "\n"), Collect(allNodes, 5))
+}
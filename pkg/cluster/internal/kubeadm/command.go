@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// Command returns a command running kubeadm with args on node that is
+// cancelled with ctx, a non zero timeout also kills kubeadm on the node once
+// it passes, cancelling only kills the host side of the command
+func Command(ctx context.Context, node nodes.Node, timeout time.Duration, args ...string) exec.Cmd {
+	command, args := commandArgs(timeout, args)
+	return node.CommandContext(ctx, command, args...)
+}
+
+// commandArgs returns the command and args running kubeadm with args,
+// wrapped in timeout(1) when timeout is not zero
+func commandArgs(timeout time.Duration, args []string) (string, []string) {
+	if timeout <= 0 {
+		return "kubeadm", args
+	}
+	seconds := strconv.FormatFloat(timeout.Seconds(), 'f', -1, 64)
+	return "timeout", append([]string{"--signal=KILL", seconds + "s", "kubeadm"}, args...)
+}
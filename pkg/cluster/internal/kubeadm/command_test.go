@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestCommandArgs(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name            string
+		Timeout         time.Duration
+		ExpectedCommand string
+		ExpectedArgs    []string
+	}{
+		{
+			Name:            "no timeout",
+			ExpectedCommand: "kubeadm",
+			ExpectedArgs:    []string{"join", "--v=6"},
+		},
+		{
+			Name:            "timeout",
+			Timeout:         5 * time.Minute,
+			ExpectedCommand: "timeout",
+			ExpectedArgs:    []string{"--signal=KILL", "300s", "kubeadm", "join", "--v=6"},
+		},
+		{
+			Name:            "fractional timeout",
+			Timeout:         1500 * time.Millisecond,
+			ExpectedCommand: "timeout",
+			ExpectedArgs:    []string{"--signal=KILL", "1.5s", "kubeadm", "join", "--v=6"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			command, args := commandArgs(tc.Timeout, []string{"join", "--v=6"})
+			assert.StringEqual(t, tc.ExpectedCommand, command)
+			assert.DeepEqual(t, tc.ExpectedArgs, args)
+		})
+	}
+}
@@ -63,6 +63,8 @@ type ConfigData struct {
 	PodSubnet string
 	// The subnet used for services
 	ServiceSubnet string
+	// The DNS domain used by services
+	ClusterDomain string
 
 	// Kubernetes FeatureGates
 	FeatureGates map[string]bool
@@ -70,12 +72,22 @@ type ConfigData struct {
 	// Kubernetes API Server RuntimeConfig
 	RuntimeConfig map[string]string
 
+	// Audit enables API server audit logging
+	Audit bool
+
+	// OIDC configures API server OpenID Connect authentication, if the
+	// IssuerURL is set
+	OIDC config.OIDC
+
 	// IPFamily of the cluster, it can be IPv4, IPv6 or DualStack
 	IPFamily config.ClusterIPFamily
 
 	// Labels are the labels, in the format "key1=val1,key2=val2", with which the respective node will be labeled
 	NodeLabels string
 
+	// KubeletVerbosity is the kubelet --v log verbosity, if it is not zero
+	KubeletVerbosity int32
+
 	// RootlessProvider is true if kind is running with rootless mode
 	RootlessProvider bool
 
@@ -205,6 +217,47 @@ apiServer:
 {{ if .FeatureGates }}
     "feature-gates": "{{ .FeatureGatesString }}"
 {{ end}}
+{{ if .Audit }}
+    "audit-policy-file": "/etc/kubernetes/audit/policy.yaml"
+    "audit-log-path": "/var/log/kubernetes/audit/audit.log"
+    "audit-log-maxage": "7"
+    "audit-log-maxbackup": "10"
+    "audit-log-maxsize": "100"
+{{ end }}
+{{ if .OIDC.IssuerURL }}
+    "oidc-issuer-url": "{{ .OIDC.IssuerURL }}"
+    "oidc-client-id": "{{ .OIDC.ClientID }}"
+{{ if .OIDC.UsernameClaim }}
+    "oidc-username-claim": "{{ .OIDC.UsernameClaim }}"
+{{ end }}
+{{ if .OIDC.GroupsClaim }}
+    "oidc-groups-claim": "{{ .OIDC.GroupsClaim }}"
+{{ end }}
+{{ if .OIDC.CertificateAuthority }}
+    "oidc-ca-file": "/etc/kubernetes/oidc/ca.crt"
+{{ end }}
+{{ end }}
+{{ if or .Audit .OIDC.CertificateAuthority }}
+  extraVolumes:
+{{ end }}
+{{ if .Audit }}
+  - name: audit-policy
+    hostPath: /etc/kubernetes/audit
+    mountPath: /etc/kubernetes/audit
+    readOnly: true
+    pathType: DirectoryOrCreate
+  - name: audit-logs
+    hostPath: /var/log/kubernetes/audit
+    mountPath: /var/log/kubernetes/audit
+    pathType: DirectoryOrCreate
+{{ end }}
+{{ if .OIDC.CertificateAuthority }}
+  - name: oidc-ca
+    hostPath: /etc/kubernetes/oidc
+    mountPath: /etc/kubernetes/oidc
+    readOnly: true
+    pathType: DirectoryOrCreate
+{{ end }}
 controllerManager:
   extraArgs:
 {{ if .FeatureGates }}
@@ -227,6 +280,7 @@ scheduler:
 networking:
   podSubnet: "{{ .PodSubnet }}"
   serviceSubnet: "{{ .ServiceSubnet }}"
+  dnsDomain: "{{ .ClusterDomain }}"
 ---
 apiVersion: kubeadm.k8s.io/v1beta2
 kind: InitConfiguration
@@ -246,6 +300,9 @@ nodeRegistration:
     node-ip: "{{ .NodeAddress }}"
     provider-id: "kind://{{.NodeProvider}}/{{.ClusterName}}/{{.NodeName}}"
     node-labels: "{{ .NodeLabels }}"
+{{- if .KubeletVerbosity }}
+    v: "{{ .KubeletVerbosity }}"
+{{- end }}
 ---
 # no-op entry that exists solely so it can be patched
 apiVersion: kubeadm.k8s.io/v1beta2
@@ -264,6 +321,9 @@ nodeRegistration:
     node-ip: "{{ .NodeAddress }}"
     provider-id: "kind://{{.NodeProvider}}/{{.ClusterName}}/{{.NodeName}}"
     node-labels: "{{ .NodeLabels }}"
+{{- if .KubeletVerbosity }}
+    v: "{{ .KubeletVerbosity }}"
+{{- end }}
 discovery:
   bootstrapToken:
     apiServerEndpoint: "{{ .ControlPlaneEndpoint }}"
@@ -275,6 +335,7 @@ kind: KubeletConfiguration
 metadata:
   name: config
 cgroupDriver: {{ .CgroupDriver }}
+clusterDomain: "{{ .ClusterDomain }}"
 cgroupRoot: /kubelet
 failSwapOn: false
 # configure ipv6 addresses in IPv6 mode
@@ -348,6 +409,47 @@ apiServer:
 {{ if .FeatureGates }}
     "feature-gates": "{{ .FeatureGatesString }}"
 {{ end}}
+{{ if .Audit }}
+    "audit-policy-file": "/etc/kubernetes/audit/policy.yaml"
+    "audit-log-path": "/var/log/kubernetes/audit/audit.log"
+    "audit-log-maxage": "7"
+    "audit-log-maxbackup": "10"
+    "audit-log-maxsize": "100"
+{{ end }}
+{{ if .OIDC.IssuerURL }}
+    "oidc-issuer-url": "{{ .OIDC.IssuerURL }}"
+    "oidc-client-id": "{{ .OIDC.ClientID }}"
+{{ if .OIDC.UsernameClaim }}
+    "oidc-username-claim": "{{ .OIDC.UsernameClaim }}"
+{{ end }}
+{{ if .OIDC.GroupsClaim }}
+    "oidc-groups-claim": "{{ .OIDC.GroupsClaim }}"
+{{ end }}
+{{ if .OIDC.CertificateAuthority }}
+    "oidc-ca-file": "/etc/kubernetes/oidc/ca.crt"
+{{ end }}
+{{ end }}
+{{ if or .Audit .OIDC.CertificateAuthority }}
+  extraVolumes:
+{{ end }}
+{{ if .Audit }}
+  - name: audit-policy
+    hostPath: /etc/kubernetes/audit
+    mountPath: /etc/kubernetes/audit
+    readOnly: true
+    pathType: DirectoryOrCreate
+  - name: audit-logs
+    hostPath: /var/log/kubernetes/audit
+    mountPath: /var/log/kubernetes/audit
+    pathType: DirectoryOrCreate
+{{ end }}
+{{ if .OIDC.CertificateAuthority }}
+  - name: oidc-ca
+    hostPath: /etc/kubernetes/oidc
+    mountPath: /etc/kubernetes/oidc
+    readOnly: true
+    pathType: DirectoryOrCreate
+{{ end }}
 controllerManager:
   extraArgs:
 {{ if .FeatureGates }}
@@ -370,6 +472,7 @@ scheduler:
 networking:
   podSubnet: "{{ .PodSubnet }}"
   serviceSubnet: "{{ .ServiceSubnet }}"
+  dnsDomain: "{{ .ClusterDomain }}"
 ---
 apiVersion: kubeadm.k8s.io/v1beta3
 kind: InitConfiguration
@@ -389,6 +492,9 @@ nodeRegistration:
     node-ip: "{{ .NodeAddress }}"
     provider-id: "kind://{{.NodeProvider}}/{{.ClusterName}}/{{.NodeName}}"
     node-labels: "{{ .NodeLabels }}"
+{{- if .KubeletVerbosity }}
+    v: "{{ .KubeletVerbosity }}"
+{{- end }}
 {{ if .InitSkipPhases -}}
 skipPhases:
   {{- range $phase := .InitSkipPhases }}
@@ -413,6 +519,9 @@ nodeRegistration:
     node-ip: "{{ .NodeAddress }}"
     provider-id: "kind://{{.NodeProvider}}/{{.ClusterName}}/{{.NodeName}}"
     node-labels: "{{ .NodeLabels }}"
+{{- if .KubeletVerbosity }}
+    v: "{{ .KubeletVerbosity }}"
+{{- end }}
 discovery:
   bootstrapToken:
     apiServerEndpoint: "{{ .ControlPlaneEndpoint }}"
@@ -430,6 +539,7 @@ kind: KubeletConfiguration
 metadata:
   name: config
 cgroupDriver: {{ .CgroupDriver }}
+clusterDomain: "{{ .ClusterDomain }}"
 cgroupRoot: /kubelet
 failSwapOn: false
 # configure ipv6 addresses in IPv6 mode
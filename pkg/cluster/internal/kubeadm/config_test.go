@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestConfigKubeletVerbosity(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name              string
+		KubernetesVersion string
+		KubeletVerbosity  int32
+		ExpectedArgs      int
+	}{
+		{
+			Name:              "v1beta3 default verbosity",
+			KubernetesVersion: "v1.31.0",
+		},
+		{
+			Name:              "v1beta3",
+			KubernetesVersion: "v1.31.0",
+			KubeletVerbosity:  4,
+			// InitConfiguration and JoinConfiguration
+			ExpectedArgs: 2,
+		},
+		{
+			Name:              "v1beta2",
+			KubernetesVersion: "v1.21.0",
+			KubeletVerbosity:  4,
+			ExpectedArgs:      2,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			config, err := Config(ConfigData{
+				ClusterName:       "kind",
+				KubernetesVersion: tc.KubernetesVersion,
+				NodeAddress:       "172.18.0.2",
+				KubeletVerbosity:  tc.KubeletVerbosity,
+			})
+			assert.ExpectError(t, false, err)
+			if got := strings.Count(config, "\n    v: \"4\"\n"); got != tc.ExpectedArgs {
+				t.Errorf("expected %d kubelet v args, got %d in:\n%s", tc.ExpectedArgs, got, config)
+			}
+			assert.BoolEqual(t, true, strings.Contains(config, "    node-labels: \"\"\n"))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/cli"
+)
+
+// phaseMarker matches the marker kubeadm prefixes the output of each of its
+// phases with, e.g. `[certs] Generating "ca" certificate and key`
+var phaseMarker = regexp.MustCompile(`^\[([a-z0-9/-]+)\] `)
+
+// ParsePhase returns the kubeadm phase a line of kubeadm output belongs to,
+// or the empty string if the line has no phase marker, like klog output
+func ParsePhase(line string) string {
+	if m := phaseMarker.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// PhaseWriter is an io.Writer for streamed kubeadm output, which collects the
+// output lines and calls OnPhase when a phase starts
+type PhaseWriter struct {
+	// OnPhase is called with the name of each phase when it starts
+	OnPhase func(phase string)
+
+	mu      sync.Mutex
+	partial string
+	lines   []string
+	seen    map[string]bool
+}
+
+// Write implements io.Writer
+func (w *PhaseWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.seen == nil {
+		w.seen = map[string]bool{}
+	}
+	split := strings.Split(w.partial+string(p), "\n")
+	w.partial = split[len(split)-1]
+	for _, line := range split[:len(split)-1] {
+		w.lines = append(w.lines, line)
+		// kubeadm may print the marker of an earlier phase again later,
+		// e.g. [kubelet-check] while waiting for the control plane
+		if phase := ParsePhase(line); phase != "" && !w.seen[phase] {
+			w.seen[phase] = true
+			if w.OnPhase != nil {
+				w.OnPhase(phase)
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// Lines returns the output lines written so far
+func (w *PhaseWriter) Lines() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	lines := append([]string{}, w.lines...)
+	if w.partial != "" {
+		lines = append(lines, w.partial)
+	}
+	return lines
+}
+
+// RunWithPhases runs the kubeadm command cmd, showing each kubeadm phase as a
+// phase of status named prefix followed by the kubeadm phase
+// It returns the combined output lines of cmd
+func RunWithPhases(status *cli.Status, prefix string, cmd exec.Cmd) ([]string, error) {
+	var current *cli.Phase
+	w := &PhaseWriter{
+		OnPhase: func(phase string) {
+			// kubeadm phases run sequentially
+			if current != nil {
+				current.End(true)
+			}
+			current = status.StartPhase(prefix + phase)
+		},
+	}
+	cmd.SetStdout(w)
+	cmd.SetStderr(w)
+	err := cmd.Run()
+	if current != nil {
+		current.End(err == nil)
+	}
+	return w.Lines(), err
+}
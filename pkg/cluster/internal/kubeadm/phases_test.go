@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeadm
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestParsePhase(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Line     string
+		Expected string
+	}{
+		{Line: `[certs] Generating "ca" certificate and key`, Expected: "certs"},
+		{Line: `[upload-config] Storing the configuration used in ConfigMap "kubeadm-config"`, Expected: "upload-config"},
+		{Line: `[addons] Applied essential addon: CoreDNS`, Expected: "addons"},
+		{Line: `[control-plane/apiserver] Creating static Pod manifest`, Expected: "control-plane/apiserver"},
+		{Line: `I0101 00:00:00.000000     123 initconfiguration.go:123] loading configuration`, Expected: ""},
+		{Line: `[certs]`, Expected: ""},
+		{Line: ` [certs] indented`, Expected: ""},
+		{Line: `[WARNING SystemVerification]: missing optional cgroups`, Expected: ""},
+		{Line: "", Expected: ""},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Line, func(t *testing.T) {
+			t.Parallel()
+			assert.StringEqual(t, tc.Expected, ParsePhase(tc.Line))
+		})
+	}
+}
+
+func TestPhaseWriter(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name           string
+		Writes         []string
+		ExpectedLines  []string
+		ExpectedPhases []string
+	}{
+		{
+			Name:           "whole lines",
+			Writes:         []string{"[preflight] Running pre-flight checks\n", "[certs] Using certificateDir folder\n"},
+			ExpectedLines:  []string{"[preflight] Running pre-flight checks", "[certs] Using certificateDir folder"},
+			ExpectedPhases: []string{"preflight", "certs"},
+		},
+		{
+			Name:           "lines split across writes",
+			Writes:         []string{"[pre", "flight] Running", " pre-flight checks\n[ce", "rts] Using certificateDir folder\n"},
+			ExpectedLines:  []string{"[preflight] Running pre-flight checks", "[certs] Using certificateDir folder"},
+			ExpectedPhases: []string{"preflight", "certs"},
+		},
+		{
+			Name:           "several lines in one write",
+			Writes:         []string{"[kubeconfig] Writing \"admin.conf\"\nI0101 klog line\n[kubelet-start] Starting the kubelet\n"},
+			ExpectedLines:  []string{"[kubeconfig] Writing \"admin.conf\"", "I0101 klog line", "[kubelet-start] Starting the kubelet"},
+			ExpectedPhases: []string{"kubeconfig", "kubelet-start"},
+		},
+		{
+			Name: "repeated phase markers",
+			Writes: []string{
+				"[kubelet-check] Waiting for a healthy kubelet\n",
+				"[control-plane] Creating static Pod manifest\n",
+				"[kubelet-check] The kubelet is healthy\n",
+				"[control-plane] Done\n",
+			},
+			ExpectedLines: []string{
+				"[kubelet-check] Waiting for a healthy kubelet",
+				"[control-plane] Creating static Pod manifest",
+				"[kubelet-check] The kubelet is healthy",
+				"[control-plane] Done",
+			},
+			ExpectedPhases: []string{"kubelet-check", "control-plane"},
+		},
+		{
+			Name:           "trailing partial line",
+			Writes:         []string{"[mark-control-plane] Marking the node\n", "error execution phase"},
+			ExpectedLines:  []string{"[mark-control-plane] Marking the node", "error execution phase"},
+			ExpectedPhases: []string{"mark-control-plane"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			phases := []string{}
+			w := &PhaseWriter{
+				OnPhase: func(phase string) {
+					phases = append(phases, phase)
+				},
+			}
+			for _, s := range tc.Writes {
+				n, err := w.Write([]byte(s))
+				assert.ExpectError(t, false, err)
+				if n != len(s) {
+					t.Fatalf("expected to write %d bytes, wrote %d", len(s), n)
+				}
+			}
+			assert.DeepEqual(t, tc.ExpectedLines, w.Lines())
+			assert.DeepEqual(t, tc.ExpectedPhases, phases)
+		})
+	}
+}
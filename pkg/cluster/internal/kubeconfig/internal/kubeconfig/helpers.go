@@ -17,6 +17,8 @@ limitations under the License.
 package kubeconfig
 
 import (
+	"encoding/base64"
+
 	"sigs.k8s.io/kind/pkg/errors"
 )
 
@@ -39,3 +41,37 @@ func checkKubeadmExpectations(cfg *Config) error {
 	}
 	return nil
 }
+
+// CertificateAuthorityData returns the decoded certificate-authority-data of
+// the cluster, which kubeadm always embeds
+func (c *Cluster) CertificateAuthorityData() ([]byte, error) {
+	raw, _ := c.OtherFields["certificate-authority-data"].(string)
+	if raw == "" {
+		return nil, errors.New("kubeconfig cluster has no certificate-authority-data")
+	}
+	ca, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode kubeconfig certificate-authority-data")
+	}
+	return ca, nil
+}
+
+// ClientCertificate returns the decoded client-certificate-data and
+// client-key-data of the user, which kubeadm embeds for the admin user, or
+// nil if the user does not authenticate with an embedded certificate
+func (u *NamedUser) ClientCertificate() (cert, key []byte, err error) {
+	rawCert, _ := u.User["client-certificate-data"].(string)
+	rawKey, _ := u.User["client-key-data"].(string)
+	if rawCert == "" || rawKey == "" {
+		return nil, nil, nil
+	}
+	cert, err = base64.StdEncoding.DecodeString(rawCert)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode kubeconfig client-certificate-data")
+	}
+	key, err = base64.StdEncoding.DecodeString(rawKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode kubeconfig client-key-data")
+	}
+	return cert, key, nil
+}
@@ -88,3 +88,38 @@ func TestCheckKubeadmExpectations(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateAuthorityData(t *testing.T) {
+	t.Parallel()
+	c := &Cluster{OtherFields: map[string]interface{}{"certificate-authority-data": "Y2EgZGF0YQ=="}}
+	ca, err := c.CertificateAuthorityData()
+	assert.ExpectError(t, false, err)
+	assert.StringEqual(t, "ca data", string(ca))
+
+	_, err = (&Cluster{}).CertificateAuthorityData()
+	assert.ExpectError(t, true, err)
+	_, err = (&Cluster{OtherFields: map[string]interface{}{"certificate-authority-data": "!"}}).CertificateAuthorityData()
+	assert.ExpectError(t, true, err)
+}
+
+func TestClientCertificate(t *testing.T) {
+	t.Parallel()
+	u := &NamedUser{User: map[string]interface{}{
+		"client-certificate-data": "Y2VydA==",
+		"client-key-data":         "a2V5",
+	}}
+	cert, key, err := u.ClientCertificate()
+	assert.ExpectError(t, false, err)
+	assert.StringEqual(t, "cert", string(cert))
+	assert.StringEqual(t, "key", string(key))
+
+	cert, key, err = (&NamedUser{User: map[string]interface{}{"token": "abc"}}).ClientCertificate()
+	assert.ExpectError(t, false, err)
+	assert.BoolEqual(t, true, cert == nil && key == nil)
+
+	_, _, err = (&NamedUser{User: map[string]interface{}{
+		"client-certificate-data": "!",
+		"client-key-data":         "a2V5",
+	}}).ClientCertificate()
+	assert.ExpectError(t, true, err)
+}
@@ -17,8 +17,6 @@ limitations under the License.
 package kubeconfig
 
 import (
-	"os"
-
 	"sigs.k8s.io/kind/pkg/errors"
 )
 
@@ -27,7 +25,7 @@ import (
 // the kind config's current context.
 func WriteMerged(kindConfig *Config, explicitConfigPath string) error {
 	// figure out what filepath we should use
-	configPath := pathForMerge(explicitConfigPath, os.Getenv)
+	configPath := PathForMerge(explicitConfigPath)
 
 	// lock config file the same as client-go
 	if err := lockFile(configPath); err != nil {
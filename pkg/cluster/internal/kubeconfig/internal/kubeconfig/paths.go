@@ -56,6 +56,12 @@ func paths(explicitPath string, getEnv func(string) string) []string {
 	return []string{path.Join(homeDir(runtime.GOOS, getEnv), ".kube", "config")}
 }
 
+// PathForMerge returns the file WriteMerged writes to for explicitPath,
+// following the same rules as kubectl
+func PathForMerge(explicitPath string) string {
+	return pathForMerge(explicitPath, os.Getenv)
+}
+
 // pathForMerge returns the file that kubectl would merge into
 func pathForMerge(explicitPath string, getEnv func(string) string) string {
 	// find the first file that exists
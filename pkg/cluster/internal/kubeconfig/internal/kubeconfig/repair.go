@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"os"
+
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// KINDServers returns the server recorded for the kind cluster
+// kindClusterName in each of the KUBECONFIG files, keyed by file path
+// Files without an entry for the cluster are omitted
+func KINDServers(kindClusterName, explicitPath string) (map[string]string, error) {
+	key := KINDClusterKey(kindClusterName)
+	servers := map[string]string{}
+	for _, configPath := range paths(explicitPath, os.Getenv) {
+		existing, err := read(configPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read kubeconfig")
+		}
+		for _, c := range existing.Clusters {
+			if c.Name == key {
+				servers[configPath] = c.Cluster.Server
+			}
+		}
+	}
+	return servers, nil
+}
+
+// RepairKIND points existing entries for the kind cluster kindClusterName
+// in the KUBECONFIG files at server, returning the paths of the files updated
+func RepairKIND(kindClusterName, explicitPath, server string) ([]string, error) {
+	repaired := []string{}
+	for _, configPath := range paths(explicitPath, os.Getenv) {
+		if err := func(configPath string) error {
+			// lock before modifying
+			if err := lockFile(configPath); err != nil {
+				return errors.Wrap(err, "failed to lock config file")
+			}
+			defer func(configPath string) {
+				_ = unlockFile(configPath)
+			}(configPath)
+
+			// read in existing
+			existing, err := read(configPath)
+			if err != nil {
+				return errors.Wrap(err, "failed to read kubeconfig to repair KIND entry")
+			}
+
+			// write out the updated config if we modified anything
+			if setServer(existing, kindClusterName, server) {
+				if err := write(existing, configPath); err != nil {
+					return err
+				}
+				repaired = append(repaired, configPath)
+			}
+			return nil
+		}(configPath); err != nil {
+			return repaired, err
+		}
+	}
+	return repaired, nil
+}
+
+// setServer sets the server of the kindClusterName cluster entry in cfg,
+// returning true if cfg was modified
+func setServer(cfg *Config, kindClusterName, server string) bool {
+	key := KINDClusterKey(kindClusterName)
+	mutated := false
+	for i := range cfg.Clusters {
+		if cfg.Clusters[i].Name == key && cfg.Clusters[i].Cluster.Server != server {
+			cfg.Clusters[i].Cluster.Server = server
+			mutated = true
+		}
+	}
+	return mutated
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestSetServer(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		Clusters: []NamedCluster{
+			{
+				Name:    "kind-kind",
+				Cluster: Cluster{Server: "https://127.0.0.1:50001"},
+			},
+			{
+				Name:    "kind-other",
+				Cluster: Cluster{Server: "https://127.0.0.1:50002"},
+			},
+		},
+	}
+	assert.BoolEqual(t, true, setServer(cfg, "kind", "https://127.0.0.1:50003"))
+	assert.StringEqual(t, "https://127.0.0.1:50003", cfg.Clusters[0].Cluster.Server)
+	assert.StringEqual(t, "https://127.0.0.1:50002", cfg.Clusters[1].Cluster.Server)
+	// already up to date
+	assert.BoolEqual(t, false, setServer(cfg, "kind", "https://127.0.0.1:50003"))
+	// no entry
+	assert.BoolEqual(t, false, setServer(cfg, "missing", "https://127.0.0.1:50003"))
+}
+
+func TestRepairKIND(t *testing.T) {
+	t.Parallel()
+	dir, err := os.MkdirTemp("", "kind-testrepairkind")
+	if err != nil {
+		t.Fatalf("Failed to create tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	kubeconfigPath := filepath.Join(dir, "config")
+	existing := &Config{
+		Clusters: []NamedCluster{
+			{
+				Name:    "kind-kind",
+				Cluster: Cluster{Server: "https://127.0.0.1:50001"},
+			},
+		},
+	}
+	if err := write(existing, kubeconfigPath); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+
+	servers, err := KINDServers("kind", kubeconfigPath)
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, map[string]string{kubeconfigPath: "https://127.0.0.1:50001"}, servers)
+
+	repaired, err := RepairKIND("kind", kubeconfigPath, "https://127.0.0.1:50003")
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []string{kubeconfigPath}, repaired)
+
+	servers, err = KINDServers("kind", kubeconfigPath)
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, map[string]string{kubeconfigPath: "https://127.0.0.1:50003"}, servers)
+}
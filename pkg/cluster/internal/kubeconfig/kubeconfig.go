@@ -20,6 +20,7 @@ package kubeconfig
 
 import (
 	"bytes"
+	"sort"
 
 	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
 	"sigs.k8s.io/kind/pkg/errors"
@@ -40,6 +41,11 @@ func Export(p providers.Provider, name, explicitPath string, external bool) erro
 	return kubeconfig.WriteMerged(cfg, explicitPath)
 }
 
+// Path returns the file Export writes to given explicitPath
+func Path(explicitPath string) string {
+	return kubeconfig.PathForMerge(explicitPath)
+}
+
 // Remove removes clusterName from the kubeconfig paths detected based on
 // either explicitPath being set or $KUBECONFIG or $HOME/.kube/config, following
 // the rules set by kubectl
@@ -62,6 +68,38 @@ func Get(p providers.Provider, name string, external bool) (string, error) {
 	return string(b), err
 }
 
+// Stale returns the kubeconfig files, detected as in Export, with an entry
+// for the cluster that does not point at its current external endpoint,
+// e.g. after Docker Desktop restarts and changes the published host ports
+func Stale(p providers.Provider, name, explicitPath string) ([]string, error) {
+	server, err := externalServer(p, name)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := kubeconfig.KINDServers(name, explicitPath)
+	if err != nil {
+		return nil, err
+	}
+	stale := []string{}
+	for path, s := range servers {
+		if s != server {
+			stale = append(stale, path)
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// Repair points any stale kubeconfig entries for the cluster at its
+// current external endpoint, returning the files updated
+func Repair(p providers.Provider, name, explicitPath string) ([]string, error) {
+	server, err := externalServer(p, name)
+	if err != nil {
+		return nil, err
+	}
+	return kubeconfig.RepairKIND(name, explicitPath, server)
+}
+
 // ContextForCluster returns the context name for a kind cluster based on
 // its name. This key is used for all list entries of kind clusters
 func ContextForCluster(kindClusterName string) string {
@@ -93,13 +131,21 @@ func get(p providers.Provider, name string, external bool) (*kubeconfig.Config,
 	// if we're doing external we need to override the server endpoint
 	server := ""
 	if external {
-		endpoint, err := p.GetAPIServerEndpoint(name)
+		server, err = externalServer(p, name)
 		if err != nil {
 			return nil, err
 		}
-		server = "https://" + endpoint
 	}
 
 	// actually encode
 	return kubeconfig.KINDFromRawKubeadm(buff.String(), name, server)
 }
+
+// externalServer returns the server URL of the cluster's host endpoint
+func externalServer(p providers.Provider, name string) (string, error) {
+	endpoint, err := p.GetAPIServerEndpoint(name)
+	if err != nil {
+		return "", err
+	}
+	return "https://" + endpoint, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+)
+
+// WaitForEndpoint polls the cluster's external API server endpoint until it
+// serves a certificate verifying against the cluster CA from the cluster's
+// kubeconfig and reports ready, or fails once timeout passes.
+// Requests authenticate with the kubeconfig client certificate.
+// A zero timeout waits without a limit
+func WaitForEndpoint(p providers.Provider, name string, timeout time.Duration) error {
+	cfg, err := get(p, name, true)
+	if err != nil {
+		return err
+	}
+	// get returns the kind kubeconfig, which has exactly one cluster
+	cluster := cfg.Clusters[0].Cluster
+	ca, err := cluster.CertificateAuthorityData()
+	if err != nil {
+		return err
+	}
+	certs := []tls.Certificate{}
+	if len(cfg.Users) == 1 {
+		cert, key, err := cfg.Users[0].ClientCertificate()
+		if err != nil {
+			return err
+		}
+		if cert != nil {
+			pair, err := tls.X509KeyPair(cert, key)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse the client certificate from the kubeconfig")
+			}
+			certs = append(certs, pair)
+		}
+	}
+	return waitForEndpoint(cluster.Server, ca, certs, timeout, time.Second)
+}
+
+func waitForEndpoint(server string, ca []byte, certs []tls.Certificate, timeout, interval time.Duration) error {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return errors.New("failed to parse the cluster CA from the kubeconfig")
+	}
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, Certificates: certs, MinVersion: tls.VersionTLS12},
+		},
+	}
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		err := checkEndpoint(client, server)
+		if err == nil {
+			return nil
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return errors.WithDetails(
+				errors.Wrapf(err, "timed out after %s waiting for the API server endpoint", timeout),
+				errors.Details{
+					Category: errors.TimeoutCategory,
+					Hint:     "check that the endpoint is reachable from the host, or raise timeouts.controlPlaneEndpointReady",
+				},
+			)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// checkEndpoint checks that server is reachable with a verified certificate
+// and reports ready
+// An API server rejecting the request, e.g. with anonymous auth disabled and
+// no client certificate, is serving and counts as ready too
+func checkEndpoint(client *http.Client, server string) error {
+	resp, err := client.Get(server + "/readyz")
+	if err != nil {
+		return errors.Wrapf(err, "failed to reach the API server endpoint %s", server)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusUnauthorized, http.StatusForbidden:
+		return nil
+	default:
+		return errors.Errorf("the API server endpoint %s is not ready: %s", server, resp.Status)
+	}
+}
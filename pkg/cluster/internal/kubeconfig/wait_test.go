@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestWaitForEndpoint(t *testing.T) {
+	t.Parallel()
+	// the server reports ready after a few requests
+	var requests int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/readyz" || atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	err := waitForEndpoint(server.URL, ca, nil, time.Minute, time.Millisecond)
+	assert.ExpectError(t, false, err)
+
+	// a certificate from another CA is never accepted
+	err = waitForEndpoint(server.URL, newTestCA(t), nil, 10*time.Millisecond, time.Millisecond)
+	assert.ExpectError(t, true, err)
+
+	err = waitForEndpoint(server.URL, []byte("not a CA"), nil, time.Minute, time.Millisecond)
+	assert.ExpectError(t, true, err)
+}
+
+func TestWaitForEndpointRejected(t *testing.T) {
+	t.Parallel()
+	// an API server with anonymous auth disabled rejects the request, which
+	// still shows it is serving
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		status := status // capture range variable
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+		ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		err := waitForEndpoint(server.URL, ca, nil, 10*time.Millisecond, time.Millisecond)
+		server.Close()
+		assert.ExpectError(t, false, err)
+	}
+}
+
+func TestWaitForEndpointClientCertificate(t *testing.T) {
+	t.Parallel()
+	// the server is only ready for the client certificate
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	err := waitForEndpoint(server.URL, ca, nil, 10*time.Millisecond, time.Millisecond)
+	assert.ExpectError(t, true, err)
+
+	cert, key := newTestCertificate(t)
+	pair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		t.Fatalf("failed to parse key pair: %v", err)
+	}
+	err = waitForEndpoint(server.URL, ca, []tls.Certificate{pair}, time.Minute, time.Millisecond)
+	assert.ExpectError(t, false, err)
+}
+
+func TestWaitForEndpointTimeout(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	err := waitForEndpoint(server.URL, ca, nil, 10*time.Millisecond, time.Millisecond)
+	assert.ExpectError(t, true, err)
+	if errors.DetailsOf(err).Category != errors.TimeoutCategory {
+		t.Errorf("expected a timeout error but got: %v", err)
+	}
+}
+
+// newTestCA returns a self-signed CA certificate, unrelated to the
+// certificate httptest servers share
+func newTestCA(t *testing.T) []byte {
+	cert, _ := newTestCertificate(t)
+	return cert
+}
+
+// newTestCertificate returns a self-signed CA certificate and its key
+func newTestCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kubernetes"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
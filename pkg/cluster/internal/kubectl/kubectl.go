@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubectl implements a minimal client for a cluster's API server
+//
+// kind does not depend on client-go, instead the client runs kubectl with
+// the admin kubeconfig on a control plane node, which also means the API
+// server does not need to be reachable from the host
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// Client runs kubectl against the API server from a control plane node
+type Client struct {
+	node nodes.Node
+}
+
+// NewClient returns a Client using the admin kubeconfig on controlPlane
+func NewClient(controlPlane nodes.Node) *Client {
+	return &Client{node: controlPlane}
+}
+
+// Apply creates or updates the objects in the YAML or JSON manifests
+func (c *Client) Apply(manifests string) error {
+	return c.run(strings.NewReader(manifests), "apply", "-f", "-")
+}
+
+// Create creates the objects in the YAML or JSON manifests, failing with an
+// AlreadyExists StatusError if any of them exists
+func (c *Client) Create(manifests string) error {
+	return c.run(strings.NewReader(manifests), "create", "-f", "-")
+}
+
+// Get decodes the JSON of the objects selected by args into out, e.g.
+// Get(&cm, "configmap", "--namespace=kube-public", "kind-cluster-info")
+func (c *Client) Get(out interface{}, args ...string) error {
+	raw, err := exec.Output(c.command(append(append([]string{"get"}, args...), "--output=json")...))
+	if err != nil {
+		return statusError(err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return errors.Wrap(err, "failed to decode kubectl output")
+	}
+	return nil
+}
+
+// Wait waits up to timeout for the objects selected by args to meet
+// condition, e.g. Wait("condition=Ready", time.Minute, "nodes", "--all")
+func (c *Client) Wait(condition string, timeout time.Duration, args ...string) error {
+	return c.run(nil, append([]string{"wait", "--for=" + condition, fmt.Sprintf("--timeout=%s", timeout)}, args...)...)
+}
+
+// Run runs kubectl with args, for anything not covered by the other methods
+func (c *Client) Run(args ...string) error {
+	return c.run(nil, args...)
+}
+
+func (c *Client) run(stdin io.Reader, args ...string) error {
+	cmd := c.command(args...)
+	if stdin != nil {
+		cmd.SetStdin(stdin)
+	}
+	return statusError(cmd.Run())
+}
+
+func (c *Client) command(args ...string) exec.Cmd {
+	return c.node.Command("kubectl", append([]string{"--kubeconfig=/etc/kubernetes/admin.conf"}, args...)...)
+}
+
+// StatusError is an error returned by the API server
+type StatusError struct {
+	// Reason is the API status reason, e.g. NotFound or AlreadyExists
+	Reason string
+	// Message is the API server's description of the error
+	Message string
+	err     error
+}
+
+var _ errors.Causer = &StatusError{}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Reason, e.Message)
+}
+
+// Cause mimics github.com/pkg/errors's Cause pattern for errors
+func (e *StatusError) Cause() error {
+	return e.err
+}
+
+// IsNotFound returns true if err or any error in its Cause chain is a
+// NotFound StatusError
+func IsNotFound(err error) bool {
+	return hasReason(err, "NotFound")
+}
+
+// IsAlreadyExists returns true if err or any error in its Cause chain is an
+// AlreadyExists StatusError
+func IsAlreadyExists(err error) bool {
+	return hasReason(err, "AlreadyExists")
+}
+
+func hasReason(err error, reason string) bool {
+	return find(err, func(e error) bool {
+		s, ok := e.(*StatusError)
+		return ok && s.Reason == reason
+	})
+}
+
+// find walks the Cause chain of err until match returns true
+func find(err error, match func(error) bool) bool {
+	for err != nil {
+		if match(err) {
+			return true
+		}
+		causerErr, ok := err.(errors.Causer)
+		// some errors, like exec.RunError, are their own cause
+		if !ok || causerErr.Cause() == err {
+			return false
+		}
+		err = causerErr.Cause()
+	}
+	return false
+}
+
+// statusError returns a StatusError for API server errors reported by
+// kubectl, other errors are returned as is
+func statusError(err error) error {
+	var runErr *exec.RunError
+	find(err, func(e error) bool {
+		runErr, _ = e.(*exec.RunError)
+		return runErr != nil
+	})
+	if runErr == nil {
+		return err
+	}
+	if reason, message, ok := parseServerError(string(runErr.Output)); ok {
+		return &StatusError{Reason: reason, Message: message, err: err}
+	}
+	return err
+}
+
+// parseServerError parses kubectl output of the form:
+// Error from server (NotFound): configmaps "foo" not found
+func parseServerError(output string) (reason, message string, ok bool) {
+	const prefix = "Error from server ("
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(line, prefix), "): ", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		return parts[0], strings.TrimSpace(parts[1]), true
+	}
+	return "", "", false
+}
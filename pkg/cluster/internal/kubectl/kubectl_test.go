@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestParseServerError(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name            string
+		Output          string
+		ExpectedReason  string
+		ExpectedMessage string
+		ExpectedOK      bool
+	}{
+		{
+			Name:            "not found",
+			Output:          "Error from server (NotFound): configmaps \"foo\" not found\n",
+			ExpectedReason:  "NotFound",
+			ExpectedMessage: `configmaps "foo" not found`,
+			ExpectedOK:      true,
+		},
+		{
+			Name:            "already exists after other output",
+			Output:          "daemonset.apps/kindnet created\nError from server (AlreadyExists): error when creating \"STDIN\": serviceaccounts \"kindnet\" already exists\n",
+			ExpectedReason:  "AlreadyExists",
+			ExpectedMessage: `error when creating "STDIN": serviceaccounts "kindnet" already exists`,
+			ExpectedOK:      true,
+		},
+		{
+			Name:       "client error",
+			Output:     "error: the path \"foo.yaml\" does not exist\n",
+			ExpectedOK: false,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			reason, message, ok := parseServerError(tc.Output)
+			assert.StringEqual(t, tc.ExpectedReason, reason)
+			assert.StringEqual(t, tc.ExpectedMessage, message)
+			assert.BoolEqual(t, tc.ExpectedOK, ok)
+		})
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+	runErr := &exec.RunError{
+		Command: []string{"kubectl", "get", "configmap", "foo"},
+		Output:  []byte("Error from server (NotFound): configmaps \"foo\" not found\n"),
+	}
+	err := errors.Wrap(statusError(runErr), "failed to get config")
+	assert.BoolEqual(t, true, IsNotFound(err))
+	assert.BoolEqual(t, false, IsAlreadyExists(err))
+
+	other := errors.New("boom")
+	assert.BoolEqual(t, false, IsNotFound(statusError(other)))
+	assert.ExpectError(t, false, statusError(nil))
+}
@@ -19,6 +19,7 @@ package loadbalancer
 import (
 	"bytes"
 	"text/template"
+	"time"
 
 	"sigs.k8s.io/kind/pkg/errors"
 )
@@ -27,7 +28,53 @@ import (
 type ConfigData struct {
 	ControlPlanePort int
 	BackendServers   map[string]string
+	ExtraBackends    []ExtraBackend
 	IPv6             bool
+	// StatsPort serves the stats page and health endpoint, if not zero
+	StatsPort int
+	// Tuning defaults to DefaultTuning if unset
+	Tuning *Tuning
+}
+
+// Tuning configures the haproxy timeouts, connection limit, health checks
+// and balancing of the loadbalancer
+type Tuning struct {
+	ConnectTimeout time.Duration
+	ClientTimeout  time.Duration
+	ServerTimeout  time.Duration
+	MaxConnections int
+	// HealthCheckInterval is the time between checks, a backend is considered
+	// up after HealthCheckRise and down after HealthCheckFall consecutive
+	// successful or failed checks
+	HealthCheckInterval time.Duration
+	HealthCheckRise     int
+	HealthCheckFall     int
+	// ClientIPAffinity forwards connections from the same client address to
+	// the same backend
+	ClientIPAffinity bool
+	// Algorithm is the haproxy balance algorithm, empty uses haproxy's
+	// roundrobin default, ClientIPAffinity takes precedence
+	Algorithm string
+}
+
+// DefaultTuning returns the historical kind loadbalancer settings
+func DefaultTuning() *Tuning {
+	return &Tuning{
+		ConnectTimeout:      5 * time.Second,
+		ClientTimeout:       50 * time.Second,
+		ServerTimeout:       50 * time.Second,
+		MaxConnections:      100000,
+		HealthCheckInterval: 2 * time.Second,
+		HealthCheckRise:     2,
+		HealthCheckFall:     3,
+	}
+}
+
+// ExtraBackend is an additional port forwarded to a set of servers
+type ExtraBackend struct {
+	Name    string
+	Port    int
+	Servers map[string]string
 }
 
 // DefaultConfigTemplate is the loadbalancer config template
@@ -36,8 +83,8 @@ global
   log /dev/log local0
   log /dev/log local1 notice
   daemon
-  # limit memory usage to approximately 18 MB
-  maxconn 100000
+  # the default limits memory usage to approximately 18 MB
+  maxconn {{ .Tuning.MaxConnections }}
 
 resolvers docker
   nameserver dns 127.0.0.11:53
@@ -46,12 +93,16 @@ defaults
   log global
   mode tcp
   option dontlognull
-  # TODO: tune these
-  timeout connect 5000
-  timeout client 50000
-  timeout server 50000
+  timeout connect {{ ms .Tuning.ConnectTimeout }}
+  timeout client {{ ms .Tuning.ClientTimeout }}
+  timeout server {{ ms .Tuning.ServerTimeout }}
+  {{- if .Tuning.ClientIPAffinity }}
+  balance source
+  {{- else if .Tuning.Algorithm }}
+  balance {{ .Tuning.Algorithm }}
+  {{- end }}
   # allow to boot despite dns don't resolve backends
-  default-server init-addr none
+  default-server init-addr none inter {{ ms .Tuning.HealthCheckInterval }} rise {{ .Tuning.HealthCheckRise }} fall {{ .Tuning.HealthCheckFall }}
 
 frontend control-plane
   bind *:{{ .ControlPlanePort }}
@@ -66,15 +117,51 @@ backend kube-apiservers
   {{range $server, $address := .BackendServers}}
   server {{ $server }} {{ $address }} check check-ssl verify none resolvers docker resolve-prefer {{ if $.IPv6 -}} ipv6 {{- else -}} ipv4 {{- end }}
   {{- end}}
+{{ if .StatsPort }}
+frontend stats
+  mode http
+  bind *:{{ .StatsPort }}
+  {{ if .IPv6 -}}
+  bind :::{{ .StatsPort }};
+  {{- end }}
+  stats enable
+  stats uri /stats
+  stats refresh 10s
+  # healthy while at least one API server is up
+  monitor-uri /healthz
+  acl kube-apiservers-down nbsrv(kube-apiservers) lt 1
+  monitor fail if kube-apiservers-down
+{{ end -}}
+{{ range .ExtraBackends }}
+frontend {{ .Name }}
+  bind *:{{ .Port }}
+  {{ if $.IPv6 -}}
+  bind :::{{ .Port }};
+  {{- end }}
+  default_backend {{ .Name }}
+
+backend {{ .Name }}
+  {{- range $server, $address := .Servers }}
+  server {{ $server }} {{ $address }} check resolvers docker resolve-prefer {{ if $.IPv6 -}} ipv6 {{- else -}} ipv4 {{- end }}
+  {{- end }}
+{{ end -}}
 `
 
 // Config returns a kubeadm config generated from config data, in particular
 // the kubernetes version
 func Config(data *ConfigData) (config string, err error) {
-	t, err := template.New("loadbalancer-config").Parse(DefaultConfigTemplate)
+	t, err := template.New("loadbalancer-config").Funcs(template.FuncMap{
+		// haproxy times default to milliseconds
+		"ms": func(d time.Duration) int64 { return d.Milliseconds() },
+	}).Parse(DefaultConfigTemplate)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to parse config template")
 	}
+	if data.Tuning == nil {
+		withDefaults := *data
+		withDefaults.Tuning = DefaultTuning()
+		data = &withDefaults
+	}
 	// execute the template
 	var buff bytes.Buffer
 	err = t.Execute(&buff, data)
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigExtraBackends(t *testing.T) {
+	t.Parallel()
+	cfg, err := Config(&ConfigData{
+		ControlPlanePort: 6443,
+		BackendServers: map[string]string{
+			"kind-control-plane": "kind-control-plane:6443",
+		},
+		ExtraBackends: []ExtraBackend{
+			{
+				Name: "webhooks",
+				Port: 8443,
+				Servers: map[string]string{
+					"kind-control-plane":  "kind-control-plane:9443",
+					"kind-control-plane2": "kind-control-plane2:9443",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"frontend webhooks\n  bind *:8443\n",
+		"default_backend webhooks\n",
+		"backend webhooks\n  server kind-control-plane kind-control-plane:9443 check resolvers docker resolve-prefer ipv4\n" +
+			"  server kind-control-plane2 kind-control-plane2:9443 check resolvers docker resolve-prefer ipv4\n",
+	} {
+		if !strings.Contains(cfg, expected) {
+			t.Errorf("expected config to contain %q, got:\n%s", expected, cfg)
+		}
+	}
+}
+
+func TestConfigTuning(t *testing.T) {
+	t.Parallel()
+	tuning := DefaultTuning()
+	tuning.ServerTimeout = 90 * time.Second
+	tuning.HealthCheckInterval = 500 * time.Millisecond
+	tuning.HealthCheckFall = 1
+	tuning.MaxConnections = 2000
+	tuning.ClientIPAffinity = true
+	cfg, err := Config(&ConfigData{
+		ControlPlanePort: 6443,
+		BackendServers: map[string]string{
+			"kind-control-plane": "kind-control-plane:6443",
+		},
+		Tuning: tuning,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"  maxconn 2000\n",
+		"  timeout connect 5000\n  timeout client 50000\n  timeout server 90000\n  balance source\n",
+		"  default-server init-addr none inter 500 rise 2 fall 1\n",
+	} {
+		if !strings.Contains(cfg, expected) {
+			t.Errorf("expected config to contain %q, got:\n%s", expected, cfg)
+		}
+	}
+}
+
+func TestConfigAlgorithm(t *testing.T) {
+	t.Parallel()
+	tuning := DefaultTuning()
+	tuning.Algorithm = "leastconn"
+	cfg, err := Config(&ConfigData{
+		ControlPlanePort: 6443,
+		BackendServers: map[string]string{
+			"kind-control-plane": "kind-control-plane:6443",
+		},
+		Tuning: tuning,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "  timeout server 50000\n  balance leastconn\n"; !strings.Contains(cfg, expected) {
+		t.Errorf("expected config to contain %q, got:\n%s", expected, cfg)
+	}
+}
+
+func TestConfigStats(t *testing.T) {
+	t.Parallel()
+	data := &ConfigData{
+		ControlPlanePort: 6443,
+		BackendServers: map[string]string{
+			"kind-control-plane": "kind-control-plane:6443",
+		},
+	}
+	cfg, err := Config(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(cfg, "frontend stats") {
+		t.Errorf("expected no stats frontend by default, got:\n%s", cfg)
+	}
+	data.StatsPort = 8404
+	cfg, err = Config(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"frontend stats\n  mode http\n  bind *:8404\n",
+		"  stats uri /stats\n",
+		"  monitor-uri /healthz\n",
+		"  monitor fail if kube-apiservers-down\n",
+	} {
+		if !strings.Contains(cfg, expected) {
+			t.Errorf("expected config to contain %q, got:\n%s", expected, cfg)
+		}
+	}
+}
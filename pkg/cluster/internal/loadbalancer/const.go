@@ -16,8 +16,18 @@ limitations under the License.
 
 package loadbalancer
 
+import "sigs.k8s.io/kind/pkg/internal/apis/config"
+
 // Image defines the loadbalancer image:tag
 const Image = "docker.io/kindest/haproxy:v20230606-42a2262b"
 
+// ImageFor returns the loadbalancer image configured for the cluster
+func ImageFor(cfg *config.Cluster) string {
+	if cfg.LoadBalancer.Image != "" {
+		return cfg.LoadBalancer.Image
+	}
+	return Image
+}
+
 // ConfigPath defines the path to the config file in the image
 const ConfigPath = "/usr/local/etc/haproxy/haproxy.cfg"
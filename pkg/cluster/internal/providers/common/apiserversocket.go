@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// apiServerSocketDir is where the host directory containing the API server
+// socket is mounted in the relay container
+const apiServerSocketDir = "/kind/api-server-socket"
+
+// APIServerSocketRelayArgs returns the trailing container run arguments for
+// the container relaying cfg.Networking.APIServerSocket to the API server
+// at target, starting with the volumes and ending with the command
+//
+// The relay runs image, which should be a node image as these ship socat
+func APIServerSocketRelayArgs(cfg *config.Cluster, image, target string) ([]string, error) {
+	socket, err := filepath.Abs(cfg.Networking.APIServerSocket)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to resolve absolute path for apiServerSocket: %q", cfg.Networking.APIServerSocket)
+	}
+	return []string{
+		"--volume", fmt.Sprintf("%s:%s", filepath.Dir(socket), apiServerSocketDir),
+		"--entrypoint", "socat",
+		image,
+		fmt.Sprintf("UNIX-LISTEN:%s/%s,fork,unlink-early,mode=0660", apiServerSocketDir, filepath.Base(socket)),
+		fmt.Sprintf("TCP:%s:%d", target, APIServerInternalPort),
+	}, nil
+}
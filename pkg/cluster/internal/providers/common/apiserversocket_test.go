@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestAPIServerSocketRelayArgs(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Cluster{}
+	cfg.Networking.APIServerSocket = "/tmp/kind/api.sock"
+	args, err := APIServerSocketRelayArgs(cfg, "kindest/node:latest", "kind-control-plane")
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []string{
+		"--volume", "/tmp/kind:/kind/api-server-socket",
+		"--entrypoint", "socat",
+		"kindest/node:latest",
+		"UNIX-LISTEN:/kind/api-server-socket/api.sock,fork,unlink-early,mode=0660",
+		"TCP:kind-control-plane:6443",
+	}, args)
+}
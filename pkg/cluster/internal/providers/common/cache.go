@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// cacheLabelKey labels pull-through cache containers created by kind with
+// the registry they cache
+const cacheLabelKey = "io.x-k8s.kind.cache"
+
+// CacheRemotes maps the registries kind can run a pull-through cache for
+// to the upstream URL the cache pulls from
+var CacheRemotes = map[string]string{
+	"docker.io":       "https://registry-1.docker.io",
+	"gcr.io":          "https://gcr.io",
+	"ghcr.io":         "https://ghcr.io",
+	"quay.io":         "https://quay.io",
+	"registry.k8s.io": "https://registry.k8s.io",
+}
+
+// CacheRegistries returns the registries in CacheRemotes, sorted
+func CacheRegistries() []string {
+	registries := make([]string, 0, len(CacheRemotes))
+	for r := range CacheRemotes {
+		registries = append(registries, r)
+	}
+	sort.Strings(registries)
+	return registries
+}
+
+// CacheName returns the name of the pull-through cache container for registry
+func CacheName(registry string) string {
+	return "kind-cache-" + strings.ReplaceAll(registry, ".", "-")
+}
+
+// CacheEndpoint returns the address nodes pull from the cache for registry at
+func CacheEndpoint(registry string) string {
+	return fmt.Sprintf("http://%s:%d", CacheName(registry), RegistryContainerPort)
+}
+
+// CacheArgs returns the container run arguments for a pull-through cache
+// of registry, it is only reachable on network
+func CacheArgs(registry, network string) []string {
+	return []string{
+		"run",
+		"--detach",
+		// like the nodes, restart with the container runtime
+		"--restart=always",
+		"--name", CacheName(registry),
+		"--network", network,
+		"--label", cacheLabelKey + "=" + registry,
+		"--env", "REGISTRY_PROXY_REMOTEURL=" + CacheRemotes[registry],
+		RegistryImage,
+	}
+}
+
+// EnsureCaches ensures a pull-through cache container is running on network
+// for each of registries, creating them if necessary
+func EnsureCaches(binaryName, network string, registries []string) error {
+	for _, r := range registries {
+		if _, ok := CacheRemotes[r]; !ok {
+			return errors.Errorf("cannot cache unknown registry %q, known registries are: %s", r, strings.Join(CacheRegistries(), ", "))
+		}
+	}
+	for _, r := range registries {
+		if err := ensureContainer(binaryName, CacheName(r), network, exec.Command(binaryName, CacheArgs(r, network)...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListCaches returns the registries with a running pull-through cache,
+// mapped to the cache's endpoint
+func ListCaches(binaryName string) (map[string]string, error) {
+	names, err := exec.OutputLines(exec.Command(binaryName, "ps", "--filter", "label="+cacheLabelKey, "--format", "{{.Names}}"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list pull-through caches")
+	}
+	caches := map[string]string{}
+	for _, name := range names {
+		lines, err := exec.OutputLines(exec.Command(binaryName, "inspect", "--format", fmt.Sprintf(`{{index .Config.Labels %q}}`, cacheLabelKey), name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to inspect pull-through cache %s", name)
+		}
+		if len(lines) == 1 && lines[0] != "" {
+			caches[lines[0]] = CacheEndpoint(lines[0])
+		}
+	}
+	return caches, nil
+}
+
+// DeleteCaches deletes all pull-through cache containers created by kind
+func DeleteCaches(binaryName string) error {
+	names, err := exec.OutputLines(exec.Command(binaryName, "ps", "--all", "--filter", "label="+cacheLabelKey, "--format", "{{.Names}}"))
+	if err != nil {
+		return errors.Wrap(err, "failed to list pull-through caches")
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if err := exec.Command(binaryName, append([]string{"rm", "--force", "--volumes"}, names...)...).Run(); err != nil {
+		return errors.Wrap(err, "failed to delete pull-through caches")
+	}
+	return nil
+}
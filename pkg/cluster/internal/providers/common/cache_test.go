@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestCacheArgs(t *testing.T) {
+	t.Parallel()
+	assert.DeepEqual(t, []string{
+		"run",
+		"--detach",
+		"--restart=always",
+		"--name", "kind-cache-docker-io",
+		"--network", "kind",
+		"--label", "io.x-k8s.kind.cache=docker.io",
+		"--env", "REGISTRY_PROXY_REMOTEURL=https://registry-1.docker.io",
+		"registry:2",
+	}, CacheArgs("docker.io", "kind"))
+	assert.StringEqual(t, "http://kind-cache-registry-k8s-io:5000", CacheEndpoint("registry.k8s.io"))
+}
+
+func TestEnsureCachesUnknownRegistry(t *testing.T) {
+	t.Parallel()
+	assert.ExpectError(t, true, EnsureCaches("docker", "kind", []string{"example.com"}))
+}
@@ -19,8 +19,11 @@ package common
 import (
 	"bufio"
 	"context"
+	"fmt"
+	"io"
 	"os"
 	"regexp"
+	"strings"
 	"sync"
 
 	"sigs.k8s.io/kind/pkg/errors"
@@ -50,7 +53,8 @@ func NodeReachedCgroupsReadyRegexp() *regexp.Regexp {
 // It will use logCtx to determine if the logCmd deadline was exceeded for producing
 // the most useful error message in failure cases, logCtx should be the context
 // supplied to create logCmd with CommandContext
-func WaitUntilLogRegexpMatches(logCtx context.Context, logCmd exec.Cmd, re *regexp.Regexp) error {
+// If out is not nil, each line read is also written to out
+func WaitUntilLogRegexpMatches(logCtx context.Context, logCmd exec.Cmd, re *regexp.Regexp, out io.Writer) error {
 	pr, pw, err := os.Pipe()
 	if err != nil {
 		return err
@@ -68,6 +72,9 @@ func WaitUntilLogRegexpMatches(logCtx context.Context, logCmd exec.Cmd, re *rege
 	sc := bufio.NewScanner(pr)
 	for sc.Scan() {
 		line := sc.Text()
+		if out != nil {
+			fmt.Fprintln(out, line)
+		}
 		if re.MatchString(line) {
 			return nil
 		}
@@ -81,5 +88,23 @@ func WaitUntilLogRegexpMatches(logCtx context.Context, logCmd exec.Cmd, re *rege
 		}
 	}
 	// otherwise generic error
-	return errors.Errorf("could not find a log line that matches %q", re.String())
+	err = errors.Errorf("could not find a log line that matches %q", re.String())
+	if logCtx.Err() == context.DeadlineExceeded {
+		return errors.WithDetails(err, errors.Details{Category: errors.TimeoutCategory})
+	}
+	return err
+}
+
+// UserDelegatedCgroupControllers returns the cgroup v2 controllers systemd
+// delegates to the current user, as read from the user's service manager cgroup
+// This fails without cgroup v2 or a systemd user session, and only reflects
+// the local host, not a remote container runtime
+func UserDelegatedCgroupControllers() ([]string, error) {
+	uid := os.Getuid()
+	path := fmt.Sprintf("/sys/fs/cgroup/user.slice/user-%d.slice/user@%d.service/cgroup.controllers", uid, uid)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read delegated cgroup controllers")
+	}
+	return strings.Fields(string(raw)), nil
 }
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"hash/fnv"
+	"math/big"
+	"net"
+
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// AddressBlockBits is log2 of the size of the block of addresses each cluster
+// gets in the upper half of a node network subnet
+const AddressBlockBits = 6
+
+// ClusterAddress returns the address offset addresses before the end of the
+// block of cluster in subnet
+// Clusters sharing the node network get blocks in the upper half of subnet,
+// chosen by a hash of their name, so that the addresses kind assigns outside
+// of the runtime IPAM do not collide, e.g. 172.18.255.255 is the end of the
+// last block of 172.18.0.0/16, node addresses are allocated from the start
+// of the subnet
+func ClusterAddress(subnet, cluster string, offset int64) (string, error) {
+	_, ipNet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return "", err
+	}
+	ones, bits := ipNet.Mask.Size()
+	if bits-ones < 8 {
+		return "", errors.Errorf("subnet %s is too small for cluster address blocks", subnet)
+	}
+	// the number of blocks in the upper half of the subnet, bounded for
+	// large IPv6 subnets
+	slotBits := bits - ones - 1 - AddressBlockBits
+	if slotBits > 16 {
+		slotBits = 16
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cluster))
+	slot := int64(h.Sum32() % (uint32(1) << uint(slotBits)))
+	last := new(big.Int).SetBytes(lastIP(ipNet))
+	address := new(big.Int).Sub(last, big.NewInt(slot<<AddressBlockBits+offset))
+	return net.IP(address.FillBytes(make([]byte, len(ipNet.IP)))).String(), nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestClusterAddress(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		Subnet      string
+		Cluster     string
+		Offset      int64
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:     "block end",
+			Subnet:   "172.18.0.0/16",
+			Cluster:  "kind",
+			Expected: "172.18.239.63",
+		},
+		{
+			Name:     "offset in block",
+			Subnet:   "172.18.0.0/16",
+			Cluster:  "kind",
+			Offset:   5,
+			Expected: "172.18.239.58",
+		},
+		{
+			Name:     "small subnet",
+			Subnet:   "10.0.0.0/24",
+			Cluster:  "kind",
+			Offset:   1,
+			Expected: "10.0.0.190",
+		},
+		{
+			Name:     "IPv6",
+			Subnet:   "fc00:f853:ccd:e793::/64",
+			Cluster:  "kind",
+			Offset:   1,
+			Expected: "fc00:f853:ccd:e793:ffff:ffff:ffc7:6f3e",
+		},
+		{
+			Name:        "subnet too small",
+			Subnet:      "10.0.0.0/25",
+			Cluster:     "kind",
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid subnet",
+			Subnet:      "10.0.0.0",
+			Cluster:     "kind",
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			address, err := ClusterAddress(tc.Subnet, tc.Cluster, tc.Offset)
+			assert.ExpectError(t, tc.ExpectError, err)
+			assert.StringEqual(t, tc.Expected, address)
+		})
+	}
+}
@@ -16,6 +16,8 @@ limitations under the License.
 
 package common
 
+import "sigs.k8s.io/kind/pkg/cluster/constants"
+
 // APIServerInternalPort defines the port where the control plane is listening
 // _inside_ the node network
-const APIServerInternalPort = 6443
+const APIServerInternalPort = constants.APIServerInternalPort
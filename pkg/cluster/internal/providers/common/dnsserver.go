@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"net"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// DNSServerImage is the image of the DNS server, any image with dnsmasq in
+// its PATH works, this is the Kubernetes project's dnsmasq for kube-dns
+const DNSServerImage = "registry.k8s.io/dns/k8s-dns-dnsmasq-nanny:1.23.1"
+
+// dnsServerOffset is the offset of the DNS server address before the end of
+// the cluster address block, see ClusterAddress
+const dnsServerOffset = 1
+
+// dnsServerLabelKey labels DNS server containers created by kind with the
+// cluster they serve
+const dnsServerLabelKey = "io.x-k8s.kind.dns-server"
+
+// dnsServerAddressFormat lists the networks of a container with its addresses
+const dnsServerAddressFormat = `{{range $k, $v := .NetworkSettings.Networks}}{{$k}} {{$v.IPAddress}} {{$v.GlobalIPv6Address}}{{"\n"}}{{end}}`
+
+// DNSServerName returns the name of the DNS server container for cluster
+func DNSServerName(cluster string) string {
+	return cluster + "-dns"
+}
+
+// dnsServerArgs returns the container run arguments for the DNS server of cfg
+// on network at address, it forwards the queries it has no record for to the
+// resolver the container runtime configures for it
+func dnsServerArgs(cfg *config.Cluster, network, address string) []string {
+	image := cfg.DNSServer.Image
+	if image == "" {
+		image = DNSServerImage
+	}
+	args := []string{
+		"run",
+		"--detach",
+		// like the nodes, restart with the container runtime
+		"--restart=always",
+		"--name", DNSServerName(cfg.Name),
+		"--network", network,
+	}
+	// the nodes resolve with this address for as long as they exist, so it
+	// must not change when the runtime restarts the containers
+	if net.ParseIP(address).To4() != nil {
+		args = append(args, "--ip", address)
+	} else {
+		args = append(args, "--ip6", address)
+	}
+	args = append(args,
+		"--label", dnsServerLabelKey+"="+cfg.Name,
+		"--entrypoint", "dnsmasq",
+		image,
+		"--keep-in-foreground",
+		"--log-facility=-",
+		"--no-hosts",
+	)
+	for _, r := range cfg.DNSServer.Records {
+		if domain := strings.TrimPrefix(r.Name, "*."); domain != r.Name {
+			// address also matches all subdomains
+			args = append(args, "--address=/"+domain+"/"+r.IP)
+		} else {
+			args = append(args, "--host-record="+r.Name+","+r.IP)
+		}
+	}
+	return args
+}
+
+// EnsureDNSServer ensures the DNS server of cfg is running on network, and
+// sets the address of cfg to its IP for the nodes to resolve with
+// The server gets a static address in the cluster address block of the first
+// IPv4, or else IPv6, subnet of network, selected by subnetFormat like for
+// NetworkSubnets
+// An existing server keeps its records
+func EnsureDNSServer(binaryName string, cfg *config.Cluster, network, subnetFormat string) error {
+	name := DNSServerName(cfg.Name)
+	subnets, err := NetworkSubnets(binaryName, network, subnetFormat)
+	if err != nil {
+		return err
+	}
+	staticAddress, err := dnsServerAddress(subnets, cfg.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to pick the address of %s", name)
+	}
+	run := exec.Command(binaryName, dnsServerArgs(cfg, network, staticAddress)...)
+	if err := ensureContainer(binaryName, name, network, run); err != nil {
+		return err
+	}
+	lines, err := exec.OutputLines(exec.Command(binaryName, "inspect", "--format", dnsServerAddressFormat, name))
+	if err != nil {
+		return errors.Wrapf(err, "failed to inspect %s", name)
+	}
+	address, err := parseDNSServerAddress(lines, network)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the address of %s", name)
+	}
+	cfg.DNSServer.Address = address
+	return nil
+}
+
+// dnsServerAddress returns the static address of the DNS server of cluster on
+// a network with subnets, preferring IPv4
+func dnsServerAddress(subnets []string, cluster string) (string, error) {
+	subnet := ""
+	for _, s := range subnets {
+		ip, _, err := net.ParseCIDR(s)
+		if err != nil {
+			continue
+		}
+		if ip.To4() != nil {
+			subnet = s
+			break
+		}
+		if subnet == "" {
+			subnet = s
+		}
+	}
+	if subnet == "" {
+		return "", errors.Errorf("the network has no subnet in %v", subnets)
+	}
+	return ClusterAddress(subnet, cluster, dnsServerOffset)
+}
+
+// parseDNSServerAddress returns the IPv4, or else IPv6, address on network
+// from dnsServerAddressFormat output, some runtimes do not name the networks
+// of a container after the network so the first address is used otherwise
+func parseDNSServerAddress(lines []string, network string) (string, error) {
+	first := ""
+	for _, line := range lines {
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
+		if parts[0] == network {
+			return parts[1], nil
+		}
+		if first == "" {
+			first = parts[1]
+		}
+	}
+	if first == "" {
+		return "", errors.New("the container has no address")
+	}
+	return first, nil
+}
+
+// DNSServerArgs returns the container run args making a node resolve with
+// the DNS server of cfg, if any
+func DNSServerArgs(cfg *config.Cluster) []string {
+	if cfg.DNSServer.Address == "" {
+		return nil
+	}
+	return []string{"--dns=" + cfg.DNSServer.Address}
+}
+
+// DeleteDNSServer deletes the DNS server of cluster if it exists
+func DeleteDNSServer(binaryName, cluster string) error {
+	return deleteLabeledContainers(binaryName, dnsServerLabelKey+"="+cluster, "DNS server")
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestDNSServerArgs(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Cluster{Name: "dev"}
+	cfg.DNSServer = config.DNSServer{
+		Enabled: true,
+		Records: []config.DNSRecord{
+			{Name: "*.local.test", IP: "172.18.0.100"},
+			{Name: "metadata.google.internal", IP: "169.254.169.254"},
+		},
+	}
+	assert.DeepEqual(t, []string{
+		"run", "--detach", "--restart=always",
+		"--name", "dev-dns",
+		"--network", "kind",
+		"--ip", "172.18.144.254",
+		"--label", "io.x-k8s.kind.dns-server=dev",
+		"--entrypoint", "dnsmasq",
+		DNSServerImage,
+		"--keep-in-foreground", "--log-facility=-", "--no-hosts",
+		"--address=/local.test/172.18.0.100",
+		"--host-record=metadata.google.internal,169.254.169.254",
+	}, dnsServerArgs(cfg, "kind", "172.18.144.254"))
+
+	assert.DeepEqual(t, []string(nil), DNSServerArgs(cfg))
+	cfg.DNSServer.Address = "172.18.0.5"
+	assert.DeepEqual(t, []string{"--dns=172.18.0.5"}, DNSServerArgs(cfg))
+}
+
+func TestParseDNSServerAddress(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		Lines       []string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:        "no networks",
+			ExpectError: true,
+		},
+		{
+			Name:     "named network",
+			Lines:    []string{"bridge 172.17.0.2 ", "kind 172.18.0.5 fc00:f853:ccd:e793::5"},
+			Expected: "172.18.0.5",
+		},
+		{
+			Name:     "IPv6 only",
+			Lines:    []string{"kind  fc00:f853:ccd:e793::5"},
+			Expected: "fc00:f853:ccd:e793::5",
+		},
+		{
+			Name:     "unnamed network",
+			Lines:    []string{"unknown-eth0 10.4.0.5 "},
+			Expected: "10.4.0.5",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			result, err := parseDNSServerAddress(tc.Lines, "kind")
+			assert.ExpectError(t, tc.ExpectError, err)
+			assert.StringEqual(t, tc.Expected, result)
+		})
+	}
+}
+
+func TestDNSServerAddress(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		Subnets     []string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:     "IPv4 is preferred",
+			Subnets:  []string{"fc00:f853:ccd:e793::/64", "172.18.0.0/16"},
+			Expected: "172.18.144.254",
+		},
+		{
+			Name:     "IPv6 only",
+			Subnets:  []string{"fc00:f853:ccd:e793::/64"},
+			Expected: "fc00:f853:ccd:e793:ffff:ffff:ffda:10fe",
+		},
+		{
+			Name:        "no subnet",
+			ExpectError: true,
+		},
+		{
+			Name:        "subnet too small",
+			Subnets:     []string{"172.18.0.0/25"},
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			address, err := dnsServerAddress(tc.Subnets, "dev")
+			assert.ExpectError(t, tc.ExpectError, err)
+			assert.StringEqual(t, tc.Expected, address)
+		})
+	}
+}
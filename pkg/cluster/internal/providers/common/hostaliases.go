@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// HostAliasArgs returns the container run args adding the node's
+// hostAliases to the container's /etc/hosts
+func HostAliasArgs(aliases ...config.HostAlias) []string {
+	args := []string{}
+	for _, alias := range aliases {
+		for _, hostname := range alias.Hostnames {
+			args = append(args, "--add-host", hostname+":"+alias.IP)
+		}
+	}
+	return args
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestHostAliasArgs(t *testing.T) {
+	t.Parallel()
+	assert.DeepEqual(t, []string{}, HostAliasArgs())
+	assert.DeepEqual(t,
+		[]string{
+			"--add-host", "registry.lab:192.168.1.10",
+			"--add-host", "registry:192.168.1.10",
+			"--add-host", "mirror.lab:fd00::10",
+		},
+		HostAliasArgs(
+			config.HostAlias{IP: "192.168.1.10", Hostnames: []string{"registry.lab", "registry"}},
+			config.HostAlias{IP: "fd00::10", Hostnames: []string{"mirror.lab"}},
+		),
+	)
+}
@@ -17,10 +17,25 @@ limitations under the License.
 package common
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
 	"sigs.k8s.io/kind/pkg/internal/apis/config"
 	"sigs.k8s.io/kind/pkg/internal/sets"
 )
 
+const (
+	// NodeImageLabelKey records the image reference a node was created from
+	NodeImageLabelKey = constants.NodeImageLabelKey
+	// NodeImageDigestLabelKey records the exact digest of the image a node
+	// was created from, see ImageDigest
+	NodeImageDigestLabelKey = constants.NodeImageDigestLabelKey
+)
+
 // RequiredNodeImages returns the set of _node_ images specified by the config
 // This does not include the loadbalancer image, and is only used to improve
 // the UX by explicit pulling the node images prior to running
@@ -31,3 +46,73 @@ func RequiredNodeImages(cfg *config.Cluster) sets.String {
 	}
 	return images
 }
+
+// ImageDigest returns the registry digest of a local image, or its ID if it
+// has none, e.g. for images built or loaded locally
+func ImageDigest(binaryName, image string) (string, error) {
+	lines, err := exec.OutputLines(exec.Command(
+		binaryName, "image", "inspect",
+		"--format", `{{range .RepoDigests}}{{println .}}{{end}}{{.Id}}`,
+		image,
+	))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to inspect image %q", image)
+	}
+	return parseImageDigest(lines)
+}
+
+// parseImageDigest parses the RepoDigests, one per line, followed by the ID
+func parseImageDigest(lines []string) (string, error) {
+	for _, line := range lines {
+		if i := strings.LastIndex(line, "@"); i != -1 {
+			return line[i+1:], nil
+		}
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		if id := strings.TrimSpace(lines[i]); id != "" {
+			return id, nil
+		}
+	}
+	return "", errors.New("image has no digest or ID")
+}
+
+// NodeImageDigests returns the digest of each node image in cfg, keyed by
+// image reference, using imageName to map references to local image names
+// Unless cfg.AllowMixedNodeImages is set, an error is returned if the
+// nodes would run images with different digests
+func NodeImageDigests(binaryName string, cfg *config.Cluster, imageName func(string) string) (map[string]string, error) {
+	digests := map[string]string{}
+	distinct := sets.NewString()
+	for _, image := range RequiredNodeImages(cfg).List() {
+		digest, err := ImageDigest(binaryName, imageName(image))
+		if err != nil {
+			return nil, err
+		}
+		digests[image] = digest
+		distinct.Insert(digest)
+	}
+	if distinct.Len() > 1 && !cfg.AllowMixedNodeImages {
+		images := []string{}
+		for image, digest := range digests {
+			images = append(images, fmt.Sprintf("%s (%s)", image, digest))
+		}
+		sort.Strings(images)
+		return nil, errors.WithDetails(
+			errors.Errorf("nodes would run images with different digests: %s", strings.Join(images, ", ")),
+			errors.Details{
+				Category: errors.ConfigCategory,
+				Hint:     "use the same image for all nodes, or pass --allow-mixed-images",
+			},
+		)
+	}
+	return digests, nil
+}
+
+// ImageLabelArgs returns the container run arguments recording the image
+// of a node and its digest
+func ImageLabelArgs(image, digest string) []string {
+	return []string{
+		"--label", fmt.Sprintf("%s=%s", NodeImageLabelKey, image),
+		"--label", fmt.Sprintf("%s=%s", NodeImageDigestLabelKey, digest),
+	}
+}
@@ -66,3 +66,45 @@ func TestRequiredNodeImages(t *testing.T) {
 		})
 	}
 }
+
+func TestParseImageDigest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		lines   []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "pulled image",
+			lines: []string{
+				"kindest/node@sha256:abc",
+				"sha256:def",
+			},
+			want: "sha256:abc",
+		},
+		{
+			name:  "local image",
+			lines: []string{"sha256:def"},
+			want:  "sha256:def",
+		},
+		{
+			name:    "no output",
+			lines:   nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseImageDigest(tt.lines)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseImageDigest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseImageDigest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
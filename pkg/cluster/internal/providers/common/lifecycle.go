@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// StopNodes implements StopNodes for providers with a docker compatible
+// `stop` command, the node containers and their volumes are preserved
+func StopNodes(binaryName string, n []nodes.Node) error {
+	if len(n) == 0 {
+		return nil
+	}
+	if err := exec.Command(binaryName, lifecycleArgs("stop", n)...).Run(); err != nil {
+		return errors.Wrap(err, "failed to stop nodes")
+	}
+	return nil
+}
+
+// StartNodes implements StartNodes for providers with a docker compatible
+// `start` command, the settings lost when the node containers stopped are
+// applied again, see RestartedNodes
+func StartNodes(binaryName string, n []nodes.Node) error {
+	if len(n) == 0 {
+		return nil
+	}
+	if err := exec.Command(binaryName, lifecycleArgs("start", n)...).Run(); err != nil {
+		return errors.Wrap(err, "failed to start nodes")
+	}
+	return RestartedNodes(binaryName, n)
+}
+
+func lifecycleArgs(verb string, n []nodes.Node) []string {
+	args := make([]string, 0, len(n)+1) // allocate once
+	args = append(args, verb)
+	for _, node := range n {
+		args = append(args, node.String())
+	}
+	return args
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+type fakeNode struct {
+	name string
+}
+
+var _ nodes.Node = &fakeNode{}
+
+func (n *fakeNode) String() string                                             { return n.name }
+func (n *fakeNode) Role() (string, error)                                      { return "", nil }
+func (n *fakeNode) IP() (string, string, error)                                { return "", "", nil }
+func (n *fakeNode) SerialLogs(io.Writer) error                                 { return nil }
+func (n *fakeNode) Command(string, ...string) exec.Cmd                         { return nil }
+func (n *fakeNode) CommandContext(context.Context, string, ...string) exec.Cmd { return nil }
+
+func TestLifecycleArgs(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name     string
+		Verb     string
+		Nodes    []nodes.Node
+		Expected []string
+	}{
+		{
+			Name:     "stop one node",
+			Verb:     "stop",
+			Nodes:    []nodes.Node{&fakeNode{name: "kind-control-plane"}},
+			Expected: []string{"stop", "kind-control-plane"},
+		},
+		{
+			Name: "start all nodes in order",
+			Verb: "start",
+			Nodes: []nodes.Node{
+				&fakeNode{name: "kind-external-load-balancer"},
+				&fakeNode{name: "kind-control-plane"},
+				&fakeNode{name: "kind-worker"},
+			},
+			Expected: []string{"start", "kind-external-load-balancer", "kind-control-plane", "kind-worker"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, tc.Expected, lifecycleArgs(tc.Verb, tc.Nodes))
+		})
+	}
+}
+
+func TestLifecycleNoNodes(t *testing.T) {
+	t.Parallel()
+	// no runtime is invoked without nodes
+	assert.ExpectError(t, false, StopNodes("kind-test-missing-binary", nil))
+	assert.ExpectError(t, false, StartNodes("kind-test-missing-binary", nil))
+	assert.ExpectError(t, false, RestartedNodes("kind-test-missing-binary", nil))
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// LoadBalancerPortMappings returns the host port mappings for the load
+// balancer's extra backends and stats endpoint that are published on the host
+func LoadBalancerPortMappings(cfg *config.Cluster) []config.PortMapping {
+	mappings := []config.PortMapping{}
+	if stats := cfg.LoadBalancer.Stats; stats.Port != 0 && stats.HostPort != 0 {
+		mappings = append(mappings, config.PortMapping{
+			ListenAddress: cfg.Networking.APIServerAddress,
+			HostPort:      stats.HostPort,
+			ContainerPort: stats.Port,
+		})
+	}
+	for _, b := range cfg.LoadBalancer.ExtraBackends {
+		if b.HostPort == 0 {
+			continue
+		}
+		mappings = append(mappings, config.PortMapping{
+			ListenAddress: cfg.Networking.APIServerAddress,
+			HostPort:      b.HostPort,
+			ContainerPort: b.Port,
+		})
+	}
+	return mappings
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+const (
+	// ClusterLabelPrefix prefixes user provided cluster labels when recorded
+	// as labels on the node containers
+	ClusterLabelPrefix = "io.x-k8s.kind.label/"
+	// ClusterAnnotationPrefix prefixes user provided cluster annotations when
+	// recorded as labels on the node containers
+	ClusterAnnotationPrefix = "io.x-k8s.kind.annotation/"
+	// ClusterConfigHashLabelKey records the hash of the cluster-wide config
+	// on all node containers, see ClusterConfigHash
+	ClusterConfigHashLabelKey = constants.ClusterConfigHashLabelKey
+	// NodeConfigHashLabelKey records the hash of a node's config on its
+	// container, see NodeConfigHash
+	NodeConfigHashLabelKey = constants.NodeConfigHashLabelKey
+)
+
+// MetadataArgs returns the container run arguments recording the cluster
+// labels, annotations and config hash on a node container
+func MetadataArgs(cfg *config.Cluster) []string {
+	return MetadataArgsWithHash(cfg, ClusterConfigHash(cfg))
+}
+
+// MetadataArgsWithHash is MetadataArgs recording clusterHash as the config
+// hash, for providers which change cluster-wide settings while provisioning
+func MetadataArgsWithHash(cfg *config.Cluster, clusterHash string) []string {
+	args := []string{"--label", fmt.Sprintf("%s=%s", ClusterConfigHashLabelKey, clusterHash)}
+	for _, k := range sortedKeys(cfg.Labels) {
+		args = append(args, "--label", fmt.Sprintf("%s%s=%s", ClusterLabelPrefix, k, cfg.Labels[k]))
+	}
+	for _, k := range sortedKeys(cfg.Annotations) {
+		args = append(args, "--label", fmt.Sprintf("%s%s=%s", ClusterAnnotationPrefix, k, cfg.Annotations[k]))
+	}
+	return args
+}
+
+// NodeConfigArgs returns the container run arguments recording the config
+// hash of a node on its container, along with the settings PostStart applies
+// again when the container is restarted, see RestartedNodes
+func NodeConfigArgs(node *config.Node) []string {
+	args := []string{"--label", fmt.Sprintf("%s=%s", NodeConfigHashLabelKey, NodeConfigHash(node))}
+	if node.IOLimits.EgressRate != "" {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", nodeEgressRateLabelKey, node.IOLimits.EgressRate))
+	}
+	if len(node.KernelModules) > 0 {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", nodeKernelModulesLabelKey, strings.Join(node.KernelModules, ",")))
+	}
+	return args
+}
+
+// ClusterConfigHash returns a hash of the cluster-wide settings in cfg, which
+// excludes the nodes, the cluster name and metadata, for detecting config changes
+func ClusterConfigHash(cfg *config.Cluster) string {
+	clusterWide := cfg.DeepCopy()
+	clusterWide.Name = ""
+	clusterWide.Nodes = nil
+	// recorded in their own labels, and may be set from flags
+	clusterWide.Labels = nil
+	clusterWide.Annotations = nil
+	clusterWide.AllowMixedNodeImages = false
+	// set when the DNS server is created, not from the config
+	clusterWide.DNSServer.Address = ""
+	// set when the OIDC provider kind runs is created, see EnsureOIDCProvider
+	if clusterWide.OIDC.Provider != "" {
+		clusterWide.OIDC.IssuerURL = ""
+		clusterWide.OIDC.CertificateAuthority = ""
+	}
+	return hashJSON(clusterWide)
+}
+
+// NodeConfigHash returns a hash of the node config, for detecting config changes
+func NodeConfigHash(node *config.Node) string {
+	return hashJSON(node)
+}
+
+// hashJSON hashes v without its zero values, so that fields added to the
+// config later do not change the hashes of existing clusters
+func hashJSON(v interface{}) string {
+	// these are plain structs, which always marshal
+	raw, _ := json.Marshal(v)
+	var generic interface{}
+	_ = json.Unmarshal(raw, &generic)
+	// maps marshal with sorted keys
+	raw, _ = json.Marshal(withoutZeroValues(generic))
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// withoutZeroValues returns v, a decoded JSON value, with all the null, false,
+// zero, empty string, empty list and empty object values dropped from objects
+func withoutZeroValues(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		ret := map[string]interface{}{}
+		for k, e := range v {
+			if e = withoutZeroValues(e); !isZeroJSON(e) {
+				ret[k] = e
+			}
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, e := range v {
+			ret[i] = withoutZeroValues(e)
+		}
+		return ret
+	}
+	return v
+}
+
+func isZeroJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// ClusterLabels extracts the cluster labels from the labels of a node container
+func ClusterLabels(containerLabels map[string]string) map[string]string {
+	labels := map[string]string{}
+	for k, v := range containerLabels {
+		if strings.HasPrefix(k, ClusterLabelPrefix) {
+			labels[strings.TrimPrefix(k, ClusterLabelPrefix)] = v
+		}
+	}
+	return labels
+}
+
+// ClusterAnnotations extracts the cluster annotations from the labels of a node container
+func ClusterAnnotations(containerLabels map[string]string) map[string]string {
+	annotations := map[string]string{}
+	for k, v := range containerLabels {
+		if strings.HasPrefix(k, ClusterAnnotationPrefix) {
+			annotations[strings.TrimPrefix(k, ClusterAnnotationPrefix)] = v
+		}
+	}
+	return annotations
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestClusterConfigHash(t *testing.T) {
+	t.Parallel()
+	newConfig := func() *config.Cluster {
+		cfg := &config.Cluster{Name: "foo"}
+		config.SetDefaultsCluster(cfg)
+		return cfg
+	}
+	hash := ClusterConfigHash(newConfig())
+	cases := []struct {
+		Name     string
+		Mutate   func(*config.Cluster)
+		Expected bool
+	}{
+		{
+			Name:     "unchanged",
+			Mutate:   func(*config.Cluster) {},
+			Expected: true,
+		},
+		{
+			Name: "name, nodes and metadata are ignored",
+			Mutate: func(cfg *config.Cluster) {
+				cfg.Name = "bar"
+				cfg.Nodes = append(cfg.Nodes, config.Node{Role: config.WorkerRole})
+				cfg.Labels = map[string]string{"team": "a"}
+				cfg.Annotations = map[string]string{"owner": "b"}
+				cfg.AllowMixedNodeImages = true
+			},
+			Expected: true,
+		},
+		{
+			Name: "empty values are ignored",
+			Mutate: func(cfg *config.Cluster) {
+				cfg.FeatureGates = map[string]bool{}
+				cfg.KubeadmConfigPatches = []string{}
+			},
+			Expected: true,
+		},
+		{
+			Name: "cluster-wide setting changed",
+			Mutate: func(cfg *config.Cluster) {
+				cfg.Networking.PodSubnet = "10.245.0.0/16"
+			},
+			Expected: false,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			cfg := newConfig()
+			tc.Mutate(cfg)
+			assert.BoolEqual(t, tc.Expected, ClusterConfigHash(cfg) == hash)
+		})
+	}
+}
+
+func TestClusterConfigHashIgnoresProvisionedValues(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name      string
+		Mutate    func(*config.Cluster)
+		Provision func(*config.Cluster)
+		Expected  bool
+	}{
+		{
+			Name: "OIDC provider run by kind",
+			Mutate: func(cfg *config.Cluster) {
+				cfg.OIDC.Provider = config.DexOIDCProvider
+			},
+			Provision: func(cfg *config.Cluster) {
+				cfg.OIDC.IssuerURL = "https://foo-oidc:5556/dex"
+				cfg.OIDC.CertificateAuthority = "ca"
+			},
+			Expected: true,
+		},
+		{
+			Name: "external OIDC provider",
+			Provision: func(cfg *config.Cluster) {
+				cfg.OIDC.IssuerURL = "https://example.com"
+			},
+			Expected: false,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &config.Cluster{Name: "foo"}
+			config.SetDefaultsCluster(cfg)
+			if tc.Mutate != nil {
+				tc.Mutate(cfg)
+			}
+			hash := ClusterConfigHash(cfg)
+			tc.Provision(cfg)
+			assert.BoolEqual(t, tc.Expected, ClusterConfigHash(cfg) == hash)
+		})
+	}
+}
+
+func TestHashJSONIgnoresNewZeroFields(t *testing.T) {
+	t.Parallel()
+	type before struct {
+		Image string
+	}
+	type after struct {
+		Image    string
+		NewField string
+		Labels   map[string]string
+		Enabled  bool
+	}
+	assert.StringEqual(t, hashJSON(before{Image: "foo"}), hashJSON(after{Image: "foo"}))
+	if hashJSON(before{Image: "foo"}) == hashJSON(after{Image: "foo", Enabled: true}) {
+		t.Errorf("expected a set field to change the hash")
+	}
+}
@@ -18,6 +18,9 @@ package common
 
 import (
 	"fmt"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/sets"
 )
 
 // MakeNodeNamer returns a func(role string)(nodeName string)
@@ -35,3 +38,16 @@ func MakeNodeNamer(clusterName string) func(string) string {
 		return fmt.Sprintf("%s-%s%s", clusterName, role, suffix)
 	}
 }
+
+// NewNodeCount returns the number of nodes in cfg that are not in existing,
+// which holds node names
+func NewNodeCount(cfg *config.Cluster, existing sets.String) int {
+	namer := MakeNodeNamer(cfg.Name)
+	count := 0
+	for _, n := range cfg.Nodes {
+		if !existing.Has(namer(string(n.Role))) {
+			count++
+		}
+	}
+	return count
+}
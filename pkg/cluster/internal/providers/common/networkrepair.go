@@ -0,0 +1,233 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/sets"
+)
+
+// NodeNetworkSubnetsLabelKey records the subnets of the primary network of a
+// node container when it was created, so a deleted network can be recreated
+const NodeNetworkSubnetsLabelKey = constants.NodeNetworkSubnetsLabelKey
+
+// networkStateFormat is the container inspect format for the primary network
+// of a node, supported by docker, podman and nerdctl alike
+const networkStateFormat = `{{.Name}}` +
+	`\t{{index .Config.Labels "` + NodeNetworkLabelKey + `"}}` +
+	`\t{{index .Config.Labels "` + NodeNetworkSubnetsLabelKey + `"}}` +
+	`\t{{json .NetworkSettings.Networks}}`
+
+// NetworkSubnetsLabelArgs returns the run args recording the subnets of network,
+// for runtimes with a docker compatible `network inspect`, see NetworkSubnets
+func NetworkSubnetsLabelArgs(binaryName, network, format string) ([]string, error) {
+	subnets, err := NetworkSubnets(binaryName, network, format)
+	if err != nil {
+		return nil, err
+	}
+	return []string{"--label", fmt.Sprintf("%s=%s", NodeNetworkSubnetsLabelKey, strings.Join(subnets, ","))}, nil
+}
+
+// nodeNetworkState is the primary network of a node container
+type nodeNetworkState struct {
+	Node    string
+	Network string
+	// Subnets are the recorded subnets, empty for nodes created before
+	// kind recorded them
+	Subnets []string
+	// IPv4Address and IPv6Address are the static addresses on Network, if any
+	IPv4Address string
+	IPv6Address string
+}
+
+// MissingNetwork returns the primary network of the nodes if it no longer
+// exists, e.g. after `docker system prune`, or "" if it exists.
+// This works for runtimes with a docker compatible `inspect` and `network ls`
+func MissingNetwork(binaryName string, n []nodes.Node) (string, error) {
+	states, err := inspectNetworkStates(binaryName, n)
+	if err != nil {
+		return "", err
+	}
+	missing, _, err := missingNetwork(binaryName, states)
+	return missing, err
+}
+
+// RepairNetwork recreates the primary network of the nodes if it no longer
+// exists, with the subnets recorded when the nodes were created, and
+// reattaches the nodes to it with their static addresses if any.
+// The egress shaping of running nodes is applied again on the new interface.
+// ensure creates the network, it is the provider's ensureNetwork.
+// It returns the recreated network, or "" if the network exists
+func RepairNetwork(binaryName string, n []nodes.Node, ensure func(name string, nodeNetwork NodeNetwork) error) (string, error) {
+	states, err := inspectNetworkStates(binaryName, n)
+	if err != nil {
+		return "", err
+	}
+	missing, subnets, err := missingNetwork(binaryName, states)
+	if err != nil || missing == "" {
+		return "", err
+	}
+	// drop the stale endpoints before the name refers to the new network
+	for _, s := range states {
+		if s.Network != missing {
+			continue
+		}
+		// this fails if the runtime already dropped the endpoint, which is fine
+		_ = exec.Command(binaryName, "network", "disconnect", "--force", missing, s.Node).Run()
+	}
+	if err := ensure(missing, nodeNetworkForSubnets(subnets)); err != nil {
+		return "", errors.Wrapf(err, "failed to recreate network %q", missing)
+	}
+	for _, s := range states {
+		if s.Network != missing {
+			continue
+		}
+		if err := exec.Command(binaryName, connectArgs(s)...).Run(); err != nil {
+			return "", errors.Wrapf(err, "failed to reconnect node %s to network %q", s.Node, missing)
+		}
+	}
+	if err := RestartedNodes(binaryName, n); err != nil {
+		return "", err
+	}
+	return missing, nil
+}
+
+// missingNetwork returns the primary network of states if it does not exist,
+// along with the subnets recorded for it
+func missingNetwork(binaryName string, states []nodeNetworkState) (string, []string, error) {
+	network := ""
+	var subnets []string
+	for _, s := range states {
+		if s.Network == "" {
+			continue
+		}
+		if network == "" {
+			network = s.Network
+		}
+		if s.Network == network && len(subnets) == 0 {
+			subnets = s.Subnets
+		}
+	}
+	if network == "" {
+		return "", nil, nil
+	}
+	networks, err := exec.OutputLines(exec.Command(binaryName, "network", "ls", "--format", "{{.Name}}"))
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to list networks")
+	}
+	if sets.NewString(networks...).Has(network) {
+		return "", nil, nil
+	}
+	return network, subnets, nil
+}
+
+// inspectNetworkStates inspects the primary network of all of the nodes at once
+func inspectNetworkStates(binaryName string, n []nodes.Node) ([]nodeNetworkState, error) {
+	if len(n) == 0 {
+		return nil, nil
+	}
+	args := []string{"inspect", "--format", networkStateFormat}
+	for _, node := range n {
+		args = append(args, node.String())
+	}
+	lines, err := exec.OutputLines(exec.Command(binaryName, args...))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to inspect node networks")
+	}
+	states := make([]nodeNetworkState, 0, len(lines))
+	for _, line := range lines {
+		s, err := parseNetworkStateLine(line)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, s)
+	}
+	return states, nil
+}
+
+// parseNetworkStateLine parses a line of `inspect --format networkStateFormat`
+// output, e.g.
+// /kind-control-plane	kind	172.18.0.0/16,fc00:f853:ccd:e793::/64	{"kind":{"IPAMConfig":null}}
+func parseNetworkStateLine(line string) (nodeNetworkState, error) {
+	parts := strings.SplitN(line, "\t", 4)
+	if len(parts) != 4 {
+		return nodeNetworkState{}, errors.Errorf("failed to parse node network %q", line)
+	}
+	s := nodeNetworkState{
+		Node:    strings.TrimPrefix(strings.TrimSpace(parts[0]), "/"),
+		Network: labelValue(parts[1]),
+		Subnets: splitList(labelValue(parts[2])),
+	}
+	networks := map[string]struct {
+		IPAMConfig *struct {
+			IPv4Address string `json:"IPv4Address"`
+			IPv6Address string `json:"IPv6Address"`
+		} `json:"IPAMConfig"`
+	}{}
+	if raw := strings.TrimSpace(parts[3]); raw != "" && raw != "null" {
+		if err := json.Unmarshal([]byte(raw), &networks); err != nil {
+			return nodeNetworkState{}, errors.Wrapf(err, "failed to parse networks of %s", s.Node)
+		}
+	}
+	if ipam := networks[s.Network].IPAMConfig; ipam != nil {
+		s.IPv4Address = ipam.IPv4Address
+		s.IPv6Address = ipam.IPv6Address
+	}
+	return s, nil
+}
+
+// labelValue normalizes a label selected with `index`, which renders
+// missing labels as "<no value>" on some runtimes
+func labelValue(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "<no value>" {
+		return ""
+	}
+	return raw
+}
+
+// nodeNetworkForSubnets returns the node network requesting subnets
+func nodeNetworkForSubnets(subnets []string) NodeNetwork {
+	n := NodeNetwork{}
+	for _, s := range subnets {
+		if isIPv6(s) {
+			n.IPv6Subnet = s
+		} else {
+			n.IPv4Subnet = s
+		}
+	}
+	return n
+}
+
+// connectArgs returns the `network connect` args reattaching s.Node
+func connectArgs(s nodeNetworkState) []string {
+	args := []string{"network", "connect"}
+	if s.IPv4Address != "" {
+		args = append(args, "--ip", s.IPv4Address)
+	}
+	if s.IPv6Address != "" {
+		args = append(args, "--ip6", s.IPv6Address)
+	}
+	return append(args, s.Network, s.Node)
+}
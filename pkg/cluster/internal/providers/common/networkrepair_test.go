@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestParseNetworkStateLine(t *testing.T) {
+	t.Parallel()
+	s, err := parseNetworkStateLine(`/kind-control-plane	kind	172.18.0.0/16,fc00:f853:ccd:e793::/64	{"kind":{"IPAMConfig":{"IPv4Address":"172.18.0.10","IPv6Address":""}}}`)
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, nodeNetworkState{
+		Node:        "kind-control-plane",
+		Network:     "kind",
+		Subnets:     []string{"172.18.0.0/16", "fc00:f853:ccd:e793::/64"},
+		IPv4Address: "172.18.0.10",
+	}, s)
+	assert.DeepEqual(t, []string{"network", "connect", "--ip", "172.18.0.10", "kind", "kind-control-plane"}, connectArgs(s))
+
+	// nodes created before the subnets were recorded, without static addresses
+	s, err = parseNetworkStateLine("kind-worker\tkind\t<no value>\t{\"kind\":{\"IPAMConfig\":null}}")
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, nodeNetworkState{Node: "kind-worker", Network: "kind", Subnets: []string{}}, s)
+	assert.DeepEqual(t, []string{"network", "connect", "kind", "kind-worker"}, connectArgs(s))
+
+	_, err = parseNetworkStateLine("kind-worker\tkind")
+	assert.ExpectError(t, true, err)
+	_, err = parseNetworkStateLine("kind-worker\tkind\t\t{")
+	assert.ExpectError(t, true, err)
+}
+
+func TestNodeNetworkForSubnets(t *testing.T) {
+	t.Parallel()
+	assert.DeepEqual(t, NodeNetwork{
+		IPv4Subnet: "172.18.0.0/16",
+		IPv6Subnet: "fc00:f853:ccd:e793::/64",
+	}, nodeNetworkForSubnets([]string{"172.18.0.0/16", "fc00:f853:ccd:e793::/64"}))
+	assert.DeepEqual(t, NodeNetwork{}, nodeNetworkForSubnets(nil))
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"net"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/sets"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// NodeNetworkLabelKey records the primary network of a node container, so
+// its addresses can be found once it is attached to extra networks
+const NodeNetworkLabelKey = constants.NodeNetworkLabelKey
+
+// NodeIPFormat selects the comma separated IPv4 and IPv6 addresses of a node
+// container on its primary network, or on its only network for nodes created
+// without NodeNetworkLabelKey
+const NodeIPFormat = `{{$primary := index .Config.Labels "` + NodeNetworkLabelKey + `"}}` +
+	`{{range $name, $network := .NetworkSettings.Networks}}{{if or (eq $primary "") (eq $name $primary)}}` +
+	`{{$network.IPAddress}},{{$network.GlobalIPv6Address}}{{end}}{{end}}`
+
+// NodeNetwork is the node network requested in the cluster config, it only
+// applies when kind creates the network
+type NodeNetwork struct {
+	IPv4Subnet  string
+	IPv4Gateway string
+	IPv6Subnet  string
+	IPv6Gateway string
+	// MTU is the requested MTU, 0 selects the provider default
+	MTU int
+}
+
+// NodeNetworkFor returns the node network requested by cfg, cfg may be nil
+// for networks created outside of creating a cluster
+// cfg is expected to be validated already
+func NodeNetworkFor(cfg *config.Cluster) NodeNetwork {
+	n := NodeNetwork{}
+	if cfg == nil {
+		return n
+	}
+	n.MTU = int(cfg.Networking.NodeMTU)
+	for _, s := range splitList(cfg.Networking.NodeSubnet) {
+		if isIPv6(s) {
+			n.IPv6Subnet = s
+		} else {
+			n.IPv4Subnet = s
+		}
+	}
+	for _, g := range splitList(cfg.Networking.NodeGateway) {
+		if isIPv6(g) {
+			n.IPv6Gateway = g
+		} else {
+			n.IPv4Gateway = g
+		}
+	}
+	return n
+}
+
+// Subnets returns the requested subnets
+func (n NodeNetwork) Subnets() []string {
+	return splitList(strings.Join([]string{n.IPv4Subnet, n.IPv6Subnet}, ","))
+}
+
+// IPv4Args returns the `network create` args for the requested IPv4 subnet
+func (n NodeNetwork) IPv4Args() []string {
+	return subnetArgs(n.IPv4Subnet, n.IPv4Gateway)
+}
+
+// IPv6Args returns the `network create` args for an IPv6 subnet, which is
+// the requested one if any or else subnet
+func (n NodeNetwork) IPv6Args(subnet string) []string {
+	if n.IPv6Subnet != "" {
+		return append([]string{"--ipv6"}, subnetArgs(n.IPv6Subnet, n.IPv6Gateway)...)
+	}
+	if subnet == "" {
+		return nil
+	}
+	return []string{"--ipv6", "--subnet", subnet}
+}
+
+func subnetArgs(subnet, gateway string) []string {
+	if subnet == "" {
+		return nil
+	}
+	args := []string{"--subnet", subnet}
+	if gateway != "" {
+		args = append(args, "--gateway", gateway)
+	}
+	return args
+}
+
+// CheckExistingNetwork checks that the existing network with the given
+// subnets has the subnets requested by n
+func (n NodeNetwork) CheckExistingNetwork(network string, subnets []string) error {
+	existing := map[string]bool{}
+	for _, s := range subnets {
+		existing[normalizeCIDR(s)] = true
+	}
+	for _, s := range n.Subnets() {
+		if !existing[normalizeCIDR(s)] {
+			return errors.Errorf(
+				"network %q already exists with subnets %v, which do not include the requested nodeSubnet %s; delete the network or change networking.nodeSubnet",
+				network, subnets, s,
+			)
+		}
+	}
+	return nil
+}
+
+// CheckSubnetsAvailable checks that none of the requested subnets overlap the
+// subnets of existing networks, for runtimes with a docker compatible
+// `network ls` and `network inspect`, format selects the space separated subnets
+func (n NodeNetwork) CheckSubnetsAvailable(binaryName, format string) error {
+	requested := n.Subnets()
+	if len(requested) == 0 {
+		return nil
+	}
+	networks, err := exec.OutputLines(exec.Command(binaryName, "network", "ls", "--format", "{{.Name}}"))
+	if err != nil {
+		return errors.Wrap(err, "failed to list networks")
+	}
+	for _, network := range networks {
+		subnets, err := NetworkSubnets(binaryName, network, format)
+		if err != nil {
+			// the network may have been deleted concurrently
+			continue
+		}
+		if overlap := overlappingSubnet(requested, subnets); overlap != "" {
+			return errors.Errorf("requested nodeSubnet %s overlaps with the subnets %v of existing network %q", overlap, subnets, network)
+		}
+	}
+	return nil
+}
+
+// overlappingSubnet returns the first of requested overlapping any of existing
+func overlappingSubnet(requested, existing []string) string {
+	for _, r := range requested {
+		_, rcidr, err := net.ParseCIDR(r)
+		if err != nil {
+			continue
+		}
+		for _, e := range existing {
+			_, ecidr, err := net.ParseCIDR(e)
+			if err != nil {
+				continue
+			}
+			if rcidr.Contains(ecidr.IP) || ecidr.Contains(rcidr.IP) {
+				return r
+			}
+		}
+	}
+	return ""
+}
+
+func normalizeCIDR(s string) string {
+	if _, cidr, err := net.ParseCIDR(s); err == nil {
+		return cidr.String()
+	}
+	return s
+}
+
+func isIPv6(s string) bool {
+	return strings.Contains(s, ":")
+}
+
+func splitList(s string) []string {
+	out := []string{}
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// ExtraNetworks returns the distinct extra networks of the nodes in cfg,
+// other than the primary network the nodes are always attached to
+func ExtraNetworks(cfg *config.Cluster, primary string) []string {
+	networks := []string{}
+	seen := map[string]bool{primary: true}
+	for _, n := range cfg.Nodes {
+		for _, network := range n.Networks {
+			if !seen[network] {
+				seen[network] = true
+				networks = append(networks, network)
+			}
+		}
+	}
+	return networks
+}
+
+// ConnectNetworks attaches the container to the node's extra networks it is
+// not attached to yet, for runtimes with a docker compatible `network connect`
+func ConnectNetworks(binaryName, container, primary string, node *config.Node) error {
+	if len(node.Networks) == 0 {
+		return nil
+	}
+	attached, err := exec.OutputLines(exec.Command(binaryName, "inspect", "--format", NetworksFormat, container))
+	if err != nil {
+		return errors.Wrapf(err, "failed to get networks of %s", container)
+	}
+	skip := sets.NewString(attached...).Insert(primary)
+	for _, network := range node.Networks {
+		if skip.Has(network) {
+			continue
+		}
+		if err := exec.Command(binaryName, "network", "connect", network, container).Run(); err != nil {
+			return errors.Wrapf(err, "failed to connect %s to network %q", container, network)
+		}
+	}
+	return nil
+}
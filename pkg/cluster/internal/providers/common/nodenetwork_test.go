@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestNodeNetworkFor(t *testing.T) {
+	t.Parallel()
+	assert.DeepEqual(t, NodeNetwork{}, NodeNetworkFor(nil))
+
+	cfg := &config.Cluster{}
+	cfg.Networking.NodeSubnet = "fc00:30::/64,172.30.0.0/16"
+	cfg.Networking.NodeGateway = "172.30.0.1"
+	cfg.Networking.NodeMTU = 1400
+	n := NodeNetworkFor(cfg)
+	assert.DeepEqual(t, NodeNetwork{
+		IPv4Subnet:  "172.30.0.0/16",
+		IPv4Gateway: "172.30.0.1",
+		IPv6Subnet:  "fc00:30::/64",
+		MTU:         1400,
+	}, n)
+	assert.DeepEqual(t, []string{"172.30.0.0/16", "fc00:30::/64"}, n.Subnets())
+	assert.DeepEqual(t, []string{"--subnet", "172.30.0.0/16", "--gateway", "172.30.0.1"}, n.IPv4Args())
+	// the requested IPv6 subnet takes precedence over a generated one
+	assert.DeepEqual(t, []string{"--ipv6", "--subnet", "fc00:30::/64"}, n.IPv6Args("fc00:f853::/64"))
+	assert.DeepEqual(t, []string{"--ipv6", "--subnet", "fc00:f853::/64"}, NodeNetwork{}.IPv6Args("fc00:f853::/64"))
+	assert.DeepEqual(t, []string(nil), NodeNetwork{}.IPv6Args(""))
+}
+
+func TestCheckExistingNetwork(t *testing.T) {
+	t.Parallel()
+	n := NodeNetwork{IPv4Subnet: "172.30.0.0/16"}
+	assert.ExpectError(t, false, n.CheckExistingNetwork("kind", []string{"172.30.0.0/16", "fc00:f853::/64"}))
+	assert.ExpectError(t, true, n.CheckExistingNetwork("kind", []string{"172.18.0.0/16"}))
+	assert.ExpectError(t, false, NodeNetwork{}.CheckExistingNetwork("kind", []string{"172.18.0.0/16"}))
+}
+
+func TestOverlappingSubnet(t *testing.T) {
+	t.Parallel()
+	existing := []string{"172.18.0.0/16", "fc00:f853:ccd:e793::/64"}
+	assert.StringEqual(t, "", overlappingSubnet([]string{"172.30.0.0/16"}, existing))
+	assert.StringEqual(t, "172.18.5.0/24", overlappingSubnet([]string{"172.18.5.0/24"}, existing))
+	assert.StringEqual(t, "172.0.0.0/8", overlappingSubnet([]string{"172.0.0.0/8"}, existing))
+	assert.StringEqual(t, "fc00:f853::/32", overlappingSubnet([]string{"172.30.0.0/16", "fc00:f853::/32"}, existing))
+}
+
+func TestExtraNetworks(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Cluster{
+		Nodes: []config.Node{
+			{Networks: []string{"storage", "kind"}},
+			{},
+			{Networks: []string{"mesh", "storage"}},
+		},
+	}
+	assert.DeepEqual(t, []string{"storage", "mesh"}, ExtraNetworks(cfg, "kind"))
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// OIDCProviderImage is the image of the OIDC provider
+const OIDCProviderImage = "ghcr.io/dexidp/dex:v2.41.1"
+
+// OIDCProviderPort is the port the OIDC provider serves https on, it is
+// published on the same port of the host's loopback address so that the
+// issuer URL works from the host too, given a hosts entry for the provider
+// name
+const OIDCProviderPort = 5556
+
+// OIDCClientSecret is the secret of the static client of the OIDC provider
+const OIDCClientSecret = "kind-secret"
+
+// oidcProviderLabelKey labels OIDC provider containers created by kind with
+// the cluster they authenticate for
+const oidcProviderLabelKey = "io.x-k8s.kind.oidc-provider"
+
+// the provider configuration and TLS material are passed through the
+// environment and written out by oidcProviderScript, so that they survive
+// container restarts without a volume, the CA is only recorded there for
+// creating nodes later
+// This exposes the serving key to anyone who can inspect the container, which
+// is acceptable for a provider with a static test user
+const (
+	oidcConfigEnv  = "KIND_OIDC_CONFIG"
+	oidcCAEnv      = "KIND_OIDC_CA"
+	oidcTLSCertEnv = "KIND_OIDC_TLS_CERT"
+	oidcTLSKeyEnv  = "KIND_OIDC_TLS_KEY"
+)
+
+// oidcProviderDir is where oidcProviderScript writes its files, the image
+// does not run as root
+const oidcProviderDir = "/tmp/kind-oidc"
+
+const oidcProviderScript = `set -o errexit
+mkdir -p ` + oidcProviderDir + `
+printf '%s' "$` + oidcConfigEnv + `" > ` + oidcProviderDir + `/config.yaml
+printf '%s' "$` + oidcTLSCertEnv + `" > ` + oidcProviderDir + `/tls.crt
+printf '%s' "$` + oidcTLSKeyEnv + `" > ` + oidcProviderDir + `/tls.key
+exec dex serve ` + oidcProviderDir + `/config.yaml
+`
+
+// oidcProviderConfig is the dex configuration, with a static client for the
+// cluster and a static user admin@example.com with the password "password"
+const oidcProviderConfig = `issuer: %s
+storage:
+  type: memory
+web:
+  https: 0.0.0.0:%d
+  tlsCert: ` + oidcProviderDir + `/tls.crt
+  tlsKey: ` + oidcProviderDir + `/tls.key
+oauth2:
+  skipApprovalScreen: true
+  passwordConnector: local
+staticClients:
+- id: %s
+  name: kind
+  secret: ` + OIDCClientSecret + `
+  redirectURIs:
+  - http://localhost:8000
+  - http://localhost:18000
+enablePasswordDB: true
+staticPasswords:
+- email: admin@example.com
+  hash: "$2a$10$2b2cU8CPhOTaGrs1HRQuAueS7JTT5ZHsHSzYiFPm1leZck7Mc8T4W"
+  username: admin
+  userID: 08a8684b-db88-4b73-90a9-3cd1661f5466
+`
+
+// OIDCProviderName returns the name of the OIDC provider container for cluster
+func OIDCProviderName(cluster string) string {
+	return cluster + "-oidc"
+}
+
+// OIDCProviderURL returns the issuer URL of the OIDC provider of cluster,
+// as reached from the nodes, and from the host with a hosts entry mapping
+// OIDCProviderName to 127.0.0.1
+func OIDCProviderURL(cluster string) string {
+	return fmt.Sprintf("https://%s:%d/dex", OIDCProviderName(cluster), OIDCProviderPort)
+}
+
+// oidcTLS is the serving certificate of the OIDC provider and its CA
+type oidcTLS struct {
+	ca, cert, key string
+}
+
+// newOIDCTLS generates a CA and a serving certificate signed by it for name,
+// which is also valid for localhost to reach the provider from the host
+func newOIDCTLS(name string) (*oidcTLS, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate CA key")
+	}
+	now := time.Now()
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name + "-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA certificate")
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate serving key")
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    caTemplate.NotBefore,
+		NotAfter:     caTemplate.NotAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{name, "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create serving certificate")
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode serving key")
+	}
+	return &oidcTLS{
+		ca:   string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})),
+		cert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})),
+		key:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}, nil
+}
+
+// OIDCProviderImageFor returns the image of the OIDC provider of cfg
+func OIDCProviderImageFor(cfg *config.Cluster) string {
+	if cfg.OIDC.Image != "" {
+		return cfg.OIDC.Image
+	}
+	return OIDCProviderImage
+}
+
+// oidcProviderArgs returns the container run arguments for the OIDC provider
+// of cfg on network, published on the host's loopback address
+func oidcProviderArgs(cfg *config.Cluster, network string) []string {
+	image := OIDCProviderImageFor(cfg)
+	return []string{
+		"run",
+		"--detach",
+		// like the nodes, restart with the container runtime
+		"--restart=always",
+		"--name", OIDCProviderName(cfg.Name),
+		"--network", network,
+		"--label", oidcProviderLabelKey + "=" + cfg.Name,
+		fmt.Sprintf("--publish=127.0.0.1:%d:%d", OIDCProviderPort, OIDCProviderPort),
+		// the values are read from the environment of the run command
+		"--env", oidcConfigEnv,
+		"--env", oidcCAEnv,
+		"--env", oidcTLSCertEnv,
+		"--env", oidcTLSKeyEnv,
+		"--entrypoint", "sh",
+		image,
+		"-c", oidcProviderScript,
+	}
+}
+
+// EnsureOIDCProvider ensures the OIDC provider of cfg is running on network,
+// and sets the issuer and certificate authority of cfg to match it
+func EnsureOIDCProvider(binaryName string, cfg *config.Cluster, network string) error {
+	name := OIDCProviderName(cfg.Name)
+	issuer := OIDCProviderURL(cfg.Name)
+	tls, err := newOIDCTLS(name)
+	if err != nil {
+		return err
+	}
+	run := exec.Command(binaryName, oidcProviderArgs(cfg, network)...)
+	run.SetEnv(append(os.Environ(),
+		oidcConfigEnv+"="+fmt.Sprintf(oidcProviderConfig, issuer, OIDCProviderPort, cfg.OIDC.ClientID),
+		oidcCAEnv+"="+tls.ca,
+		oidcTLSCertEnv+"="+tls.cert,
+		oidcTLSKeyEnv+"="+tls.key,
+	)...)
+	if err := ensureContainer(binaryName, name, network, run); err != nil {
+		return errors.WithDetails(err, errors.Details{
+			Hint: fmt.Sprintf("the OIDC provider is published on 127.0.0.1:%d, which must be free, only one cluster at a time can run it", OIDCProviderPort),
+		})
+	}
+	// an existing provider keeps its CA, which the existing nodes trust
+	out, err := exec.Output(exec.Command(binaryName, "inspect", "--format", "{{json .Config.Env}}", name))
+	if err != nil {
+		return errors.Wrapf(err, "failed to inspect %s", name)
+	}
+	ca, err := parseOIDCProviderCA(out)
+	if err != nil {
+		return err
+	}
+	cfg.OIDC.IssuerURL = issuer
+	cfg.OIDC.CertificateAuthority = ca
+	return nil
+}
+
+// parseOIDCProviderCA reads the CA from the JSON encoded environment of the
+// OIDC provider container
+func parseOIDCProviderCA(envJSON []byte) (string, error) {
+	env := []string{}
+	if err := json.Unmarshal(envJSON, &env); err != nil {
+		return "", errors.Wrap(err, "failed to parse OIDC provider environment")
+	}
+	for _, e := range env {
+		if ca := strings.TrimPrefix(e, oidcCAEnv+"="); ca != e {
+			return ca, nil
+		}
+	}
+	return "", errors.Errorf("OIDC provider was not created by kind, %s is not set", oidcCAEnv)
+}
+
+// DeleteOIDCProvider deletes the OIDC provider of cluster if it exists
+func DeleteOIDCProvider(binaryName, cluster string) error {
+	return deleteLabeledContainers(binaryName, oidcProviderLabelKey+"="+cluster, "OIDC provider")
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestNewOIDCTLS(t *testing.T) {
+	t.Parallel()
+	result, err := newOIDCTLS("dev-oidc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tls.X509KeyPair([]byte(result.cert), []byte(result.key)); err != nil {
+		t.Fatalf("serving certificate does not match its key: %v", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(result.ca)) {
+		t.Fatal("failed to parse CA")
+	}
+	block, _ := pem.Decode([]byte(result.cert))
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse serving certificate: %v", err)
+	}
+	// the nodes reach the provider by name, the host by loopback address
+	for _, name := range []string{"dev-oidc", "localhost", "127.0.0.1"} {
+		if _, err := cert.Verify(x509.VerifyOptions{DNSName: name, Roots: roots}); err != nil {
+			t.Errorf("serving certificate is not valid for %s: %v", name, err)
+		}
+	}
+}
+
+func TestParseOIDCProviderCA(t *testing.T) {
+	t.Parallel()
+	ca, err := parseOIDCProviderCA([]byte(`["PATH=/usr/bin","KIND_OIDC_CA=-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"]`))
+	assert.ExpectError(t, false, err)
+	assert.StringEqual(t, "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n", ca)
+
+	_, err = parseOIDCProviderCA([]byte(`["PATH=/usr/bin"]`))
+	assert.ExpectError(t, true, err)
+	_, err = parseOIDCProviderCA([]byte(`bogus`))
+	assert.ExpectError(t, true, err)
+}
+
+func TestOIDCProviderArgs(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Cluster{Name: "dev"}
+	cfg.OIDC = config.OIDC{Provider: config.DexOIDCProvider, Image: "registry.example.com/dex:v2"}
+	assert.DeepEqual(t, []string{
+		"run", "--detach", "--restart=always",
+		"--name", "dev-oidc",
+		"--network", "kind",
+		"--label", "io.x-k8s.kind.oidc-provider=dev",
+		"--publish=127.0.0.1:5556:5556",
+		"--env", "KIND_OIDC_CONFIG",
+		"--env", "KIND_OIDC_CA",
+		"--env", "KIND_OIDC_TLS_CERT",
+		"--env", "KIND_OIDC_TLS_KEY",
+		"--entrypoint", "sh",
+		"registry.example.com/dex:v2",
+		"-c", oidcProviderScript,
+	}, oidcProviderArgs(cfg, "kind"))
+	assert.StringEqual(t, "https://dev-oidc:5556/dex", OIDCProviderURL(cfg.Name))
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// portsFormat is the container inspect format for published ports,
+// supported by docker, podman and nerdctl alike
+const portsFormat = "{{.Name}}\t{{json .NetworkSettings.Ports}}"
+
+// CollectPublishedPorts implements PublishedPorts for providers with a docker
+// compatible `inspect` command, inspecting all of the nodes at once
+func CollectPublishedPorts(binaryName string, n []nodes.Node) ([]providers.PublishedPort, error) {
+	if len(n) == 0 {
+		return nil, nil
+	}
+	args := []string{"inspect", "--format", portsFormat}
+	for _, node := range n {
+		args = append(args, node.String())
+	}
+	lines, err := exec.OutputLines(exec.Command(binaryName, args...))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to inspect node ports")
+	}
+	ret := []providers.PublishedPort{}
+	for _, line := range lines {
+		ports, err := parsePortsLine(line)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, ports...)
+	}
+	return ret, nil
+}
+
+// parsePortsLine parses a line of `inspect --format portsFormat` output, e.g.
+// /kind-control-plane	{"6443/tcp":[{"HostIp":"127.0.0.1","HostPort":"41469"}]}
+// ports that are exposed but not published have no bindings and are skipped
+func parsePortsLine(line string) ([]providers.PublishedPort, error) {
+	parts := strings.SplitN(line, "\t", 2)
+	if len(parts) != 2 {
+		return nil, errors.Errorf("failed to parse ports %q", line)
+	}
+	node := strings.TrimPrefix(strings.TrimSpace(parts[0]), "/")
+	bindings := map[string][]struct {
+		HostIP   string `json:"HostIp"`
+		HostPort string `json:"HostPort"`
+	}{}
+	if raw := strings.TrimSpace(parts[1]); raw != "" && raw != "null" {
+		if err := json.Unmarshal([]byte(raw), &bindings); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse ports of %s", node)
+		}
+	}
+	ports := []providers.PublishedPort{}
+	for key, hostBindings := range bindings {
+		containerPort, protocol := key, "tcp"
+		if i := strings.Index(key, "/"); i >= 0 {
+			containerPort, protocol = key[:i], key[i+1:]
+		}
+		cp, err := strconv.ParseInt(containerPort, 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse container port %q of %s", key, node)
+		}
+		for _, b := range hostBindings {
+			hp, err := strconv.ParseInt(b.HostPort, 10, 32)
+			if err != nil {
+				return nil, errors.Errorf("failed to parse host port %q of %s", b.HostPort, node)
+			}
+			ports = append(ports, providers.PublishedPort{
+				Node:          node,
+				ContainerPort: int32(cp),
+				Protocol:      strings.ToUpper(protocol),
+				ListenAddress: b.HostIP,
+				HostPort:      int32(hp),
+			})
+		}
+	}
+	// map iteration is random, keep the output stable
+	sort.Slice(ports, func(i, j int) bool {
+		a, b := ports[i], ports[j]
+		if a.ContainerPort != b.ContainerPort {
+			return a.ContainerPort < b.ContainerPort
+		}
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		return a.ListenAddress < b.ListenAddress
+	})
+	return ports, nil
+}
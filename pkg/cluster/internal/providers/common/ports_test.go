@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestParsePortsLine(t *testing.T) {
+	t.Parallel()
+	ports, err := parsePortsLine(`/kind-control-plane	{"443/tcp":[{"HostIp":"0.0.0.0","HostPort":"443"},{"HostIp":"::","HostPort":"443"}],"53/udp":[{"HostIp":"127.0.0.1","HostPort":"5353"}],"6443/tcp":[{"HostIp":"127.0.0.1","HostPort":"41469"}],"8080/tcp":null}`)
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []providers.PublishedPort{
+		{Node: "kind-control-plane", ContainerPort: 53, Protocol: "UDP", ListenAddress: "127.0.0.1", HostPort: 5353},
+		{Node: "kind-control-plane", ContainerPort: 443, Protocol: "TCP", ListenAddress: "0.0.0.0", HostPort: 443},
+		{Node: "kind-control-plane", ContainerPort: 443, Protocol: "TCP", ListenAddress: "::", HostPort: 443},
+		{Node: "kind-control-plane", ContainerPort: 6443, Protocol: "TCP", ListenAddress: "127.0.0.1", HostPort: 41469},
+	}, ports)
+
+	// podman names have no leading slash, and nodes may publish nothing
+	ports, err = parsePortsLine("kind-worker\t{}")
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []providers.PublishedPort{}, ports)
+	ports, err = parsePortsLine("kind-worker\tnull")
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []providers.PublishedPort{}, ports)
+
+	_, err = parsePortsLine("kind-worker")
+	assert.ExpectError(t, true, err)
+	_, err = parsePortsLine(`kind-worker	{"http/tcp":[{"HostIp":"","HostPort":"80"}]}`)
+	assert.ExpectError(t, true, err)
+}
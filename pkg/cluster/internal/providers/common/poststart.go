@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+const (
+	// nodeEgressRateLabelKey records the egress rate of a node container,
+	// the shaping is lost whenever the container restarts
+	nodeEgressRateLabelKey = "io.x-k8s.kind.egress-rate"
+	// nodeKernelModulesLabelKey records the comma separated kernel modules
+	// of a node container, which are gone from the host after a reboot
+	nodeKernelModulesLabelKey = "io.x-k8s.kind.kernel-modules"
+)
+
+// postStartStateFormat is the container inspect format for the settings
+// recorded by NodeConfigArgs
+const postStartStateFormat = `{{.Name}}\t{{.State.Running}}` +
+	`\t{{index .Config.Labels "` + nodeEgressRateLabelKey + `"}}` +
+	`\t{{index .Config.Labels "` + nodeKernelModulesLabelKey + `"}}`
+
+// PostStart applies the node settings that only take effect in a running
+// node container: it attaches the container to the node's extra networks,
+// shapes its egress and loads its kernel modules
+// primary is the network the container was created on
+func PostStart(binaryName, container, primary string, node *config.Node) error {
+	if err := ConnectNetworks(binaryName, container, primary, node); err != nil {
+		return err
+	}
+	if err := ShapeEgress(binaryName, container, node); err != nil {
+		return err
+	}
+	return LoadKernelModules(binaryName, container, node)
+}
+
+// RestartedNodes applies PostStart again to the running nodes among n, with
+// the settings recorded when they were created, e.g. after they are resumed
+// or reattached to their recreated network
+// Extra networks are kept by the container runtime so they are not attached
+func RestartedNodes(binaryName string, n []nodes.Node) error {
+	if len(n) == 0 {
+		return nil
+	}
+	args := []string{"inspect", "--format", postStartStateFormat}
+	for _, node := range n {
+		args = append(args, node.String())
+	}
+	lines, err := exec.OutputLines(exec.Command(binaryName, args...))
+	if err != nil {
+		return errors.Wrap(err, "failed to inspect nodes")
+	}
+	fns := []func() error{}
+	for _, line := range lines {
+		container, node, running, err := parsePostStartStateLine(line)
+		if err != nil {
+			return err
+		}
+		if !running {
+			continue
+		}
+		fns = append(fns, func() error {
+			return PostStart(binaryName, container, "", node)
+		})
+	}
+	return errors.UntilErrorConcurrent(fns)
+}
+
+// parsePostStartStateLine parses a line of
+// `inspect --format postStartStateFormat` output, e.g.
+// /kind-worker	true	10mbit	ip_vs,nf_conntrack
+func parsePostStartStateLine(line string) (string, *config.Node, bool, error) {
+	parts := strings.Split(line, "\t")
+	if len(parts) != 4 {
+		return "", nil, false, errors.Errorf("failed to parse node state %q", line)
+	}
+	node := &config.Node{}
+	node.IOLimits.EgressRate = labelValue(parts[2])
+	node.KernelModules = splitList(labelValue(parts[3]))
+	return strings.TrimPrefix(strings.TrimSpace(parts[0]), "/"), node, strings.TrimSpace(parts[1]) == "true", nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestParsePostStartStateLine(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name              string
+		Line              string
+		ExpectedContainer string
+		ExpectedNode      *config.Node
+		ExpectedRunning   bool
+		ExpectError       bool
+	}{
+		{
+			Name:              "running node with settings",
+			Line:              "/kind-worker\ttrue\t10mbit\tip_vs,nf_conntrack",
+			ExpectedContainer: "kind-worker",
+			ExpectedNode: &config.Node{
+				IOLimits:      config.IOLimits{EgressRate: "10mbit"},
+				KernelModules: []string{"ip_vs", "nf_conntrack"},
+			},
+			ExpectedRunning: true,
+		},
+		{
+			Name:              "stopped node without labels",
+			Line:              "/kind-control-plane\tfalse\t<no value>\t<no value>",
+			ExpectedContainer: "kind-control-plane",
+			ExpectedNode:      &config.Node{KernelModules: []string{}},
+		},
+		{
+			Name:        "malformed",
+			Line:        "/kind-worker\ttrue",
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			container, node, running, err := parsePostStartStateLine(tc.Line)
+			assert.ExpectError(t, tc.ExpectError, err)
+			if err != nil {
+				return
+			}
+			assert.StringEqual(t, tc.ExpectedContainer, container)
+			assert.DeepEqual(t, tc.ExpectedNode, node)
+			assert.BoolEqual(t, tc.ExpectedRunning, running)
+		})
+	}
+}
+
+func TestNodeConfigArgsPostStart(t *testing.T) {
+	t.Parallel()
+	node := &config.Node{
+		IOLimits:      config.IOLimits{EgressRate: "10mbit"},
+		KernelModules: []string{"ip_vs", "nf_conntrack"},
+	}
+	args := NodeConfigArgs(node)
+	assert.DeepEqual(t, []string{
+		"--label", nodeEgressRateLabelKey + "=10mbit",
+		"--label", nodeKernelModulesLabelKey + "=ip_vs,nf_conntrack",
+	}, args[2:])
+	assert.DeepEqual(t, 2, len(NodeConfigArgs(&config.Node{})))
+}
@@ -34,6 +34,7 @@ const (
 
 // GetProxyEnvs returns a map of proxy environment variables to their values
 // If proxy settings are set, NO_PROXY is modified to include the cluster subnets
+// With a proxy helper, HTTP_PROXY and HTTPS_PROXY are the helper instead
 func GetProxyEnvs(cfg *config.Cluster) map[string]string {
 	return getProxyEnvs(cfg, os.Getenv)
 }
@@ -50,6 +51,13 @@ func getProxyEnvs(cfg *config.Cluster, getEnv func(string) string) map[string]st
 			envs[strings.ToLower(name)] = val
 		}
 	}
+	// the proxy helper authenticates to the proxy, the credentials stay on the host
+	if cfg.ProxyHelper.Auth != "" && (envs[HTTPProxy] != "" || envs[HTTPSProxy] != "") {
+		for _, name := range []string{HTTPProxy, HTTPSProxy} {
+			envs[name] = ProxyHelperURL(cfg.Name)
+			envs[strings.ToLower(name)] = envs[name]
+		}
+	}
 	// Specifically add the cluster subnets to NO_PROXY if we are using a proxy
 	if len(envs) > 0 {
 		noProxy := envs[NOProxy]
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// RegistryImage is the image of local registry containers
+const RegistryImage = "registry:2"
+
+// RegistryContainerPort is the port the registry listens on in its container
+const RegistryContainerPort = constants.LocalRegistryPort
+
+// registryLabelKey labels registry containers created by kind, so that kind
+// never deletes containers it did not create
+const registryLabelKey = "io.x-k8s.kind.registry"
+
+// RegistryArgs returns the container run arguments for a local registry
+// published on the host loopback at port
+func RegistryArgs(name, network string, port int32) []string {
+	return []string{
+		"run",
+		"--detach",
+		// like the nodes, restart with the container runtime
+		"--restart=always",
+		"--name", name,
+		"--network", network,
+		"--label", registryLabelKey + "=true",
+		"--publish", fmt.Sprintf("127.0.0.1:%d:%d", port, RegistryContainerPort),
+		RegistryImage,
+	}
+}
+
+// EnsureRegistry ensures the registry container name exists, is running and
+// is attached to network, creating it if necessary
+// An existing registry is reused as is, including its published port
+func EnsureRegistry(binaryName, name, network string, port int32) error {
+	return ensureContainer(binaryName, name, network, exec.Command(binaryName, RegistryArgs(name, network, port)...))
+}
+
+// ensureContainer ensures the container name exists, is running and
+// is attached to network, creating it with run if necessary
+func ensureContainer(binaryName, name, network string, run exec.Cmd) error {
+	networks, err := exec.OutputLines(exec.Command(binaryName, "inspect", "--format", NetworksFormat, name))
+	if err != nil {
+		// the container does not exist yet
+		if err := run.Run(); err != nil {
+			return errors.Wrapf(err, "failed to create %s", name)
+		}
+		return nil
+	}
+	attached := false
+	for _, n := range networks {
+		attached = attached || n == network
+	}
+	if !attached {
+		if err := exec.Command(binaryName, "network", "connect", network, name).Run(); err != nil {
+			return errors.Wrapf(err, "failed to connect %s to network %s", name, network)
+		}
+	}
+	// starting a running container is a no-op
+	if err := exec.Command(binaryName, "start", name).Run(); err != nil {
+		return errors.Wrapf(err, "failed to start %s", name)
+	}
+	return nil
+}
+
+// deleteLabeledContainers deletes all containers with label, which are
+// described as what in errors
+func deleteLabeledContainers(binaryName, label, what string) error {
+	names, err := exec.OutputLines(exec.Command(binaryName, "ps", "--all", "--filter", "label="+label, "--format", "{{.Names}}"))
+	if err != nil {
+		return errors.Wrapf(err, "failed to list %ss", what)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if err := exec.Command(binaryName, append([]string{"rm", "--force", "--volumes"}, names...)...).Run(); err != nil {
+		return errors.Wrapf(err, "failed to delete %s %s", what, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// DeleteRegistry deletes the registry container name if it exists, it is
+// an error if the container was not created by kind
+func DeleteRegistry(binaryName, name string) error {
+	lines, err := exec.OutputLines(exec.Command(binaryName, "inspect", "--format", fmt.Sprintf(`{{index .Config.Labels %q}}`, registryLabelKey), name))
+	if err != nil {
+		// nothing to delete
+		return nil
+	}
+	if len(lines) == 0 || lines[0] != "true" {
+		return errors.Errorf("container %s is not a registry created by kind", name)
+	}
+	if err := exec.Command(binaryName, "rm", "--force", "--volumes", name).Run(); err != nil {
+		return errors.Wrapf(err, "failed to delete registry %s", name)
+	}
+	return nil
+}
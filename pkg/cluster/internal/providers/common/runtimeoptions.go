@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sort"
+	"strconv"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// RuntimeOptionArgs returns the container run args for the node's devices,
+// sysctls, tmpfs mounts, user namespace mode, block IO limits and resources
+//
+// supportsUserNS reports if the provider supports a user namespace mode,
+// unsupported modes are an error rather than being silently dropped
+func RuntimeOptionArgs(provider string, node *config.Node, supportsUserNS func(mode string) bool) ([]string, error) {
+	args := []string{}
+	for _, device := range node.Devices {
+		args = append(args, "--device", device)
+	}
+	// sort for deterministic args
+	keys := make([]string, 0, len(node.Sysctls))
+	for key := range node.Sysctls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--sysctl", key+"="+node.Sysctls[key])
+	}
+	for _, tmpfs := range node.Tmpfs {
+		args = append(args, "--tmpfs", tmpfs)
+	}
+	for _, limit := range node.IOLimits.DeviceReadBPS {
+		args = append(args, "--device-read-bps", limit)
+	}
+	for _, limit := range node.IOLimits.DeviceWriteBPS {
+		args = append(args, "--device-write-bps", limit)
+	}
+	for _, limit := range node.IOLimits.DeviceReadIOPS {
+		args = append(args, "--device-read-iops", limit)
+	}
+	for _, limit := range node.IOLimits.DeviceWriteIOPS {
+		args = append(args, "--device-write-iops", limit)
+	}
+	if node.Resources.CPUs != "" {
+		args = append(args, "--cpus", node.Resources.CPUs)
+	}
+	if node.Resources.Memory != "" {
+		args = append(args, "--memory", node.Resources.Memory)
+	}
+	if node.Resources.PIDs > 0 {
+		args = append(args, "--pids-limit", strconv.FormatInt(node.Resources.PIDs, 10))
+	}
+	if node.UserNS != "" {
+		if !supportsUserNS(node.UserNS) {
+			return nil, errors.Errorf("userNS %q is not supported by the %s provider", node.UserNS, provider)
+		}
+		args = append(args, "--userns", node.UserNS)
+	}
+	return args, nil
+}
+
+// egressShapingArgs returns the command shaping the egress of the node's
+// primary interface to rate with a token bucket filter
+func egressShapingArgs(rate string) []string {
+	return []string{"tc", "qdisc", "replace", "dev", "eth0", "root", "tbf",
+		"rate", rate, "burst", "32kbit", "latency", "400ms"}
+}
+
+// ShapeEgress applies the node's egress rate limit, if any, in the running
+// node container
+// The limit lives in the container's network namespace, so it does not
+// survive the container restarting
+func ShapeEgress(binaryName, container string, node *config.Node) error {
+	if node.IOLimits.EgressRate == "" {
+		return nil
+	}
+	args := append([]string{"exec", container}, egressShapingArgs(node.IOLimits.EgressRate)...)
+	if err := exec.Command(binaryName, args...).Run(); err != nil {
+		return errors.Wrapf(err, "failed to limit egress of %s to %s", container, node.IOLimits.EgressRate)
+	}
+	return nil
+}
+
+// LoadKernelModules loads the node's kernel modules, if any, with modprobe in
+// the running node container
+// Modules are loaded into the host kernel, so they outlive the node
+func LoadKernelModules(binaryName, container string, node *config.Node) error {
+	if len(node.KernelModules) == 0 {
+		return nil
+	}
+	args := append([]string{"exec", container, "modprobe", "-a"}, node.KernelModules...)
+	if err := exec.Command(binaryName, args...).Run(); err != nil {
+		return errors.Wrapf(err, "failed to load kernel modules %v in %s, check the modules exist in the host's /lib/modules", node.KernelModules, container)
+	}
+	return nil
+}
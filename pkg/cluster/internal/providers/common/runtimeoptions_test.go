@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestRuntimeOptionArgs(t *testing.T) {
+	t.Parallel()
+	hostOnly := func(mode string) bool { return mode == "host" }
+	cases := []struct {
+		Name        string
+		Node        config.Node
+		Expected    []string
+		ExpectError bool
+	}{
+		{
+			Name:     "no options",
+			Node:     config.Node{},
+			Expected: []string{},
+		},
+		{
+			Name: "all options",
+			Node: config.Node{
+				Devices: []string{"/dev/kvm", "/dev/sda:/dev/xvda:r"},
+				Sysctls: map[string]string{"net.ipv4.ip_forward": "1", "kernel.shm_rmid_forced": "1"},
+				Tmpfs:   []string{"/scratch:size=64m"},
+				UserNS:  "host",
+				IOLimits: config.IOLimits{
+					DeviceReadBPS:   []string{"/dev/sda:50mb"},
+					DeviceWriteBPS:  []string{"/dev/sda:10mb"},
+					DeviceReadIOPS:  []string{"/dev/sda:2000"},
+					DeviceWriteIOPS: []string{"/dev/sda:1000"},
+					EgressRate:      "100mbit",
+				},
+				Resources: config.NodeResources{CPUs: "1.5", Memory: "2g", PIDs: 4096},
+			},
+			Expected: []string{
+				"--device", "/dev/kvm",
+				"--device", "/dev/sda:/dev/xvda:r",
+				"--sysctl", "kernel.shm_rmid_forced=1",
+				"--sysctl", "net.ipv4.ip_forward=1",
+				"--tmpfs", "/scratch:size=64m",
+				"--device-read-bps", "/dev/sda:50mb",
+				"--device-write-bps", "/dev/sda:10mb",
+				"--device-read-iops", "/dev/sda:2000",
+				"--device-write-iops", "/dev/sda:1000",
+				"--cpus", "1.5",
+				"--memory", "2g",
+				"--pids-limit", "4096",
+				"--userns", "host",
+			},
+		},
+		{
+			Name:        "unsupported userns",
+			Node:        config.Node{UserNS: "keep-id"},
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			args, err := RuntimeOptionArgs("test", &tc.Node, hostOnly)
+			if tc.ExpectError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.DeepEqual(t, tc.Expected, args)
+		})
+	}
+}
+
+func TestEgressShapingArgs(t *testing.T) {
+	t.Parallel()
+	assert.DeepEqual(t, []string{
+		"tc", "qdisc", "replace", "dev", "eth0", "root", "tbf",
+		"rate", "100mbit", "burst", "32kbit", "latency", "400ms",
+	}, egressShapingArgs("100mbit"))
+}
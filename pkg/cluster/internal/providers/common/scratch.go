@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// NetworksFormat is the container inspect format listing the names of the
+// networks a container is attached to, one per line, starting with the
+// primary network of nodes recorded in NodeNetworkLabelKey
+const NetworksFormat = `{{with index .Config.Labels "` + NodeNetworkLabelKey + `"}}{{.}}{{"\n"}}{{end}}` +
+	`{{range $k, $v := .NetworkSettings.Networks}}{{$k}}{{"\n"}}{{end}}`
+
+// scratchNodeLabelKey labels scratch nodes created by kind with the name of
+// their cluster
+const scratchNodeLabelKey = "io.x-k8s.kind.scratch-node"
+
+// scratchNodeName returns the name of the scratch node for cluster
+func scratchNodeName(cluster string) string {
+	return cluster + "-scratch"
+}
+
+// scratchNodeArgs returns the container run arguments for the scratch node
+// of cluster
+// The scratch node does not boot systemd and is not labeled as part of the
+// cluster, so it is never listed as one of the cluster's nodes
+func scratchNodeArgs(cluster, network, image string) []string {
+	name := scratchNodeName(cluster)
+	return []string{
+		"run",
+		"--detach",
+		"--name", name,
+		"--hostname", name,
+		"--label", scratchNodeLabelKey + "=" + cluster,
+		"--network", network,
+		"--entrypoint", "sleep",
+		image,
+		"infinity",
+	}
+}
+
+// CreateScratchNode starts the scratch node of cluster from image on network
+// and returns its name, a scratch node left behind by an earlier run is
+// replaced
+func CreateScratchNode(binaryName, cluster, network, image string) (string, error) {
+	if err := DeleteScratchNode(binaryName, cluster); err != nil {
+		return "", err
+	}
+	if err := exec.Command(binaryName, scratchNodeArgs(cluster, network, image)...).Run(); err != nil {
+		return "", errors.Wrap(err, "failed to create scratch node")
+	}
+	return scratchNodeName(cluster), nil
+}
+
+// DeleteScratchNode deletes the scratch node of cluster if it exists
+func DeleteScratchNode(binaryName, cluster string) error {
+	return deleteLabeledContainers(binaryName, scratchNodeLabelKey+"="+cluster, "scratch node")
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestScratchNodeArgs(t *testing.T) {
+	t.Parallel()
+	assert.DeepEqual(t, []string{
+		"run",
+		"--detach",
+		"--name", "kind-scratch",
+		"--hostname", "kind-scratch",
+		"--label", "io.x-k8s.kind.scratch-node=kind",
+		"--network", "kind",
+		"--entrypoint", "sleep",
+		"kindest/node:v1.31.0",
+		"infinity",
+	}, scratchNodeArgs("kind", "kind", "kindest/node:v1.31.0"))
+}
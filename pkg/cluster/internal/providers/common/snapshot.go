@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"io"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// SnapshotImage returns the image a snapshot of the node named node is
+// committed to, and imported nodes run from
+func SnapshotImage(node string) string {
+	return "kind-snapshot/" + node + ":latest"
+}
+
+// DeleteSnapshotImages implements DeleteSnapshotImages for providers with
+// docker compatible `image inspect` and `rmi` commands
+func DeleteSnapshotImages(binaryName string, nodes []nodes.Node) error {
+	images := []string{}
+	for _, n := range nodes {
+		image := SnapshotImage(n.String())
+		if exec.Command(binaryName, "image", "inspect", image).Run() == nil {
+			images = append(images, image)
+		}
+	}
+	if len(images) == 0 {
+		return nil
+	}
+	if err := exec.Command(binaryName, append([]string{"rmi"}, images...)...).Run(); err != nil {
+		return errors.Wrapf(err, "failed to delete snapshot images %s", strings.Join(images, ", "))
+	}
+	return nil
+}
+
+// CommitNode implements CommitNode for providers with a docker compatible
+// `commit` command
+func CommitNode(binaryName string, node nodes.Node, image string) error {
+	if err := exec.Command(binaryName, "commit", node.String(), image).Run(); err != nil {
+		return errors.Wrapf(err, "failed to commit node %s", node.String())
+	}
+	return nil
+}
+
+// SaveImages implements SaveImages for providers with a docker compatible
+// `save` command
+func SaveImages(binaryName string, w io.Writer, images ...string) error {
+	cmd := exec.Command(binaryName, append([]string{"save"}, images...)...)
+	if err := cmd.SetStdout(w).Run(); err != nil {
+		return errors.Wrap(err, "failed to save images")
+	}
+	return nil
+}
+
+// LoadImages implements LoadImages for providers with a docker compatible
+// `load` command
+func LoadImages(binaryName string, r io.Reader) error {
+	if err := exec.Command(binaryName, "load").SetStdin(r).Run(); err != nil {
+		return errors.Wrap(err, "failed to load images")
+	}
+	return nil
+}
+
+// CopyFromNode implements CopyFromNode for providers with a docker
+// compatible `cp` command, which also works on stopped nodes
+func CopyFromNode(binaryName string, node nodes.Node, path string, w io.Writer) error {
+	cmd := exec.Command(binaryName, "cp", node.String()+":"+path, "-")
+	if err := cmd.SetStdout(w).Run(); err != nil {
+		return errors.Wrapf(err, "failed to copy %s from node %s", path, node.String())
+	}
+	return nil
+}
+
+// CopyToNode implements CopyToNode for providers with a docker
+// compatible `cp` command, which also works on stopped nodes
+func CopyToNode(binaryName string, node nodes.Node, path string, r io.Reader) error {
+	cmd := exec.Command(binaryName, "cp", "-", node.String()+":"+path)
+	if err := cmd.SetStdin(r).Run(); err != nil {
+		return errors.Wrapf(err, "failed to copy to %s on node %s", path, node.String())
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"net"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// HasStaticIPs returns true if any node in cfg has a static address
+func HasStaticIPs(cfg *config.Cluster) bool {
+	for i := range cfg.Nodes {
+		if HasStaticIP(&cfg.Nodes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasStaticIP returns true if node has a static address
+func HasStaticIP(node *config.Node) bool {
+	return node.IPv4Address != "" || node.IPv6Address != ""
+}
+
+// NetworkSubnets returns the subnets of the named network for runtimes with a
+// docker compatible `network inspect`, format selects the space separated subnets
+func NetworkSubnets(binaryName, network, format string) ([]string, error) {
+	lines, err := exec.OutputLines(exec.Command(binaryName, "network", "inspect", "--format", format, network))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get subnets of network %q", network)
+	}
+	return strings.Fields(strings.Join(lines, " ")), nil
+}
+
+// ValidateStaticIPs checks that the static node addresses in cfg are usable
+// on a network with the given subnets
+func ValidateStaticIPs(cfg *config.Cluster, network string, subnets []string) error {
+	cidrs := make([]*net.IPNet, 0, len(subnets))
+	for _, s := range subnets {
+		_, cidr, err := net.ParseCIDR(s)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse subnet of network %q", network)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	errs := []error{}
+	for _, n := range cfg.Nodes {
+		for _, address := range []string{n.IPv4Address, n.IPv6Address} {
+			if address == "" {
+				continue
+			}
+			if err := validateStaticIP(net.ParseIP(address), cidrs); err != nil {
+				errs = append(errs, errors.Wrapf(err, "invalid node address %s for network %q", address, network))
+			}
+		}
+	}
+	return errors.NewAggregate(errs)
+}
+
+func validateStaticIP(ip net.IP, cidrs []*net.IPNet) error {
+	for _, cidr := range cidrs {
+		if !cidr.Contains(ip) {
+			continue
+		}
+		if ip.Equal(cidr.IP) {
+			return errors.Errorf("%s is the network address of %s", ip, cidr)
+		}
+		if ip.To4() != nil && ip.Equal(lastIP(cidr)) {
+			return errors.Errorf("%s is the broadcast address of %s", ip, cidr)
+		}
+		return nil
+	}
+	return errors.Errorf("not within any of the network's subnets %v", cidrs)
+}
+
+// lastIP returns the last address in cidr
+func lastIP(cidr *net.IPNet) net.IP {
+	ip := make(net.IP, len(cidr.IP))
+	for i := range cidr.IP {
+		ip[i] = cidr.IP[i] | ^cidr.Mask[i]
+	}
+	return ip
+}
+
+// StaticIPArgs returns the run args assigning node its static addresses
+func StaticIPArgs(node *config.Node) []string {
+	args := []string{}
+	if node.IPv4Address != "" {
+		args = append(args, "--ip", node.IPv4Address)
+	}
+	if node.IPv6Address != "" {
+		args = append(args, "--ip6", node.IPv6Address)
+	}
+	return args
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestValidateStaticIPs(t *testing.T) {
+	t.Parallel()
+	subnets := []string{"172.18.0.0/16", "fc00:f853:ccd:e793::/64"}
+	cases := []struct {
+		Name        string
+		IPv4Address string
+		IPv6Address string
+		ExpectError bool
+	}{
+		{Name: "dynamic"},
+		{Name: "valid", IPv4Address: "172.18.0.10", IPv6Address: "fc00:f853:ccd:e793::10"},
+		{Name: "outside subnet", IPv4Address: "10.0.0.10", ExpectError: true},
+		{Name: "network address", IPv4Address: "172.18.0.0", ExpectError: true},
+		{Name: "broadcast address", IPv4Address: "172.18.255.255", ExpectError: true},
+		{Name: "ipv6 outside subnet", IPv6Address: "fd00::10", ExpectError: true},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &config.Cluster{Nodes: []config.Node{{IPv4Address: tc.IPv4Address, IPv6Address: tc.IPv6Address}}}
+			err := ValidateStaticIPs(cfg, "kind", subnets)
+			if err != nil && !tc.ExpectError {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && tc.ExpectError {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestStaticIPArgs(t *testing.T) {
+	t.Parallel()
+	assert.DeepEqual(t, []string{}, StaticIPArgs(&config.Node{}))
+	assert.DeepEqual(t,
+		[]string{"--ip", "172.18.0.10", "--ip6", "fc00:f853:ccd:e793::10"},
+		StaticIPArgs(&config.Node{IPv4Address: "172.18.0.10", IPv6Address: "fc00:f853:ccd:e793::10"}),
+	)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// statsFormat is the container stats format, supported by docker,
+// podman and nerdctl alike
+const statsFormat = "{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.PIDs}}\t{{.NetIO}}\t{{.BlockIO}}"
+
+// CollectNodeStats implements NodeStats for providers with a docker
+// compatible `stats` command
+func CollectNodeStats(binaryName string, n []nodes.Node) ([]providers.NodeStats, error) {
+	if len(n) == 0 {
+		return nil, nil
+	}
+	args := []string{"stats", "--no-stream", "--format", statsFormat}
+	for _, node := range n {
+		args = append(args, node.String())
+	}
+	lines, err := exec.OutputLines(exec.Command(binaryName, args...))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get node stats")
+	}
+	byName := map[string]providers.NodeStats{}
+	for _, line := range lines {
+		stats, err := parseStatsLine(line)
+		if err != nil {
+			return nil, err
+		}
+		byName[stats.Name] = stats
+	}
+
+	// the volume usage is not reported by stats, measure it in each node
+	// this is best effort, e.g. the load balancer image does not ship du
+	disk := make([]int64, len(n))
+	fns := []func() error{}
+	for i, node := range n {
+		i, node := i, node // capture loop variables
+		fns = append(fns, func() error {
+			lines, err := exec.OutputLines(node.Command("du", "-sxb", "/var"))
+			if err != nil || len(lines) == 0 {
+				return nil
+			}
+			if fields := strings.Fields(lines[0]); len(fields) > 0 {
+				disk[i], _ = strconv.ParseInt(fields[0], 10, 64)
+			}
+			return nil
+		})
+	}
+	_ = errors.AggregateConcurrent(fns)
+
+	ret := make([]providers.NodeStats, 0, len(n))
+	for i, node := range n {
+		stats, ok := byName[node.String()]
+		if !ok {
+			return nil, errors.Errorf("no stats reported for node %s", node.String())
+		}
+		stats.DiskBytes = disk[i]
+		ret = append(ret, stats)
+	}
+	return ret, nil
+}
+
+// parseStatsLine parses a line of `stats --format statsFormat` output, e.g.
+// kind-control-plane	10.53%	612.4MiB / 15.55GiB	241	1.2MB / 3.4MB	0B / 120MB
+func parseStatsLine(line string) (providers.NodeStats, error) {
+	parts := strings.Split(line, "\t")
+	if len(parts) != 6 {
+		return providers.NodeStats{}, errors.Errorf("failed to parse stats %q", line)
+	}
+	stats := providers.NodeStats{Name: strings.TrimSpace(parts[0])}
+	var err error
+	// stopped containers report -- rather than their usage
+	if cpu := strings.TrimSpace(parts[1]); cpu != "--" {
+		if stats.CPUPercent, err = strconv.ParseFloat(strings.TrimSuffix(cpu, "%"), 64); err != nil {
+			return stats, errors.Wrapf(err, "failed to parse CPU usage %q", parts[1])
+		}
+	}
+	if stats.MemoryBytes, stats.MemoryLimitBytes, err = parseSizePair(parts[2]); err != nil {
+		return stats, err
+	}
+	if pids := strings.TrimSpace(parts[3]); pids != "--" {
+		if stats.PIDs, err = strconv.ParseInt(pids, 10, 64); err != nil {
+			return stats, errors.Wrapf(err, "failed to parse PIDs %q", parts[3])
+		}
+	}
+	if stats.NetRxBytes, stats.NetTxBytes, err = parseSizePair(parts[4]); err != nil {
+		return stats, err
+	}
+	if stats.BlockReadBytes, stats.BlockWriteBytes, err = parseSizePair(parts[5]); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// parseSizePair parses sizes of the form "1.2MB / 3.4MB"
+func parseSizePair(raw string) (int64, int64, error) {
+	parts := strings.Split(raw, "/")
+	if len(parts) != 2 {
+		return 0, 0, errors.Errorf("failed to parse sizes %q", raw)
+	}
+	a, err := parseSize(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	b, err := parseSize(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return a, b, nil
+}
+
+// sizeUnits are the units used by the runtimes' human readable sizes,
+// longest suffixes first so they match before their prefixes
+var sizeUnits = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"kB", 1e3}, {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"B", 1},
+}
+
+// parseSize parses a human readable size such as 612.4MiB or 1.2kB
+func parseSize(raw string) (int64, error) {
+	raw = strings.TrimSpace(raw)
+	// unlimited memory is reported as 0B by docker, and -- by some runtimes
+	if raw == "--" {
+		return 0, nil
+	}
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(raw, unit.suffix) {
+			v, err := strconv.ParseFloat(strings.TrimSuffix(raw, unit.suffix), 64)
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to parse size %q", raw)
+			}
+			return int64(math.Round(v * unit.multiplier)), nil
+		}
+	}
+	return 0, errors.Errorf("failed to parse size %q", raw)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestParseStatsLine(t *testing.T) {
+	t.Parallel()
+	stats, err := parseStatsLine("kind-control-plane\t10.50%\t612MiB / 16GiB\t241\t1.5kB / 3MB\t0B / 120MB")
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, providers.NodeStats{
+		Name:             "kind-control-plane",
+		CPUPercent:       10.5,
+		MemoryBytes:      612 << 20,
+		MemoryLimitBytes: 16 << 30,
+		PIDs:             241,
+		NetRxBytes:       1500,
+		NetTxBytes:       3000000,
+		BlockReadBytes:   0,
+		BlockWriteBytes:  120000000,
+	}, stats)
+
+	stats, err = parseStatsLine("kind-worker\t--\t-- / --\t--\t-- / --\t-- / --")
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, providers.NodeStats{Name: "kind-worker"}, stats)
+
+	_, err = parseStatsLine("kind-control-plane\t10.50%")
+	assert.ExpectError(t, true, err)
+}
+
+func TestParseSize(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Raw         string
+		Expected    int64
+		ExpectError bool
+	}{
+		{Raw: "0B", Expected: 0},
+		{Raw: "--", Expected: 0},
+		{Raw: "1.5KiB", Expected: 1536},
+		{Raw: " 2kB ", Expected: 2000},
+		{Raw: "1GB", Expected: 1000000000},
+		{Raw: "1.5", ExpectError: true},
+		{Raw: "aMB", ExpectError: true},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Raw, func(t *testing.T) {
+			t.Parallel()
+			size, err := parseSize(tc.Raw)
+			assert.ExpectError(t, tc.ExpectError, err)
+			if err == nil && size != tc.Expected {
+				t.Errorf("expected %d but got %d", tc.Expected, size)
+			}
+		})
+	}
+}
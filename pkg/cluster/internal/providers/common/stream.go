@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"sigs.k8s.io/kind/pkg/log"
+)
+
+// StreamLogLevel is the verbosity at which container creation output is
+// streamed into the logs while nodes are being prepared
+const StreamLogLevel log.Level = 2
+
+// NewLineLogger returns a writer that logs each line written to it to logger
+// at StreamLogLevel, prefixed with prefix
+//
+// Close must be called to log any trailing partial line
+func NewLineLogger(logger log.Logger, prefix string) io.WriteCloser {
+	return &lineLogger{
+		logger: logger.V(StreamLogLevel),
+		prefix: prefix,
+	}
+}
+
+type lineLogger struct {
+	mu     sync.Mutex
+	logger log.InfoLogger
+	prefix string
+	buf    []byte
+}
+
+var _ io.WriteCloser = &lineLogger{}
+
+func (l *lineLogger) Write(p []byte) (int, error) {
+	if !l.logger.Enabled() {
+		return len(p), nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		l.log(l.buf[:i])
+		l.buf = l.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (l *lineLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.buf) > 0 {
+		l.log(l.buf)
+		l.buf = nil
+	}
+	return nil
+}
+
+func (l *lineLogger) log(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if len(line) == 0 {
+		return
+	}
+	l.logger.Infof("%s: %s", l.prefix, line)
+}
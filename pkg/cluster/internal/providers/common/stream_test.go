@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+	"sigs.k8s.io/kind/pkg/log"
+)
+
+type recordingLogger struct {
+	log.NoopLogger
+	enabled bool
+	lines   []string
+}
+
+func (r *recordingLogger) V(log.Level) log.InfoLogger { return r }
+
+func (r *recordingLogger) Enabled() bool { return r.enabled }
+
+func (r *recordingLogger) Info(message string) { r.lines = append(r.lines, message) }
+
+func (r *recordingLogger) Infof(format string, args ...interface{}) {
+	r.Info(fmt.Sprintf(format, args...))
+}
+
+func TestLineLogger(t *testing.T) {
+	t.Parallel()
+	logger := &recordingLogger{enabled: true}
+	out := NewLineLogger(logger, "kind-control-plane")
+	fmt.Fprint(out, "INFO: ensuring we can execute mount/umount\r\nINFO: detected cgr")
+	fmt.Fprint(out, "oup v1\n\npartial")
+	assert.DeepEqual(t, []string{
+		"kind-control-plane: INFO: ensuring we can execute mount/umount",
+		"kind-control-plane: INFO: detected cgroup v1",
+	}, logger.lines)
+	if err := out.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.StringEqual(t, "kind-control-plane: partial", logger.lines[len(logger.lines)-1])
+}
+
+func TestLineLoggerDisabled(t *testing.T) {
+	t.Parallel()
+	logger := &recordingLogger{}
+	out := NewLineLogger(logger, "kind-control-plane")
+	fmt.Fprintln(out, "hidden")
+	_ = out.Close()
+	assert.DeepEqual(t, []string(nil), logger.lines)
+}
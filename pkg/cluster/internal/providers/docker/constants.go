@@ -16,9 +16,11 @@ limitations under the License.
 
 package docker
 
+import "sigs.k8s.io/kind/pkg/cluster/constants"
+
 // clusterLabelKey is applied to each "node" docker container for identification
-const clusterLabelKey = "io.x-k8s.kind.cluster"
+const clusterLabelKey = constants.ClusterLabelKey
 
 // nodeRoleLabelKey is applied to each "node" docker container for categorization
 // of nodes by role
-const nodeRoleLabelKey = "io.x-k8s.kind.role"
+const nodeRoleLabelKey = constants.NodeRoleLabelKey
@@ -31,6 +31,8 @@ import (
 
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 )
 
 // This may be overridden by KIND_EXPERIMENTAL_DOCKER_NETWORK env,
@@ -46,8 +48,12 @@ import (
 // networks.
 const fixedNetworkName = "kind"
 
+// dockerSubnetFormat selects the space separated subnets of a network
+const dockerSubnetFormat = "{{range .IPAM.Config}}{{.Subnet}} {{end}}"
+
 // ensureNetwork checks if docker network by name exists, if not it creates it
-func ensureNetwork(name string) error {
+// with the node network requested in the config, if any
+func ensureNetwork(name string, nodeNetwork common.NodeNetwork) error {
 	// check if network exists already and remove any duplicate networks
 	exists, err := removeDuplicateNetworks(name)
 	if err != nil {
@@ -58,16 +64,30 @@ func ensureNetwork(name string) error {
 	// TODO: the network might already exist and not have ipv6 ... :|
 	// discussion: https://github.com/kubernetes-sigs/kind/pull/1508#discussion_r414594198
 	if exists {
-		return nil
+		return checkExistingNetwork(name, nodeNetwork)
+	}
+
+	// requested subnets are not probed, so fail early with a clear error
+	if err := nodeNetwork.CheckSubnetsAvailable("docker", dockerSubnetFormat); err != nil {
+		return err
+	}
+
+	// Use the requested MTU, or the MTU configured for the docker default network
+	mtu := nodeNetwork.MTU
+	if mtu == 0 {
+		mtu = getDefaultNetworkMTU()
+	}
+
+	// a requested IPv6 subnet is used as is
+	if nodeNetwork.IPv6Subnet != "" {
+		return createNetworkNoDuplicates(name, nodeNetwork, "", mtu)
 	}
 
 	// Generate unique subnet per network based on the name
 	// obtained from the ULA fc00::/8 range
-	// Use the MTU configured for the docker default network
 	// Make N attempts with "probing" in case we happen to collide
 	subnet := generateULASubnetFromName(name, 0)
-	mtu := getDefaultNetworkMTU()
-	err = createNetworkNoDuplicates(name, subnet, mtu)
+	err = createNetworkNoDuplicates(name, nodeNetwork, subnet, mtu)
 	if err == nil {
 		// Success!
 		return nil
@@ -79,7 +99,7 @@ func ensureNetwork(name string) error {
 	// If it is, make more attempts below
 	if isIPv6UnavailableError(err) {
 		// only one attempt, IPAM is automatic in ipv4 only
-		return createNetworkNoDuplicates(name, "", mtu)
+		return createNetworkNoDuplicates(name, nodeNetwork, "", mtu)
 	}
 	if isPoolOverlapError(err) {
 		// pool overlap suggests perhaps another process created the network
@@ -101,7 +121,7 @@ func ensureNetwork(name string) error {
 	const maxAttempts = 5
 	for attempt := int32(1); attempt < maxAttempts; attempt++ {
 		subnet := generateULASubnetFromName(name, attempt)
-		err = createNetworkNoDuplicates(name, subnet, mtu)
+		err = createNetworkNoDuplicates(name, nodeNetwork, subnet, mtu)
 		if err == nil {
 			// success!
 			return nil
@@ -125,8 +145,20 @@ func ensureNetwork(name string) error {
 	return errors.New("exhausted attempts trying to find a non-overlapping subnet")
 }
 
-func createNetworkNoDuplicates(name, ipv6Subnet string, mtu int) error {
-	if err := createNetwork(name, ipv6Subnet, mtu); err != nil && !isNetworkAlreadyExistsError(err) {
+// checkExistingNetwork checks an existing network has the requested subnets
+func checkExistingNetwork(name string, nodeNetwork common.NodeNetwork) error {
+	if len(nodeNetwork.Subnets()) == 0 {
+		return nil
+	}
+	subnets, err := common.NetworkSubnets("docker", name, dockerSubnetFormat)
+	if err != nil {
+		return err
+	}
+	return nodeNetwork.CheckExistingNetwork(name, subnets)
+}
+
+func createNetworkNoDuplicates(name string, nodeNetwork common.NodeNetwork, ipv6Subnet string, mtu int) error {
+	if err := createNetwork(name, nodeNetwork, ipv6Subnet, mtu); err != nil && !isNetworkAlreadyExistsError(err) {
 		return err
 	}
 	_, err := removeDuplicateNetworks(name)
@@ -146,16 +178,15 @@ func removeDuplicateNetworks(name string) (bool, error) {
 	return len(networks) > 0, nil
 }
 
-func createNetwork(name, ipv6Subnet string, mtu int) error {
+func createNetwork(name string, nodeNetwork common.NodeNetwork, ipv6Subnet string, mtu int) error {
 	args := []string{"network", "create", "-d=bridge",
 		"-o", "com.docker.network.bridge.enable_ip_masquerade=true",
 	}
 	if mtu > 0 {
 		args = append(args, "-o", fmt.Sprintf("com.docker.network.driver.mtu=%d", mtu))
 	}
-	if ipv6Subnet != "" {
-		args = append(args, "--ipv6", "--subnet", ipv6Subnet)
-	}
+	args = append(args, nodeNetwork.IPv4Args()...)
+	args = append(args, nodeNetwork.IPv6Args(ipv6Subnet)...)
 	args = append(args, name)
 	return exec.Command("docker", args...).Run()
 }
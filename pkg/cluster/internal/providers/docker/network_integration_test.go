@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
 
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 	"sigs.k8s.io/kind/pkg/internal/integration"
 )
 
@@ -52,7 +53,7 @@ func TestIntegrationEnsureNetworkConcurrent(t *testing.T) {
 	errCh := make(chan error, networkConcurrency)
 	for i := 0; i < networkConcurrency; i++ {
 		go func() {
-			errCh <- ensureNetwork(testNetworkName)
+			errCh <- ensureNetwork(testNetworkName, common.NodeNetwork{})
 		}()
 	}
 	for i := 0; i < networkConcurrency; i++ {
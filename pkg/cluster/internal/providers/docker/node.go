@@ -24,6 +24,8 @@ import (
 
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 )
 
 // nodes.Node implementation for the docker provider
@@ -53,7 +55,7 @@ func (n *node) Role() (string, error) {
 func (n *node) IP() (ipv4 string, ipv6 string, err error) {
 	// retrieve the IP address of the node using docker inspect
 	cmd := exec.Command("docker", "inspect",
-		"-f", "{{range .NetworkSettings.Networks}}{{.IPAddress}},{{.GlobalIPv6Address}}{{end}}",
+		"-f", common.NodeIPFormat,
 		n.name, // ... against the "node" container
 	)
 	lines, err := exec.OutputLines(cmd)
@@ -20,6 +20,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
@@ -61,7 +62,25 @@ func (p *provider) String() string {
 }
 
 // Provision is part of the providers.Provider interface
-func (p *provider) Provision(status *cli.Status, cfg *config.Cluster) (err error) {
+func (p *provider) Provision(status *cli.Status, cfg *config.Cluster) error {
+	return p.provision(status, cfg, sets.NewString())
+}
+
+// ProvisionNodes is part of the providers.Provider interface
+func (p *provider) ProvisionNodes(status *cli.Status, cfg *config.Cluster) error {
+	n, err := p.ListNodes(cfg.Name)
+	if err != nil {
+		return err
+	}
+	existing := sets.NewString()
+	for _, node := range n {
+		existing.Insert(node.String())
+	}
+	return p.provision(status, cfg, existing)
+}
+
+// provision creates and starts the nodes in cfg, except for existing
+func (p *provider) provision(status *cli.Status, cfg *config.Cluster, existing sets.String) (err error) {
 	// TODO: validate cfg
 	// ensure node images are pulled before actually provisioning
 	if err := ensureNodeImages(p.logger, status, cfg); err != nil {
@@ -75,22 +94,61 @@ func (p *provider) Provision(status *cli.Status, cfg *config.Cluster) (err error
 		p.logger.Warn("WARNING: Here be dragons! This is not supported currently.")
 		networkName = n
 	}
-	if err := ensureNetwork(networkName); err != nil {
+	if err := ensureNetwork(networkName, common.NodeNetworkFor(cfg)); err != nil {
 		return errors.Wrap(err, "failed to ensure docker network")
 	}
+	for _, network := range common.ExtraNetworks(cfg, networkName) {
+		if err := ensureNetwork(network, common.NodeNetwork{}); err != nil {
+			return errors.Wrapf(err, "failed to ensure docker network %q", network)
+		}
+	}
+
+	// relay the host's proxy for the nodes, if they cannot authenticate to it
+	if cfg.ProxyHelper.Auth != "" {
+		if err := common.EnsureProxyHelper("docker", cfg, networkName); err != nil {
+			return errors.Wrap(err, "failed to ensure proxy helper")
+		}
+	}
+
+	// run the OIDC provider, this fills in the issuer the API server trusts
+	if cfg.OIDC.Provider != "" {
+		if err := common.EnsureOIDCProvider("docker", cfg, networkName); err != nil {
+			return errors.Wrap(err, "failed to ensure OIDC provider")
+		}
+	}
+
+	// run the DNS server, this fills in the address the nodes resolve with
+	if cfg.DNSServer.Enabled {
+		if err := common.EnsureDNSServer("docker", cfg, networkName, dockerSubnetFormat); err != nil {
+			return errors.Wrap(err, "failed to ensure DNS server")
+		}
+	}
+
+	// make sure the API server will be reachable from this machine
+	// the config hash is computed first, so it records the API server address
+	// as configured, which apply can reproduce, not the resolved remote address
+	clusterHash := common.ClusterConfigHash(cfg)
+	if r := getRemoteHost(); r != nil {
+		if err := configureForRemoteHost(p.logger, cfg, r); err != nil {
+			return err
+		}
+	}
 
 	// actually provision the cluster
-	icons := strings.Repeat("📦 ", len(cfg.Nodes))
+	icons := strings.Repeat("📦 ", common.NewNodeCount(cfg, existing))
 	status.Start(fmt.Sprintf("Preparing nodes %s", icons))
 	defer func() { status.End(err == nil) }()
 
 	// plan creating the containers
-	createContainerFuncs, err := planCreation(cfg, networkName)
+	staticIPFuncs, createContainerFuncs, err := planCreation(p.logger, status, cfg, clusterHash, networkName, existing)
 	if err != nil {
 		return err
 	}
 
-	// actually create nodes
+	// actually create nodes, starting with those with static addresses
+	if err := errors.UntilErrorConcurrent(staticIPFuncs); err != nil {
+		return err
+	}
 	return errors.UntilErrorConcurrent(createContainerFuncs)
 }
 
@@ -133,6 +191,134 @@ func (p *provider) ListNodes(cluster string) ([]nodes.Node, error) {
 	return ret, nil
 }
 
+// GetClusterLabels is part of the providers.Provider interface
+func (p *provider) GetClusterLabels(cluster string) (map[string]string, error) {
+	n, err := p.ListNodes(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("no nodes found for cluster %q", cluster)
+	}
+	containerLabels, err := p.GetNodeLabels(n[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster labels")
+	}
+	return common.ClusterLabels(containerLabels), nil
+}
+
+// GetNodeLabels is part of the providers.Provider interface
+func (p *provider) GetNodeLabels(node nodes.Node) (map[string]string, error) {
+	cmd := exec.Command("docker", "inspect", "--format", "{{json .Config.Labels}}", node.String())
+	out, err := exec.Output(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get labels for node %s", node.String())
+	}
+	containerLabels := map[string]string{}
+	if err := json.Unmarshal(out, &containerLabels); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse labels for node %s", node.String())
+	}
+	return containerLabels, nil
+}
+
+// PullImageDigest is part of the providers.Provider interface
+func (p *provider) PullImageDigest(image string) (string, error) {
+	_, pullImage := sanitizeImage(image)
+	if err := pull(p.logger, pullImage, 4, 0); err != nil {
+		return "", err
+	}
+	return common.ImageDigest("docker", pullImage)
+}
+
+// CreateScratchNode is part of the providers.Provider interface
+func (p *provider) CreateScratchNode(cluster, image string) (nodes.Node, error) {
+	n, err := p.ListNodes(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("no nodes found for cluster %q", cluster)
+	}
+	networks, err := exec.OutputLines(exec.Command("docker", "inspect", "--format", common.NetworksFormat, n[0].String()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster network")
+	}
+	if len(networks) == 0 || networks[0] == "" {
+		return nil, errors.Errorf("no network found for cluster %q", cluster)
+	}
+	name, err := common.CreateScratchNode("docker", cluster, networks[0], image)
+	if err != nil {
+		return nil, err
+	}
+	return p.node(name), nil
+}
+
+// DeleteScratchNode is part of the providers.Provider interface
+func (p *provider) DeleteScratchNode(cluster string) error {
+	return common.DeleteScratchNode("docker", cluster)
+}
+
+// EnsureRegistry is part of the providers.Provider interface
+func (p *provider) EnsureRegistry(name string, port int32) error {
+	network, err := ensureSharedNetwork()
+	if err != nil {
+		return err
+	}
+	return common.EnsureRegistry("docker", name, network, port)
+}
+
+// DeleteRegistry is part of the providers.Provider interface
+func (p *provider) DeleteRegistry(name string) error {
+	return common.DeleteRegistry("docker", name)
+}
+
+// EnsureCaches is part of the providers.Provider interface
+func (p *provider) EnsureCaches(registries []string) error {
+	network, err := ensureSharedNetwork()
+	if err != nil {
+		return err
+	}
+	return common.EnsureCaches("docker", network, registries)
+}
+
+// ListCaches is part of the providers.Provider interface
+func (p *provider) ListCaches() (map[string]string, error) {
+	return common.ListCaches("docker")
+}
+
+// DeleteCaches is part of the providers.Provider interface
+func (p *provider) DeleteCaches() error {
+	return common.DeleteCaches("docker")
+}
+
+// DeleteProxyHelper is part of the providers.Provider interface
+func (p *provider) DeleteProxyHelper(cluster string) error {
+	return common.DeleteProxyHelper("docker", cluster)
+}
+
+// DeleteOIDCProvider is part of the providers.Provider interface
+func (p *provider) DeleteOIDCProvider(cluster string) error {
+	return common.DeleteOIDCProvider("docker", cluster)
+}
+
+// DeleteDNSServer is part of the providers.Provider interface
+func (p *provider) DeleteDNSServer(cluster string) error {
+	return common.DeleteDNSServer("docker", cluster)
+}
+
+// ensureSharedNetwork ensures the network shared by all clusters exists,
+// for containers such as the local registry that serve every cluster
+func ensureSharedNetwork() (string, error) {
+	network := fixedNetworkName
+	if n := os.Getenv("KIND_EXPERIMENTAL_DOCKER_NETWORK"); n != "" {
+		network = n
+	}
+	if err := ensureNetwork(network, common.NodeNetwork{}); err != nil {
+		return "", errors.Wrap(err, "failed to ensure docker network")
+	}
+	return network, nil
+}
+
 // DeleteNodes is part of the providers.Provider interface
 func (p *provider) DeleteNodes(n []nodes.Node) error {
 	if len(n) == 0 {
@@ -148,12 +334,78 @@ func (p *provider) DeleteNodes(n []nodes.Node) error {
 	for _, node := range n {
 		args = append(args, node.String())
 	}
+	// note the clusters before deleting, for cleaning up ssh tunnels after
+	r := getRemoteHost()
+	var clusters []string
+	if useSSHTunnel(r) {
+		clusters = p.nodeClusters(n)
+	}
 	if err := exec.Command(command, args...).Run(); err != nil {
 		return errors.Wrap(err, "failed to delete nodes")
 	}
+	for _, cluster := range clusters {
+		if remaining, err := p.ListNodes(cluster); err == nil && len(remaining) == 0 {
+			closeSSHTunnel(cluster, r)
+		}
+	}
 	return nil
 }
 
+// nodeClusters returns the names of the clusters the nodes belong to
+func (p *provider) nodeClusters(n []nodes.Node) []string {
+	clusters := sets.NewString()
+	for _, node := range n {
+		labels, err := p.GetNodeLabels(node)
+		if err != nil {
+			continue
+		}
+		if cluster := labels[clusterLabelKey]; cluster != "" {
+			clusters.Insert(cluster)
+		}
+	}
+	return clusters.List()
+}
+
+// StopNodes is part of the providers.Provider interface
+func (p *provider) StopNodes(n []nodes.Node) error {
+	return common.StopNodes("docker", n)
+}
+
+// StartNodes is part of the providers.Provider interface
+func (p *provider) StartNodes(n []nodes.Node) error {
+	return common.StartNodes("docker", n)
+}
+
+// CommitNode is part of the providers.Provider interface
+func (p *provider) CommitNode(node nodes.Node, image string) error {
+	return common.CommitNode("docker", node, image)
+}
+
+// DeleteSnapshotImages is part of the providers.Provider interface
+func (p *provider) DeleteSnapshotImages(n []nodes.Node) error {
+	return common.DeleteSnapshotImages("docker", n)
+}
+
+// SaveImages is part of the providers.Provider interface
+func (p *provider) SaveImages(w io.Writer, images ...string) error {
+	return common.SaveImages("docker", w, images...)
+}
+
+// LoadImages is part of the providers.Provider interface
+func (p *provider) LoadImages(r io.Reader) error {
+	return common.LoadImages("docker", r)
+}
+
+// CopyFromNode is part of the providers.Provider interface
+func (p *provider) CopyFromNode(node nodes.Node, path string, w io.Writer) error {
+	return common.CopyFromNode("docker", node, path, w)
+}
+
+// CopyToNode is part of the providers.Provider interface
+func (p *provider) CopyToNode(node nodes.Node, path string, r io.Reader) error {
+	return common.CopyToNode("docker", node, path, r)
+}
+
 // GetAPIServerEndpoint is part of the providers.Provider interface
 func (p *provider) GetAPIServerEndpoint(cluster string) (string, error) {
 	// locate the node that hosts this
@@ -208,6 +460,15 @@ func (p *provider) GetAPIServerEndpoint(cluster string) (string, error) {
 		return "", errors.Errorf("network details should only be two parts, got %d", len(parts))
 	}
 
+	// the API server of a cluster on a remote docker host may be reached
+	// through an ssh tunnel on the same local port
+	if r := getRemoteHost(); useSSHTunnel(r) {
+		if err := ensureSSHTunnel(cluster, r, parts[1]); err != nil {
+			return "", err
+		}
+		return net.JoinHostPort("127.0.0.1", parts[1]), nil
+	}
+
 	// join host and port
 	return net.JoinHostPort(parts[0], parts[1]), nil
 }
@@ -302,6 +563,8 @@ type dockerInfo struct {
 	PidsLimit       bool     `json:"PidsLimit"`
 	CPUShares       bool     `json:"CPUShares"`
 	SecurityOptions []string `json:"SecurityOptions"`
+	NCPU            int      `json:"NCPU"`
+	MemTotal        int64    `json:"MemTotal"`
 }
 
 func info() (*providers.ProviderInfo, error) {
@@ -315,7 +578,9 @@ func info() (*providers.ProviderInfo, error) {
 		return nil, err
 	}
 	info := providers.ProviderInfo{
-		Cgroup2: dInfo.CgroupVersion == "2",
+		Cgroup2:  dInfo.CgroupVersion == "2",
+		NCPU:     dInfo.NCPU,
+		MemTotal: dInfo.MemTotal,
 	}
 	// When CgroupDriver == "none", the MemoryLimit/PidsLimit/CPUShares
 	// values are meaningless and need to be considered false.
@@ -342,3 +607,23 @@ func info() (*providers.ProviderInfo, error) {
 	}
 	return &info, nil
 }
+
+// NodeStats is part of the providers.Provider interface
+func (p *provider) NodeStats(n []nodes.Node) ([]providers.NodeStats, error) {
+	return common.CollectNodeStats("docker", n)
+}
+
+// PublishedPorts is part of the providers.Provider interface
+func (p *provider) PublishedPorts(n []nodes.Node) ([]providers.PublishedPort, error) {
+	return common.CollectPublishedPorts("docker", n)
+}
+
+// MissingNetwork is part of the providers.Provider interface
+func (p *provider) MissingNetwork(n []nodes.Node) (string, error) {
+	return common.MissingNetwork("docker", n)
+}
+
+// RepairNetwork is part of the providers.Provider interface
+func (p *provider) RepairNetwork(n []nodes.Node) (string, error) {
+	return common.RepairNetwork("docker", n, ensureNetwork)
+}
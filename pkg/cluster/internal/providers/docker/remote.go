@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+)
+
+// sshTunnelEnvVar opts in to reaching the API server of clusters on a remote
+// ssh:// docker host through an ssh tunnel managed by kind
+const sshTunnelEnvVar = "KIND_EXPERIMENTAL_DOCKER_SSH_TUNNEL"
+
+// remoteHost is a docker daemon on another machine
+type remoteHost struct {
+	// Scheme is tcp or ssh
+	Scheme string
+	// Destination is the ssh destination, [user@]host
+	Destination string
+	// Host is the hostname or address of the machine
+	Host string
+	// Port is the ssh port, if any
+	Port string
+}
+
+// getRemoteHost returns the remote docker host in use, or nil if the daemon is local
+func getRemoteHost() *remoteHost {
+	return parseRemoteHost(dockerHost())
+}
+
+// dockerHost returns the docker daemon endpoint from DOCKER_HOST or the
+// current docker context
+func dockerHost() string {
+	if h := os.Getenv("DOCKER_HOST"); h != "" {
+		return h
+	}
+	lines, err := exec.OutputLines(exec.Command(
+		"docker", "context", "inspect", "--format", "{{.Endpoints.docker.Host}}",
+	))
+	if err != nil || len(lines) != 1 {
+		return ""
+	}
+	return lines[0]
+}
+
+// parseRemoteHost parses a docker endpoint such as tcp://10.0.0.5:2376 or
+// ssh://user@host, returning nil for local endpoints
+func parseRemoteHost(endpoint string) *remoteHost {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil
+	}
+	if u.Scheme != "tcp" && u.Scheme != "ssh" {
+		return nil
+	}
+	host := u.Hostname()
+	if host == "" || host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	r := &remoteHost{
+		Scheme:      u.Scheme,
+		Destination: host,
+		Host:        host,
+	}
+	if u.Scheme == "ssh" {
+		if u.User != nil {
+			r.Destination = u.User.Username() + "@" + host
+		}
+		r.Port = u.Port()
+	}
+	return r
+}
+
+// useSSHTunnel returns true if the API server of clusters on r should be
+// reached through an ssh tunnel
+func useSSHTunnel(r *remoteHost) bool {
+	return r != nil && r.Scheme == "ssh" && os.Getenv(sshTunnelEnvVar) == "true"
+}
+
+// configureForRemoteHost binds the API server to the remote host's address
+// instead of its loopback address, so the kubeconfig is reachable from here,
+// unless an ssh tunnel will be used
+func configureForRemoteHost(logger log.Logger, cfg *config.Cluster, r *remoteHost) error {
+	if os.Getenv(sshTunnelEnvVar) == "true" && r.Scheme != "ssh" {
+		logger.Warnf("WARNING: %s requires an ssh:// docker host, ignoring it", sshTunnelEnvVar)
+	}
+	if useSSHTunnel(r) {
+		return nil
+	}
+	if ip := net.ParseIP(cfg.Networking.APIServerAddress); ip == nil || !ip.IsLoopback() {
+		// the user chose a reachable address already
+		return nil
+	}
+	address, err := resolveRemoteAddress(r.Host, cfg.Networking.IPFamily)
+	if err != nil {
+		return errors.Wrapf(err, "failed to resolve remote docker host %q, set networking.apiServerAddress", r.Host)
+	}
+	logger.Warnf(
+		"WARNING: docker host %q is remote, the API server will be published on %s. Set %s=true to use an ssh tunnel instead.",
+		r.Host, address, sshTunnelEnvVar,
+	)
+	cfg.Networking.APIServerAddress = address
+	return nil
+}
+
+// resolveRemoteAddress returns an address of host in the cluster's IP family
+func resolveRemoteAddress(host string, family config.ClusterIPFamily) (string, error) {
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return "", err
+	}
+	for _, ip := range ips {
+		if (ip.To4() == nil) == (family == config.IPv6Family) {
+			return ip.String(), nil
+		}
+	}
+	return "", errors.Errorf("no %s address found", family)
+}
+
+// tunnelSocket returns the path of the ssh control socket for cluster's tunnel
+func tunnelSocket(cluster string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kind", "tunnels", cluster+".sock"), nil
+}
+
+// sshArgs returns the common ssh args for reaching r using socket
+func sshArgs(r *remoteHost, socket string) []string {
+	args := []string{"-S", socket}
+	if r.Port != "" {
+		args = append(args, "-p", r.Port)
+	}
+	return args
+}
+
+// ensureSSHTunnel forwards the loopback port on this machine to the same
+// port on the remote host, if not already forwarded
+func ensureSSHTunnel(cluster string, r *remoteHost, port string) error {
+	socket, err := tunnelSocket(cluster)
+	if err != nil {
+		return err
+	}
+	// an existing tunnel answers on its control socket
+	check := append(sshArgs(r, socket), "-O", "check", r.Destination)
+	if exec.Command("ssh", check...).Run() == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(socket), 0700); err != nil {
+		return err
+	}
+	forward := fmt.Sprintf("127.0.0.1:%s:127.0.0.1:%s", port, port)
+	args := append(sshArgs(r, socket),
+		"-f", "-N", "-M",
+		"-o", "ExitOnForwardFailure=yes",
+		"-L", forward,
+		r.Destination,
+	)
+	// NOTE: ssh -f keeps running in the background holding any output pipes
+	// open, so this must not capture output like sigs.k8s.io/kind/pkg/exec
+	if err := osexec.Command("ssh", args...).Run(); err != nil {
+		return errors.Wrapf(err, "failed to open ssh tunnel to %s", r.Destination)
+	}
+	return nil
+}
+
+// closeSSHTunnel stops cluster's tunnel, if any
+func closeSSHTunnel(cluster string, r *remoteHost) {
+	socket, err := tunnelSocket(cluster)
+	if err != nil {
+		return
+	}
+	if _, err := os.Stat(socket); err != nil {
+		return
+	}
+	exit := append(sshArgs(r, socket), "-O", "exit", r.Destination)
+	_ = exec.Command("ssh", exit...).Run()
+	_ = os.Remove(socket)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package docker
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+	"sigs.k8s.io/kind/pkg/log"
+)
+
+func TestParseRemoteHost(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name     string
+		Endpoint string
+		Expected *remoteHost
+	}{
+		{Name: "unset", Endpoint: "", Expected: nil},
+		{Name: "unix socket", Endpoint: "unix:///var/run/docker.sock", Expected: nil},
+		{Name: "loopback tcp", Endpoint: "tcp://127.0.0.1:2375", Expected: nil},
+		{Name: "localhost tcp", Endpoint: "tcp://localhost:2375", Expected: nil},
+		{
+			Name:     "remote tcp",
+			Endpoint: "tcp://10.0.0.5:2376",
+			Expected: &remoteHost{Scheme: "tcp", Destination: "10.0.0.5", Host: "10.0.0.5"},
+		},
+		{
+			Name:     "remote ssh",
+			Endpoint: "ssh://builder@docker.lab:2222",
+			Expected: &remoteHost{Scheme: "ssh", Destination: "builder@docker.lab", Host: "docker.lab", Port: "2222"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, tc.Expected, parseRemoteHost(tc.Endpoint))
+		})
+	}
+}
+
+func TestConfigureForRemoteHost(t *testing.T) {
+	t.Parallel()
+	r := &remoteHost{Scheme: "tcp", Destination: "10.0.0.5", Host: "10.0.0.5"}
+
+	cfg := &config.Cluster{}
+	cfg.Networking.IPFamily = config.IPv4Family
+	cfg.Networking.APIServerAddress = "127.0.0.1"
+	if err := configureForRemoteHost(log.NoopLogger{}, cfg, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.StringEqual(t, "10.0.0.5", cfg.Networking.APIServerAddress)
+
+	// an explicit reachable address is kept
+	cfg.Networking.APIServerAddress = "0.0.0.0"
+	if err := configureForRemoteHost(log.NoopLogger{}, cfg, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.StringEqual(t, "0.0.0.0", cfg.Networking.APIServerAddress)
+
+	// the remote host has no address in the cluster's family
+	cfg.Networking.IPFamily = config.IPv6Family
+	cfg.Networking.APIServerAddress = "::1"
+	if err := configureForRemoteHost(log.NoopLogger{}, cfg, r); err == nil {
+		t.Errorf("expected an error resolving an IPv6 address")
+	}
+}
+
+func TestRemoteHostConfigHash(t *testing.T) {
+	t.Parallel()
+	r := &remoteHost{Scheme: "tcp", Destination: "10.0.0.5", Host: "10.0.0.5"}
+	newConfig := func() *config.Cluster {
+		cfg := &config.Cluster{Name: "foo"}
+		config.SetDefaultsCluster(cfg)
+		return cfg
+	}
+
+	// provision computes the hash before binding the API server to the
+	// remote host, as apply computes it from the config
+	cfg := newConfig()
+	clusterHash := common.ClusterConfigHash(cfg)
+	if err := configureForRemoteHost(log.NoopLogger{}, cfg, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.BoolEqual(t, false, clusterHash == common.ClusterConfigHash(cfg))
+	expected := common.ClusterConfigHashLabelKey + "=" + common.ClusterConfigHash(newConfig())
+	assert.StringEqual(t, expected, common.MetadataArgsWithHash(cfg, clusterHash)[1])
+}
@@ -33,6 +33,11 @@ func IsAvailable() bool {
 	return strings.HasPrefix(lines[0], "Docker version")
 }
 
+// IsHealthy checks if the docker cli can reach the docker daemon
+func IsHealthy() bool {
+	return exec.Command("docker", "info", "--format", "{{.ServerVersion}}").Run() == nil
+}
+
 // usernsRemap checks if userns-remap is enabled in dockerd
 func usernsRemap() bool {
 	cmd := exec.Command("docker", "info", "--format", "'{{json .SecurityOptions}}'")
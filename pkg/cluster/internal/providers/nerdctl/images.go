@@ -34,11 +34,12 @@ import (
 // configuration are present
 func ensureNodeImages(logger log.Logger, status *cli.Status, cfg *config.Cluster, binaryName string) error {
 	// pull each required image
+	timeout := config.TimeoutDuration(cfg.Timeouts.ImagePull)
 	for _, image := range common.RequiredNodeImages(cfg).List() {
 		// prints user friendly message
 		friendlyImageName, image := sanitizeImage(image)
 		status.Start(fmt.Sprintf("Ensuring node image (%s) 🖼", friendlyImageName))
-		if _, err := pullIfNotPresent(logger, image, 4, binaryName); err != nil {
+		if _, err := pullIfNotPresent(logger, image, 4, timeout, binaryName); err != nil {
 			status.End(false)
 			return err
 		}
@@ -47,9 +48,9 @@ func ensureNodeImages(logger log.Logger, status *cli.Status, cfg *config.Cluster
 }
 
 // pullIfNotPresent will pull an image if it is not present locally
-// retrying up to retries times
+// retrying up to retries times, for at most timeout when it is not zero
 // it returns true if it attempted to pull, and any errors from pulling
-func pullIfNotPresent(logger log.Logger, image string, retries int, binaryName string) (pulled bool, err error) {
+func pullIfNotPresent(logger log.Logger, image string, retries int, timeout time.Duration, binaryName string) (pulled bool, err error) {
 	// TODO(bentheelder): switch most (all) of the logging here to debug level
 	// once we have configurable log levels
 	// if this did not return an error, then the image exists locally
@@ -59,25 +60,34 @@ func pullIfNotPresent(logger log.Logger, image string, retries int, binaryName s
 		return false, nil
 	}
 	// otherwise try to pull it
-	return true, pull(logger, image, retries, binaryName)
+	return true, pull(logger, image, retries, timeout, binaryName)
 }
 
 // pull pulls an image, retrying up to retries times
-func pull(logger log.Logger, image string, retries int, binaryName string) error {
+// for at most timeout when it is not zero
+func pull(logger log.Logger, image string, retries int, timeout time.Duration, binaryName string) error {
+	ctx, cancel := common.TimeoutContext(timeout)
+	defer cancel()
 	logger.V(1).Infof("Pulling image: %s ...", image)
-	err := exec.Command(binaryName, "pull", image).Run()
+	err := exec.CommandContext(ctx, binaryName, "pull", image).Run()
 	// retry pulling up to retries times if necessary
 	if err != nil {
-		for i := 0; i < retries; i++ {
+		for i := 0; i < retries && !common.TimedOut(ctx); i++ {
 			time.Sleep(time.Second * time.Duration(i+1))
 			logger.V(1).Infof("Trying again to pull image: %q ... %v", image, err)
 			// TODO(bentheelder): add some backoff / sleep?
-			err = exec.Command(binaryName, "pull", image).Run()
+			err = exec.CommandContext(ctx, binaryName, "pull", image).Run()
 			if err == nil {
 				break
 			}
 		}
 	}
+	if common.TimedOut(ctx) {
+		return errors.WithDetails(
+			errors.Wrapf(err, "timed out after %s pulling image %q", timeout, image),
+			errors.Details{Category: errors.TimeoutCategory},
+		)
+	}
 	return errors.Wrapf(err, "failed to pull image %q", image)
 }
 
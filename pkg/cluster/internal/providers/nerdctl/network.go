@@ -26,6 +26,8 @@ import (
 
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 )
 
 // This may be overridden by KIND_EXPERIMENTAL_DOCKER_NETWORK env,
@@ -41,8 +43,12 @@ import (
 // networks.
 const fixedNetworkName = "kind"
 
+// nerdctlSubnetFormat selects the space separated subnets of a network
+const nerdctlSubnetFormat = "{{range .IPAM.Config}}{{.Subnet}} {{end}}"
+
 // ensureNetwork checks if docker network by name exists, if not it creates it
-func ensureNetwork(name, binaryName string) error {
+// with the node network requested in the config, if any
+func ensureNetwork(name, binaryName string, nodeNetwork common.NodeNetwork) error {
 	// check if network exists already and remove any duplicate networks
 	exists, err := checkIfNetworkExists(name, binaryName)
 	if err != nil {
@@ -53,12 +59,26 @@ func ensureNetwork(name, binaryName string) error {
 	// TODO: the network might already exist and not have ipv6 ... :|
 	// discussion: https://github.com/kubernetes-sigs/kind/pull/1508#discussion_r414594198
 	if exists {
-		return nil
+		return checkExistingNetwork(name, binaryName, nodeNetwork)
+	}
+
+	// requested subnets are not probed, so fail early with a clear error
+	if err := nodeNetwork.CheckSubnetsAvailable(binaryName, nerdctlSubnetFormat); err != nil {
+		return err
+	}
+
+	mtu := nodeNetwork.MTU
+	if mtu == 0 {
+		mtu = getDefaultNetworkMTU(binaryName)
+	}
+
+	// a requested IPv6 subnet is used as is
+	if nodeNetwork.IPv6Subnet != "" {
+		return createNetwork(name, nodeNetwork, "", mtu, binaryName)
 	}
 
 	subnet := generateULASubnetFromName(name, 0)
-	mtu := getDefaultNetworkMTU(binaryName)
-	err = createNetwork(name, subnet, mtu, binaryName)
+	err = createNetwork(name, nodeNetwork, subnet, mtu, binaryName)
 	if err == nil {
 		// Success!
 		return nil
@@ -70,7 +90,7 @@ func ensureNetwork(name, binaryName string) error {
 	// If it is, make more attempts below
 	if isIPv6UnavailableError(err) {
 		// only one attempt, IPAM is automatic in ipv4 only
-		return createNetwork(name, "", mtu, binaryName)
+		return createNetwork(name, nodeNetwork, "", mtu, binaryName)
 	}
 	if isPoolOverlapError(err) {
 		// pool overlap suggests perhaps another process created the network
@@ -92,7 +112,7 @@ func ensureNetwork(name, binaryName string) error {
 	const maxAttempts = 5
 	for attempt := int32(1); attempt < maxAttempts; attempt++ {
 		subnet := generateULASubnetFromName(name, attempt)
-		err = createNetwork(name, subnet, mtu, binaryName)
+		err = createNetwork(name, nodeNetwork, subnet, mtu, binaryName)
 		if err == nil {
 			// success!
 			return nil
@@ -116,16 +136,27 @@ func ensureNetwork(name, binaryName string) error {
 	return errors.New("exhausted attempts trying to find a non-overlapping subnet")
 }
 
-func createNetwork(name, ipv6Subnet string, mtu int, binaryName string) error {
+// checkExistingNetwork checks an existing network has the requested subnets
+func checkExistingNetwork(name, binaryName string, nodeNetwork common.NodeNetwork) error {
+	if len(nodeNetwork.Subnets()) == 0 {
+		return nil
+	}
+	subnets, err := common.NetworkSubnets(binaryName, name, nerdctlSubnetFormat)
+	if err != nil {
+		return err
+	}
+	return nodeNetwork.CheckExistingNetwork(name, subnets)
+}
+
+func createNetwork(name string, nodeNetwork common.NodeNetwork, ipv6Subnet string, mtu int, binaryName string) error {
 	args := []string{"network", "create", "-d=bridge"}
 	// TODO: Not supported in nerdctl yet
 	//	"-o", "com.docker.network.bridge.enable_ip_masquerade=true",
 	if mtu > 0 {
 		args = append(args, "-o", fmt.Sprintf("com.docker.network.driver.mtu=%d", mtu))
 	}
-	if ipv6Subnet != "" {
-		args = append(args, "--ipv6", "--subnet", ipv6Subnet)
-	}
+	args = append(args, nodeNetwork.IPv4Args()...)
+	args = append(args, nodeNetwork.IPv6Args(ipv6Subnet)...)
 	args = append(args, name)
 	return exec.Command(binaryName, args...).Run()
 }
@@ -54,15 +54,17 @@ func (n *node) Role() (string, error) {
 func (n *node) IP() (ipv4 string, ipv6 string, err error) {
 	// retrieve the IP address of the node using docker inspect
 	cmd := exec.Command(n.binaryName, "inspect",
-		"-f", "{{range .NetworkSettings.Networks}}{{.IPAddress}},{{.GlobalIPv6Address}}{{end}}",
+		// one line per network, nerdctl names them by interface so the first
+		// is eth0 on the primary network
+		"-f", `{{range .NetworkSettings.Networks}}{{.IPAddress}},{{.GlobalIPv6Address}}{{"\n"}}{{end}}`,
 		n.name, // ... against the "node" container
 	)
 	lines, err := exec.OutputLines(cmd)
 	if err != nil {
 		return "", "", errors.Wrap(err, "failed to get container details")
 	}
-	if len(lines) != 1 {
-		return "", "", errors.Errorf("file should only be one line, got %d lines", len(lines))
+	if len(lines) < 1 {
+		return "", "", errors.New("container has no network addresses")
 	}
 	ips := strings.Split(lines[0], ",")
 	if len(ips) != 2 {
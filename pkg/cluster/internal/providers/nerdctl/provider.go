@@ -20,6 +20,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	osexec "os/exec"
 	"path/filepath"
@@ -48,13 +49,11 @@ func NewProvider(logger log.Logger, binaryName string) providers.Provider {
 	// install that triggered IsAvailable() to be true would fail
 	// to be used if we default to nerdctl when unset.
 	if binaryName == "" {
-		// default to "nerdctl"; but look for "finch" if
-		// nerctl binary lookup fails
-		binaryName = "nerdctl"
-		if _, err := osexec.LookPath("nerdctl"); err != nil {
-			if _, err := osexec.LookPath("finch"); err == nil {
-				binaryName = "finch"
-			}
+		// default to "nerdctl"; but look for "finch" and then
+		// "nerdctl.lima" if nerctl binary lookup fails
+		binaryName = lookupBinary(osexec.LookPath)
+		if binaryName == "" {
+			binaryName = "nerdctl"
 		}
 	}
 	return &provider{
@@ -83,7 +82,25 @@ func (p *provider) Binary() string {
 }
 
 // Provision is part of the providers.Provider interface
-func (p *provider) Provision(status *cli.Status, cfg *config.Cluster) (err error) {
+func (p *provider) Provision(status *cli.Status, cfg *config.Cluster) error {
+	return p.provision(status, cfg, sets.NewString())
+}
+
+// ProvisionNodes is part of the providers.Provider interface
+func (p *provider) ProvisionNodes(status *cli.Status, cfg *config.Cluster) error {
+	n, err := p.ListNodes(cfg.Name)
+	if err != nil {
+		return err
+	}
+	existing := sets.NewString()
+	for _, node := range n {
+		existing.Insert(node.String())
+	}
+	return p.provision(status, cfg, existing)
+}
+
+// provision creates and starts the nodes in cfg, except for existing
+func (p *provider) provision(status *cli.Status, cfg *config.Cluster, existing sets.String) (err error) {
 	// TODO: validate cfg
 	// ensure node images are pulled before actually provisioning
 	if err := ensureNodeImages(p.logger, status, cfg, p.Binary()); err != nil {
@@ -91,25 +108,51 @@ func (p *provider) Provision(status *cli.Status, cfg *config.Cluster) (err error
 	}
 
 	// ensure the pre-requisite network exists
-	if err := ensureNetwork(fixedNetworkName, p.Binary()); err != nil {
+	if err := ensureNetwork(fixedNetworkName, p.Binary(), common.NodeNetworkFor(cfg)); err != nil {
 		return errors.Wrap(err, "failed to ensure nerdctl network")
 	}
+	for _, network := range common.ExtraNetworks(cfg, fixedNetworkName) {
+		if err := ensureNetwork(network, p.Binary(), common.NodeNetwork{}); err != nil {
+			return errors.Wrapf(err, "failed to ensure nerdctl network %q", network)
+		}
+	}
+
+	// relay the host's proxy for the nodes, if they cannot authenticate to it
+	if cfg.ProxyHelper.Auth != "" {
+		if err := common.EnsureProxyHelper(p.Binary(), cfg, fixedNetworkName); err != nil {
+			return errors.Wrap(err, "failed to ensure proxy helper")
+		}
+	}
+
+	// run the OIDC provider, this fills in the issuer the API server trusts
+	if cfg.OIDC.Provider != "" {
+		if err := common.EnsureOIDCProvider(p.Binary(), cfg, fixedNetworkName); err != nil {
+			return errors.Wrap(err, "failed to ensure OIDC provider")
+		}
+	}
+
+	// run the DNS server, this fills in the address the nodes resolve with
+	if cfg.DNSServer.Enabled {
+		if err := common.EnsureDNSServer(p.Binary(), cfg, fixedNetworkName, nerdctlSubnetFormat); err != nil {
+			return errors.Wrap(err, "failed to ensure DNS server")
+		}
+	}
 
 	// actually provision the cluster
-	icons := strings.Repeat("📦 ", len(cfg.Nodes))
+	icons := strings.Repeat("📦 ", common.NewNodeCount(cfg, existing))
 	status.Start(fmt.Sprintf("Preparing nodes %s", icons))
 	defer func() { status.End(err == nil) }()
 
 	// plan creating the containers
-	createContainerFuncs, err := planCreation(cfg, fixedNetworkName, p.Binary())
+	staticIPFuncs, createContainerFuncs, err := planCreation(p.logger, status, cfg, fixedNetworkName, p.Binary(), existing)
 	if err != nil {
 		return err
 	}
 
-	// actually create nodes
+	// actually create nodes, starting with those with static addresses
 	// TODO: remove once nerdctl handles concurrency better
 	// xref: https://github.com/containerd/nerdctl/issues/2908
-	for _, f := range createContainerFuncs {
+	for _, f := range append(staticIPFuncs, createContainerFuncs...) {
 		if err := f(); err != nil {
 			return err
 		}
@@ -159,6 +202,119 @@ func (p *provider) ListNodes(cluster string) ([]nodes.Node, error) {
 	return ret, nil
 }
 
+// GetClusterLabels is part of the providers.Provider interface
+func (p *provider) GetClusterLabels(cluster string) (map[string]string, error) {
+	n, err := p.ListNodes(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("no nodes found for cluster %q", cluster)
+	}
+	containerLabels, err := p.GetNodeLabels(n[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster labels")
+	}
+	return common.ClusterLabels(containerLabels), nil
+}
+
+// GetNodeLabels is part of the providers.Provider interface
+func (p *provider) GetNodeLabels(node nodes.Node) (map[string]string, error) {
+	cmd := exec.Command(p.Binary(), "inspect", "--format", "{{json .Config.Labels}}", node.String())
+	out, err := exec.Output(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get labels for node %s", node.String())
+	}
+	containerLabels := map[string]string{}
+	if err := json.Unmarshal(out, &containerLabels); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse labels for node %s", node.String())
+	}
+	return containerLabels, nil
+}
+
+// PullImageDigest is part of the providers.Provider interface
+func (p *provider) PullImageDigest(image string) (string, error) {
+	_, pullImage := sanitizeImage(image)
+	if err := pull(p.logger, pullImage, 4, 0, p.Binary()); err != nil {
+		return "", err
+	}
+	return common.ImageDigest(p.Binary(), pullImage)
+}
+
+// CreateScratchNode is part of the providers.Provider interface
+func (p *provider) CreateScratchNode(cluster, image string) (nodes.Node, error) {
+	n, err := p.ListNodes(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("no nodes found for cluster %q", cluster)
+	}
+	networks, err := exec.OutputLines(exec.Command(p.Binary(), "inspect", "--format", common.NetworksFormat, n[0].String()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster network")
+	}
+	if len(networks) == 0 || networks[0] == "" {
+		return nil, errors.Errorf("no network found for cluster %q", cluster)
+	}
+	name, err := common.CreateScratchNode(p.Binary(), cluster, networks[0], image)
+	if err != nil {
+		return nil, err
+	}
+	return p.node(name), nil
+}
+
+// DeleteScratchNode is part of the providers.Provider interface
+func (p *provider) DeleteScratchNode(cluster string) error {
+	return common.DeleteScratchNode(p.Binary(), cluster)
+}
+
+// EnsureRegistry is part of the providers.Provider interface
+func (p *provider) EnsureRegistry(name string, port int32) error {
+	if err := ensureNetwork(fixedNetworkName, p.Binary(), common.NodeNetwork{}); err != nil {
+		return errors.Wrap(err, "failed to ensure nerdctl network")
+	}
+	return common.EnsureRegistry(p.Binary(), name, fixedNetworkName, port)
+}
+
+// DeleteRegistry is part of the providers.Provider interface
+func (p *provider) DeleteRegistry(name string) error {
+	return common.DeleteRegistry(p.Binary(), name)
+}
+
+// EnsureCaches is part of the providers.Provider interface
+func (p *provider) EnsureCaches(registries []string) error {
+	if err := ensureNetwork(fixedNetworkName, p.Binary(), common.NodeNetwork{}); err != nil {
+		return errors.Wrap(err, "failed to ensure nerdctl network")
+	}
+	return common.EnsureCaches(p.Binary(), fixedNetworkName, registries)
+}
+
+// ListCaches is part of the providers.Provider interface
+func (p *provider) ListCaches() (map[string]string, error) {
+	return common.ListCaches(p.Binary())
+}
+
+// DeleteCaches is part of the providers.Provider interface
+func (p *provider) DeleteCaches() error {
+	return common.DeleteCaches(p.Binary())
+}
+
+// DeleteProxyHelper is part of the providers.Provider interface
+func (p *provider) DeleteProxyHelper(cluster string) error {
+	return common.DeleteProxyHelper(p.Binary(), cluster)
+}
+
+// DeleteOIDCProvider is part of the providers.Provider interface
+func (p *provider) DeleteOIDCProvider(cluster string) error {
+	return common.DeleteOIDCProvider(p.Binary(), cluster)
+}
+
+// DeleteDNSServer is part of the providers.Provider interface
+func (p *provider) DeleteDNSServer(cluster string) error {
+	return common.DeleteDNSServer(p.Binary(), cluster)
+}
+
 // DeleteNodes is part of the providers.Provider interface
 func (p *provider) DeleteNodes(n []nodes.Node) error {
 	if len(n) == 0 {
@@ -201,6 +357,46 @@ func (p *provider) DeleteNodes(n []nodes.Node) error {
 	return nil
 }
 
+// StopNodes is part of the providers.Provider interface
+func (p *provider) StopNodes(n []nodes.Node) error {
+	return common.StopNodes(p.Binary(), n)
+}
+
+// StartNodes is part of the providers.Provider interface
+func (p *provider) StartNodes(n []nodes.Node) error {
+	return common.StartNodes(p.Binary(), n)
+}
+
+// CommitNode is part of the providers.Provider interface
+func (p *provider) CommitNode(node nodes.Node, image string) error {
+	return common.CommitNode(p.Binary(), node, image)
+}
+
+// DeleteSnapshotImages is part of the providers.Provider interface
+func (p *provider) DeleteSnapshotImages(n []nodes.Node) error {
+	return common.DeleteSnapshotImages(p.Binary(), n)
+}
+
+// SaveImages is part of the providers.Provider interface
+func (p *provider) SaveImages(w io.Writer, images ...string) error {
+	return common.SaveImages(p.Binary(), w, images...)
+}
+
+// LoadImages is part of the providers.Provider interface
+func (p *provider) LoadImages(r io.Reader) error {
+	return common.LoadImages(p.Binary(), r)
+}
+
+// CopyFromNode is part of the providers.Provider interface
+func (p *provider) CopyFromNode(node nodes.Node, path string, w io.Writer) error {
+	return common.CopyFromNode(p.Binary(), node, path, w)
+}
+
+// CopyToNode is part of the providers.Provider interface
+func (p *provider) CopyToNode(node nodes.Node, path string, r io.Reader) error {
+	return common.CopyToNode(p.Binary(), node, path, r)
+}
+
 // GetAPIServerEndpoint is part of the providers.Provider interface
 func (p *provider) GetAPIServerEndpoint(cluster string) (string, error) {
 	// locate the node that hosts this
@@ -350,6 +546,8 @@ type dockerInfo struct {
 	PidsLimit       bool     `json:"PidsLimit"`
 	CPUShares       bool     `json:"CPUShares"`
 	SecurityOptions []string `json:"SecurityOptions"`
+	NCPU            int      `json:"NCPU"`
+	MemTotal        int64    `json:"MemTotal"`
 }
 
 func info(binaryName string) (*providers.ProviderInfo, error) {
@@ -363,7 +561,9 @@ func info(binaryName string) (*providers.ProviderInfo, error) {
 		return nil, err
 	}
 	info := providers.ProviderInfo{
-		Cgroup2: dInfo.CgroupVersion == "2",
+		Cgroup2:  dInfo.CgroupVersion == "2",
+		NCPU:     dInfo.NCPU,
+		MemTotal: dInfo.MemTotal,
 	}
 	// When CgroupDriver == "none", the MemoryLimit/PidsLimit/CPUShares
 	// values are meaningless and need to be considered false.
@@ -390,3 +590,25 @@ func info(binaryName string) (*providers.ProviderInfo, error) {
 	}
 	return &info, nil
 }
+
+// NodeStats is part of the providers.Provider interface
+func (p *provider) NodeStats(n []nodes.Node) ([]providers.NodeStats, error) {
+	return common.CollectNodeStats(p.Binary(), n)
+}
+
+// PublishedPorts is part of the providers.Provider interface
+func (p *provider) PublishedPorts(n []nodes.Node) ([]providers.PublishedPort, error) {
+	return common.CollectPublishedPorts(p.Binary(), n)
+}
+
+// MissingNetwork is part of the providers.Provider interface
+func (p *provider) MissingNetwork(n []nodes.Node) (string, error) {
+	return common.MissingNetwork(p.Binary(), n)
+}
+
+// RepairNetwork is part of the providers.Provider interface
+func (p *provider) RepairNetwork(n []nodes.Node) (string, error) {
+	return common.RepairNetwork(p.Binary(), n, func(name string, nodeNetwork common.NodeNetwork) error {
+		return ensureNetwork(name, p.Binary(), nodeNetwork)
+	})
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package nerdctl
 
 import (
-	"context"
 	"fmt"
 	"net"
 	"path/filepath"
@@ -28,14 +27,17 @@ import (
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
 	"sigs.k8s.io/kind/pkg/fs"
+	"sigs.k8s.io/kind/pkg/log"
 
 	"sigs.k8s.io/kind/pkg/cluster/internal/loadbalancer"
 	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/sets"
 )
 
 // planCreation creates a slice of funcs that will create the containers
-func planCreation(cfg *config.Cluster, networkName, binaryName string) (createContainerFuncs []func() error, err error) {
+func planCreation(logger log.Logger, status *cli.Status, cfg *config.Cluster, networkName, binaryName string, existing sets.String) (staticIPFuncs, createContainerFuncs []func() error, err error) {
 	// we need to know all the names for NO_PROXY
 	// compute the names first before any actual node details
 	nodeNamer := common.MakeNodeNamer(cfg.Name)
@@ -49,10 +51,49 @@ func planCreation(cfg *config.Cluster, networkName, binaryName string) (createCo
 		names = append(names, nodeNamer(constants.ExternalLoadBalancerNodeRoleValue))
 	}
 
+	// each node must boot within the node provisioning timeout
+	bootTimeout := config.TimeoutDuration(cfg.Timeouts.NodeProvision)
+
+	// plan creating the named container, unless it already exists
+	plan := func(name string, create func() error) {
+		if !existing.Has(name) {
+			createContainerFuncs = append(createContainerFuncs, status.WithPhase(name, create))
+		}
+	}
+	// nodes with static addresses are planned separately so they can be
+	// created before any dynamically addressed container takes their address
+	planNode := func(name string, node *config.Node, create func() error) {
+		if !common.HasStaticIP(node) {
+			plan(name, create)
+		} else if !existing.Has(name) {
+			staticIPFuncs = append(staticIPFuncs, status.WithPhase(name, create))
+		}
+	}
+
+	// static node addresses must fit the network, check before creating anything
+	if common.HasStaticIPs(cfg) {
+		subnets, err := common.NetworkSubnets(binaryName, networkName, nerdctlSubnetFormat)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := common.ValidateStaticIPs(cfg, networkName, subnets); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// these apply to all container creation
 	genericArgs, err := commonArgs(cfg.Name, cfg, networkName, names, binaryName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// record the exact node image digests for provenance
+	digests, err := common.NodeImageDigests(binaryName, cfg, func(image string) string {
+		_, pullImage := sanitizeImage(image)
+		return pullImage
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// only the external LB should reflect the port if we have multiple control planes
@@ -71,12 +112,33 @@ func planCreation(cfg *config.Cluster, networkName, binaryName string) (createCo
 		}
 		// plan loadbalancer node
 		name := names[len(names)-1]
-		createContainerFuncs = append(createContainerFuncs, func() error {
+		plan(name, func() error {
 			args, err := runArgsForLoadBalancer(cfg, name, genericArgs)
 			if err != nil {
 				return err
 			}
-			return createContainer(name, args, binaryName)
+			return createContainer(logger, name, args, binaryName)
+		})
+	}
+
+	// plan the API server socket relay, pointed at the loadbalancer if any
+	if cfg.Networking.APIServerSocket != "" {
+		target := names[len(names)-1]
+		if !haveLoadbalancer {
+			for i, node := range cfg.Nodes {
+				if node.Role == config.ControlPlaneRole {
+					target = names[i]
+					break
+				}
+			}
+		}
+		name := nodeNamer(constants.APIServerSocketRelayNodeRoleValue)
+		plan(name, func() error {
+			args, err := runArgsForAPIServerSocketRelay(cfg, name, target, genericArgs)
+			if err != nil {
+				return err
+			}
+			return createContainer(logger, name, args, binaryName)
 		})
 	}
 
@@ -84,6 +146,14 @@ func planCreation(cfg *config.Cluster, networkName, binaryName string) (createCo
 	for i, node := range cfg.Nodes {
 		node := node.DeepCopy() // copy so we can modify
 		name := names[i]
+		nodeArgs := append(common.ImageLabelArgs(node.Image, digests[node.Image]), common.NodeConfigArgs(&cfg.Nodes[i])...)
+		nodeArgs = append(nodeArgs, genericArgs...)
+		// nerdctl has no network connect, but attaches to every --net given
+		for _, network := range node.Networks {
+			if network != networkName {
+				nodeArgs = append(nodeArgs, "--net", network)
+			}
+		}
 
 		// fixup relative paths, docker can only handle absolute paths
 		for m := range node.ExtraMounts {
@@ -91,7 +161,7 @@ func planCreation(cfg *config.Cluster, networkName, binaryName string) (createCo
 			if !fs.IsAbs(hostPath) {
 				absHostPath, err := filepath.Abs(hostPath)
 				if err != nil {
-					return nil, errors.Wrapf(err, "unable to resolve absolute path for hostPath: %q", hostPath)
+					return nil, nil, errors.Wrapf(err, "unable to resolve absolute path for hostPath: %q", hostPath)
 				}
 				node.ExtraMounts[m].HostPath = absHostPath
 			}
@@ -100,7 +170,7 @@ func planCreation(cfg *config.Cluster, networkName, binaryName string) (createCo
 		// plan actual creation based on role
 		switch node.Role {
 		case config.ControlPlaneRole:
-			createContainerFuncs = append(createContainerFuncs, func() error {
+			planNode(name, node, func() error {
 				node.ExtraPortMappings = append(node.ExtraPortMappings,
 					config.PortMapping{
 						ListenAddress: apiServerAddress,
@@ -108,25 +178,31 @@ func planCreation(cfg *config.Cluster, networkName, binaryName string) (createCo
 						ContainerPort: common.APIServerInternalPort,
 					},
 				)
-				args, err := runArgsForNode(node, cfg.Networking.IPFamily, name, genericArgs)
+				args, err := runArgsForNode(node, cfg.Networking.IPFamily, name, nodeArgs)
 				if err != nil {
 					return err
 				}
-				return createContainerWithWaitUntilSystemdReachesMultiUserSystem(name, args, binaryName)
+				if err := createContainerWithWaitUntilSystemdReachesMultiUserSystem(logger, name, args, bootTimeout, binaryName); err != nil {
+					return err
+				}
+				return common.PostStart(binaryName, name, networkName, node)
 			})
 		case config.WorkerRole:
-			createContainerFuncs = append(createContainerFuncs, func() error {
-				args, err := runArgsForNode(node, cfg.Networking.IPFamily, name, genericArgs)
+			planNode(name, node, func() error {
+				args, err := runArgsForNode(node, cfg.Networking.IPFamily, name, nodeArgs)
 				if err != nil {
 					return err
 				}
-				return createContainerWithWaitUntilSystemdReachesMultiUserSystem(name, args, binaryName)
+				if err := createContainerWithWaitUntilSystemdReachesMultiUserSystem(logger, name, args, bootTimeout, binaryName); err != nil {
+					return err
+				}
+				return common.PostStart(binaryName, name, networkName, node)
 			})
 		default:
-			return nil, errors.Errorf("unknown node role: %q", node.Role)
+			return nil, nil, errors.Errorf("unknown node role: %q", node.Role)
 		}
 	}
-	return createContainerFuncs, nil
+	return staticIPFuncs, createContainerFuncs, nil
 }
 
 // commonArgs computes static arguments that apply to all containers
@@ -139,6 +215,8 @@ func commonArgs(cluster string, cfg *config.Cluster, networkName string, nodeNam
 		"--label", fmt.Sprintf("%s=%s", clusterLabelKey, cluster),
 		// user a user defined network so we get embedded DNS
 		"--net", networkName,
+		// record the primary network, nodes may be attached to extra networks
+		"--label", fmt.Sprintf("%s=%s", common.NodeNetworkLabelKey, networkName),
 		// containerd supports the following restart modes:
 		// - no
 		// - on-failure[:max-retries]
@@ -155,6 +233,19 @@ func commonArgs(cluster string, cfg *config.Cluster, networkName string, nodeNam
 		"--init=false",
 	}
 
+	// record the cluster labels and annotations
+	args = append(args, common.MetadataArgs(cfg)...)
+
+	// resolve with the DNS server of the cluster, if any
+	args = append(args, common.DNSServerArgs(cfg)...)
+
+	// record the network subnets, so the network can be recreated if deleted
+	subnetArgs, err := common.NetworkSubnetsLabelArgs(binaryName, networkName, nerdctlSubnetFormat)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, subnetArgs...)
+
 	// enable IPv6 if necessary
 	if config.ClusterHasIPv6(cfg) {
 		args = append(args, "--sysctl=net.ipv6.conf.all.disable_ipv6=0", "--sysctl=net.ipv6.conf.all.forwarding=1")
@@ -212,8 +303,15 @@ func runArgsForNode(node *config.Node, clusterIPFamily config.ClusterIPFamily, n
 		args...,
 	)
 
-	// convert mounts and port mappings to container run args
+	// convert mounts, host aliases and port mappings to container run args
 	args = append(args, generateMountBindings(node.ExtraMounts...)...)
+	args = append(args, common.HostAliasArgs(node.HostAliases...)...)
+	args = append(args, common.StaticIPArgs(node)...)
+	runtimeArgs, err := common.RuntimeOptionArgs("nerdctl", node, supportsUserNS)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, runtimeArgs...)
 	mappingArgs, err := generatePortMappings(clusterIPFamily, node.ExtraPortMappings...)
 	if err != nil {
 		return nil, err
@@ -229,6 +327,12 @@ func runArgsForNode(node *config.Node, clusterIPFamily config.ClusterIPFamily, n
 	return append(args, node.Image), nil
 }
 
+// supportsUserNS reports if nerdctl supports the user namespace mode
+// nerdctl does not support selecting user namespace modes
+func supportsUserNS(mode string) bool {
+	return false
+}
+
 func runArgsForLoadBalancer(cfg *config.Cluster, name string, args []string) ([]string, error) {
 	args = append([]string{
 		"--hostname", name, // make hostname match container name
@@ -239,20 +343,36 @@ func runArgsForLoadBalancer(cfg *config.Cluster, name string, args []string) ([]
 	)
 
 	// load balancer port mapping
-	mappingArgs, err := generatePortMappings(cfg.Networking.IPFamily,
-		config.PortMapping{
+	mappings := append([]config.PortMapping{
+		{
 			ListenAddress: cfg.Networking.APIServerAddress,
 			HostPort:      cfg.Networking.APIServerPort,
 			ContainerPort: common.APIServerInternalPort,
 		},
-	)
+	}, common.LoadBalancerPortMappings(cfg)...)
+	mappingArgs, err := generatePortMappings(cfg.Networking.IPFamily, mappings...)
 	if err != nil {
 		return nil, err
 	}
 	args = append(args, mappingArgs...)
 
 	// finally, specify the image to run
-	return append(args, loadbalancer.Image), nil
+	return append(args, loadbalancer.ImageFor(cfg)), nil
+}
+
+func runArgsForAPIServerSocketRelay(cfg *config.Cluster, name, target string, args []string) ([]string, error) {
+	relayArgs, err := common.APIServerSocketRelayArgs(cfg, cfg.Nodes[0].Image, target)
+	if err != nil {
+		return nil, err
+	}
+	args = append([]string{
+		"--hostname", name, // make hostname match container name
+		// label the node with the role ID
+		"--label", fmt.Sprintf("%s=%s", nodeRoleLabelKey, constants.APIServerSocketRelayNodeRoleValue),
+	},
+		args...,
+	)
+	return append(args, relayArgs...), nil
 }
 
 func getProxyEnv(cfg *config.Cluster, networkName string, nodeNames []string, binaryName string) (map[string]string, error) {
@@ -271,7 +391,7 @@ func getProxyEnv(cfg *config.Cluster, networkName string, nodeNames []string, bi
 		// https://github.com/kubernetes/dns/blob/master/docs/specification.md
 		// Any user created pod/service hostnames, namespaces, custom DNS services
 		// are expected to be no-proxied by the user explicitly.
-		noProxyList = append(noProxyList, ".svc", ".svc.cluster", ".svc.cluster.local")
+		noProxyList = append(noProxyList, ".svc", ".svc.cluster", ".svc."+cfg.Networking.ClusterDomain)
 		noProxyJoined := strings.Join(noProxyList, ",")
 		envs[common.NOProxy] = noProxyJoined
 		envs[strings.ToLower(common.NOProxy)] = noProxyJoined
@@ -372,17 +492,26 @@ func generatePortMappings(clusterIPFamily config.ClusterIPFamily, portMappings .
 	return args, nil
 }
 
-func createContainer(name string, args []string, binaryName string) error {
-	return exec.Command(binaryName, append([]string{"run", "--name", name}, args...)...).Run()
+// createContainer runs the container, streaming the runtime's stderr into
+// the verbose logs
+func createContainer(logger log.Logger, name string, args []string, binaryName string) error {
+	out := common.NewLineLogger(logger, name)
+	defer out.Close()
+	return exec.Command(binaryName, append([]string{"run", "--name", name}, args...)...).SetStderr(out).Run()
 }
 
-func createContainerWithWaitUntilSystemdReachesMultiUserSystem(name string, args []string, binaryName string) error {
-	if err := exec.Command(binaryName, append([]string{"run", "--name", name}, args...)...).Run(); err != nil {
+// createContainerWithWaitUntilSystemdReachesMultiUserSystem is like
+// createContainer, but additionally streams the node's early boot logs
+// until systemd is ready, waiting at most timeout when it is not zero
+func createContainerWithWaitUntilSystemdReachesMultiUserSystem(logger log.Logger, name string, args []string, timeout time.Duration, binaryName string) error {
+	if err := createContainer(logger, name, args, binaryName); err != nil {
 		return err
 	}
 
-	logCtx, logCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	logCtx, logCancel := common.TimeoutContext(timeout)
 	logCmd := exec.CommandContext(logCtx, binaryName, "logs", "-f", name)
 	defer logCancel()
-	return common.WaitUntilLogRegexpMatches(logCtx, logCmd, common.NodeReachedCgroupsReadyRegexp())
+	out := common.NewLineLogger(logger, name)
+	defer out.Close()
+	return common.WaitUntilLogRegexpMatches(logCtx, logCmd, common.NodeReachedCgroupsReadyRegexp(), out)
 }
@@ -17,25 +17,45 @@ limitations under the License.
 package nerdctl
 
 import (
+	osexec "os/exec"
 	"strings"
 
 	"sigs.k8s.io/kind/pkg/exec"
 )
 
-// IsAvailable checks if nerdctl (or finch) is available in the system
+// binaryNames are the nerdctl compatible CLIs in lookup order, finch and
+// lima's nerdctl.lima wrapper run nerdctl in a VM, e.g. on macOS
+var binaryNames = []string{"nerdctl", "finch", "nerdctl.lima"}
+
+// lookupBinary returns the first of binaryNames found by lookPath, or ""
+func lookupBinary(lookPath func(file string) (string, error)) string {
+	for _, name := range binaryNames {
+		if _, err := lookPath(name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// IsAvailable checks if nerdctl (or finch, or nerdctl.lima) is available in
+// the system, it checks the binary NewProvider uses, the first on the PATH
 func IsAvailable() bool {
-	cmd := exec.Command("nerdctl", "-v")
-	lines, err := exec.OutputLines(cmd)
+	name := lookupBinary(osexec.LookPath)
+	if name == "" {
+		return false
+	}
+	lines, err := exec.OutputLines(exec.Command(name, "-v"))
 	if err != nil || len(lines) != 1 {
-		// check finch
-		cmd = exec.Command("finch", "-v")
-		lines, err = exec.OutputLines(cmd)
-		if err != nil || len(lines) != 1 {
-			return false
-		}
-		return strings.HasPrefix(lines[0], "finch version")
+		return false
 	}
-	return strings.HasPrefix(lines[0], "nerdctl version")
+	return strings.HasPrefix(lines[0], "nerdctl version") || strings.HasPrefix(lines[0], "finch version")
+}
+
+// IsHealthy checks if nerdctl (or finch, or nerdctl.lima) can reach
+// containerd, it checks the binary NewProvider uses, the first on the PATH
+func IsHealthy() bool {
+	name := lookupBinary(osexec.LookPath)
+	return name != "" && exec.Command(name, "info").Run() == nil
 }
 
 // rootless: use fuse-overlayfs by default
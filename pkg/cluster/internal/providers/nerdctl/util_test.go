@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nerdctl
+
+import (
+	"os"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestLookupBinary(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name     string
+		OnPath   []string
+		Expected string
+	}{
+		{Name: "none", Expected: ""},
+		{Name: "nerdctl", OnPath: []string{"nerdctl"}, Expected: "nerdctl"},
+		{Name: "finch", OnPath: []string{"finch"}, Expected: "finch"},
+		{Name: "lima", OnPath: []string{"nerdctl.lima"}, Expected: "nerdctl.lima"},
+		{Name: "nerdctl before lima", OnPath: []string{"nerdctl.lima", "nerdctl"}, Expected: "nerdctl"},
+		{Name: "finch before lima", OnPath: []string{"nerdctl.lima", "finch"}, Expected: "finch"},
+		{Name: "unrelated binaries", OnPath: []string{"docker", "lima"}, Expected: ""},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			lookPath := func(file string) (string, error) {
+				for _, name := range tc.OnPath {
+					if name == file {
+						return "/usr/local/bin/" + name, nil
+					}
+				}
+				return "", os.ErrNotExist
+			}
+			assert.StringEqual(t, tc.Expected, lookupBinary(lookPath))
+		})
+	}
+}
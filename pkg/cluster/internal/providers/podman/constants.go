@@ -16,9 +16,11 @@ limitations under the License.
 
 package podman
 
+import "sigs.k8s.io/kind/pkg/cluster/constants"
+
 // clusterLabelKey is applied to each "node" podman container for identification
-const clusterLabelKey = "io.x-k8s.kind.cluster"
+const clusterLabelKey = constants.ClusterLabelKey
 
 // nodeRoleLabelKey is applied to each "node" podman container for categorization
 // of nodes by role
-const nodeRoleLabelKey = "io.x-k8s.kind.role"
+const nodeRoleLabelKey = constants.NodeRoleLabelKey
@@ -19,12 +19,15 @@ package podman
 import (
 	"crypto/sha1"
 	"encoding/binary"
+	"fmt"
 	"net"
 	"regexp"
 	"strings"
 
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 )
 
 // This may be overridden by KIND_EXPERIMENTAL_PODMAN_NETWORK env,
@@ -40,19 +43,34 @@ import (
 // networks.
 const fixedNetworkName = "kind"
 
-// ensureNetwork creates a new network
+// podmanSubnetFormat selects the space separated subnets of a network,
+// as reported by podman 4+
+const podmanSubnetFormat = "{{range .Subnets}}{{.Subnet}} {{end}}"
+
+// ensureNetwork creates a new network with the node network requested in the
+// config, if any
 // podman only creates IPv6 networks for versions >= 2.2.0
-func ensureNetwork(name string) error {
+func ensureNetwork(name string, nodeNetwork common.NodeNetwork) error {
 	// network already exists
 	if checkIfNetworkExists(name) {
-		return nil
+		return checkExistingNetwork(name, nodeNetwork)
+	}
+
+	// requested subnets are not probed, so fail early with a clear error
+	if err := nodeNetwork.CheckSubnetsAvailable("podman", podmanSubnetFormat); err != nil {
+		return err
+	}
+
+	// a requested IPv6 subnet is used as is
+	if nodeNetwork.IPv6Subnet != "" {
+		return createNetwork(name, nodeNetwork, "")
 	}
 
 	// generate unique subnet per network based on the name
 	// obtained from the ULA fc00::/8 range
 	// Make N attempts with "probing" in case we happen to collide
 	subnet := generateULASubnetFromName(name, 0)
-	err := createNetwork(name, subnet)
+	err := createNetwork(name, nodeNetwork, subnet)
 	if err == nil {
 		// Success!
 		return nil
@@ -60,7 +78,7 @@ func ensureNetwork(name string) error {
 
 	if isUnknownIPv6FlagError(err) ||
 		isIPv6DisabledError(err) {
-		return createNetwork(name, "")
+		return createNetwork(name, nodeNetwork, "")
 	}
 
 	// Only continue if the error is because of the subnet range
@@ -73,7 +91,7 @@ func ensureNetwork(name string) error {
 	const maxAttempts = 5
 	for attempt := int32(1); attempt < maxAttempts; attempt++ {
 		subnet := generateULASubnetFromName(name, attempt)
-		err = createNetwork(name, subnet)
+		err = createNetwork(name, nodeNetwork, subnet)
 		if err == nil {
 			// success!
 			return nil
@@ -86,12 +104,27 @@ func ensureNetwork(name string) error {
 
 }
 
-func createNetwork(name, ipv6Subnet string) error {
-	if ipv6Subnet == "" {
-		return exec.Command("podman", "network", "create", "-d=bridge", name).Run()
+// checkExistingNetwork checks an existing network has the requested subnets
+func checkExistingNetwork(name string, nodeNetwork common.NodeNetwork) error {
+	if len(nodeNetwork.Subnets()) == 0 {
+		return nil
+	}
+	subnets, err := common.NetworkSubnets("podman", name, podmanSubnetFormat)
+	if err != nil {
+		return err
+	}
+	return nodeNetwork.CheckExistingNetwork(name, subnets)
+}
+
+func createNetwork(name string, nodeNetwork common.NodeNetwork, ipv6Subnet string) error {
+	args := []string{"network", "create", "-d=bridge"}
+	if nodeNetwork.MTU > 0 {
+		args = append(args, "--opt", fmt.Sprintf("mtu=%d", nodeNetwork.MTU))
 	}
-	return exec.Command("podman", "network", "create", "-d=bridge",
-		"--ipv6", "--subnet", ipv6Subnet, name).Run()
+	args = append(args, nodeNetwork.IPv4Args()...)
+	args = append(args, nodeNetwork.IPv6Args(ipv6Subnet)...)
+	args = append(args, name)
+	return exec.Command("podman", args...).Run()
 }
 
 func checkIfNetworkExists(name string) bool {
@@ -19,6 +19,7 @@ package podman
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
@@ -63,7 +64,25 @@ func (p *provider) String() string {
 }
 
 // Provision is part of the providers.Provider interface
-func (p *provider) Provision(status *cli.Status, cfg *config.Cluster) (err error) {
+func (p *provider) Provision(status *cli.Status, cfg *config.Cluster) error {
+	return p.provision(status, cfg, sets.NewString())
+}
+
+// ProvisionNodes is part of the providers.Provider interface
+func (p *provider) ProvisionNodes(status *cli.Status, cfg *config.Cluster) error {
+	n, err := p.ListNodes(cfg.Name)
+	if err != nil {
+		return err
+	}
+	existing := sets.NewString()
+	for _, node := range n {
+		existing.Insert(node.String())
+	}
+	return p.provision(status, cfg, existing)
+}
+
+// provision creates and starts the nodes in cfg, except for existing
+func (p *provider) provision(status *cli.Status, cfg *config.Cluster, existing sets.String) (err error) {
 	if err := ensureMinVersion(); err != nil {
 		return err
 	}
@@ -81,22 +100,51 @@ func (p *provider) Provision(status *cli.Status, cfg *config.Cluster) (err error
 		p.logger.Warn("WARNING: Here be dragons! This is not supported currently.")
 		networkName = n
 	}
-	if err := ensureNetwork(networkName); err != nil {
+	if err := ensureNetwork(networkName, common.NodeNetworkFor(cfg)); err != nil {
 		return errors.Wrap(err, "failed to ensure podman network")
 	}
+	for _, network := range common.ExtraNetworks(cfg, networkName) {
+		if err := ensureNetwork(network, common.NodeNetwork{}); err != nil {
+			return errors.Wrapf(err, "failed to ensure podman network %q", network)
+		}
+	}
+
+	// relay the host's proxy for the nodes, if they cannot authenticate to it
+	if cfg.ProxyHelper.Auth != "" {
+		if err := common.EnsureProxyHelper("podman", cfg, networkName); err != nil {
+			return errors.Wrap(err, "failed to ensure proxy helper")
+		}
+	}
+
+	// run the OIDC provider, this fills in the issuer the API server trusts
+	if cfg.OIDC.Provider != "" {
+		if err := common.EnsureOIDCProvider("podman", cfg, networkName); err != nil {
+			return errors.Wrap(err, "failed to ensure OIDC provider")
+		}
+	}
+
+	// run the DNS server, this fills in the address the nodes resolve with
+	if cfg.DNSServer.Enabled {
+		if err := common.EnsureDNSServer("podman", cfg, networkName, podmanSubnetFormat); err != nil {
+			return errors.Wrap(err, "failed to ensure DNS server")
+		}
+	}
 
 	// actually provision the cluster
-	icons := strings.Repeat("📦 ", len(cfg.Nodes))
+	icons := strings.Repeat("📦 ", common.NewNodeCount(cfg, existing))
 	status.Start(fmt.Sprintf("Preparing nodes %s", icons))
 	defer func() { status.End(err == nil) }()
 
 	// plan creating the containers
-	createContainerFuncs, err := planCreation(cfg, networkName)
+	staticIPFuncs, createContainerFuncs, err := planCreation(p.logger, status, cfg, networkName, existing)
 	if err != nil {
 		return err
 	}
 
-	// actually create nodes
+	// actually create nodes, starting with those with static addresses
+	if err := errors.UntilErrorConcurrent(staticIPFuncs); err != nil {
+		return err
+	}
 	return errors.UntilErrorConcurrent(createContainerFuncs)
 }
 
@@ -139,6 +187,134 @@ func (p *provider) ListNodes(cluster string) ([]nodes.Node, error) {
 	return ret, nil
 }
 
+// GetClusterLabels is part of the providers.Provider interface
+func (p *provider) GetClusterLabels(cluster string) (map[string]string, error) {
+	n, err := p.ListNodes(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("no nodes found for cluster %q", cluster)
+	}
+	containerLabels, err := p.GetNodeLabels(n[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster labels")
+	}
+	return common.ClusterLabels(containerLabels), nil
+}
+
+// GetNodeLabels is part of the providers.Provider interface
+func (p *provider) GetNodeLabels(node nodes.Node) (map[string]string, error) {
+	cmd := exec.Command("podman", "inspect", "--format", "{{json .Config.Labels}}", node.String())
+	out, err := exec.Output(cmd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get labels for node %s", node.String())
+	}
+	containerLabels := map[string]string{}
+	if err := json.Unmarshal(out, &containerLabels); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse labels for node %s", node.String())
+	}
+	return containerLabels, nil
+}
+
+// PullImageDigest is part of the providers.Provider interface
+func (p *provider) PullImageDigest(image string) (string, error) {
+	_, pullImage := sanitizeImage(image)
+	if err := pull(p.logger, pullImage, 4, 0); err != nil {
+		return "", err
+	}
+	return common.ImageDigest("podman", pullImage)
+}
+
+// CreateScratchNode is part of the providers.Provider interface
+func (p *provider) CreateScratchNode(cluster, image string) (nodes.Node, error) {
+	n, err := p.ListNodes(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("no nodes found for cluster %q", cluster)
+	}
+	networks, err := exec.OutputLines(exec.Command("podman", "inspect", "--format", common.NetworksFormat, n[0].String()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster network")
+	}
+	if len(networks) == 0 || networks[0] == "" {
+		return nil, errors.Errorf("no network found for cluster %q", cluster)
+	}
+	name, err := common.CreateScratchNode("podman", cluster, networks[0], image)
+	if err != nil {
+		return nil, err
+	}
+	return p.node(name), nil
+}
+
+// DeleteScratchNode is part of the providers.Provider interface
+func (p *provider) DeleteScratchNode(cluster string) error {
+	return common.DeleteScratchNode("podman", cluster)
+}
+
+// EnsureRegistry is part of the providers.Provider interface
+func (p *provider) EnsureRegistry(name string, port int32) error {
+	network, err := ensureSharedNetwork()
+	if err != nil {
+		return err
+	}
+	return common.EnsureRegistry("podman", name, network, port)
+}
+
+// DeleteRegistry is part of the providers.Provider interface
+func (p *provider) DeleteRegistry(name string) error {
+	return common.DeleteRegistry("podman", name)
+}
+
+// EnsureCaches is part of the providers.Provider interface
+func (p *provider) EnsureCaches(registries []string) error {
+	network, err := ensureSharedNetwork()
+	if err != nil {
+		return err
+	}
+	return common.EnsureCaches("podman", network, registries)
+}
+
+// ListCaches is part of the providers.Provider interface
+func (p *provider) ListCaches() (map[string]string, error) {
+	return common.ListCaches("podman")
+}
+
+// DeleteCaches is part of the providers.Provider interface
+func (p *provider) DeleteCaches() error {
+	return common.DeleteCaches("podman")
+}
+
+// DeleteProxyHelper is part of the providers.Provider interface
+func (p *provider) DeleteProxyHelper(cluster string) error {
+	return common.DeleteProxyHelper("podman", cluster)
+}
+
+// DeleteOIDCProvider is part of the providers.Provider interface
+func (p *provider) DeleteOIDCProvider(cluster string) error {
+	return common.DeleteOIDCProvider("podman", cluster)
+}
+
+// DeleteDNSServer is part of the providers.Provider interface
+func (p *provider) DeleteDNSServer(cluster string) error {
+	return common.DeleteDNSServer("podman", cluster)
+}
+
+// ensureSharedNetwork ensures the network shared by all clusters exists,
+// for containers such as the local registry that serve every cluster
+func ensureSharedNetwork() (string, error) {
+	network := fixedNetworkName
+	if n := os.Getenv("KIND_EXPERIMENTAL_PODMAN_NETWORK"); n != "" {
+		network = n
+	}
+	if err := ensureNetwork(network, common.NodeNetwork{}); err != nil {
+		return "", errors.Wrap(err, "failed to ensure podman network")
+	}
+	return network, nil
+}
+
 // DeleteNodes is part of the providers.Provider interface
 func (p *provider) DeleteNodes(n []nodes.Node) error {
 	if len(n) == 0 {
@@ -171,6 +347,46 @@ func (p *provider) DeleteNodes(n []nodes.Node) error {
 	return deleteVolumes(nodeVolumes)
 }
 
+// StopNodes is part of the providers.Provider interface
+func (p *provider) StopNodes(n []nodes.Node) error {
+	return common.StopNodes("podman", n)
+}
+
+// StartNodes is part of the providers.Provider interface
+func (p *provider) StartNodes(n []nodes.Node) error {
+	return common.StartNodes("podman", n)
+}
+
+// CommitNode is part of the providers.Provider interface
+func (p *provider) CommitNode(node nodes.Node, image string) error {
+	return common.CommitNode("podman", node, image)
+}
+
+// DeleteSnapshotImages is part of the providers.Provider interface
+func (p *provider) DeleteSnapshotImages(n []nodes.Node) error {
+	return common.DeleteSnapshotImages("podman", n)
+}
+
+// SaveImages is part of the providers.Provider interface
+func (p *provider) SaveImages(w io.Writer, images ...string) error {
+	return common.SaveImages("podman", w, images...)
+}
+
+// LoadImages is part of the providers.Provider interface
+func (p *provider) LoadImages(r io.Reader) error {
+	return common.LoadImages("podman", r)
+}
+
+// CopyFromNode is part of the providers.Provider interface
+func (p *provider) CopyFromNode(node nodes.Node, path string, w io.Writer) error {
+	return common.CopyFromNode("podman", node, path, w)
+}
+
+// CopyToNode is part of the providers.Provider interface
+func (p *provider) CopyToNode(node nodes.Node, path string, r io.Reader) error {
+	return common.CopyToNode("podman", node, path, r)
+}
+
 // GetAPIServerEndpoint is part of the providers.Provider interface
 func (p *provider) GetAPIServerEndpoint(cluster string) (string, error) {
 	// locate the node that hosts this
@@ -376,6 +592,9 @@ type podmanInfo struct {
 	Host struct {
 		CgroupVersion     string   `json:"cgroupVersion,omitempty"` // "v2"
 		CgroupControllers []string `json:"cgroupControllers,omitempty"`
+		CgroupManager     string   `json:"cgroupManager,omitempty"` // "systemd"
+		CPUs              int      `json:"cpus,omitempty"`
+		MemTotal          int64    `json:"memTotal,omitempty"`
 		Security          struct {
 			Rootless bool `json:"rootless,omitempty"`
 		} `json:"security"`
@@ -419,10 +638,19 @@ func info(logger log.Logger) (*providers.ProviderInfo, error) {
 	}
 	// Info for controllers must be available after v4.0.0
 	// via https://github.com/containers/podman/pull/10387
-	if v.AtLeast(version.MustParseSemantic("4.0.0")) {
-		cgroupSupportsMemoryLimit = stringSliceContains(pInfo.Host.CgroupControllers, "memory")
-		cgroupSupportsPidsLimit = stringSliceContains(pInfo.Host.CgroupControllers, "pids")
-		cgroupSupportsCPUShares = stringSliceContains(pInfo.Host.CgroupControllers, "cpu")
+	// Before that, rootless podman uses the controllers systemd delegated
+	// to the user, which we can read directly if podman is local
+	controllers := pInfo.Host.CgroupControllers
+	controllersKnown := v.AtLeast(version.MustParseSemantic("4.0.0"))
+	if !controllersKnown && pInfo.Host.Security.Rootless && pInfo.Host.CgroupVersion == "v2" {
+		if delegated, err := common.UserDelegatedCgroupControllers(); err == nil {
+			controllers, controllersKnown = delegated, true
+		}
+	}
+	if controllersKnown {
+		cgroupSupportsMemoryLimit = stringSliceContains(controllers, "memory")
+		cgroupSupportsPidsLimit = stringSliceContains(controllers, "pids")
+		cgroupSupportsCPUShares = stringSliceContains(controllers, "cpu")
 	}
 
 	info := &providers.ProviderInfo{
@@ -431,12 +659,40 @@ func info(logger log.Logger) (*providers.ProviderInfo, error) {
 		SupportsMemoryLimit: cgroupSupportsMemoryLimit,
 		SupportsPidsLimit:   cgroupSupportsPidsLimit,
 		SupportsCPUShares:   cgroupSupportsCPUShares,
+		NCPU:                pInfo.Host.CPUs,
+		MemTotal:            pInfo.Host.MemTotal,
 	}
-	if info.Rootless && !v.AtLeast(version.MustParseSemantic("4.0.0")) {
+	if info.Rootless && !controllersKnown {
 		if logger != nil {
-			logger.Warn("Cgroup controller detection is not implemented for Podman. " +
+			logger.Warn("Cgroup controller detection is not available for this Podman version. " +
 				"If you see cgroup-related errors, you might need to set systemd property \"Delegate=yes\", see https://kind.sigs.k8s.io/docs/user/rootless/")
 		}
 	}
+	// only systemd can delegate cgroup controllers to rootless containers
+	if info.Rootless && info.Cgroup2 && pInfo.Host.CgroupManager != "" && pInfo.Host.CgroupManager != "systemd" {
+		if logger != nil {
+			logger.Warnf("Rootless Podman is using the %q cgroup manager, kind nodes need cgroup_manager = \"systemd\" in containers.conf, see https://kind.sigs.k8s.io/docs/user/rootless/", pInfo.Host.CgroupManager)
+		}
+	}
 	return info, nil
 }
+
+// NodeStats is part of the providers.Provider interface
+func (p *provider) NodeStats(n []nodes.Node) ([]providers.NodeStats, error) {
+	return common.CollectNodeStats("podman", n)
+}
+
+// PublishedPorts is part of the providers.Provider interface
+func (p *provider) PublishedPorts(n []nodes.Node) ([]providers.PublishedPort, error) {
+	return common.CollectPublishedPorts("podman", n)
+}
+
+// MissingNetwork is part of the providers.Provider interface
+func (p *provider) MissingNetwork(n []nodes.Node) (string, error) {
+	return common.MissingNetwork("podman", n)
+}
+
+// RepairNetwork is part of the providers.Provider interface
+func (p *provider) RepairNetwork(n []nodes.Node) (string, error) {
+	return common.RepairNetwork("podman", n, ensureNetwork)
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package podman
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -28,14 +27,17 @@ import (
 	"sigs.k8s.io/kind/pkg/cluster/constants"
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/log"
 
 	"sigs.k8s.io/kind/pkg/cluster/internal/loadbalancer"
 	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/sets"
 )
 
 // planCreation creates a slice of funcs that will create the containers
-func planCreation(cfg *config.Cluster, networkName string) (createContainerFuncs []func() error, err error) {
+func planCreation(logger log.Logger, status *cli.Status, cfg *config.Cluster, networkName string, existing sets.String) (staticIPFuncs, createContainerFuncs []func() error, err error) {
 	// these apply to all container creation
 	nodeNamer := common.MakeNodeNamer(cfg.Name)
 	names := make([]string, len(cfg.Nodes))
@@ -47,9 +49,48 @@ func planCreation(cfg *config.Cluster, networkName string) (createContainerFuncs
 	if haveLoadbalancer {
 		names = append(names, nodeNamer(constants.ExternalLoadBalancerNodeRoleValue))
 	}
+	// each node must boot within the node provisioning timeout
+	bootTimeout := config.TimeoutDuration(cfg.Timeouts.NodeProvision)
+
+	// plan creating the named container, unless it already exists
+	plan := func(name string, create func() error) {
+		if !existing.Has(name) {
+			createContainerFuncs = append(createContainerFuncs, status.WithPhase(name, create))
+		}
+	}
+	// nodes with static addresses are planned separately so they can be
+	// created before any dynamically addressed container takes their address
+	planNode := func(name string, node *config.Node, create func() error) {
+		if !common.HasStaticIP(node) {
+			plan(name, create)
+		} else if !existing.Has(name) {
+			staticIPFuncs = append(staticIPFuncs, status.WithPhase(name, create))
+		}
+	}
+
+	// static node addresses must fit the network, check before creating anything
+	if common.HasStaticIPs(cfg) {
+		subnets, err := common.NetworkSubnets("podman", networkName, podmanSubnetFormat)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := common.ValidateStaticIPs(cfg, networkName, subnets); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	genericArgs, err := commonArgs(cfg, networkName, names)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// record the exact node image digests for provenance
+	digests, err := common.NodeImageDigests("podman", cfg, func(image string) string {
+		_, pullImage := sanitizeImage(image)
+		return pullImage
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// only the external LB should reflect the port if we have multiple control planes
@@ -69,12 +110,33 @@ func planCreation(cfg *config.Cluster, networkName string) (createContainerFuncs
 		}
 		// plan loadbalancer node
 		name := names[len(names)-1]
-		createContainerFuncs = append(createContainerFuncs, func() error {
+		plan(name, func() error {
 			args, err := runArgsForLoadBalancer(cfg, name, genericArgs)
 			if err != nil {
 				return err
 			}
-			return createContainer(name, args)
+			return createContainer(logger, name, args)
+		})
+	}
+
+	// plan the API server socket relay, pointed at the loadbalancer if any
+	if cfg.Networking.APIServerSocket != "" {
+		target := names[len(names)-1]
+		if !haveLoadbalancer {
+			for i, node := range cfg.Nodes {
+				if node.Role == config.ControlPlaneRole {
+					target = names[i]
+					break
+				}
+			}
+		}
+		name := nodeNamer(constants.APIServerSocketRelayNodeRoleValue)
+		plan(name, func() error {
+			args, err := runArgsForAPIServerSocketRelay(cfg, name, target, genericArgs)
+			if err != nil {
+				return err
+			}
+			return createContainer(logger, name, args)
 		})
 	}
 
@@ -82,13 +144,15 @@ func planCreation(cfg *config.Cluster, networkName string) (createContainerFuncs
 	for i, node := range cfg.Nodes {
 		node := node.DeepCopy() // copy so we can modify
 		name := names[i]
+		nodeArgs := append(common.ImageLabelArgs(node.Image, digests[node.Image]), common.NodeConfigArgs(&cfg.Nodes[i])...)
+		nodeArgs = append(nodeArgs, genericArgs...)
 
 		// fixup relative paths, podman can only handle absolute paths
 		for i := range node.ExtraMounts {
 			hostPath := node.ExtraMounts[i].HostPath
 			absHostPath, err := filepath.Abs(hostPath)
 			if err != nil {
-				return nil, errors.Wrapf(err, "unable to resolve absolute path for hostPath: %q", hostPath)
+				return nil, nil, errors.Wrapf(err, "unable to resolve absolute path for hostPath: %q", hostPath)
 			}
 			node.ExtraMounts[i].HostPath = absHostPath
 		}
@@ -96,7 +160,7 @@ func planCreation(cfg *config.Cluster, networkName string) (createContainerFuncs
 		// plan actual creation based on role
 		switch node.Role {
 		case config.ControlPlaneRole:
-			createContainerFuncs = append(createContainerFuncs, func() error {
+			planNode(name, node, func() error {
 				node.ExtraPortMappings = append(node.ExtraPortMappings,
 					config.PortMapping{
 						ListenAddress: apiServerAddress,
@@ -104,25 +168,31 @@ func planCreation(cfg *config.Cluster, networkName string) (createContainerFuncs
 						ContainerPort: common.APIServerInternalPort,
 					},
 				)
-				args, err := runArgsForNode(node, cfg.Networking.IPFamily, name, genericArgs)
+				args, err := runArgsForNode(node, cfg.Networking.IPFamily, name, nodeArgs)
 				if err != nil {
 					return err
 				}
-				return createContainerWithWaitUntilSystemdReachesMultiUserSystem(name, args)
+				if err := createContainerWithWaitUntilSystemdReachesMultiUserSystem(logger, name, args, bootTimeout); err != nil {
+					return err
+				}
+				return common.PostStart("podman", name, networkName, node)
 			})
 		case config.WorkerRole:
-			createContainerFuncs = append(createContainerFuncs, func() error {
-				args, err := runArgsForNode(node, cfg.Networking.IPFamily, name, genericArgs)
+			planNode(name, node, func() error {
+				args, err := runArgsForNode(node, cfg.Networking.IPFamily, name, nodeArgs)
 				if err != nil {
 					return err
 				}
-				return createContainerWithWaitUntilSystemdReachesMultiUserSystem(name, args)
+				if err := createContainerWithWaitUntilSystemdReachesMultiUserSystem(logger, name, args, bootTimeout); err != nil {
+					return err
+				}
+				return common.PostStart("podman", name, networkName, node)
 			})
 		default:
-			return nil, errors.Errorf("unknown node role: %q", node.Role)
+			return nil, nil, errors.Errorf("unknown node role: %q", node.Role)
 		}
 	}
-	return createContainerFuncs, nil
+	return staticIPFuncs, createContainerFuncs, nil
 }
 
 // commonArgs computes static arguments that apply to all containers
@@ -132,6 +202,8 @@ func commonArgs(cfg *config.Cluster, networkName string, nodeNames []string) ([]
 		"--detach",           // run the container detached
 		"--tty",              // allocate a tty for entrypoint logs
 		"--net", networkName, // attach to its own network
+		// record the primary network, nodes may be attached to extra networks
+		"--label", fmt.Sprintf("%s=%s", common.NodeNetworkLabelKey, networkName),
 		// label the node with the cluster ID
 		"--label", fmt.Sprintf("%s=%s", clusterLabelKey, cfg.Name),
 		// specify container implementation to systemd
@@ -140,6 +212,19 @@ func commonArgs(cfg *config.Cluster, networkName string, nodeNames []string) ([]
 		"--cgroupns=private",
 	}
 
+	// record the cluster labels and annotations
+	args = append(args, common.MetadataArgs(cfg)...)
+
+	// resolve with the DNS server of the cluster, if any
+	args = append(args, common.DNSServerArgs(cfg)...)
+
+	// record the network subnets, so the network can be recreated if deleted
+	subnetArgs, err := common.NetworkSubnetsLabelArgs("podman", networkName, podmanSubnetFormat)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, subnetArgs...)
+
 	// enable IPv6 if necessary
 	if config.ClusterHasIPv6(cfg) {
 		args = append(args, "--sysctl=net.ipv6.conf.all.disable_ipv6=0", "--sysctl=net.ipv6.conf.all.forwarding=1")
@@ -212,8 +297,15 @@ func runArgsForNode(node *config.Node, clusterIPFamily config.ClusterIPFamily, n
 		args...,
 	)
 
-	// convert mounts and port mappings to container run args
+	// convert mounts, host aliases and port mappings to container run args
 	args = append(args, generateMountBindings(node.ExtraMounts...)...)
+	args = append(args, common.HostAliasArgs(node.HostAliases...)...)
+	args = append(args, common.StaticIPArgs(node)...)
+	runtimeArgs, err := common.RuntimeOptionArgs("podman", node, supportsUserNS)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, runtimeArgs...)
 	mappingArgs, err := generatePortMappings(clusterIPFamily, node.ExtraPortMappings...)
 	if err != nil {
 		return nil, err
@@ -230,6 +322,19 @@ func runArgsForNode(node *config.Node, clusterIPFamily config.ClusterIPFamily, n
 	return append(args, image), nil
 }
 
+// supportsUserNS reports if podman supports the user namespace mode
+// https://docs.podman.io/en/latest/markdown/podman-run.1.html#userns-mode
+func supportsUserNS(mode string) bool {
+	name := strings.SplitN(mode, ":", 2)[0]
+	switch name {
+	case "host", "private", "nomap":
+		return name == mode
+	case "auto", "keep-id", "ns":
+		return true
+	}
+	return false
+}
+
 func runArgsForLoadBalancer(cfg *config.Cluster, name string, args []string) ([]string, error) {
 	args = append([]string{
 		"--hostname", name, // make hostname match container name
@@ -240,23 +345,40 @@ func runArgsForLoadBalancer(cfg *config.Cluster, name string, args []string) ([]
 	)
 
 	// load balancer port mapping
-	mappingArgs, err := generatePortMappings(cfg.Networking.IPFamily,
-		config.PortMapping{
+	mappings := append([]config.PortMapping{
+		{
 			ListenAddress: cfg.Networking.APIServerAddress,
 			HostPort:      cfg.Networking.APIServerPort,
 			ContainerPort: common.APIServerInternalPort,
 		},
-	)
+	}, common.LoadBalancerPortMappings(cfg)...)
+	mappingArgs, err := generatePortMappings(cfg.Networking.IPFamily, mappings...)
 	if err != nil {
 		return nil, err
 	}
 	args = append(args, mappingArgs...)
 
 	// finally, specify the image to run
-	_, image := sanitizeImage(loadbalancer.Image)
+	_, image := sanitizeImage(loadbalancer.ImageFor(cfg))
 	return append(args, image), nil
 }
 
+func runArgsForAPIServerSocketRelay(cfg *config.Cluster, name, target string, args []string) ([]string, error) {
+	_, image := sanitizeImage(cfg.Nodes[0].Image)
+	relayArgs, err := common.APIServerSocketRelayArgs(cfg, image, target)
+	if err != nil {
+		return nil, err
+	}
+	args = append([]string{
+		"--hostname", name, // make hostname match container name
+		// label the node with the role ID
+		"--label", fmt.Sprintf("%s=%s", nodeRoleLabelKey, constants.APIServerSocketRelayNodeRoleValue),
+	},
+		args...,
+	)
+	return append(args, relayArgs...), nil
+}
+
 func getProxyEnv(cfg *config.Cluster, networkName string, nodeNames []string) (map[string]string, error) {
 	envs := common.GetProxyEnvs(cfg)
 	// Specifically add the podman network subnets to NO_PROXY if we are using a proxy
@@ -274,7 +396,7 @@ func getProxyEnv(cfg *config.Cluster, networkName string, nodeNames []string) (m
 		// Any user created pod/service hostnames, namespaces, custom DNS services
 		// are expected to be no-proxied by the user explicitly.
 
-		noProxyList = append(noProxyList, ".svc", ".svc.cluster", ".svc.cluster.local")
+		noProxyList = append(noProxyList, ".svc", ".svc.cluster", ".svc."+cfg.Networking.ClusterDomain)
 		noProxyJoined := strings.Join(noProxyList, ",")
 		envs[common.NOProxy] = noProxyJoined
 		envs[strings.ToLower(common.NOProxy)] = noProxyJoined
@@ -420,17 +542,26 @@ func generatePortMappings(clusterIPFamily config.ClusterIPFamily, portMappings .
 	return args, nil
 }
 
-func createContainer(name string, args []string) error {
-	return exec.Command("podman", append([]string{"run", "--name", name}, args...)...).Run()
+// createContainer runs the container, streaming the runtime's stderr into
+// the verbose logs
+func createContainer(logger log.Logger, name string, args []string) error {
+	out := common.NewLineLogger(logger, name)
+	defer out.Close()
+	return exec.Command("podman", append([]string{"run", "--name", name}, args...)...).SetStderr(out).Run()
 }
 
-func createContainerWithWaitUntilSystemdReachesMultiUserSystem(name string, args []string) error {
-	if err := exec.Command("podman", append([]string{"run", "--name", name}, args...)...).Run(); err != nil {
+// createContainerWithWaitUntilSystemdReachesMultiUserSystem is like
+// createContainer, but additionally streams the node's early boot logs
+// until systemd is ready, waiting at most timeout when it is not zero
+func createContainerWithWaitUntilSystemdReachesMultiUserSystem(logger log.Logger, name string, args []string, timeout time.Duration) error {
+	if err := createContainer(logger, name, args); err != nil {
 		return err
 	}
 
-	logCtx, logCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	logCtx, logCancel := common.TimeoutContext(timeout)
 	defer logCancel()
 	logCmd := exec.CommandContext(logCtx, "podman", "logs", "-f", name)
-	return common.WaitUntilLogRegexpMatches(logCtx, logCmd, common.NodeReachedCgroupsReadyRegexp())
+	out := common.NewLineLogger(logger, name)
+	defer out.Close()
+	return common.WaitUntilLogRegexpMatches(logCtx, logCmd, common.NodeReachedCgroupsReadyRegexp(), out)
 }
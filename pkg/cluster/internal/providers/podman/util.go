@@ -37,6 +37,11 @@ func IsAvailable() bool {
 	return strings.HasPrefix(lines[0], "podman version")
 }
 
+// IsHealthy checks if podman can reach its service, e.g. a podman machine
+func IsHealthy() bool {
+	return exec.Command("podman", "info", "--format", "{{.Host.Arch}}").Run() == nil
+}
+
 func getPodmanVersion() (*version.Version, error) {
 	cmd := exec.Command("podman", "--version")
 	lines, err := exec.OutputLines(cmd)
@@ -17,6 +17,8 @@ limitations under the License.
 package providers
 
 import (
+	"io"
+
 	"sigs.k8s.io/kind/pkg/cluster/nodes"
 
 	"sigs.k8s.io/kind/pkg/internal/apis/config"
@@ -29,16 +31,76 @@ type Provider interface {
 	// Provision should create and start the nodes, just short of
 	// actually starting up Kubernetes, based on the given cluster config
 	Provision(status *cli.Status, cfg *config.Cluster) error
+	// ProvisionNodes is like Provision for growing an existing cluster,
+	// it only creates the nodes in cfg that do not exist yet
+	ProvisionNodes(status *cli.Status, cfg *config.Cluster) error
 	// ListClusters discovers the clusters that currently have resources
 	// under this providers
 	ListClusters() ([]string, error)
 	// ListNodes returns the nodes under this provider for the given
 	// cluster name, they may or may not be running correctly
 	ListNodes(cluster string) ([]nodes.Node, error)
+	// GetClusterLabels returns the labels recorded on the given cluster's nodes
+	GetClusterLabels(cluster string) (map[string]string, error)
+	// GetNodeLabels returns the container labels of the provided node
+	GetNodeLabels(node nodes.Node) (map[string]string, error)
+	// PullImageDigest pulls the latest image for the reference and returns
+	// its digest, as recorded on nodes created from it
+	PullImageDigest(image string) (string, error)
+	// CreateScratchNode starts a container from image on the same network as
+	// the given cluster's nodes, without booting it or joining it to the
+	// cluster, it should be removed with DeleteNodes when no longer needed
+	// An existing scratch node of the cluster is replaced
+	CreateScratchNode(cluster, image string) (nodes.Node, error)
+	// DeleteScratchNode deletes the scratch node of the cluster if it exists
+	DeleteScratchNode(cluster string) error
+	// EnsureRegistry ensures a local registry container with the given name
+	// is running on the provider's network, published on the host loopback
+	// at port, an existing registry is reused
+	EnsureRegistry(name string, port int32) error
+	// DeleteRegistry deletes the local registry container with the given name
+	// if it exists
+	DeleteRegistry(name string) error
+	// EnsureCaches ensures a pull-through cache container is running on the
+	// provider's network for each of the registries
+	EnsureCaches(registries []string) error
+	// ListCaches returns the registries with a running pull-through cache,
+	// mapped to the endpoint nodes reach the cache at
+	ListCaches() (map[string]string, error)
+	// DeleteCaches deletes all pull-through cache containers
+	DeleteCaches() error
+	// DeleteProxyHelper deletes the proxy helper container of the cluster
+	// if it exists
+	DeleteProxyHelper(cluster string) error
+	// DeleteOIDCProvider deletes the OIDC provider container of the cluster
+	// if it exists
+	DeleteOIDCProvider(cluster string) error
+	// DeleteDNSServer deletes the DNS server container of the cluster
+	// if it exists
+	DeleteDNSServer(cluster string) error
 	// DeleteNodes deletes the provided list of nodes
 	// These should be from results previously returned by this provider
 	// E.G. by ListNodes()
 	DeleteNodes([]nodes.Node) error
+	// StopNodes stops the provided list of nodes, preserving their state
+	// so they can be started again with StartNodes
+	StopNodes([]nodes.Node) error
+	// StartNodes starts the provided list of previously stopped nodes
+	StartNodes([]nodes.Node) error
+	// CommitNode saves the root filesystem of the provided stopped node as
+	// image, volumes are not included
+	CommitNode(node nodes.Node, image string) error
+	// DeleteSnapshotImages deletes the images the provided nodes were
+	// committed to by CommitNode, or imported from a snapshot as, if any
+	DeleteSnapshotImages([]nodes.Node) error
+	// SaveImages writes an archive of the provided images to w
+	SaveImages(w io.Writer, images ...string) error
+	// LoadImages loads the images in an archive written by SaveImages
+	LoadImages(r io.Reader) error
+	// CopyFromNode writes a tar archive of path in the provided node to w
+	CopyFromNode(node nodes.Node, path string, w io.Writer) error
+	// CopyToNode extracts the tar archive read from r to path in the provided node
+	CopyToNode(node nodes.Node, path string, r io.Reader) error
 	// GetAPIServerEndpoint returns the host endpoint for the cluster's API server
 	GetAPIServerEndpoint(cluster string) (string, error)
 	// GetAPIServerInternalEndpoint returns the internal network endpoint for the cluster's API server
@@ -47,6 +109,16 @@ type Provider interface {
 	CollectLogs(dir string, nodes []nodes.Node) error
 	// Info returns the provider info
 	Info() (*ProviderInfo, error)
+	// NodeStats returns a snapshot of the resource usage of the provided nodes
+	NodeStats([]nodes.Node) ([]NodeStats, error)
+	// PublishedPorts returns the host ports published by the provided nodes
+	PublishedPorts([]nodes.Node) ([]PublishedPort, error)
+	// MissingNetwork returns the primary network of the provided nodes if it
+	// was deleted, or "" if it exists
+	MissingNetwork([]nodes.Node) (string, error)
+	// RepairNetwork recreates the primary network of the provided nodes if it
+	// was deleted and reattaches them, returning the recreated network or ""
+	RepairNetwork([]nodes.Node) (string, error)
 }
 
 // ProviderInfo is the info of the provider
@@ -56,4 +128,34 @@ type ProviderInfo struct {
 	SupportsMemoryLimit bool
 	SupportsPidsLimit   bool
 	SupportsCPUShares   bool
+	// NCPU and MemTotal are the CPUs and bytes of memory available to
+	// containers, zero if unknown
+	NCPU     int
+	MemTotal int64
+}
+
+// NodeStats is a snapshot of the resource usage of a node container
+type NodeStats struct {
+	Name       string
+	CPUPercent float64
+	// MemoryBytes is the memory usage, MemoryLimitBytes the limit if any
+	MemoryBytes      int64
+	MemoryLimitBytes int64
+	PIDs             int64
+	NetRxBytes       int64
+	NetTxBytes       int64
+	BlockReadBytes   int64
+	BlockWriteBytes  int64
+	// DiskBytes is the disk usage of the node's /var volume
+	DiskBytes int64
+}
+
+// PublishedPort is a container port of a node published on the host
+type PublishedPort struct {
+	Node          string
+	ContainerPort int32
+	// Protocol is TCP, UDP or SCTP
+	Protocol      string
+	ListenAddress string
+	HostPort      int32
 }
@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prune implements removing unused images from node containers
+// to reclaim disk space in their /var volumes
+package prune
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/sets"
+)
+
+// DefaultKeep are image name prefixes that are never pruned, in addition
+// to pinned and preloaded images, the cluster cannot restart offline without
+// them
+var DefaultKeep = []string{"registry.k8s.io/pause", "docker.io/kindest/"}
+
+// containerdRoot is the containerd state measured to report reclaimed space
+const containerdRoot = "/var/lib/containerd"
+
+// Result is the outcome of pruning images on a node
+type Result struct {
+	Node string
+	// Removed are the names of the removed images, or their IDs if untagged
+	Removed []string
+	// ReclaimedBytes is how much the containerd state shrank
+	ReclaimedBytes int64
+}
+
+// image is an image listed by `crictl images -o json`
+type image struct {
+	ID       string   `json:"id"`
+	RepoTags []string `json:"repoTags"`
+	Pinned   bool     `json:"pinned"`
+}
+
+// Images removes the images on node that are not used by any container,
+// are not pinned or preloaded in the node image and do not match DefaultKeep
+// or keep
+// If dryRun is true the images are only reported, not removed
+func Images(node nodes.Node, keep []string, dryRun bool) (Result, error) {
+	result := Result{Node: node.String()}
+	before, err := diskUsage(node)
+	if err != nil {
+		return result, err
+	}
+	out, err := exec.Output(node.Command("crictl", "images", "-o", "json"))
+	if err != nil {
+		return result, errors.Wrap(err, "failed to list images")
+	}
+	images, err := parseImages(out)
+	if err != nil {
+		return result, err
+	}
+	out, err = exec.Output(node.Command("crictl", "ps", "--all", "-o", "json"))
+	if err != nil {
+		return result, errors.Wrap(err, "failed to list containers")
+	}
+	used, err := parseUsedImages(out)
+	if err != nil {
+		return result, err
+	}
+	preloaded, err := preloadedImages(node)
+	if err != nil {
+		return result, err
+	}
+	for _, i := range unusedImages(images, used, preloaded, append(DefaultKeep, keep...)) {
+		if !dryRun {
+			// containerd garbage collects the content and snapshots of
+			// removed images synchronously
+			if err := node.Command("crictl", "rmi", i.ID).Run(); err != nil {
+				return result, errors.Wrapf(err, "failed to remove image %s", displayName(i))
+			}
+		}
+		result.Removed = append(result.Removed, displayName(i))
+	}
+	if dryRun || len(result.Removed) == 0 {
+		return result, nil
+	}
+	after, err := diskUsage(node)
+	if err != nil {
+		return result, err
+	}
+	result.ReclaimedBytes = before - after
+	return result, nil
+}
+
+// preloadedImages returns the names of the images preloaded in the node
+// image of node, for node images without a manifest these are the images
+// kubeadm needs
+func preloadedImages(node nodes.Node) (sets.String, error) {
+	lines, err := exec.OutputLines(node.Command("sh", "-c", `[ ! -f "$1" ] || cat "$1"`, "-", constants.NodeImageManifestPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the node image manifest")
+	}
+	if len(lines) == 0 {
+		kubeVersion, err := nodeutils.KubeVersion(node)
+		if err != nil {
+			return nil, err
+		}
+		lines, err = exec.OutputLines(node.Command("kubeadm", "config", "images", "list", "--kubernetes-version", kubeVersion))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list the images kubeadm needs")
+		}
+	}
+	preloaded := sets.NewString(lines...)
+	preloaded.Delete("")
+	return preloaded, nil
+}
+
+// unusedImages returns the images that are not used, pinned, preloaded or kept
+func unusedImages(images []image, used, preloaded sets.String, keep []string) []image {
+	unused := []image{}
+	for _, i := range images {
+		if i.Pinned || used.Has(i.ID) || matchesAny(i.RepoTags, keep) {
+			continue
+		}
+		// containers may reference their image by name rather than ID
+		inUse := false
+		for _, tag := range i.RepoTags {
+			if used.Has(tag) || preloaded.Has(tag) {
+				inUse = true
+				break
+			}
+		}
+		if !inUse {
+			unused = append(unused, i)
+		}
+	}
+	return unused
+}
+
+func matchesAny(tags, prefixes []string) bool {
+	for _, tag := range tags {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(tag, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func displayName(i image) string {
+	if len(i.RepoTags) > 0 {
+		return i.RepoTags[0]
+	}
+	return i.ID
+}
+
+// parseImages parses `crictl images -o json`
+func parseImages(raw []byte) ([]image, error) {
+	var list struct {
+		Images []image `json:"images"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, errors.Wrap(err, "failed to parse image list")
+	}
+	return list.Images, nil
+}
+
+// parseUsedImages parses `crictl ps -o json` into the images referenced
+// by the containers, both by ID and by name
+func parseUsedImages(raw []byte) (sets.String, error) {
+	var list struct {
+		Containers []struct {
+			ImageRef string `json:"imageRef"`
+			Image    struct {
+				Image string `json:"image"`
+			} `json:"image"`
+		} `json:"containers"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, errors.Wrap(err, "failed to parse container list")
+	}
+	used := sets.NewString()
+	for _, c := range list.Containers {
+		used.Insert(c.ImageRef, c.Image.Image)
+	}
+	used.Delete("")
+	return used, nil
+}
+
+// diskUsage returns the size of the containerd state on node in bytes
+func diskUsage(node nodes.Node) (int64, error) {
+	lines, err := exec.OutputLines(node.Command("du", "--summarize", "--bytes", containerdRoot))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to measure disk usage")
+	}
+	if len(lines) == 0 {
+		return 0, errors.New("failed to measure disk usage: no output")
+	}
+	return strconv.ParseInt(strings.Fields(lines[0])[0], 10, 64)
+}
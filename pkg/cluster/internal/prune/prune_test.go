@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prune
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+	"sigs.k8s.io/kind/pkg/internal/sets"
+)
+
+func TestParseImages(t *testing.T) {
+	t.Parallel()
+	raw := []byte(`{"images":[
+		{"id":"sha256:aaa","repoTags":["registry.k8s.io/pause:3.10"],"repoDigests":[],"size":"320368","pinned":true},
+		{"id":"sha256:bbb","repoTags":[],"repoDigests":["example.com/app@sha256:ccc"],"size":"1024","pinned":false}
+	]}`)
+	images, err := parseImages(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.DeepEqual(t, []image{
+		{ID: "sha256:aaa", RepoTags: []string{"registry.k8s.io/pause:3.10"}, Pinned: true},
+		{ID: "sha256:bbb", RepoTags: []string{}},
+	}, images)
+}
+
+func TestParseUsedImages(t *testing.T) {
+	t.Parallel()
+	raw := []byte(`{"containers":[
+		{"id":"1","imageRef":"sha256:aaa","image":{"image":"sha256:aaa"}},
+		{"id":"2","imageRef":"","image":{"image":"example.com/app:v1"}}
+	]}`)
+	used, err := parseUsedImages(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.DeepEqual(t, []string{"example.com/app:v1", "sha256:aaa"}, used.List())
+}
+
+func TestUnusedImages(t *testing.T) {
+	t.Parallel()
+	images := []image{
+		{ID: "sha256:pinned", RepoTags: []string{"registry.k8s.io/coredns/coredns:v1.11.3"}, Pinned: true},
+		{ID: "sha256:running", RepoTags: []string{"example.com/app:v2"}},
+		{ID: "sha256:byname", RepoTags: []string{"example.com/app:v3"}},
+		{ID: "sha256:kindest", RepoTags: []string{"docker.io/kindest/kindnetd:v20240813"}},
+		{ID: "sha256:kept", RepoTags: []string{"example.com/keep/db:v1"}},
+		{ID: "sha256:preloaded", RepoTags: []string{"registry.k8s.io/kube-apiserver:v1.31.0"}},
+		{ID: "sha256:upgraded", RepoTags: []string{"registry.k8s.io/kube-apiserver:v1.30.0"}},
+		{ID: "sha256:old", RepoTags: []string{"example.com/app:v1"}},
+		{ID: "sha256:untagged", RepoTags: []string{}},
+	}
+	used := sets.NewString("sha256:running", "example.com/app:v3")
+	preloaded := sets.NewString("registry.k8s.io/kube-apiserver:v1.31.0", "registry.k8s.io/etcd:3.5.15-0")
+	unused := unusedImages(images, used, preloaded, append(DefaultKeep, "example.com/keep/"))
+	names := []string{}
+	for _, i := range unused {
+		names = append(names, displayName(i))
+	}
+	assert.DeepEqual(t, []string{"registry.k8s.io/kube-apiserver:v1.30.0", "example.com/app:v1", "sha256:untagged"}, names)
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package routes implements managing host routes to a cluster's pod and
+// service subnets via the node containers
+package routes
+
+import (
+	"net"
+	"runtime"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/log"
+)
+
+// Route is a host route to Destination (a CIDR) via Gateway (a node IP)
+type Route struct {
+	Destination string
+	Gateway     string
+}
+
+// String implements fmt.Stringer
+func (r Route) String() string {
+	return r.Destination + " via " + r.Gateway
+}
+
+// Plan computes the host routes needed to reach the pod subnet of each node
+// and the service subnet of the cluster from the host
+func Plan(allNodes []nodes.Node) ([]Route, error) {
+	node, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	// map node names to their addresses
+	addresses := map[string][]string{}
+	internalNodes, err := nodeutils.InternalNodes(allNodes)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range internalNodes {
+		ipv4, ipv6, err := n.IP()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get IP for node %s", n.String())
+		}
+		addresses[n.String()] = []string{ipv4, ipv6}
+	}
+
+	// each node routes its own pod subnet(s)
+	routes := []Route{}
+	lines, err := exec.OutputLines(node.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf", "get", "nodes",
+		`-o=jsonpath={range .items[*]}{.metadata.name}{"\t"}{.spec.podCIDRs}{"\n"}{end}`,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get node pod subnets")
+	}
+	for _, line := range lines {
+		parts := strings.SplitN(line, "\t", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		for _, cidr := range parsePodCIDRs(parts[1]) {
+			gateway := gatewayForCIDR(cidr, addresses[parts[0]])
+			if gateway == "" {
+				continue
+			}
+			routes = append(routes, Route{Destination: cidr, Gateway: gateway})
+		}
+	}
+
+	// kube-proxy handles service IPs on every node, so the bootstrap
+	// control plane is as good a gateway as any
+	serviceSubnet, err := serviceSubnet(node)
+	if err != nil {
+		return nil, err
+	}
+	for _, cidr := range strings.Split(serviceSubnet, ",") {
+		gateway := gatewayForCIDR(cidr, addresses[node.String()])
+		if gateway == "" {
+			continue
+		}
+		routes = append(routes, Route{Destination: cidr, Gateway: gateway})
+	}
+	return routes, nil
+}
+
+// recordPath is where the routes kind added to the host are recorded on the
+// bootstrap control plane node, one Route.String() per line
+const recordPath = "/kind/host-routes"
+
+// Install adds routes to the host routing table, replacing existing routes
+// to the same destinations, and records them on the nodes for Remove
+func Install(logger log.Logger, allNodes []nodes.Node, routes []Route) error {
+	if err := checkSupported(); err != nil {
+		return err
+	}
+	node, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+	if err != nil {
+		return err
+	}
+	recorded, err := readRecord(node)
+	if err != nil {
+		return err
+	}
+	for _, r := range routes {
+		if err := exec.Command("ip", ipRouteArgs("replace", r)...).Run(); err != nil {
+			return errors.Wrapf(err, "failed to add route %s", r)
+		}
+		logger.V(0).Infof("Added route %s", r)
+		// record each route as soon as it is added, so that Remove finds it
+		// even if a later route fails
+		recorded = mergeRoutes(recorded, r)
+		if err := writeRecord(node, recorded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove deletes the host routes Install added via the nodes, other routes
+// via the nodes are left alone
+// It returns the routes removed
+func Remove(logger log.Logger, allNodes []nodes.Node) ([]Route, error) {
+	if err := checkSupported(); err != nil {
+		return nil, err
+	}
+	node, err := nodeutils.BootstrapControlPlaneNode(allNodes)
+	if err != nil {
+		return nil, err
+	}
+	recorded, err := readRecord(node)
+	if err != nil || len(recorded) == 0 {
+		return nil, err
+	}
+
+	installed := []Route{}
+	for _, family := range []string{"-4", "-6"} {
+		lines, err := exec.OutputLines(exec.Command("ip", family, "route", "show"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list host routes")
+		}
+		installed = append(installed, parseRoutes(lines)...)
+	}
+	var removed []Route
+	for _, r := range installedRoutes(recorded, installed) {
+		if err := exec.Command("ip", ipRouteArgs("del", r)...).Run(); err != nil {
+			return removed, errors.Wrapf(err, "failed to delete route %s", r)
+		}
+		logger.V(0).Infof("Removed route %s", r)
+		removed = append(removed, r)
+	}
+	return removed, writeRecord(node, nil)
+}
+
+// installedRoutes returns the recorded routes that are in the host routing
+// table, the recorded routes may have been removed or replaced since
+func installedRoutes(recorded, installed []Route) []Route {
+	present := map[Route]bool{}
+	for _, r := range installed {
+		present[r] = true
+	}
+	routes := []Route{}
+	for _, r := range recorded {
+		if present[r] {
+			routes = append(routes, r)
+		}
+	}
+	return routes
+}
+
+// mergeRoutes returns routes with r, which replaces a route to the same
+// destination like `ip route replace` does
+func mergeRoutes(routes []Route, r Route) []Route {
+	merged := []Route{}
+	for _, existing := range routes {
+		if existing.Destination != r.Destination {
+			merged = append(merged, existing)
+		}
+	}
+	return append(merged, r)
+}
+
+// readRecord reads the routes recorded on node, which may not exist
+func readRecord(node nodes.Node) ([]Route, error) {
+	lines, err := exec.OutputLines(node.Command("sh", "-c", `[ ! -f "$1" ] || cat "$1"`, "-", recordPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the recorded host routes")
+	}
+	return parseRoutes(lines), nil
+}
+
+// writeRecord records routes on node
+func writeRecord(node nodes.Node, routes []Route) error {
+	var b strings.Builder
+	for _, r := range routes {
+		b.WriteString(r.String() + "\n")
+	}
+	if err := nodeutils.WriteFile(node, recordPath, b.String()); err != nil {
+		return errors.Wrap(err, "failed to record the host routes")
+	}
+	return nil
+}
+
+// Supported returns true if managing host routes is supported on this host
+func Supported() bool {
+	return runtime.GOOS == "linux"
+}
+
+func checkSupported() error {
+	if !Supported() {
+		return errors.Errorf("managing host routes is not supported on %s", runtime.GOOS)
+	}
+	return nil
+}
+
+func ipRouteArgs(verb string, r Route) []string {
+	family := "-4"
+	if ip := net.ParseIP(r.Gateway); ip != nil && ip.To4() == nil {
+		family = "-6"
+	}
+	return []string{family, "route", verb, r.Destination, "via", r.Gateway}
+}
+
+// parseRoutes parses `ip route show` output into routes with a gateway
+// lines look like: 10.244.1.0/24 via 172.18.0.3 dev br-4fd2e4d4fca1
+func parseRoutes(lines []string) []Route {
+	routes := []Route{}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		for i := 1; i+1 < len(fields); i++ {
+			if fields[i] == "via" {
+				routes = append(routes, Route{Destination: fields[0], Gateway: fields[i+1]})
+				break
+			}
+		}
+	}
+	return routes
+}
+
+// parsePodCIDRs parses the jsonpath rendering of .spec.podCIDRs,
+// which looks like: ["10.244.0.0/24","fd00:10:244::/64"]
+func parsePodCIDRs(raw string) []string {
+	raw = strings.Trim(strings.TrimSpace(raw), "[]")
+	cidrs := []string{}
+	for _, c := range strings.Split(raw, ",") {
+		c = strings.Trim(strings.TrimSpace(c), `"`)
+		if c != "" {
+			cidrs = append(cidrs, c)
+		}
+	}
+	return cidrs
+}
+
+// gatewayForCIDR selects the address from addresses in the same IP family as cidr
+func gatewayForCIDR(cidr string, addresses []string) string {
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return ""
+	}
+	for _, a := range addresses {
+		gw := net.ParseIP(a)
+		if gw == nil {
+			continue
+		}
+		if (gw.To4() == nil) == (ip.To4() == nil) {
+			return a
+		}
+	}
+	return ""
+}
+
+// serviceSubnet reads the service subnet from the kubeadm ClusterConfiguration
+func serviceSubnet(node nodes.Node) (string, error) {
+	out, err := exec.Output(node.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf",
+		"get", "configmap", "-n", "kube-system", "kubeadm-config",
+		"-o=jsonpath={.data.ClusterConfiguration}",
+	))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read kubeadm config")
+	}
+	clusterConfiguration := struct {
+		Networking struct {
+			ServiceSubnet string `json:"serviceSubnet"`
+		} `json:"networking"`
+	}{}
+	if err := yaml.Unmarshal(out, &clusterConfiguration); err != nil {
+		return "", errors.Wrap(err, "failed to parse kubeadm config")
+	}
+	if clusterConfiguration.Networking.ServiceSubnet == "" {
+		return "", errors.New("kubeadm config does not specify a service subnet")
+	}
+	return clusterConfiguration.Networking.ServiceSubnet, nil
+}
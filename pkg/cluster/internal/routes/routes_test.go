@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routes
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestParseRoutes(t *testing.T) {
+	t.Parallel()
+	lines := []string{
+		"default via 192.168.1.1 dev wlp2s0 proto dhcp metric 600",
+		"10.244.1.0/24 via 172.18.0.3 dev br-4fd2e4d4fca1",
+		"172.18.0.0/16 dev br-4fd2e4d4fca1 proto kernel scope link src 172.18.0.1",
+		"fd00:10:244:1::/64 via fc00:f853:ccd:e793::3 dev br-4fd2e4d4fca1 metric 1024 pref medium",
+	}
+	assert.DeepEqual(t, []Route{
+		{Destination: "default", Gateway: "192.168.1.1"},
+		{Destination: "10.244.1.0/24", Gateway: "172.18.0.3"},
+		{Destination: "fd00:10:244:1::/64", Gateway: "fc00:f853:ccd:e793::3"},
+	}, parseRoutes(lines))
+}
+
+func TestParsePodCIDRs(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name     string
+		Raw      string
+		Expected []string
+	}{
+		{
+			Name:     "empty",
+			Raw:      "",
+			Expected: []string{},
+		},
+		{
+			Name:     "single stack",
+			Raw:      `["10.244.0.0/24"]`,
+			Expected: []string{"10.244.0.0/24"},
+		},
+		{
+			Name:     "dual stack",
+			Raw:      `["10.244.0.0/24","fd00:10:244::/64"]`,
+			Expected: []string{"10.244.0.0/24", "fd00:10:244::/64"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, tc.Expected, parsePodCIDRs(tc.Raw))
+		})
+	}
+}
+
+func TestGatewayForCIDR(t *testing.T) {
+	t.Parallel()
+	addresses := []string{"172.18.0.2", "fc00:f853:ccd:e793::2"}
+	assert.StringEqual(t, "172.18.0.2", gatewayForCIDR("10.96.0.0/16", addresses))
+	assert.StringEqual(t, "fc00:f853:ccd:e793::2", gatewayForCIDR("fd00:10:96::/112", addresses))
+	assert.StringEqual(t, "", gatewayForCIDR("fd00:10:96::/112", []string{"172.18.0.2", ""}))
+	assert.StringEqual(t, "", gatewayForCIDR("bogus", addresses))
+}
+
+func TestInstalledRoutes(t *testing.T) {
+	t.Parallel()
+	recorded := []Route{
+		{Destination: "10.244.1.0/24", Gateway: "172.18.0.3"},
+		{Destination: "10.244.2.0/24", Gateway: "172.18.0.4"},
+		{Destination: "10.96.0.0/16", Gateway: "172.18.0.2"},
+	}
+	installed := []Route{
+		{Destination: "default", Gateway: "192.168.1.1"},
+		{Destination: "10.244.1.0/24", Gateway: "172.18.0.3"},
+		// replaced by the user since it was recorded
+		{Destination: "10.244.2.0/24", Gateway: "172.18.0.9"},
+		// added by the user via a node
+		{Destination: "192.168.50.0/24", Gateway: "172.18.0.3"},
+		{Destination: "10.96.0.0/16", Gateway: "172.18.0.2"},
+	}
+	assert.DeepEqual(t, []Route{
+		{Destination: "10.244.1.0/24", Gateway: "172.18.0.3"},
+		{Destination: "10.96.0.0/16", Gateway: "172.18.0.2"},
+	}, installedRoutes(recorded, installed))
+	assert.DeepEqual(t, []Route{}, installedRoutes(nil, installed))
+}
+
+func TestMergeRoutes(t *testing.T) {
+	t.Parallel()
+	routes := mergeRoutes(nil, Route{Destination: "10.244.1.0/24", Gateway: "172.18.0.3"})
+	routes = mergeRoutes(routes, Route{Destination: "10.96.0.0/16", Gateway: "172.18.0.2"})
+	routes = mergeRoutes(routes, Route{Destination: "10.244.1.0/24", Gateway: "172.18.0.5"})
+	assert.DeepEqual(t, []Route{
+		{Destination: "10.96.0.0/16", Gateway: "172.18.0.2"},
+		{Destination: "10.244.1.0/24", Gateway: "172.18.0.5"},
+	}, routes)
+	// the record round trips through parseRoutes
+	lines := []string{}
+	for _, r := range routes {
+		lines = append(lines, r.String())
+	}
+	assert.DeepEqual(t, routes, parseRoutes(lines))
+}
@@ -0,0 +1,358 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scale implements adding and removing worker nodes of an existing cluster
+package scale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/coredns"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	configaction "sigs.k8s.io/kind/pkg/cluster/internal/create/actions/config"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/kubeadmjoin"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/loadbalancer"
+	"sigs.k8s.io/kind/pkg/cluster/internal/kubeadm"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/cli"
+)
+
+// Workers adds or removes worker nodes until the cluster name has workers of them
+// If cfg is not nil it is the config the cluster was created with, which the
+// new nodes are configured from, otherwise a config is derived from the cluster
+// If wait is positive Workers waits up to wait for new nodes to be Ready
+func Workers(logger log.Logger, status *cli.Status, p providers.Provider, name string, cfg *config.Cluster, workers int, wait time.Duration) error {
+	if workers < 0 {
+		return errors.Errorf("invalid number of workers: %d", workers)
+	}
+	allNodes, err := p.ListNodes(name)
+	if err != nil {
+		return err
+	}
+	internalNodes, err := nodeutils.InternalNodes(allNodes)
+	if err != nil {
+		return err
+	}
+	if len(internalNodes) == 0 {
+		return errors.Errorf("unknown cluster %q", name)
+	}
+	controlPlane, err := nodeutils.BootstrapControlPlaneNode(internalNodes)
+	if err != nil {
+		return err
+	}
+	existingWorkers, err := nodeutils.SelectNodesByRole(internalNodes, constants.WorkerNodeRoleValue)
+	if err != nil {
+		return err
+	}
+	sortByIndex(existingWorkers)
+
+	explicitConfig := cfg != nil
+	switch {
+	case workers > len(existingWorkers):
+		cfg, err = configForWorkers(p, name, controlPlane, internalNodes, cfg, workers)
+		if err != nil {
+			return err
+		}
+		if err := AddNodes(logger, status, p, cfg, controlPlane, allNodes, wait); err != nil {
+			return err
+		}
+	case workers < len(existingWorkers):
+		if err := RemoveNodes(status, p, controlPlane, existingWorkers[workers:]); err != nil {
+			return err
+		}
+	default:
+		logger.V(0).Infof("Cluster %q already has %d worker nodes", name, workers)
+		return nil
+	}
+	if !explicitConfig {
+		return nil
+	}
+
+	// the rest of the cluster depends on the workers only if configured to
+	allNodes, err = p.ListNodes(name)
+	if err != nil {
+		return err
+	}
+	if len(cfg.LoadBalancer.ExtraBackends) > 0 {
+		cfg = cfg.DeepCopy()
+		cfg.Name = name
+		if err := loadbalancer.NewAction().Execute(actions.NewActionContextForNodes(logger, status, p, cfg, allNodes)); err != nil {
+			return err
+		}
+	}
+	if coredns.Enabled(&cfg.DNS) {
+		internalNodes, err := nodeutils.InternalNodes(allNodes)
+		if err != nil {
+			return err
+		}
+		return coredns.Apply(controlPlane, &cfg.DNS, len(internalNodes))
+	}
+	return nil
+}
+
+// AddNodes provisions and joins the nodes in cfg that are not in existing
+// If wait is positive AddNodes waits up to wait for them to be Ready
+func AddNodes(logger log.Logger, status *cli.Status, p providers.Provider, cfg *config.Cluster, controlPlane nodes.Node, existing []nodes.Node, wait time.Duration) error {
+	// the well known bootstrap token expires, make sure it is valid
+	_ = controlPlane.Command("kubeadm", "token", "delete", kubeadm.Token).Run()
+	if err := controlPlane.Command("kubeadm", "token", "create", kubeadm.Token, "--ttl", "1h").Run(); err != nil {
+		return errors.Wrap(err, "failed to create bootstrap token")
+	}
+
+	if err := p.ProvisionNodes(status, cfg); err != nil {
+		return err
+	}
+	allNodes, err := p.ListNodes(cfg.Name)
+	if err != nil {
+		return err
+	}
+	existingNames := map[string]bool{}
+	for _, n := range existing {
+		existingNames[n.String()] = true
+	}
+	newNodes := []nodes.Node{}
+	for _, n := range allNodes {
+		if !existingNames[n.String()] {
+			newNodes = append(newNodes, n)
+		}
+	}
+
+	ctx := actions.NewActionContextForNodes(logger, status, p, cfg, newNodes)
+	for _, action := range []actions.Action{
+		configaction.NewAction(), // write the kubeadm config on the new nodes
+		kubeadmjoin.NewAction(),  // and join them
+	} {
+		if err := action.Execute(ctx); err != nil {
+			return err
+		}
+	}
+
+	if wait <= 0 {
+		return nil
+	}
+	status.Start("Waiting for new nodes ⏳")
+	defer status.End(false)
+	deadline := time.Now().Add(wait)
+	fns := []func() error{}
+	for _, n := range newNodes {
+		n := n // capture loop variable
+		fns = append(fns, func() error {
+			_, err := nodeutils.WaitForNodeReady(controlPlane, n, time.Until(deadline))
+			return err
+		})
+	}
+	if err := errors.AggregateConcurrent(fns); err != nil {
+		return err
+	}
+	status.End(true)
+	return nil
+}
+
+// RemoveNodes drains and deletes the worker nodes
+func RemoveNodes(status *cli.Status, p providers.Provider, controlPlane nodes.Node, workers []nodes.Node) error {
+	status.Start("Removing worker nodes 🚜")
+	defer status.End(false)
+	for _, n := range workers {
+		if err := kubectl(controlPlane,
+			"drain", n.String(),
+			"--ignore-daemonsets", "--delete-emptydir-data", "--force", "--timeout=2m",
+		); err != nil {
+			return errors.Wrapf(err, "failed to drain node %s", n.String())
+		}
+		if err := kubectl(controlPlane, "delete", "node", n.String()); err != nil {
+			return errors.Wrapf(err, "failed to delete Node %s", n.String())
+		}
+	}
+	if err := p.DeleteNodes(workers); err != nil {
+		return err
+	}
+	status.End(true)
+	return nil
+}
+
+func kubectl(controlPlane nodes.Node, args ...string) error {
+	return controlPlane.Command(
+		"kubectl", append([]string{"--kubeconfig=/etc/kubernetes/admin.conf"}, args...)...,
+	).Run()
+}
+
+// configForWorkers returns the config for the cluster with workers worker
+// nodes, based on cfg if not nil
+func configForWorkers(p providers.Provider, name string, controlPlane nodes.Node, internalNodes []nodes.Node, cfg *config.Cluster, workers int) (*config.Cluster, error) {
+	if cfg == nil {
+		var err error
+		cfg, err = derivedConfig(p, name, controlPlane, internalNodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cfg = cfg.DeepCopy()
+	cfg.Name = name
+
+	// the nodes are named in order by role, so existing nodes keep their names
+	controlPlanes := []config.Node{}
+	configWorkers := []config.Node{}
+	for _, n := range cfg.Nodes {
+		if n.Role == config.ControlPlaneRole {
+			controlPlanes = append(controlPlanes, n)
+		} else {
+			configWorkers = append(configWorkers, n)
+		}
+	}
+	existingControlPlanes, err := nodeutils.SelectNodesByRole(internalNodes, constants.ControlPlaneNodeRoleValue)
+	if err != nil {
+		return nil, err
+	}
+	if len(controlPlanes) != len(existingControlPlanes) {
+		return nil, errors.Errorf("config has %d control-plane nodes but the cluster has %d", len(controlPlanes), len(existingControlPlanes))
+	}
+	cfg.Nodes = controlPlanes
+	for i := 0; i < workers; i++ {
+		if i < len(configWorkers) {
+			cfg.Nodes = append(cfg.Nodes, configWorkers[i])
+			continue
+		}
+		// additional workers are copies of the last one, minus host specifics
+		template := controlPlanes[0]
+		if len(configWorkers) > 0 {
+			template = configWorkers[len(configWorkers)-1]
+		}
+		node := template.DeepCopy()
+		node.Role = config.WorkerRole
+		node.ExtraPortMappings = nil
+		node.IPv4Address = ""
+		node.IPv6Address = ""
+		cfg.Nodes = append(cfg.Nodes, *node)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// derivedConfig reconstructs the config of cluster name from its nodes
+// This covers the node images, IP family and cluster metadata, but not
+// e.g. mounts or kubeadm patches, which require the original config
+func derivedConfig(p providers.Provider, name string, controlPlane nodes.Node, internalNodes []nodes.Node) (*config.Cluster, error) {
+	cfg := &config.Cluster{
+		Name:        name,
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+	}
+	sorted := append([]nodes.Node{}, internalNodes...)
+	sortByIndex(sorted)
+	for _, n := range sorted {
+		labels, err := p.GetNodeLabels(n)
+		if err != nil {
+			return nil, err
+		}
+		image := labels[common.NodeImageLabelKey]
+		if image == "" {
+			return nil, errors.Errorf("node %s does not record its image, pass the cluster's config", n.String())
+		}
+		role, err := n.Role()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Nodes = append(cfg.Nodes, config.Node{Role: config.NodeRole(role), Image: image})
+		for k, v := range labels {
+			if strings.HasPrefix(k, common.ClusterLabelPrefix) {
+				cfg.Labels[strings.TrimPrefix(k, common.ClusterLabelPrefix)] = v
+			} else if strings.HasPrefix(k, common.ClusterAnnotationPrefix) {
+				cfg.Annotations[strings.TrimPrefix(k, common.ClusterAnnotationPrefix)] = v
+			}
+		}
+	}
+
+	// the kubelet node IPs reflect the IP family
+	lines, err := exec.OutputLines(controlPlane.Command(
+		"kubectl", "--kubeconfig=/etc/kubernetes/admin.conf", "get", "node", controlPlane.String(),
+		`-o=jsonpath={range .status.addresses[?(@.type=="InternalIP")]}{.address}{"\n"}{end}`,
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get control-plane node addresses")
+	}
+	cfg.Networking.IPFamily = ipFamily(lines)
+	config.SetDefaultsCluster(cfg)
+	return cfg, nil
+}
+
+// ipFamily returns the IP family of a cluster with the given node addresses
+func ipFamily(addresses []string) config.ClusterIPFamily {
+	ipv4, ipv6 := false, false
+	for _, a := range addresses {
+		if a == "" {
+			continue
+		}
+		if strings.Contains(a, ":") {
+			ipv6 = true
+		} else {
+			ipv4 = true
+		}
+	}
+	switch {
+	case ipv4 && ipv6:
+		return config.DualStackFamily
+	case ipv6:
+		return config.IPv6Family
+	default:
+		return config.IPv4Family
+	}
+}
+
+// sortByIndex sorts nodes by role and then the index in their name,
+// e.g. worker, worker2, ..., worker10
+func sortByIndex(n []nodes.Node) {
+	sort.SliceStable(n, func(i, j int) bool {
+		ri, ii := roleAndIndex(n[i].String())
+		rj, ij := roleAndIndex(n[j].String())
+		if ri != rj {
+			// control-plane nodes first
+			return ri == constants.ControlPlaneNodeRoleValue
+		}
+		return ii < ij
+	})
+}
+
+// roleAndIndex parses a node name like kind-worker2 into worker and 2
+func roleAndIndex(name string) (string, int) {
+	for _, role := range []string{constants.ControlPlaneNodeRoleValue, constants.WorkerNodeRoleValue} {
+		i := strings.LastIndex(name, "-"+role)
+		if i < 0 {
+			continue
+		}
+		suffix := name[i+len(role)+1:]
+		if suffix == "" {
+			return role, 1
+		}
+		if index, err := strconv.Atoi(suffix); err == nil {
+			return role, index
+		}
+	}
+	return "", 0
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+type fakeNode struct {
+	name string
+	role string
+}
+
+var _ nodes.Node = &fakeNode{}
+
+func (n *fakeNode) String() string                                             { return n.name }
+func (n *fakeNode) Role() (string, error)                                      { return n.role, nil }
+func (n *fakeNode) IP() (string, string, error)                                { return "", "", nil }
+func (n *fakeNode) SerialLogs(io.Writer) error                                 { return nil }
+func (n *fakeNode) Command(string, ...string) exec.Cmd                         { return nil }
+func (n *fakeNode) CommandContext(context.Context, string, ...string) exec.Cmd { return nil }
+
+func names(n []nodes.Node) []string {
+	ret := []string{}
+	for _, node := range n {
+		ret = append(ret, node.String())
+	}
+	return ret
+}
+
+func TestSortByIndex(t *testing.T) {
+	t.Parallel()
+	n := []nodes.Node{
+		&fakeNode{name: "kind-worker10", role: "worker"},
+		&fakeNode{name: "kind-worker2", role: "worker"},
+		&fakeNode{name: "kind-control-plane2", role: "control-plane"},
+		&fakeNode{name: "kind-worker", role: "worker"},
+		&fakeNode{name: "kind-control-plane", role: "control-plane"},
+	}
+	sortByIndex(n)
+	assert.DeepEqual(t, []string{
+		"kind-control-plane", "kind-control-plane2", "kind-worker", "kind-worker2", "kind-worker10",
+	}, names(n))
+}
+
+func TestIPFamily(t *testing.T) {
+	t.Parallel()
+	assert.StringEqual(t, string(config.IPv4Family), string(ipFamily([]string{"172.18.0.2"})))
+	assert.StringEqual(t, string(config.IPv6Family), string(ipFamily([]string{"fc00:f853:ccd:e793::2"})))
+	assert.StringEqual(t, string(config.DualStackFamily), string(ipFamily([]string{"172.18.0.2", "fc00:f853:ccd:e793::2"})))
+}
+
+func TestConfigForWorkers(t *testing.T) {
+	t.Parallel()
+	internalNodes := []nodes.Node{
+		&fakeNode{name: "foo-control-plane", role: "control-plane"},
+		&fakeNode{name: "foo-worker", role: "worker"},
+	}
+	cfg := &config.Cluster{Nodes: []config.Node{
+		{Role: config.ControlPlaneRole},
+		{
+			Role:              config.WorkerRole,
+			Labels:            map[string]string{"tier": "frontend"},
+			ExtraPortMappings: []config.PortMapping{{ContainerPort: 80, HostPort: 8080}},
+		},
+	}}
+	config.SetDefaultsCluster(cfg)
+
+	scaled, err := configForWorkers(nil, "foo", internalNodes[0], internalNodes, cfg, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.StringEqual(t, "foo", scaled.Name)
+	if len(scaled.Nodes) != 4 {
+		t.Fatalf("expected 4 nodes but got %d", len(scaled.Nodes))
+	}
+	// the existing worker keeps its config, new workers copy it minus host ports
+	if len(scaled.Nodes[1].ExtraPortMappings) != 1 {
+		t.Errorf("expected the existing worker to keep its port mappings")
+	}
+	for _, n := range scaled.Nodes[2:] {
+		assert.StringEqual(t, string(config.WorkerRole), string(n.Role))
+		assert.StringEqual(t, "frontend", n.Labels["tier"])
+		if len(n.ExtraPortMappings) != 0 {
+			t.Errorf("expected new workers to have no port mappings")
+		}
+	}
+
+	// the control-plane nodes cannot change
+	cfg.Nodes = append(cfg.Nodes, config.Node{Role: config.ControlPlaneRole, Image: cfg.Nodes[0].Image})
+	if _, err := configForWorkers(nil, "foo", internalNodes[0], internalNodes, cfg, 3); err == nil {
+		t.Errorf("expected an error for mismatched control-plane nodes")
+	}
+}
@@ -0,0 +1,445 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot implements exporting a cluster's node containers to an
+// archive and recreating the cluster from it
+package snapshot
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions"
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/loadbalancer"
+	"sigs.k8s.io/kind/pkg/cluster/internal/kubeconfig"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
+	"sigs.k8s.io/kind/pkg/internal/cli"
+)
+
+const (
+	// manifestName is the archive entry describing the snapshot
+	manifestName = "snapshot.json"
+	// imagesName is the archive entry holding the node root filesystems
+	imagesName = "images.tar"
+	// configName is the archive entry holding the cluster config, if any
+	configName = "config.yaml"
+	// varSuffix is the suffix of the archive entries holding the /var
+	// volume of each node, which includes etcd and containerd data
+	varSuffix = ".var.tar"
+)
+
+// Manifest describes the nodes in a snapshot
+type Manifest struct {
+	Name  string `json:"name"`
+	Nodes []Node `json:"nodes"`
+}
+
+// Node is a node in a snapshot
+type Node struct {
+	// Suffix is the node name without the cluster name, e.g. worker2
+	Suffix string `json:"suffix"`
+	Role   string `json:"role"`
+	// Image is the committed root filesystem of the node
+	Image string `json:"image"`
+	// IPv4 and IPv6 are the addresses of the node, which are in its
+	// certificates and kubeconfigs
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+}
+
+// Export stops the cluster, writes its nodes to an archive at path and starts
+// the cluster again
+// rawConfig is the config the cluster was created with, it is recorded in the
+// snapshot if not nil
+func Export(logger log.Logger, p providers.Provider, name, path string, rawConfig []byte) (err error) {
+	allNodes, err := p.ListNodes(name)
+	if err != nil {
+		return err
+	}
+	internalNodes, err := nodeutils.InternalNodes(allNodes)
+	if err != nil {
+		return err
+	}
+	if len(internalNodes) == 0 {
+		return errors.Errorf("unknown cluster %q", name)
+	}
+
+	if rawConfig != nil {
+		if _, err := encoding.Parse(rawConfig); err != nil {
+			return err
+		}
+	}
+	// record the addresses while the nodes are running
+	addresses := map[string][2]string{}
+	for _, n := range internalNodes {
+		ipv4, ipv6, err := n.IP()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the address of node %s", n.String())
+		}
+		addresses[n.String()] = [2]string{ipv4, ipv6}
+	}
+
+	dir, err := os.MkdirTemp("", "kind-snapshot-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	// the nodes must be stopped for a consistent copy of etcd and containerd
+	logger.V(0).Infof("Stopping cluster %q ...", name)
+	if err := p.StopNodes(allNodes); err != nil {
+		return err
+	}
+	defer func() {
+		logger.V(0).Infof("Starting cluster %q ...", name)
+		if startErr := p.StartNodes(allNodes); startErr != nil && err == nil {
+			err = startErr
+		}
+	}()
+
+	manifest := Manifest{Name: name}
+	images := []string{}
+	files := []string{manifestName, imagesName}
+	for _, n := range internalNodes {
+		role, err := n.Role()
+		if err != nil {
+			return err
+		}
+		node := Node{
+			Suffix: strings.TrimPrefix(n.String(), name+"-"),
+			Role:   role,
+			Image:  common.SnapshotImage(n.String()),
+			IPv4:   addresses[n.String()][0],
+			IPv6:   addresses[n.String()][1],
+		}
+		logger.V(0).Infof("Saving node %s ...", n.String())
+		if err := p.CommitNode(n, node.Image); err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(dir, node.Suffix+varSuffix), func(w io.Writer) error {
+			return p.CopyFromNode(n, "/var", w)
+		}); err != nil {
+			return err
+		}
+		manifest.Nodes = append(manifest.Nodes, node)
+		images = append(images, node.Image)
+		files = append(files, node.Suffix+varSuffix)
+	}
+	if err := writeFile(filepath.Join(dir, imagesName), func(w io.Writer) error {
+		return p.SaveImages(w, images...)
+	}); err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(dir, manifestName), func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(&manifest)
+	}); err != nil {
+		return err
+	}
+	if rawConfig != nil {
+		if err := os.WriteFile(filepath.Join(dir, configName), rawConfig, 0644); err != nil {
+			return err
+		}
+		files = append(files, configName)
+	}
+
+	logger.V(0).Infof("Writing snapshot to %s ...", path)
+	return writeFile(path, func(w io.Writer) error {
+		return writeArchive(w, dir, files)
+	})
+}
+
+// Import recreates a cluster from an archive written by Export
+// If name is not empty it must be the snapshot's cluster name, cfg may be
+// nil to use the config recorded in the snapshot, if any, or a cluster config
+// with nodes matching the snapshot
+// The nodes get the names and addresses they were exported with, as these
+// are in their certificates and kubeconfigs
+func Import(logger log.Logger, status *cli.Status, p providers.Provider, path, name string, cfg *config.Cluster, kubeconfigPath string) error {
+	dir, err := os.MkdirTemp("", "kind-snapshot-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := extractArchive(f, dir); err != nil {
+		return errors.Wrapf(err, "failed to read snapshot %s", path)
+	}
+	manifest := Manifest{}
+	raw, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if err != nil {
+		return errors.Wrap(err, "invalid snapshot")
+	}
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return errors.Wrap(err, "invalid snapshot")
+	}
+	if name != "" && name != manifest.Name {
+		return errors.Errorf("cannot import the snapshot of cluster %q as %q, its certificates and kubeconfigs are issued for %q", manifest.Name, name, manifest.Name)
+	}
+	name = manifest.Name
+	if cfg == nil {
+		if raw, err := os.ReadFile(filepath.Join(dir, configName)); err == nil {
+			if cfg, err = encoding.Parse(raw); err != nil {
+				return errors.Wrap(err, "invalid snapshot config")
+			}
+		}
+	}
+
+	cfg, err = configForSnapshot(&manifest, cfg)
+	if err != nil {
+		return err
+	}
+	if existing, err := p.ListNodes(name); err != nil {
+		return err
+	} else if len(existing) != 0 {
+		return errors.Errorf("node(s) already exist for a cluster with the name %q", name)
+	}
+
+	status.Start("Loading node images 🖼")
+	images, err := os.Open(filepath.Join(dir, imagesName))
+	if err != nil {
+		status.End(false)
+		return errors.Wrap(err, "invalid snapshot")
+	}
+	err = p.LoadImages(images)
+	images.Close()
+	status.End(err == nil)
+	if err != nil {
+		return err
+	}
+
+	if err := p.Provision(status, cfg); err != nil {
+		return err
+	}
+
+	// restore each node's /var while stopped, as a live copy would race etcd
+	status.Start("Restoring node volumes 💾")
+	defer status.End(false)
+	allNodes, err := p.ListNodes(name)
+	if err != nil {
+		return err
+	}
+	internalNodes, err := nodeutils.InternalNodes(allNodes)
+	if err != nil {
+		return err
+	}
+	if err := checkAddresses(cfg, internalNodes); err != nil {
+		return err
+	}
+	if err := p.StopNodes(internalNodes); err != nil {
+		return err
+	}
+	for _, n := range internalNodes {
+		if err := restoreVar(p, n, filepath.Join(dir, strings.TrimPrefix(n.String(), name+"-")+varSuffix)); err != nil {
+			return err
+		}
+	}
+	if err := p.StartNodes(internalNodes); err != nil {
+		return err
+	}
+	status.End(true)
+
+	// the load balancer is recreated rather than restored
+	actionsContext := actions.NewActionContext(logger, status, p, cfg)
+	if err := loadbalancer.NewAction().Execute(actionsContext); err != nil {
+		return err
+	}
+	return kubeconfig.Export(p, name, kubeconfigPath, true)
+}
+
+// configForSnapshot returns the cluster config for recreating the snapshot's
+// nodes with their names and addresses, based on cfg if not nil
+func configForSnapshot(manifest *Manifest, cfg *config.Cluster) (*config.Cluster, error) {
+	if cfg == nil {
+		cfg = &config.Cluster{}
+		for _, n := range manifest.Nodes {
+			role := config.WorkerRole
+			if n.Role == constants.ControlPlaneNodeRoleValue {
+				role = config.ControlPlaneRole
+			}
+			cfg.Nodes = append(cfg.Nodes, config.Node{Role: role})
+		}
+		config.SetDefaultsCluster(cfg)
+	}
+	cfg = cfg.DeepCopy()
+	if cfg.Name != "" && cfg.Name != constants.DefaultClusterName && cfg.Name != manifest.Name {
+		return nil, errors.Errorf("config is for cluster %q but the snapshot is of cluster %q", cfg.Name, manifest.Name)
+	}
+	cfg.Name = manifest.Name
+
+	// match the nodes by the name they will be given
+	snapshotNodes := map[string]Node{}
+	for _, n := range manifest.Nodes {
+		snapshotNodes[n.Suffix] = n
+	}
+	if len(cfg.Nodes) != len(manifest.Nodes) {
+		return nil, errors.Errorf("config has %d nodes but the snapshot has %d", len(cfg.Nodes), len(manifest.Nodes))
+	}
+	namer := common.MakeNodeNamer("")
+	for i := range cfg.Nodes {
+		suffix := strings.TrimPrefix(namer(string(cfg.Nodes[i].Role)), "-")
+		n, ok := snapshotNodes[suffix]
+		if !ok {
+			return nil, errors.Errorf("snapshot has no %s node", suffix)
+		}
+		cfg.Nodes[i].Image = n.Image
+		// pin the addresses of the cluster's IP family
+		if cfg.Networking.IPFamily != config.IPv6Family {
+			if err := pinAddress(&cfg.Nodes[i].IPv4Address, n.IPv4, suffix); err != nil {
+				return nil, err
+			}
+		}
+		if cfg.Networking.IPFamily != config.IPv4Family {
+			if err := pinAddress(&cfg.Nodes[i].IPv6Address, n.IPv6, suffix); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// pinAddress sets the static address of a node to the address it was
+// exported with, if any
+func pinAddress(address *string, exported, suffix string) error {
+	if exported == "" {
+		return nil
+	}
+	if *address != "" && *address != exported {
+		return errors.Errorf("config sets address %s for node %s but it was exported with %s", *address, suffix, exported)
+	}
+	*address = exported
+	return nil
+}
+
+// checkAddresses checks the recreated nodes got the addresses pinned in cfg
+func checkAddresses(cfg *config.Cluster, internalNodes []nodes.Node) error {
+	pinned := map[string]config.Node{}
+	namer := common.MakeNodeNamer(cfg.Name)
+	for _, n := range cfg.Nodes {
+		pinned[namer(string(n.Role))] = n
+	}
+	for _, n := range internalNodes {
+		ipv4, ipv6, err := n.IP()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the address of node %s", n.String())
+		}
+		e := pinned[n.String()]
+		if (e.IPv4Address != "" && e.IPv4Address != ipv4) || (e.IPv6Address != "" && e.IPv6Address != ipv6) {
+			return errors.Errorf("node %s did not get the address it was exported with", n.String())
+		}
+	}
+	return nil
+}
+
+func restoreVar(p providers.Provider, n nodes.Node, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "invalid snapshot, no data for node %s", n.String())
+	}
+	defer f.Close()
+	return p.CopyToNode(n, "/", f)
+}
+
+// writeFile creates path and writes it with write
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeArchive writes a tar archive of the named files in dir to w
+func writeArchive(w io.Writer, dir string, files []string) error {
+	tw := tar.NewWriter(w)
+	for _, name := range files {
+		if err := addFile(tw, dir, name); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func addFile(tw *tar.Writer, dir, name string) error {
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// extractArchive extracts a tar archive written by writeArchive to dir
+func extractArchive(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// snapshots are flat, anything else is not ours
+		if hdr.Typeflag != tar.TypeReg || hdr.Name != filepath.Base(hdr.Name) {
+			return errors.Errorf("unexpected entry %q", hdr.Name)
+		}
+		if err := writeFile(filepath.Join(dir, hdr.Name), func(w io.Writer) error {
+			_, err := io.Copy(w, tr)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	t.Parallel()
+	src, dst := t.TempDir(), t.TempDir()
+	files := map[string]string{
+		manifestName:                "{}",
+		"control-plane" + varSuffix: "etcd",
+	}
+	names := []string{}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, src, names); err != nil {
+		t.Fatalf("unexpected error writing archive: %v", err)
+	}
+	if err := extractArchive(&buf, dst); err != nil {
+		t.Fatalf("unexpected error extracting archive: %v", err)
+	}
+	for name, contents := range files {
+		actual, err := os.ReadFile(filepath.Join(dst, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.StringEqual(t, contents, string(actual))
+	}
+}
+
+func TestConfigForSnapshot(t *testing.T) {
+	t.Parallel()
+	manifest := &Manifest{
+		Name: "kind",
+		Nodes: []Node{
+			{Suffix: "worker2", Role: "worker", Image: "kind-snapshot/kind-worker2:latest", IPv4: "172.18.0.4", IPv6: "fc00:f853:ccd:e793::4"},
+			{Suffix: "control-plane", Role: "control-plane", Image: "kind-snapshot/kind-control-plane:latest", IPv4: "172.18.0.2", IPv6: "fc00:f853:ccd:e793::2"},
+			{Suffix: "worker", Role: "worker", Image: "kind-snapshot/kind-worker:latest", IPv4: "172.18.0.3", IPv6: "fc00:f853:ccd:e793::3"},
+		},
+	}
+
+	cfg, err := configForSnapshot(manifest, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.StringEqual(t, "kind", cfg.Name)
+	images := []string{}
+	addresses := []string{}
+	for _, n := range cfg.Nodes {
+		images = append(images, n.Image)
+		// only the addresses of the default ipv4 family are pinned
+		addresses = append(addresses, n.IPv4Address+","+n.IPv6Address)
+	}
+	assert.DeepEqual(t, []string{
+		"kind-snapshot/kind-worker:latest",
+		"kind-snapshot/kind-control-plane:latest",
+		"kind-snapshot/kind-worker2:latest",
+	}, images)
+	assert.DeepEqual(t, []string{"172.18.0.3,", "172.18.0.2,", "172.18.0.4,"}, addresses)
+
+	newConfig := func(mutate func(*config.Cluster)) *config.Cluster {
+		cfg := &config.Cluster{Nodes: []config.Node{{Role: config.ControlPlaneRole}, {Role: config.WorkerRole}, {Role: config.WorkerRole}}}
+		mutate(cfg)
+		config.SetDefaultsCluster(cfg)
+		return cfg
+	}
+	cases := []struct {
+		Name   string
+		Config *config.Cluster
+	}{
+		{
+			// a config must match the snapshot's nodes
+			Name: "mismatched nodes",
+			Config: newConfig(func(cfg *config.Cluster) {
+				cfg.Nodes[1].Role = config.ControlPlaneRole
+			}),
+		},
+		{
+			Name: "other cluster name",
+			Config: newConfig(func(cfg *config.Cluster) {
+				cfg.Name = "other"
+			}),
+		},
+		{
+			Name: "other node address",
+			Config: newConfig(func(cfg *config.Cluster) {
+				cfg.Nodes[0].IPv4Address = "172.18.0.9"
+			}),
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := configForSnapshot(manifest, tc.Config); err == nil {
+				t.Errorf("expected an error for a config not matching the snapshot")
+			}
+		})
+	}
+}
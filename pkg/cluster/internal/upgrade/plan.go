@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upgrade implements checking whether a cluster can be upgraded
+// in-place to a new node image
+package upgrade
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+	"sigs.k8s.io/kind/pkg/internal/version"
+)
+
+// Component is the version of a node component in the running cluster
+// and in the target node image
+type Component struct {
+	Name    string
+	Current string
+	Target  string
+}
+
+// Plan is the result of checking an in-place upgrade to a node image
+type Plan struct {
+	// Components are the versions of the node components
+	Components []Component
+	// Viable is false if the upgrade is known not to be supported
+	Viable bool
+	// Reasons explains why the upgrade is not viable
+	Reasons []string
+	// KubeadmOutput is the output of kubeadm upgrade plan run from the target image
+	KubeadmOutput []string
+}
+
+// components maps component names to how their version is read from a node
+var components = []struct {
+	name    string
+	version func(nodes.Node) (string, error)
+}{
+	{name: "kubernetes", version: nodeutils.KubeVersion},
+	{name: "kubeadm", version: commandVersion(-1, "kubeadm", "version", "-o", "short")},
+	{name: "kubelet", version: commandVersion(-1, "kubelet", "--version")},
+	// containerd github.com/containerd/containerd v1.7.13 7c3aca7a61...
+	{name: "containerd", version: commandVersion(2, "containerd", "--version")},
+}
+
+// Compute computes a Plan for upgrading the cluster of controlPlane to the
+// image of scratch, a node started from the target image on the cluster
+// network. Only scratch is modified.
+func Compute(controlPlane, scratch nodes.Node) (*Plan, error) {
+	plan := &Plan{}
+	for _, c := range components {
+		current, err := c.version(controlPlane)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get current %s version", c.name)
+		}
+		target, err := c.version(scratch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get target %s version", c.name)
+		}
+		plan.Components = append(plan.Components, Component{
+			Name:    c.name,
+			Current: current,
+			Target:  target,
+		})
+	}
+	plan.Reasons = checkSkew(plan.Components[0].Current, plan.Components[0].Target)
+
+	// run kubeadm from the target image against the running cluster
+	var kubeconfig strings.Builder
+	if err := controlPlane.Command("cat", "/etc/kubernetes/admin.conf").SetStdout(&kubeconfig).Run(); err != nil {
+		return nil, errors.Wrap(err, "failed to read admin kubeconfig")
+	}
+	if err := nodeutils.WriteFile(scratch, "/etc/kubernetes/admin.conf", kubeconfig.String()); err != nil {
+		return nil, errors.Wrap(err, "failed to write admin kubeconfig to scratch node")
+	}
+	output, err := exec.CombinedOutputLines(scratch.Command(
+		"kubeadm", "upgrade", "plan", plan.Components[0].Target,
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"--allow-experimental-upgrades",
+		"--allow-release-candidate-upgrades",
+		// the scratch node is not a control plane node
+		"--ignore-preflight-errors=all",
+	))
+	plan.KubeadmOutput = output
+	if err != nil {
+		plan.Reasons = append(plan.Reasons, "kubeadm upgrade plan failed")
+	}
+	plan.Viable = len(plan.Reasons) == 0
+	return plan, nil
+}
+
+// checkSkew returns the reasons kubeadm will not upgrade from current to target
+func checkSkew(current, target string) []string {
+	c, err := version.ParseSemantic(current)
+	if err != nil {
+		return []string{fmt.Sprintf("could not parse current version %q", current)}
+	}
+	t, err := version.ParseSemantic(target)
+	if err != nil {
+		return []string{fmt.Sprintf("could not parse target version %q", target)}
+	}
+	reasons := []string{}
+	if t.LessThan(c) {
+		reasons = append(reasons, fmt.Sprintf("target version %s is older than current version %s, downgrades are not supported", target, current))
+	}
+	if t.Major() != c.Major() || t.Minor() > c.Minor()+1 {
+		reasons = append(reasons, fmt.Sprintf("kubeadm only supports upgrading one minor version at a time, %s to %s skips minor versions", current, target))
+	}
+	return reasons
+}
+
+// commandVersion returns a function reading a version from the given field
+// of the first line of output of a command, negative fields count from the end
+func commandVersion(field int, command string, args ...string) func(nodes.Node) (string, error) {
+	return func(n nodes.Node) (string, error) {
+		lines, err := exec.OutputLines(n.Command(command, args...))
+		if err != nil {
+			return "", err
+		}
+		if len(lines) == 0 {
+			return "", errors.Errorf("%s produced no output", command)
+		}
+		return parseField(lines[0], field)
+	}
+}
+
+func parseField(line string, field int) (string, error) {
+	fields := strings.Fields(line)
+	if field < 0 {
+		field += len(fields)
+	}
+	if field < 0 || field >= len(fields) {
+		return "", errors.Errorf("failed to parse version from %q", line)
+	}
+	return fields[field], nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestCheckSkew(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name    string
+		Current string
+		Target  string
+		Viable  bool
+	}{
+		{
+			Name:    "patch upgrade",
+			Current: "v1.29.1",
+			Target:  "v1.29.2",
+			Viable:  true,
+		},
+		{
+			Name:    "minor upgrade",
+			Current: "v1.29.2",
+			Target:  "v1.30.0",
+			Viable:  true,
+		},
+		{
+			Name:    "same version",
+			Current: "v1.30.0",
+			Target:  "v1.30.0",
+			Viable:  true,
+		},
+		{
+			Name:    "skips a minor version",
+			Current: "v1.28.0",
+			Target:  "v1.30.0",
+		},
+		{
+			Name:    "downgrade",
+			Current: "v1.30.0",
+			Target:  "v1.29.2",
+		},
+		{
+			Name:    "invalid target",
+			Current: "v1.30.0",
+			Target:  "latest",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			reasons := checkSkew(tc.Current, tc.Target)
+			assert.BoolEqual(t, tc.Viable, len(reasons) == 0)
+		})
+	}
+}
+
+func TestParseField(t *testing.T) {
+	t.Parallel()
+	v, err := parseField("Kubernetes v1.30.0", -1)
+	assert.ExpectError(t, false, err)
+	assert.StringEqual(t, "v1.30.0", v)
+	v, err = parseField("containerd github.com/containerd/containerd v1.7.13 7c3aca7a61", 2)
+	assert.ExpectError(t, false, err)
+	assert.StringEqual(t, "v1.7.13", v)
+	_, err = parseField("", -1)
+	assert.ExpectError(t, true, err)
+}
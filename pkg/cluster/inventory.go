@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/create/actions/inventory"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+)
+
+// Inventory returns the JSON manifest of the images, binaries and manifests
+// kind installed into the cluster, as recorded when it was created
+func (p *Provider) Inventory(name string) ([]byte, error) {
+	name = defaultName(name)
+	n, err := p.provider.ListNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("unknown cluster %q", name)
+	}
+	node, err := nodeutils.BootstrapControlPlaneNode(n)
+	if err != nil {
+		return nil, err
+	}
+	var raw bytes.Buffer
+	if err := node.Command("cat", inventory.Path).SetStdout(&raw).Run(); err != nil {
+		return nil, errors.Wrap(err, "failed to read inventory, clusters created by older versions of kind do not record one")
+	}
+	return raw.Bytes(), nil
+}
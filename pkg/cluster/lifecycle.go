@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// Pause stops all of the cluster's node containers, including any external
+// load balancer, without deleting them so the cluster can be resumed later
+func (p *Provider) Pause(name string) error {
+	n, err := p.clusterNodes(name)
+	if err != nil {
+		return err
+	}
+	return p.provider.StopNodes(n)
+}
+
+// Resume starts all of the node containers of a cluster stopped with Pause
+// If the cluster's network was deleted meanwhile, e.g. by `docker system prune`,
+// it is recreated with its original subnets first, see MissingNetwork.
+// If wait is positive it then waits up to wait for the API server endpoint and
+// all nodes to be Ready
+func (p *Provider) Resume(name string, wait time.Duration) error {
+	n, err := p.clusterNodes(name)
+	if err != nil {
+		return err
+	}
+	network, err := p.provider.RepairNetwork(n)
+	if err != nil {
+		return err
+	}
+	if network != "" {
+		p.logger.V(0).Infof("Recreated deleted network %q", network)
+	}
+	if err := p.provider.StartNodes(n); err != nil {
+		return err
+	}
+	if wait <= 0 {
+		return nil
+	}
+	internalNodes, err := nodeutils.InternalNodes(n)
+	if err != nil {
+		return err
+	}
+	controlPlane, err := nodeutils.BootstrapControlPlaneNode(internalNodes)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(wait)
+	if err := p.WaitForControlPlaneEndpoint(name, wait); err != nil {
+		return err
+	}
+	fns := []func() error{}
+	for _, node := range internalNodes {
+		node := node // capture loop variable
+		fns = append(fns, func() error {
+			_, err := nodeutils.WaitForNodeReady(controlPlane, node, time.Until(deadline))
+			return err
+		})
+	}
+	return errors.AggregateConcurrent(fns)
+}
+
+// MissingNetwork returns the cluster's network if it no longer exists, or ""
+// if it exists. A missing network is recreated by Resume
+func (p *Provider) MissingNetwork(name string) (string, error) {
+	n, err := p.clusterNodes(name)
+	if err != nil {
+		return "", err
+	}
+	return p.provider.MissingNetwork(n)
+}
+
+// clusterNodes returns all of the cluster's nodes, or an error if there are none
+func (p *Provider) clusterNodes(name string) ([]nodes.Node, error) {
+	name = defaultName(name)
+	n, err := p.provider.ListNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("unknown cluster %q", name)
+	}
+	return n, nil
+}
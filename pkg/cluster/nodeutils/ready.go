@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutils
+
+import (
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// NodeReadiness is the Ready condition of a Kubernetes Node object
+type NodeReadiness struct {
+	// Ready is true if the condition status is True
+	Ready bool
+	// Reason is the condition's machine readable reason, e.g. KubeletNotReady
+	Reason string
+	// Message is the condition's human readable message
+	Message string
+}
+
+// GetNodeReadiness returns the readiness of the Kubernetes Node object for node,
+// using controlPlane to query the API server
+func GetNodeReadiness(controlPlane, node nodes.Node) (NodeReadiness, error) {
+	cmd := controlPlane.Command(
+		"kubectl",
+		"--kubeconfig=/etc/kubernetes/admin.conf",
+		"get",
+		"node",
+		// kind nodes are registered with their container name
+		node.String(),
+		`-o=jsonpath={range .status.conditions[?(@.type=="Ready")]}{.status}{"\t"}{.reason}{"\t"}{.message}{end}`,
+	)
+	lines, err := exec.OutputLines(cmd)
+	if err != nil {
+		return NodeReadiness{}, errors.Wrapf(err, "failed to get Node %q", node.String())
+	}
+	return parseNodeReadiness(lines), nil
+}
+
+// WaitForNodeReady waits up to timeout for the Kubernetes Node object for node
+// to be Ready, using controlPlane to query the API server
+// If the node is not Ready by the deadline an error is returned reporting
+// the last observed reason, the last observed readiness is always returned
+func WaitForNodeReady(controlPlane, node nodes.Node, timeout time.Duration) (NodeReadiness, error) {
+	deadline := time.Now().Add(timeout)
+	var readiness NodeReadiness
+	var err error
+	for {
+		readiness, err = GetNodeReadiness(controlPlane, node)
+		if err == nil && readiness.Ready {
+			return readiness, nil
+		}
+		if !time.Now().Before(deadline) {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "timed out after %s waiting for Node %q to be Ready", timeout, node.String())
+	} else if readiness.Reason == "" {
+		err = errors.Errorf("timed out after %s waiting for Node %q to be Ready", timeout, node.String())
+	} else {
+		err = errors.Errorf(
+			"timed out after %s waiting for Node %q to be Ready: %s: %s",
+			timeout, node.String(), readiness.Reason, readiness.Message,
+		)
+	}
+	return readiness, errors.WithDetails(err, errors.Details{
+		Category: errors.TimeoutCategory,
+		Node:     node.String(),
+	})
+}
+
+// parseNodeReadiness parses the tab separated status, reason
+// and message of the Ready condition
+func parseNodeReadiness(lines []string) NodeReadiness {
+	if len(lines) == 0 {
+		return NodeReadiness{}
+	}
+	// the message may span multiple lines
+	parts := strings.SplitN(strings.Join(lines, "\n"), "\t", 3)
+	readiness := NodeReadiness{Ready: parts[0] == "True"}
+	if len(parts) > 1 {
+		readiness.Reason = parts[1]
+	}
+	if len(parts) > 2 {
+		readiness.Message = parts[2]
+	}
+	return readiness
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutils
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestParseNodeReadiness(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name     string
+		Lines    []string
+		Expected NodeReadiness
+	}{
+		{
+			Name:     "no ready condition",
+			Lines:    nil,
+			Expected: NodeReadiness{},
+		},
+		{
+			Name:  "ready",
+			Lines: []string{"True\tKubeletReady\tkubelet is posting ready status"},
+			Expected: NodeReadiness{
+				Ready:   true,
+				Reason:  "KubeletReady",
+				Message: "kubelet is posting ready status",
+			},
+		},
+		{
+			Name: "not ready with multi-line message",
+			Lines: []string{
+				"False\tKubeletNotReady\tcontainer runtime network not ready:",
+				"cni plugin not initialized",
+			},
+			Expected: NodeReadiness{
+				Reason:  "KubeletNotReady",
+				Message: "container runtime network not ready:\ncni plugin not initialized",
+			},
+		},
+		{
+			Name:     "unknown without reason",
+			Lines:    []string{"Unknown"},
+			Expected: NodeReadiness{},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, tc.Expected, parseNodeReadiness(tc.Lines))
+		})
+	}
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package nodeutils
 
 import (
+	"archive/tar"
 	"bytes"
 	"encoding/json"
 	"io"
+	"os"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/pelletier/go-toml"
@@ -78,17 +81,153 @@ func CopyNodeToNode(a, b nodes.Node, file string) error {
 
 // LoadImageArchive loads image onto the node, where image is a Reader over an image archive
 func LoadImageArchive(n nodes.Node, image io.Reader) error {
+	return importArchive(n, image, "")
+}
+
+// importArchive imports the image archive read from image into containerd on
+// the node, naming the image index indexName if it is set
+func importArchive(n nodes.Node, image io.Reader, indexName string) error {
 	snapshotter, err := getSnapshotter(n)
 	if err != nil {
 		return err
 	}
-	cmd := n.Command("ctr", "--namespace=k8s.io", "images", "import", "--all-platforms", "--digests", "--snapshotter="+snapshotter, "-").SetStdin(image)
+	args := []string{"--namespace=k8s.io", "images", "import", "--all-platforms", "--digests", "--snapshotter=" + snapshotter}
+	if indexName != "" {
+		args = append(args, "--index-name="+indexName)
+	}
+	cmd := n.Command("ctr", append(args, "-")...).SetStdin(image)
 	if err := cmd.Run(); err != nil {
 		return errors.Wrap(err, "failed to load image")
 	}
 	return nil
 }
 
+// LoadOCILayout loads all images in the OCI image layout directory at dir on
+// the host into containerd on the node, naming the image index indexName if
+// it is set
+// Like ImportOCILayout, blobs already in the containerd content store are not
+// copied
+func LoadOCILayout(n nodes.Node, dir, indexName string) error {
+	for _, name := range []string{"oci-layout", "index.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return errors.Errorf("%s is not an OCI image layout, it has no %s", dir, name)
+		}
+	}
+	layoutBlobs := []string{}
+	entries, err := os.ReadDir(filepath.Join(dir, "blobs", "sha256"))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to list blobs in %s", dir)
+	}
+	for _, e := range entries {
+		layoutBlobs = append(layoutBlobs, e.Name())
+	}
+	storeBlobs, err := listBlobs(n, contentStoreBlobs)
+	if err != nil {
+		return err
+	}
+	files := append([]string{"oci-layout", "index.json"}, missingBlobs(layoutBlobs, storeBlobs)...)
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(writeArchive(pw, dir, files))
+	}()
+	err = importArchive(n, pr, indexName)
+	// unblock the writer if the import failed before reading everything
+	_ = pr.CloseWithError(err)
+	return err
+}
+
+// writeArchive writes files, which are slash separated paths relative to dir,
+// to w as a tar archive
+func writeArchive(w io.Writer, dir string, files []string) error {
+	tw := tar.NewWriter(w)
+	for _, name := range files {
+		if err := writeArchiveFile(tw, dir, name); err != nil {
+			return errors.Wrapf(err, "failed to archive %s", name)
+		}
+	}
+	return tw.Close()
+}
+
+func writeArchiveFile(tw *tar.Writer, dir, name string) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// contentStoreBlobs is where containerd stores the blobs on the node
+const contentStoreBlobs = "/var/lib/containerd/io.containerd.content.v1.content/blobs/sha256"
+
+// ImportOCILayout imports all images in the OCI image layout directory at
+// dir on the node into containerd
+// Blobs already in the containerd content store are not copied
+func ImportOCILayout(n nodes.Node, dir string) error {
+	snapshotter, err := getSnapshotter(n)
+	if err != nil {
+		return err
+	}
+	layoutBlobs, err := listBlobs(n, path.Join(dir, "blobs", "sha256"))
+	if err != nil {
+		return err
+	}
+	storeBlobs, err := listBlobs(n, contentStoreBlobs)
+	if err != nil {
+		return err
+	}
+	// ctr only imports archives, so stream the layout as one, the blobs
+	// left out are read from the content store
+	files := append([]string{"oci-layout", "index.json"}, missingBlobs(layoutBlobs, storeBlobs)...)
+	cmd := n.Command("bash", "-c",
+		`set -o pipefail; tar -C "$1" -c -T - | ctr --namespace=k8s.io images import --all-platforms --digests --snapshotter="$2" -`,
+		"-", dir, snapshotter,
+	).SetStdin(strings.NewReader(strings.Join(files, "\n") + "\n"))
+	if err := cmd.Run(); err != nil {
+		return errors.Wrap(err, "failed to import OCI layout")
+	}
+	return nil
+}
+
+// listBlobs lists the blob digests in the directory dir on the node, which
+// may not exist
+func listBlobs(n nodes.Node, dir string) ([]string, error) {
+	lines, err := exec.OutputLines(n.Command("bash", "-c", `[ ! -d "$1" ] || ls -1 "$1"`, "-", dir))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list blobs in %s", dir)
+	}
+	return lines, nil
+}
+
+// missingBlobs returns the layout paths of the layout blobs not in the store
+func missingBlobs(layoutBlobs, storeBlobs []string) []string {
+	stored := make(map[string]bool, len(storeBlobs))
+	for _, b := range storeBlobs {
+		stored[b] = true
+	}
+	missing := []string{}
+	for _, b := range layoutBlobs {
+		if b != "" && !stored[b] {
+			missing = append(missing, path.Join("blobs", "sha256", b))
+		}
+	}
+	return missing
+}
+
 func getSnapshotter(n nodes.Node) (string, error) {
 	out, err := exec.Output(n.Command("containerd", "config", "dump"))
 	if err != nil {
@@ -17,7 +17,14 @@ limitations under the License.
 package nodeutils
 
 import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
 )
 
 func TestParseSnapshotter(t *testing.T) {
@@ -262,3 +269,87 @@ func TestParseSnapshotter(t *testing.T) {
 		t.Fatal("expected error parsing invalid config")
 	}
 }
+
+func TestMissingBlobs(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		LayoutBlobs []string
+		StoreBlobs  []string
+		Expected    []string
+	}{
+		{
+			Name:        "empty store",
+			LayoutBlobs: []string{"aaa", "bbb"},
+			Expected:    []string{"blobs/sha256/aaa", "blobs/sha256/bbb"},
+		},
+		{
+			Name:        "some blobs stored",
+			LayoutBlobs: []string{"aaa", "bbb", "ccc"},
+			StoreBlobs:  []string{"bbb", "ddd"},
+			Expected:    []string{"blobs/sha256/aaa", "blobs/sha256/ccc"},
+		},
+		{
+			Name:        "all blobs stored",
+			LayoutBlobs: []string{"aaa"},
+			StoreBlobs:  []string{"aaa"},
+			Expected:    []string{},
+		},
+		{
+			Name:     "empty layout",
+			Expected: []string{},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, tc.Expected, missingBlobs(tc.LayoutBlobs, tc.StoreBlobs))
+		})
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.json":                 "{}",
+		"oci-layout":                 `{"imageLayoutVersion":"1.0.0"}`,
+		"blobs/sha256/0123456789abc": "blob",
+		"blobs/sha256/def":           "stored blob",
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writeArchive(io.Discard, dir, []string{"blobs/sha256/missing"}); err == nil {
+		t.Fatal("expected an error archiving a missing file")
+	}
+	var buff bytes.Buffer
+	if err := writeArchive(&buff, dir, []string{"oci-layout", "index.json", "blobs/sha256/0123456789abc"}); err != nil {
+		t.Fatal(err)
+	}
+	archived := map[string]string{}
+	tr := tar.NewReader(&buff)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		archived[hdr.Name] = string(contents)
+	}
+	delete(files, "blobs/sha256/def")
+	assert.DeepEqual(t, files, archived)
+}
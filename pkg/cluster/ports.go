@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// PublishedPort is a container port of a node published on the host, e.g.
+// the API server, the external load balancer or an extraPortMapping
+type PublishedPort struct {
+	Node          string `json:"node"`
+	ContainerPort int32  `json:"containerPort"`
+	// Protocol is TCP, UDP or SCTP
+	Protocol      string `json:"protocol"`
+	ListenAddress string `json:"listenAddress"`
+	HostPort      int32  `json:"hostPort"`
+}
+
+// PublishedPorts returns the host ports published by all of the cluster's
+// node containers, including any external load balancer
+func (p *Provider) PublishedPorts(name string) ([]PublishedPort, error) {
+	name = defaultName(name)
+	n, err := p.provider.ListNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("unknown cluster %q", name)
+	}
+	ports, err := p.provider.PublishedPorts(n)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]PublishedPort, 0, len(ports))
+	for _, port := range ports {
+		ret = append(ret, PublishedPort(port))
+	}
+	return ret, nil
+}
@@ -20,6 +20,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"sigs.k8s.io/kind/pkg/cmd/kind/version"
 
@@ -33,9 +34,12 @@ import (
 	internaldelete "sigs.k8s.io/kind/pkg/cluster/internal/delete"
 	"sigs.k8s.io/kind/pkg/cluster/internal/kubeconfig"
 	internalproviders "sigs.k8s.io/kind/pkg/cluster/internal/providers"
+	"sigs.k8s.io/kind/pkg/cluster/internal/providers/common"
 	"sigs.k8s.io/kind/pkg/cluster/internal/providers/docker"
 	"sigs.k8s.io/kind/pkg/cluster/internal/providers/nerdctl"
 	"sigs.k8s.io/kind/pkg/cluster/internal/providers/podman"
+	"sigs.k8s.io/kind/pkg/cluster/internal/routes"
+	"sigs.k8s.io/kind/pkg/internal/labels"
 )
 
 // DefaultName is the default cluster name
@@ -112,15 +116,34 @@ var NoNodeProviderDetectedError = errors.NewWithoutStack("failed to detect any s
 // In the future when this is not considered experimental,
 // that logic will be in a public API as well.
 func DetectNodeProvider() (ProviderOption, error) {
-	// auto-detect based on each node provider's IsAvailable() function
-	if docker.IsAvailable() {
-		return ProviderWithDocker(), nil
+	// auto-detect based on each node provider's IsAvailable() function,
+	// in priority order, preferring the first one that is also healthy
+	// i.e. can reach its container runtime
+	detectors := []struct {
+		isAvailable func() bool
+		isHealthy   func() bool
+		option      func() ProviderOption
+	}{
+		{docker.IsAvailable, docker.IsHealthy, ProviderWithDocker},
+		{nerdctl.IsAvailable, nerdctl.IsHealthy, func() ProviderOption { return ProviderWithNerdctl("") }},
+		{podman.IsAvailable, podman.IsHealthy, ProviderWithPodman},
 	}
-	if nerdctl.IsAvailable() {
-		return ProviderWithNerdctl(""), nil
+	var firstAvailable ProviderOption
+	for _, d := range detectors {
+		if !d.isAvailable() {
+			continue
+		}
+		if d.isHealthy() {
+			return d.option(), nil
+		}
+		if firstAvailable == nil {
+			firstAvailable = d.option()
+		}
 	}
-	if podman.IsAvailable() {
-		return ProviderWithPodman(), nil
+	// none are healthy, use the first available one so that the user gets
+	// the error from the runtime they most likely intended to use
+	if firstAvailable != nil {
+		return firstAvailable, nil
 	}
 	return nil, errors.WithStack(NoNodeProviderDetectedError)
 }
@@ -186,7 +209,10 @@ func (p *Provider) Create(name string, options ...CreateOption) error {
 	}
 	for _, o := range options {
 		if err := o.apply(opts); err != nil {
-			return err
+			return errors.WithDetails(err, errors.Details{
+				Category: errors.ConfigCategory,
+				Phase:    "config",
+			})
 		}
 	}
 	return internalcreate.Cluster(p.logger, p.provider, opts)
@@ -202,6 +228,52 @@ func (p *Provider) List() ([]string, error) {
 	return p.provider.ListClusters()
 }
 
+// ListWithSelector returns a list of clusters whose labels match selector
+// Selectors are comma separated requirements of the form key=value,
+// key!=value, key or !key
+func (p *Provider) ListWithSelector(selector string) ([]string, error) {
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+	clusters, err := p.provider.ListClusters()
+	if err != nil {
+		return nil, err
+	}
+	matched := []string{}
+	for _, cluster := range clusters {
+		clusterLabels, err := p.provider.GetClusterLabels(cluster)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get labels for cluster %q", cluster)
+		}
+		if s.Matches(clusterLabels) {
+			matched = append(matched, cluster)
+		}
+	}
+	return matched, nil
+}
+
+// GetLabels returns the labels recorded on the cluster at creation
+func (p *Provider) GetLabels(name string) (map[string]string, error) {
+	return p.provider.GetClusterLabels(defaultName(name))
+}
+
+// GetAnnotations returns the annotations recorded on the cluster at creation
+func (p *Provider) GetAnnotations(name string) (map[string]string, error) {
+	n, err := p.provider.ListNodes(defaultName(name))
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("unknown cluster %q", defaultName(name))
+	}
+	containerLabels, err := p.provider.GetNodeLabels(n[0])
+	if err != nil {
+		return nil, err
+	}
+	return common.ClusterAnnotations(containerLabels), nil
+}
+
 // KubeConfig returns the KUBECONFIG for the cluster
 // If internal is true, this will contain the internal IP etc.
 // If internal is false, this will contain the host IP etc.
@@ -217,6 +289,35 @@ func (p *Provider) ExportKubeConfig(name string, explicitPath string, internal b
 	return kubeconfig.Export(p.provider, defaultName(name), explicitPath, !internal)
 }
 
+// WaitForControlPlaneEndpoint polls the cluster's exported API server endpoint
+// until it serves a certificate verifying against the cluster CA recorded in
+// the KUBECONFIG and reports ready, or fails once timeout passes.
+// Tools should wait on this rather than race the API server TLS bootstrap.
+func (p *Provider) WaitForControlPlaneEndpoint(name string, timeout time.Duration) error {
+	return kubeconfig.WaitForEndpoint(p.provider, defaultName(name), timeout)
+}
+
+// KubeConfigPath returns the file ExportKubeConfig and Create write the
+// KUBECONFIG to, where explicitPath is the --kubeconfig value.
+func (p *Provider) KubeConfigPath(explicitPath string) string {
+	return kubeconfig.Path(explicitPath)
+}
+
+// StaleKubeConfigs returns the kubeconfig files with an entry for the cluster
+// that no longer points at its API server endpoint, see RepairKubeConfig
+// explicitPath is handled as in ExportKubeConfig
+func (p *Provider) StaleKubeConfigs(name, explicitPath string) ([]string, error) {
+	return kubeconfig.Stale(p.provider, defaultName(name), explicitPath)
+}
+
+// RepairKubeConfig re-resolves the cluster's API server endpoint and rewrites
+// any kubeconfig entries for the cluster pointing elsewhere, returning the
+// files updated. This is useful when the published host ports change, e.g.
+// on Docker Desktop restarts.
+func (p *Provider) RepairKubeConfig(name, explicitPath string) ([]string, error) {
+	return kubeconfig.Repair(p.provider, defaultName(name), explicitPath)
+}
+
 // ListNodes returns the list of container IDs for the "nodes" in the cluster
 func (p *Provider) ListNodes(name string) ([]nodes.Node, error) {
 	return p.provider.ListNodes(defaultName(name))
@@ -232,6 +333,35 @@ func (p *Provider) ListInternalNodes(name string) ([]nodes.Node, error) {
 	return nodeutils.InternalNodes(n)
 }
 
+// InstallRoutes adds host routes to the cluster's pod and service subnets
+// via the node containers, so they can be reached directly from the host.
+// This is only supported on linux and typically requires root.
+func (p *Provider) InstallRoutes(name string) error {
+	n, err := p.provider.ListNodes(defaultName(name))
+	if err != nil {
+		return err
+	}
+	if len(n) == 0 {
+		return errors.Errorf("unknown cluster %q", defaultName(name))
+	}
+	r, err := routes.Plan(n)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute host routes")
+	}
+	return routes.Install(p.logger, n, r)
+}
+
+// RemoveRoutes removes the host routes InstallRoutes added via the cluster's
+// node containers
+func (p *Provider) RemoveRoutes(name string) error {
+	n, err := p.provider.ListNodes(defaultName(name))
+	if err != nil {
+		return err
+	}
+	_, err = routes.Remove(p.logger, n)
+	return err
+}
+
 // CollectLogs will populate dir with cluster logs and other debug files
 func (p *Provider) CollectLogs(name, dir string) error {
 	// TODO: should use ListNodes and Collect should handle nodes differently
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/prune"
+)
+
+// PruneResult is the outcome of pruning the images on a node
+type PruneResult struct {
+	Node string
+	// Removed are the names of the removed images, or their IDs if untagged
+	Removed []string
+	// ReclaimedBytes is the disk space reclaimed on the node
+	ReclaimedBytes int64
+}
+
+// PruneImages removes the images on each of the cluster's nodes that are not
+// used by any container, except for images kind itself needs and images
+// whose names start with any of the keep prefixes
+// If dryRun is true the images that would be removed are only reported
+func (p *Provider) PruneImages(name string, keep []string, dryRun bool) ([]PruneResult, error) {
+	n, err := p.clusterNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	internalNodes, err := nodeutils.InternalNodes(n)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]PruneResult, len(internalNodes))
+	fns := make([]func() error, len(internalNodes))
+	for i, node := range internalNodes {
+		i, node := i, node // capture loop variables
+		fns[i] = func() error {
+			r, err := prune.Images(node, keep, dryRun)
+			results[i] = PruneResult(r)
+			return errors.Wrapf(err, "failed to prune images on node %s", node.String())
+		}
+	}
+	return results, errors.AggregateConcurrent(fns)
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+)
+
+// CreateRegistry ensures the local registry container shared by clusters
+// with localRegistry enabled is running, published on the host at
+// localhost:port, an existing registry is reused
+func (p *Provider) CreateRegistry(port int32) error {
+	return p.provider.EnsureRegistry(constants.LocalRegistryName, port)
+}
+
+// DeleteRegistry deletes the local registry container if it exists
+// Clusters using the registry are not deleted, but can no longer pull from it
+func (p *Provider) DeleteRegistry() error {
+	return p.provider.DeleteRegistry(constants.LocalRegistryName)
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"time"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/scale"
+	internalencoding "sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/cli"
+)
+
+// ScaleWorkers adds or removes worker nodes of an existing cluster until it
+// has workers of them, new nodes are joined and removed nodes are drained
+// If configPath is set it should be the config the cluster was created with,
+// new workers are then configured like the last worker in it
+// If wait is positive ScaleWorkers waits up to wait for new nodes to be Ready
+func (p *Provider) ScaleWorkers(name string, workers int, configPath string, wait time.Duration) error {
+	var cfg *config.Cluster
+	if configPath != "" {
+		var err error
+		cfg, err = internalencoding.Load(configPath)
+		if err != nil {
+			return err
+		}
+	}
+	return scale.Workers(p.logger, cli.StatusForLogger(p.logger), p.provider, defaultName(name), cfg, workers, wait)
+}
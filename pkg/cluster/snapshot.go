@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/snapshot"
+	internalencoding "sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/cli"
+)
+
+// ExportSnapshot writes the cluster's node containers, including their
+// volumes and etcd data, to an archive at path
+// If configPath is set the cluster config is recorded in the snapshot
+// The cluster is stopped while the snapshot is taken, and started again after
+func (p *Provider) ExportSnapshot(name, path, configPath string) error {
+	var rawConfig []byte
+	if configPath != "" {
+		var err error
+		rawConfig, err = os.ReadFile(configPath)
+		if err != nil {
+			return err
+		}
+	}
+	return snapshot.Export(p.logger, p.provider, defaultName(name), path, rawConfig)
+}
+
+// ImportSnapshot recreates a cluster from an archive written by ExportSnapshot
+// and exports its kubeconfig to kubeconfigPath, or the default kubeconfig if empty
+// If name is not empty it must be the name of the exported cluster
+// If configPath is set the cluster config is loaded from it, otherwise the
+// config recorded in the snapshot or a default config with the snapshot's
+// nodes is used, its nodes must match the snapshot
+func (p *Provider) ImportSnapshot(path, name, configPath, kubeconfigPath string) error {
+	var cfg *config.Cluster
+	if configPath != "" {
+		var err error
+		cfg, err = internalencoding.Load(configPath)
+		if err != nil {
+			return err
+		}
+	}
+	return snapshot.Import(p.logger, cli.StatusForLogger(p.logger), p.provider, path, name, cfg, kubeconfigPath)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// NodeStats is a snapshot of the resource usage of a node container
+type NodeStats struct {
+	Name       string
+	CPUPercent float64
+	// MemoryBytes is the memory usage, MemoryLimitBytes the limit if any
+	MemoryBytes      int64
+	MemoryLimitBytes int64
+	PIDs             int64
+	NetRxBytes       int64
+	NetTxBytes       int64
+	BlockReadBytes   int64
+	BlockWriteBytes  int64
+	// DiskBytes is the disk usage of the node's /var volume
+	DiskBytes int64
+}
+
+// NodeStats returns a snapshot of the resource usage of all of the
+// cluster's node containers, including any external load balancer
+func (p *Provider) NodeStats(name string) ([]NodeStats, error) {
+	name = defaultName(name)
+	n, err := p.provider.ListNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("unknown cluster %q", name)
+	}
+	stats, err := p.provider.NodeStats(n)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]NodeStats, 0, len(stats))
+	for _, s := range stats {
+		ret = append(ret, NodeStats(s))
+	}
+	return ret, nil
+}
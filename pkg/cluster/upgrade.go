@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sigs.k8s.io/kind/pkg/cluster/nodes"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/errors"
+
+	"sigs.k8s.io/kind/pkg/cluster/internal/upgrade"
+)
+
+// UpgradeComponent is the version of a node component in the running
+// cluster and in the target node image of an UpgradePlan
+type UpgradeComponent struct {
+	Name    string
+	Current string
+	Target  string
+}
+
+// UpgradePlan reports whether a cluster can be upgraded in-place to a node image
+type UpgradePlan struct {
+	// Image is the target node image
+	Image string
+	// Components are the versions of the node components
+	Components []UpgradeComponent
+	// Viable is false if the upgrade is known not to be supported
+	Viable bool
+	// Reasons explains why the upgrade is not viable
+	Reasons []string
+	// KubeadmOutput is the output of kubeadm upgrade plan run from the target image
+	KubeadmOutput []string
+}
+
+// UpgradePlan checks upgrading the cluster name in-place to the node image
+// by running a scratch container of the image, the cluster is not modified
+func (p *Provider) UpgradePlan(name, image string) (*UpgradePlan, error) {
+	name = defaultName(name)
+	n, err := p.ListInternalNodes(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(n) == 0 {
+		return nil, errors.Errorf("unknown cluster %q", name)
+	}
+	controlPlane, err := nodeutils.BootstrapControlPlaneNode(n)
+	if err != nil {
+		return nil, err
+	}
+	p.logger.V(0).Infof("Starting scratch node from %s ...", image)
+	scratch, err := p.provider.CreateScratchNode(name, image)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := p.provider.DeleteNodes([]nodes.Node{scratch}); err != nil {
+			p.logger.Warnf("failed to delete scratch node %s: %v", scratch.String(), err)
+		}
+	}()
+	plan, err := upgrade.Compute(controlPlane, scratch)
+	if err != nil {
+		return nil, err
+	}
+	result := &UpgradePlan{
+		Image:         image,
+		Viable:        plan.Viable,
+		Reasons:       plan.Reasons,
+		KubeadmOutput: plan.KubeadmOutput,
+	}
+	for _, c := range plan.Components {
+		result.Components = append(result.Components, UpgradeComponent(c))
+	}
+	return result, nil
+}
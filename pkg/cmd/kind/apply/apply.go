@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apply implements the `apply` command
+package apply
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name     string
+	Filename string
+	DryRun   bool
+	Wait     time.Duration
+	// ImageName and Ingress must match the create flags of the cluster
+	ImageName string
+	Ingress   string
+}
+
+// NewCommand returns a new cobra.Command for reconciling a cluster with a config
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "apply",
+		Short: "Reconciles an existing cluster with a config file",
+		Long: "Compares a config file with the config an existing cluster was created with, then adds, " +
+			"removes or recreates worker nodes to match it. Changes to control-plane nodes or cluster-wide " +
+			"settings require recreating the cluster",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, streams, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		"",
+		"cluster name, overrides KIND_CLUSTER_NAME, config (default kind)",
+	)
+	cmd.Flags().StringVarP(
+		&flags.Filename,
+		"filename",
+		"f",
+		"",
+		"path to a kind config file, or - to read it from stdin",
+	)
+	_ = cmd.MarkFlagRequired("filename")
+	cmd.Flags().BoolVar(
+		&flags.DryRun,
+		"dry-run",
+		false,
+		"only print the changes that would be made",
+	)
+	cmd.Flags().DurationVar(
+		&flags.Wait,
+		"wait",
+		time.Duration(0),
+		"wait for new nodes to be ready (default 0s)",
+	)
+	cmd.Flags().StringVar(
+		&flags.ImageName,
+		"image",
+		"",
+		"node docker image, as passed to kind create cluster --image",
+	)
+	cmd.Flags().StringVar(
+		&flags.Ingress,
+		"ingress",
+		"",
+		"ingress controller, as passed to kind create cluster --ingress",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	raw, err := readConfig(flags.Filename, streams.In)
+	if err != nil {
+		return err
+	}
+	options := []cluster.CreateOption{
+		cluster.CreateWithNodeImage(flags.ImageName),
+		cluster.CreateWithIngress(flags.Ingress),
+	}
+	var changes []cluster.NodeChange
+	if flags.DryRun {
+		changes, err = provider.PlanApply(flags.Name, raw, options...)
+	} else {
+		changes, err = provider.Apply(flags.Name, raw, flags.Wait, options...)
+	}
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(streams.Out, "No changes")
+		return nil
+	}
+	for _, c := range changes {
+		fmt.Fprintln(streams.Out, c.String())
+	}
+	return nil
+}
+
+func readConfig(filename string, stdin io.Reader) ([]byte, error) {
+	if filename == "-" {
+		raw, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading config from stdin")
+		}
+		return raw, nil
+	}
+	return os.ReadFile(filename)
+}
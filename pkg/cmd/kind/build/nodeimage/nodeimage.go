@@ -17,6 +17,8 @@ limitations under the License.
 package nodeimage
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kind/pkg/build/nodeimage"
@@ -32,6 +34,8 @@ type flagpole struct {
 	BaseImage string
 	KubeRoot  string
 	Arch      string
+	Config    string
+	Push      bool
 }
 
 // NewCommand returns a new cobra.Command for building the node image
@@ -81,7 +85,19 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		&flags.Arch,
 		"arch",
 		"",
-		"architecture to build for, defaults to the host architecture",
+		"architecture to build for, defaults to the host architecture; a comma separated list builds each as image-arch, e.g. amd64,arm64",
+	)
+	cmd.Flags().BoolVar(
+		&flags.Push,
+		"push",
+		false,
+		"push the built image, with multiple architectures also push a manifest list for them named --image",
+	)
+	cmd.Flags().StringVar(
+		&flags.Config,
+		"config",
+		"",
+		"path to a build config file with extra images and packages for the image",
 	)
 	return cmd
 }
@@ -91,14 +107,27 @@ func runE(logger log.Logger, flags *flagpole, args []string) error {
 	if len(args) > 0 {
 		kubeRoot = args[0]
 	}
-	if err := nodeimage.Build(
+	options := []nodeimage.Option{
 		nodeimage.WithImage(flags.Image),
 		nodeimage.WithBaseImage(flags.BaseImage),
 		nodeimage.WithKubeParam(kubeRoot),
 		nodeimage.WithLogger(logger),
-		nodeimage.WithArch(flags.Arch),
 		nodeimage.WithBuildType(flags.BuildType),
-	); err != nil {
+		nodeimage.WithPush(flags.Push),
+	}
+	if arches := strings.Split(flags.Arch, ","); len(arches) > 1 {
+		options = append(options, nodeimage.WithArches(arches))
+	} else {
+		options = append(options, nodeimage.WithArch(flags.Arch))
+	}
+	if flags.Config != "" {
+		cfg, err := nodeimage.LoadBuildConfig(flags.Config)
+		if err != nil {
+			return err
+		}
+		options = append(options, nodeimage.WithBuildConfig(cfg))
+	}
+	if err := nodeimage.Build(options...); err != nil {
 		return errors.Wrap(err, "error building node image")
 	}
 	return nil
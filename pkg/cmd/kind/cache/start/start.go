@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package start implements the `cache start` command
+package start
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Registries []string
+}
+
+// NewCommand returns a new cobra.Command for starting pull-through caches
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "start",
+		Short: "Starts pull-through image caches",
+		Long: "Starts a registry pull-through cache container for each registry, or starts the existing ones. " +
+			"Clusters created afterwards pull images from these registries through the caches",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(logger, flags)
+		},
+	}
+	cmd.Flags().StringSliceVar(
+		&flags.Registries,
+		"registry",
+		nil,
+		"registries to cache, one of: "+strings.Join(cluster.CacheRegistries(), ", ")+" (default all)",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, flags *flagpole) error {
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	if err := provider.StartCaches(flags.Registries...); err != nil {
+		return errors.Wrap(err, "failed to start caches")
+	}
+	caches, err := provider.ListCaches()
+	if err != nil {
+		return err
+	}
+	for _, registry := range cluster.CacheRegistries() {
+		if endpoint, ok := caches[registry]; ok {
+			logger.V(0).Infof("Caching %s at %s", registry, endpoint)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package stop implements the `cache stop` command
+package stop
+
+import (
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+// NewCommand returns a new cobra.Command for stopping pull-through caches
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	confirm := cli.ConfirmFlags{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "stop",
+		Short: "Stops pull-through image caches",
+		Long: "Deletes all pull-through cache containers and their cached images. " +
+			"Existing clusters fall back to pulling from the registries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(logger, streams, confirm)
+		},
+	}
+	confirm.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, confirm cli.ConfirmFlags) error {
+	if err := cli.Confirm(streams.In, streams.ErrOut, confirm, "delete the pull-through caches and their cached images"); err != nil {
+		return err
+	}
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	if err := provider.StopCaches(); err != nil {
+		return errors.Wrap(err, "failed to stop caches")
+	}
+	return nil
+}
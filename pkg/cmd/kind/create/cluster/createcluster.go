@@ -18,7 +18,9 @@ limitations under the License.
 package cluster
 
 import (
+	"fmt"
 	"io"
+	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -26,21 +28,40 @@ import (
 	"sigs.k8s.io/kind/pkg/cluster"
 	"sigs.k8s.io/kind/pkg/cmd"
 	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/sets"
 	"sigs.k8s.io/kind/pkg/log"
 
+	"sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
 	"sigs.k8s.io/kind/pkg/internal/cli"
 	"sigs.k8s.io/kind/pkg/internal/runtime"
+	"sigs.k8s.io/kind/pkg/internal/templates"
 )
 
 type flagpole struct {
-	Name       string
-	Config     string
-	ImageName  string
-	Retain     bool
-	Wait       time.Duration
-	Kubeconfig string
+	Name        string
+	Config      string
+	Template    string
+	ImageName   string
+	ImageBundle string
+	Ingress     string
+	Retain      bool
+	Wait        time.Duration
+	Kubeconfig  string
+	Labels      map[string]string
+	Annotations map[string]string
+	AllowMixed  bool
+	Output      string
+	// Workspace is the workspace root when the name was derived from it
+	Workspace string
 }
 
+const (
+	// outputKubeconfigPath prints only the kubeconfig path on stdout
+	outputKubeconfigPath = "kubeconfig-path"
+	// outputKubeconfig prints only the cluster's kubeconfig on stdout
+	outputKubeconfig = "kubeconfig"
+)
+
 // NewCommand returns a new cobra.Command for cluster creation
 func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	flags := &flagpole{}
@@ -50,7 +71,11 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		Short: "Creates a local Kubernetes cluster",
 		Long:  "Creates a local Kubernetes cluster using Docker container 'nodes'",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.OverrideDefaultName(cmd.Flags())
+			workspace, err := cli.OverrideDefaultNameWithWorkspace(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			flags.Workspace = workspace
 			return runE(logger, streams, flags)
 		},
 	}
@@ -59,7 +84,7 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		"name",
 		"n",
 		"",
-		"cluster name, overrides KIND_CLUSTER_NAME, config (default kind)",
+		"cluster name, overrides KIND_CLUSTER_NAME, config (default kind), auto derives it from the workspace",
 	)
 	cmd.Flags().StringVar(
 		&flags.Config,
@@ -67,18 +92,42 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		"",
 		"path to a kind config file",
 	)
+	cmd.Flags().StringVar(
+		&flags.Template,
+		"template",
+		"",
+		"name of a cluster config template to use instead of --config, see kind get templates",
+	)
 	cmd.Flags().StringVar(
 		&flags.ImageName,
 		"image",
 		"",
 		"node docker image to use for booting the cluster",
 	)
+	cmd.Flags().StringVar(
+		&flags.ImageBundle,
+		"image-bundle",
+		"",
+		"path to an image archive to load into every node before starting Kubernetes, overrides config",
+	)
+	cmd.Flags().StringVar(
+		&flags.Ingress,
+		"ingress",
+		"",
+		"ingress controller to install and serve on host ports 80 and 443, one of: nginx, contour, traefik, overrides config",
+	)
 	cmd.Flags().BoolVar(
 		&flags.Retain,
 		"retain",
 		false,
 		"retain nodes for debugging when cluster creation fails",
 	)
+	cmd.Flags().BoolVar(
+		&flags.AllowMixed,
+		"allow-mixed-images",
+		false,
+		"allow nodes to run images with different digests",
+	)
 	cmd.Flags().DurationVar(
 		&flags.Wait,
 		"wait",
@@ -91,49 +140,226 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		"",
 		"sets kubeconfig path instead of $KUBECONFIG or $HOME/.kube/config",
 	)
+	cmd.Flags().StringToStringVar(
+		&flags.Labels,
+		"labels",
+		nil,
+		"labels to record on the cluster, e.g. pr=1234,owner=alice",
+	)
+	cmd.Flags().StringToStringVar(
+		&flags.Annotations,
+		"annotations",
+		nil,
+		"annotations to record on the cluster",
+	)
+	cmd.Flags().StringVarP(
+		&flags.Output,
+		"output",
+		"o",
+		"",
+		"print only the result on stdout for scripting, one of: kubeconfig-path, kubeconfig; all other output goes to stderr",
+	)
 	return cmd
 }
 
 func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
+	switch flags.Output {
+	case "", outputKubeconfigPath, outputKubeconfig:
+	default:
+		return errors.Errorf("invalid --output %q, must be one of: %s, %s", flags.Output, outputKubeconfigPath, outputKubeconfig)
+	}
+
+	// handle config flag, we might need to read from stdin
+	var withConfig cluster.CreateOption
+	var rawConfig []byte
+	if flags.Template != "" {
+		if flags.Config != "" {
+			return errors.New("only one of --config and --template may be specified")
+		}
+		template, err := templates.Get(flags.Template, templates.Dirs())
+		if err != nil {
+			return err
+		}
+		rawConfig = template.Config
+		withConfig = cluster.CreateWithRawConfig(rawConfig)
+	} else if flags.Config != "" {
+		raw, err := readConfig(flags.Config, streams.In)
+		if err != nil {
+			return err
+		}
+		// a config with multiple Cluster documents creates all of them
+		docs, err := encoding.SplitDocuments(raw)
+		if err != nil {
+			return err
+		}
+		if len(docs) > 1 {
+			return createClusters(logger, streams, flags, docs)
+		}
+		rawConfig = raw
+		withConfig = cluster.CreateWithRawConfig(rawConfig)
+	} else {
+		withConfig = cluster.CreateWithConfigFile("")
+	}
+
 	provider := cluster.NewProvider(
 		cluster.ProviderWithLogger(logger),
 		runtime.GetDefault(logger),
 	)
 
-	// handle config flag, we might need to read from stdin
-	withConfig, err := configOption(flags.Config, streams.In)
-	if err != nil {
-		return err
+	if flags.Workspace != "" {
+		if err := claimWorkspaceName(provider, flags); err != nil {
+			return err
+		}
 	}
 
 	// create the cluster
-	if err = provider.Create(
+	if err := provider.Create(
 		flags.Name,
-		withConfig,
-		cluster.CreateWithNodeImage(flags.ImageName),
-		cluster.CreateWithRetain(flags.Retain),
-		cluster.CreateWithWaitForReady(flags.Wait),
-		cluster.CreateWithKubeconfigPath(flags.Kubeconfig),
-		cluster.CreateWithDisplayUsage(true),
-		cluster.CreateWithDisplaySalutation(true),
+		createOptions(flags,
+			withConfig,
+			cluster.CreateWithDisplayUsage(true),
+			cluster.CreateWithDisplaySalutation(true),
+		)...,
 	); err != nil {
 		return errors.Wrap(err, "failed to create cluster")
 	}
 
+	// the cluster name may come from the config when not set by flag
+	name := flags.Name
+	if name == "" && rawConfig != nil && flags.Output == outputKubeconfig {
+		cfg, err := encoding.Parse(rawConfig)
+		if err != nil {
+			return err
+		}
+		name = cfg.Name
+	}
+	return writeOutput(streams.Out, provider, name, flags)
+}
+
+// claimWorkspaceName records the workspace in the cluster annotations and
+// ensures a cluster with the derived name does not belong to another workspace
+func claimWorkspaceName(provider *cluster.Provider, flags *flagpole) error {
+	clusters, err := provider.List()
+	if err != nil {
+		return err
+	}
+	for _, c := range clusters {
+		if c != flags.Name {
+			continue
+		}
+		annotations, err := provider.GetAnnotations(c)
+		if err != nil {
+			return err
+		}
+		if annotations[cli.WorkspaceAnnotation] != flags.Workspace {
+			return errors.Errorf("cluster %q already exists and was not created from %s, pass --name to choose another name", c, flags.Workspace)
+		}
+	}
+	if flags.Annotations == nil {
+		flags.Annotations = map[string]string{}
+	}
+	flags.Annotations[cli.WorkspaceAnnotation] = flags.Workspace
 	return nil
 }
 
-// configOption converts the raw --config flag value to a cluster creation
-// option matching it. it will read from stdin if the flag value is `-`
-func configOption(rawConfigFlag string, stdin io.Reader) (cluster.CreateOption, error) {
-	// if not - then we are using a real file
+// kubeconfigProvider is the subset of *cluster.Provider writeOutput uses
+type kubeconfigProvider interface {
+	KubeConfigPath(explicitPath string) string
+	KubeConfig(name string, internal bool) (string, error)
+}
+
+// writeOutput prints the --output result for the created cluster
+func writeOutput(w io.Writer, provider kubeconfigProvider, name string, flags *flagpole) error {
+	switch flags.Output {
+	case outputKubeconfigPath:
+		fmt.Fprintln(w, provider.KubeConfigPath(flags.Kubeconfig))
+	case outputKubeconfig:
+		kubeconfig, err := provider.KubeConfig(name, false)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, kubeconfig)
+	}
+	return nil
+}
+
+// createClusters creates a cluster for each of the config documents
+// concurrently, on the same network and with a context for each cluster in
+// the same kubeconfig. The output of each cluster is prefixed with its name
+func createClusters(logger log.Logger, streams cmd.IOStreams, flags *flagpole, docs [][]byte) error {
+	if flags.Name != "" {
+		return errors.New("--name and KIND_CLUSTER_NAME may not be used with a config containing multiple clusters")
+	}
+	if flags.Output == outputKubeconfig {
+		return errors.Errorf("--output %s may not be used with a config containing multiple clusters, use %s", outputKubeconfig, outputKubeconfigPath)
+	}
+	names := sets.NewString()
+	fns := []func() error{}
+	for _, doc := range docs {
+		doc := doc // capture loop variable
+		cfg, err := encoding.Parse(doc)
+		if err != nil {
+			return err
+		}
+		name := cfg.Name
+		if name == "" {
+			name = cluster.DefaultName
+		}
+		if names.Has(name) {
+			return errors.Errorf("multiple clusters in the config are named %q", name)
+		}
+		names.Insert(name)
+		fns = append(fns, func() error {
+			clusterLogger := cli.PrefixLogger(logger, fmt.Sprintf("[%s] ", name))
+			provider := cluster.NewProvider(
+				cluster.ProviderWithLogger(clusterLogger),
+				runtime.GetDefault(logger),
+			)
+			return provider.Create("", createOptions(flags,
+				cluster.CreateWithRawConfig(doc),
+				cluster.CreateWithDisplayUsage(true),
+			)...)
+		})
+	}
+	if err := errors.AggregateConcurrent(fns); err != nil {
+		return errors.Wrap(err, "failed to create clusters")
+	}
+	// every cluster is exported to the same kubeconfig file
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	return writeOutput(streams.Out, provider, "", flags)
+}
+
+// createOptions returns the cluster creation options for flags followed by extra
+func createOptions(flags *flagpole, extra ...cluster.CreateOption) []cluster.CreateOption {
+	return append([]cluster.CreateOption{
+		cluster.CreateWithNodeImage(flags.ImageName),
+		cluster.CreateWithImageBundle(flags.ImageBundle),
+		cluster.CreateWithIngress(flags.Ingress),
+		cluster.CreateWithRetain(flags.Retain),
+		cluster.CreateWithWaitForReady(flags.Wait),
+		cluster.CreateWithKubeconfigPath(flags.Kubeconfig),
+		cluster.CreateWithLabels(flags.Labels),
+		cluster.CreateWithAnnotations(flags.Annotations),
+		cluster.CreateWithAllowMixedNodeImages(flags.AllowMixed),
+	}, extra...)
+}
+
+// readConfig reads the --config flag value, which is a path to a config
+// file or `-` to read from stdin
+func readConfig(rawConfigFlag string, stdin io.Reader) ([]byte, error) {
 	if rawConfigFlag != "-" {
-		return cluster.CreateWithConfigFile(rawConfigFlag), nil
+		raw, err := os.ReadFile(rawConfigFlag)
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading config file")
+		}
+		return raw, nil
 	}
-	// otherwise read from stdin
 	raw, err := io.ReadAll(stdin)
 	if err != nil {
 		return nil, errors.Wrap(err, "error reading config from stdin")
 	}
-	return cluster.CreateWithRawConfig(raw), nil
+	return raw, nil
 }
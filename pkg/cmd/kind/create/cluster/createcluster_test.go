@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"bytes"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+type fakeKubeconfigProvider struct {
+	kubeconfigs map[string]string
+}
+
+func (p *fakeKubeconfigProvider) KubeConfigPath(explicitPath string) string {
+	if explicitPath != "" {
+		return explicitPath
+	}
+	return "/home/user/.kube/config"
+}
+
+func (p *fakeKubeconfigProvider) KubeConfig(name string, internal bool) (string, error) {
+	kubeconfig, ok := p.kubeconfigs[name]
+	if !ok || internal {
+		return "", errors.Errorf("no kubeconfig for %q", name)
+	}
+	return kubeconfig, nil
+}
+
+func TestWriteOutput(t *testing.T) {
+	t.Parallel()
+	provider := &fakeKubeconfigProvider{kubeconfigs: map[string]string{
+		"kind": "apiVersion: v1\nkind: Config\n",
+	}}
+	cases := []struct {
+		Name        string
+		Flags       flagpole
+		Cluster     string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:    "no output",
+			Cluster: "kind",
+		},
+		{
+			Name:     "default kubeconfig path",
+			Flags:    flagpole{Output: outputKubeconfigPath},
+			Cluster:  "kind",
+			Expected: "/home/user/.kube/config\n",
+		},
+		{
+			Name:     "explicit kubeconfig path",
+			Flags:    flagpole{Output: outputKubeconfigPath, Kubeconfig: "/tmp/kind.kubeconfig"},
+			Cluster:  "kind",
+			Expected: "/tmp/kind.kubeconfig\n",
+		},
+		{
+			Name:     "kubeconfig",
+			Flags:    flagpole{Output: outputKubeconfig},
+			Cluster:  "kind",
+			Expected: "apiVersion: v1\nkind: Config\n",
+		},
+		{
+			Name:        "kubeconfig of an unknown cluster",
+			Flags:       flagpole{Output: outputKubeconfig},
+			Cluster:     "other",
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			err := writeOutput(&out, provider, tc.Cluster, &tc.Flags)
+			assert.ExpectError(t, tc.ExpectError, err)
+			assert.StringEqual(t, tc.Expected, out.String())
+		})
+	}
+}
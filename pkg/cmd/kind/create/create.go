@@ -24,6 +24,7 @@ import (
 
 	"sigs.k8s.io/kind/pkg/cmd"
 	createcluster "sigs.k8s.io/kind/pkg/cmd/kind/create/cluster"
+	createregistry "sigs.k8s.io/kind/pkg/cmd/kind/create/registry"
 	"sigs.k8s.io/kind/pkg/log"
 )
 
@@ -32,8 +33,8 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
 		Args:  cobra.NoArgs,
 		Use:   "create",
-		Short: "Creates one of [cluster]",
-		Long:  "Creates one of local Kubernetes cluster (cluster)",
+		Short: "Creates one of [cluster, registry]",
+		Long:  "Creates one of local Kubernetes cluster (cluster) or local image registry (registry)",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			err := cmd.Help()
 			if err != nil {
@@ -43,5 +44,6 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		},
 	}
 	cmd.AddCommand(createcluster.NewCommand(logger, streams))
+	cmd.AddCommand(createregistry.NewCommand(logger, streams))
 	return cmd
 }
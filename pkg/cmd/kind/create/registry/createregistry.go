@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registry implements the `create registry` command
+package registry
+
+import (
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Port int32
+}
+
+// NewCommand returns a new cobra.Command for local registry creation
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "registry",
+		Short: "Creates a local image registry",
+		Long: "Creates the local image registry container used by clusters with localRegistry enabled, " +
+			"or starts it if it already exists",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(logger, flags)
+		},
+	}
+	cmd.Flags().Int32Var(
+		&flags.Port,
+		"port",
+		5001,
+		"the host port to publish the registry on",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, flags *flagpole) error {
+	if flags.Port < 1 || flags.Port > 65535 {
+		return errors.Errorf("invalid --port %d", flags.Port)
+	}
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	if err := provider.CreateRegistry(flags.Port); err != nil {
+		return errors.Wrap(err, "failed to create registry")
+	}
+	logger.V(0).Infof("Registry %s is running, use it by enabling localRegistry in the cluster config", constants.LocalRegistryName)
+	return nil
+}
@@ -44,7 +44,9 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		Short: "Deletes a cluster",
 		Long:  "Deletes a resource",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.OverrideDefaultName(cmd.Flags())
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
 			return deleteCluster(logger, flags)
 		},
 	}
@@ -18,6 +18,8 @@ limitations under the License.
 package clusters
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kind/pkg/cluster"
@@ -25,29 +27,30 @@ import (
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/log"
 
+	"sigs.k8s.io/kind/pkg/internal/cli"
 	"sigs.k8s.io/kind/pkg/internal/runtime"
 )
 
 type flagpole struct {
 	Kubeconfig string
 	All        bool
+	Selector   string
+	Confirm    cli.ConfirmFlags
 }
 
 // NewCommand returns a new cobra.Command for cluster deletion
 func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	flags := &flagpole{}
 	cmd := &cobra.Command{
-		Args: cobra.MinimumNArgs(0),
+		Args: func(cmd *cobra.Command, args []string) error {
+			return validateArgs(flags, args)
+		},
 		// TODO(bentheelder): more detailed usage
 		Use:   "clusters",
 		Short: "Deletes one or more clusters",
 		Long:  "Deletes a resource",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !flags.All && len(args) == 0 {
-				return errors.New("no cluster names provided")
-			}
-
-			return deleteClusters(logger, flags, args)
+			return deleteClusters(logger, streams, flags, args)
 		},
 	}
 	cmd.Flags().StringVar(
@@ -63,10 +66,36 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		false,
 		"delete all clusters",
 	)
+	cmd.Flags().StringVarP(
+		&flags.Selector,
+		"selector",
+		"l",
+		"",
+		"delete clusters with labels matching the selector, e.g. pr=1234",
+	)
+	flags.Confirm.AddFlags(cmd.Flags())
 	return cmd
 }
 
-func deleteClusters(logger log.Logger, flags *flagpole, clusters []string) error {
+// validateArgs rejects ambiguous combinations of cluster names, --all and
+// --selector, since guessing which clusters were meant could delete the
+// wrong ones
+func validateArgs(flags *flagpole, args []string) error {
+	if flags.Selector != "" {
+		if flags.All {
+			return errors.New("--selector cannot be combined with --all")
+		}
+		if len(args) > 0 {
+			return errors.New("--selector cannot be combined with cluster names")
+		}
+	}
+	if !flags.All && flags.Selector == "" && len(args) == 0 {
+		return errors.New("no cluster names provided")
+	}
+	return nil
+}
+
+func deleteClusters(logger log.Logger, streams cmd.IOStreams, flags *flagpole, clusters []string) error {
 	provider := cluster.NewProvider(
 		cluster.ProviderWithLogger(logger),
 		runtime.GetDefault(logger),
@@ -77,6 +106,17 @@ func deleteClusters(logger log.Logger, flags *flagpole, clusters []string) error
 		if clusters, err = provider.List(); err != nil {
 			return errors.Wrap(err, "failed listing clusters for delete")
 		}
+	} else if flags.Selector != "" {
+		selected, err := provider.ListWithSelector(flags.Selector)
+		if err != nil {
+			return errors.Wrap(err, "failed listing clusters for delete")
+		}
+		clusters = selected
+	}
+	if len(clusters) > 0 {
+		if err := cli.Confirm(streams.In, streams.ErrOut, flags.Confirm, "delete clusters "+strings.Join(clusters, ", ")); err != nil {
+			return err
+		}
 	}
 	var success []string
 	for _, cluster := range clusters {
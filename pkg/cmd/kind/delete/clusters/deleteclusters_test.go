@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestValidateArgs(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		Flags       flagpole
+		Args        []string
+		ExpectError bool
+	}{
+		{
+			Name:        "nothing to delete",
+			ExpectError: true,
+		},
+		{
+			Name: "names",
+			Args: []string{"a", "b"},
+		},
+		{
+			Name:  "all",
+			Flags: flagpole{All: true},
+		},
+		{
+			Name:  "selector",
+			Flags: flagpole{Selector: "team=a"},
+		},
+		{
+			Name:        "selector with all",
+			Flags:       flagpole{All: true, Selector: "team=a"},
+			ExpectError: true,
+		},
+		{
+			Name:        "selector with names",
+			Flags:       flagpole{Selector: "team=a"},
+			Args:        []string{"a"},
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			err := validateArgs(&tc.Flags, tc.Args)
+			assert.ExpectError(t, tc.ExpectError, err)
+		})
+	}
+}
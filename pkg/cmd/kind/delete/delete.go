@@ -25,6 +25,7 @@ import (
 	"sigs.k8s.io/kind/pkg/cmd"
 	deletecluster "sigs.k8s.io/kind/pkg/cmd/kind/delete/cluster"
 	deleteclusters "sigs.k8s.io/kind/pkg/cmd/kind/delete/clusters"
+	deleteregistry "sigs.k8s.io/kind/pkg/cmd/kind/delete/registry"
 	"sigs.k8s.io/kind/pkg/log"
 )
 
@@ -34,8 +35,8 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		Args: cobra.NoArgs,
 		// TODO(bentheelder): more detailed usage
 		Use:   "delete",
-		Short: "Deletes one of [cluster]",
-		Long:  "Deletes one of [cluster]",
+		Short: "Deletes one of [cluster, registry]",
+		Long:  "Deletes one of [cluster, registry]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			err := cmd.Help()
 			if err != nil {
@@ -46,5 +47,6 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	}
 	cmd.AddCommand(deletecluster.NewCommand(logger, streams))
 	cmd.AddCommand(deleteclusters.NewCommand(logger, streams))
+	cmd.AddCommand(deleteregistry.NewCommand(logger, streams))
 	return cmd
 }
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registry implements the `delete registry` command
+package registry
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+// NewCommand returns a new cobra.Command for local registry deletion
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	confirm := cli.ConfirmFlags{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "registry",
+		Short: "Deletes the local image registry",
+		Long:  "Deletes the local image registry container and its images, clusters using it are not deleted",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(logger, streams, confirm)
+		},
+	}
+	confirm.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, confirm cli.ConfirmFlags) error {
+	action := fmt.Sprintf("delete registry %q and its images", constants.LocalRegistryName)
+	if err := cli.Confirm(streams.In, streams.ErrOut, confirm, action); err != nil {
+		return err
+	}
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	logger.V(0).Infof("Deleting registry %q ...", constants.LocalRegistryName)
+	if err := provider.DeleteRegistry(); err != nil {
+		return errors.Wrap(err, "failed to delete registry")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster implements the `cluster` command
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name   string
+	Output string
+	Config string
+}
+
+// NewCommand returns a new cobra.Command for exporting a cluster snapshot
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "cluster",
+		Short: "Exports a snapshot of a cluster's nodes to an archive",
+		Long: "Exports a snapshot of a cluster's node containers, including their volumes and etcd data, " +
+			"to an archive that can be restored with `kind import cluster`. The cluster is stopped while the snapshot is taken",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	cmd.Flags().StringVarP(
+		&flags.Output,
+		"output",
+		"o",
+		"",
+		"the snapshot archive to write",
+	)
+	_ = cmd.MarkFlagRequired("output")
+	cmd.Flags().StringVar(
+		&flags.Config,
+		"config",
+		"",
+		"path to the kind config file the cluster was created with, to record in the snapshot",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, flags *flagpole) error {
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	if err := provider.ExportSnapshot(flags.Name, flags.Output, flags.Config); err != nil {
+		return err
+	}
+	logger.V(0).Infof("Exported cluster %q to %s", flags.Name, flags.Output)
+	return nil
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/cmd/kind/export/cluster"
+	"sigs.k8s.io/kind/pkg/cmd/kind/export/inventory"
 	"sigs.k8s.io/kind/pkg/cmd/kind/export/kubeconfig"
 	"sigs.k8s.io/kind/pkg/cmd/kind/export/logs"
 	"sigs.k8s.io/kind/pkg/log"
@@ -34,8 +36,8 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		Args: cobra.NoArgs,
 		// TODO(bentheelder): more detailed usage
 		Use:   "export",
-		Short: "Exports one of [kubeconfig, logs]",
-		Long:  "Exports one of [kubeconfig, logs]",
+		Short: "Exports one of [cluster, inventory, kubeconfig, logs]",
+		Long:  "Exports one of [cluster, inventory, kubeconfig, logs]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			err := cmd.Help()
 			if err != nil {
@@ -47,5 +49,7 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	// add subcommands
 	cmd.AddCommand(logs.NewCommand(logger, streams))
 	cmd.AddCommand(kubeconfig.NewCommand(logger, streams))
+	cmd.AddCommand(inventory.NewCommand(logger, streams))
+	cmd.AddCommand(cluster.NewCommand(logger, streams))
 	return cmd
 }
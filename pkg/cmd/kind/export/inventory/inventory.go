@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory implements the `inventory` command
+package inventory
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name string
+}
+
+// NewCommand returns a new cobra.Command for exporting the cluster inventory
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.MaximumNArgs(1),
+		Use:   "inventory [output-file]",
+		Short: "Exports the software inventory to stdout or [output-file] if specified",
+		Long: "Exports a JSON manifest of the images, binaries and manifests kind installed into the cluster, " +
+			"to stdout or [output-file] if specified",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, streams, flags, args)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole, args []string) error {
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	raw, err := provider.Inventory(flags.Name)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		_, err = streams.Out.Write(append(raw, '\n'))
+		return err
+	}
+	return os.WriteFile(args[0], raw, 0644)
+}
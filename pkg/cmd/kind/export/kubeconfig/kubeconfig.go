@@ -43,7 +43,9 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		Short: "Exports cluster kubeconfig",
 		Long:  "Exports cluster kubeconfig",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.OverrideDefaultName(cmd.Flags())
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
 			return runE(logger, flags)
 		},
 	}
@@ -45,7 +45,9 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		Short: "Exports logs to a tempdir or [output-dir] if specified",
 		Long:  "Exports logs to a tempdir or [output-dir] if specified",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.OverrideDefaultName(cmd.Flags())
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
 			return runE(logger, streams, flags, args)
 		},
 	}
@@ -29,8 +29,13 @@ import (
 	"sigs.k8s.io/kind/pkg/internal/runtime"
 )
 
+type flagpole struct {
+	Selector string
+}
+
 // NewCommand returns a new cobra.Command for getting the list of clusters
 func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
 	cmd := &cobra.Command{
 		Args: cobra.NoArgs,
 		// TODO(bentheelder): more detailed usage
@@ -38,18 +43,31 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		Short: "Lists existing kind clusters by their name",
 		Long:  "Lists existing kind clusters by their name",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runE(logger, streams)
+			return runE(logger, streams, flags)
 		},
 	}
+	cmd.Flags().StringVarP(
+		&flags.Selector,
+		"selector",
+		"l",
+		"",
+		"only list clusters with labels matching the selector, e.g. pr=1234,owner=alice",
+	)
 	return cmd
 }
 
-func runE(logger log.Logger, streams cmd.IOStreams) error {
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
 	provider := cluster.NewProvider(
 		cluster.ProviderWithLogger(logger),
 		runtime.GetDefault(logger),
 	)
-	clusters, err := provider.List()
+	var clusters []string
+	var err error
+	if flags.Selector != "" {
+		clusters, err = provider.ListWithSelector(flags.Selector)
+	} else {
+		clusters, err = provider.List()
+	}
 	if err != nil {
 		return err
 	}
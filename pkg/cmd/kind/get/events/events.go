@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events implements the `events` command
+package events
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name         string
+	KubeletLines int
+}
+
+// NewCommand returns a new cobra.Command for getting the events of a cluster
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "events",
+		Short: "Prints the events, pods not running and kubelet journals of a cluster",
+		Long: "Prints the cluster's events, its pods that are not running and the end of the kubelet " +
+			"journal of each node. This is what kind reports when creating a cluster fails after kubeadm init",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, streams, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	cmd.Flags().IntVar(
+		&flags.KubeletLines,
+		"kubelet-lines",
+		cluster.DefaultKubeletLines,
+		"the number of kubelet journal lines to print for each node",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	report, err := provider.Events(flags.Name, flags.KubeletLines)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(streams.Out, report)
+	return nil
+}
@@ -24,8 +24,11 @@ import (
 
 	"sigs.k8s.io/kind/pkg/cmd"
 	"sigs.k8s.io/kind/pkg/cmd/kind/get/clusters"
+	"sigs.k8s.io/kind/pkg/cmd/kind/get/events"
 	"sigs.k8s.io/kind/pkg/cmd/kind/get/kubeconfig"
 	"sigs.k8s.io/kind/pkg/cmd/kind/get/nodes"
+	"sigs.k8s.io/kind/pkg/cmd/kind/get/ports"
+	"sigs.k8s.io/kind/pkg/cmd/kind/get/templates"
 	"sigs.k8s.io/kind/pkg/log"
 )
 
@@ -35,8 +38,8 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		Args: cobra.NoArgs,
 		// TODO(bentheelder): more detailed usage
 		Use:   "get",
-		Short: "Gets one of [clusters, nodes, kubeconfig]",
-		Long:  "Gets one of [clusters, nodes, kubeconfig]",
+		Short: "Gets one of [clusters, nodes, kubeconfig, ports, templates, events]",
+		Long:  "Gets one of [clusters, nodes, kubeconfig, ports, templates, events]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			err := cmd.Help()
 			if err != nil {
@@ -49,5 +52,8 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	cmd.AddCommand(clusters.NewCommand(logger, streams))
 	cmd.AddCommand(nodes.NewCommand(logger, streams))
 	cmd.AddCommand(kubeconfig.NewCommand(logger, streams))
+	cmd.AddCommand(ports.NewCommand(logger, streams))
+	cmd.AddCommand(templates.NewCommand(logger, streams))
+	cmd.AddCommand(events.NewCommand(logger, streams))
 	return cmd
 }
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ports implements the `ports` command
+package ports
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name   string
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for listing the host ports of a cluster
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "ports",
+		Short: "Lists the host ports published by a cluster's node containers",
+		Long:  "Lists the host ports published by a cluster's node containers, including the API server, the external load balancer and extraPortMappings",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, streams, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	cmd.Flags().StringVarP(
+		&flags.Output,
+		"output",
+		"o",
+		"text",
+		"output format, one of: text, json",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
+	if flags.Output != "text" && flags.Output != "json" {
+		return errors.Errorf("invalid --output %q, must be one of: text, json", flags.Output)
+	}
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	ports, err := provider.PublishedPorts(flags.Name)
+	if err != nil {
+		return err
+	}
+	if flags.Output == "json" {
+		enc := json.NewEncoder(streams.Out)
+		for _, p := range ports {
+			if err := enc.Encode(p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return printPorts(streams.Out, ports)
+}
+
+func printPorts(out io.Writer, ports []cluster.PublishedPort) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tHOST ADDRESS\tCONTAINER PORT\tPROTOCOL")
+	for _, p := range ports {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n",
+			p.Node,
+			net.JoinHostPort(p.ListenAddress, fmt.Sprint(p.HostPort)),
+			p.ContainerPort,
+			p.Protocol,
+		)
+	}
+	return w.Flush()
+}
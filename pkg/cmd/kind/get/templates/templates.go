@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package templates implements the `templates` command
+package templates
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/templates"
+)
+
+// NewCommand returns a new cobra.Command for listing cluster templates
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "templates",
+		Short: "Lists cluster templates usable with kind create cluster --template",
+		Long: "Lists cluster templates usable with kind create cluster --template\n\n" +
+			"User templates are <name>.yaml cluster config files in the directories\n" +
+			"listed in $KIND_TEMPLATE_PATH, or in the kind/templates directory under the\n" +
+			"user config directory (e.g. ~/.config/kind/templates).\n" +
+			"User templates take precedence over built-in templates of the same name.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(streams)
+		},
+	}
+	return cmd
+}
+
+func runE(streams cmd.IOStreams) error {
+	available, err := templates.List(templates.Dirs())
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(streams.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSOURCE\tDESCRIPTION")
+	for _, t := range available {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name, t.Source, t.Description)
+	}
+	return w.Flush()
+}
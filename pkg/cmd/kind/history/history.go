@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history implements the `history` command
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
+	"sigs.k8s.io/kind/pkg/internal/history"
+	"sigs.k8s.io/kind/pkg/internal/sets"
+)
+
+type flagpole struct {
+	Name   string
+	Limit  int
+	Output string
+}
+
+// NewCommand returns a new cobra.Command for querying the cluster operation history
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "history",
+		Short: "Lists previous cluster operations on this machine",
+		Long: "Lists previous cluster operations on this machine, oldest first.\n\n" +
+			"Operations are recorded in ~/.kind/history.jsonl, or $KIND_HISTORY_FILE if set. " +
+			"Set KIND_HISTORY_FILE=/dev/null to disable recording.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(streams, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		"",
+		"only list operations on this cluster",
+	)
+	cmd.Flags().IntVar(
+		&flags.Limit,
+		"limit",
+		0,
+		"only list the most recent N operations, 0 lists all",
+	)
+	cmd.Flags().StringVarP(
+		&flags.Output,
+		"output",
+		"o",
+		"text",
+		"output format, one of: text, json",
+	)
+	return cmd
+}
+
+func runE(streams cmd.IOStreams, flags *flagpole) error {
+	if flags.Output != "text" && flags.Output != "json" {
+		return errors.Errorf("invalid --output %q, must be one of: text, json", flags.Output)
+	}
+	path, err := history.DefaultPath()
+	if err != nil {
+		return err
+	}
+	all, err := history.Read(path)
+	if err != nil {
+		return err
+	}
+	entries := []history.Entry{}
+	for _, e := range all {
+		if flags.Name == "" || e.Cluster == flags.Name {
+			entries = append(entries, e)
+		}
+	}
+	if flags.Limit > 0 && len(entries) > flags.Limit {
+		entries = entries[len(entries)-flags.Limit:]
+	}
+
+	if flags.Output == "json" {
+		enc := json.NewEncoder(streams.Out)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(streams.Out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "TIME\tUSER\tCOMMAND\tCLUSTER\tCONFIG\tDURATION\tRESULT")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Time.Local().Format(time.RFC3339), e.User, e.Command, e.Cluster,
+			shortHash(e.ConfigHash), e.Duration.Round(time.Second), e.Result,
+		)
+	}
+	return w.Flush()
+}
+
+// shortHash abbreviates a "sha256:<hex>" config hash for display
+func shortHash(hash string) string {
+	hash = strings.TrimPrefix(hash, "sha256:")
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+// recordedCommands are the command paths that operate on clusters
+var recordedCommands = sets.NewString(
+	"kind apply",
+	"kind create cluster",
+	"kind delete cluster",
+	"kind delete clusters",
+	"kind import cluster",
+	"kind pause",
+	"kind resume",
+	"kind scale",
+	"kind upgrade",
+)
+
+// Record records the outcome of executed, a command that started at start
+// and returned err, in the history if it is a cluster operation
+func Record(logger log.Logger, executed *cobra.Command, start time.Time, err error) {
+	if executed == nil || !recordedCommands.Has(executed.CommandPath()) {
+		return
+	}
+	if help := executed.Flags().Lookup("help"); help != nil && help.Changed {
+		return
+	}
+	entry := history.Entry{
+		Time:     start,
+		User:     currentUser(),
+		Command:  strings.TrimPrefix(executed.CommandPath(), "kind "),
+		Duration: time.Since(start),
+		Result:   history.ResultSuccess,
+	}
+	if executed.Flags().Lookup("name") != nil {
+		entry.Cluster = clusterName(executed.Flags())
+	}
+	if config := executed.Flags().Lookup("config"); config != nil {
+		entry.ConfigHash = configHash(config.Value.String())
+	}
+	if err != nil {
+		entry.Result = history.ResultFailure
+		entry.Error = err.Error()
+	}
+	path, pathErr := history.DefaultPath()
+	if pathErr == nil {
+		pathErr = history.Record(path, entry)
+	}
+	// failing to record history should never fail the operation itself
+	if pathErr != nil {
+		logger.V(1).Infof("failed to record history: %v", pathErr)
+	}
+}
+
+// clusterName returns the effective cluster name of flags, which is --name,
+// else KIND_CLUSTER_NAME, else the name in the --config file, else the
+// default name
+func clusterName(flags *pflag.FlagSet) string {
+	if name := flags.Lookup("name"); name != nil && name.Value.String() != "" {
+		return name.Value.String()
+	}
+	if name := os.Getenv("KIND_CLUSTER_NAME"); name != "" {
+		return name
+	}
+	// the config was already read from stdin, so it cannot be read again
+	if config := flags.Lookup("config"); config != nil && config.Value.String() != "" && config.Value.String() != "-" {
+		if cfg, err := encoding.Load(config.Value.String()); err == nil && cfg.Name != "" {
+			return cfg.Name
+		}
+	}
+	return cluster.DefaultName
+}
+
+// configHash returns the sha256 of the config file at path, or "" if there
+// is no config file, the config was read from stdin or it cannot be read
+func configHash(path string) string {
+	if path == "" || path == "-" {
+		return ""
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestClusterName(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "kind.yaml")
+	if err := os.WriteFile(configPath, []byte("kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\nname: from-config\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		Name     string
+		Args     []string
+		Env      string
+		Expected string
+	}{
+		{
+			Name:     "default",
+			Expected: "kind",
+		},
+		{
+			Name:     "flag",
+			Args:     []string{"--name=from-flag", "--config=" + configPath},
+			Env:      "from-env",
+			Expected: "from-flag",
+		},
+		{
+			Name:     "environment",
+			Args:     []string{"--config=" + configPath},
+			Env:      "from-env",
+			Expected: "from-env",
+		},
+		{
+			Name:     "config",
+			Args:     []string{"--config=" + configPath},
+			Expected: "from-config",
+		},
+		{
+			Name:     "unreadable config",
+			Args:     []string{"--config=" + filepath.Join(t.TempDir(), "missing.yaml")},
+			Expected: "kind",
+		},
+		{
+			Name:     "config from stdin",
+			Args:     []string{"--config=-"},
+			Expected: "kind",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv("KIND_CLUSTER_NAME", tc.Env)
+			flags := pflag.NewFlagSet("create", pflag.ContinueOnError)
+			flags.String("name", "", "")
+			flags.String("config", "", "")
+			if err := flags.Parse(tc.Args); err != nil {
+				t.Fatal(err)
+			}
+			assert.StringEqual(t, tc.Expected, clusterName(flags))
+		})
+	}
+}
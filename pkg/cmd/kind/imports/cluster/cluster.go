@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster implements the `cluster` command
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name       string
+	Input      string
+	Config     string
+	Kubeconfig string
+}
+
+// NewCommand returns a new cobra.Command for importing a cluster snapshot
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "cluster",
+		Short: "Recreates a cluster from a snapshot written by `kind export cluster`",
+		Long: "Recreates a cluster from a snapshot written by `kind export cluster`, restoring its nodes' " +
+			"filesystems, volumes and etcd data",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(logger, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		"",
+		"the cluster context name, must be the name of the exported cluster",
+	)
+	cmd.Flags().StringVarP(
+		&flags.Input,
+		"input",
+		"i",
+		"",
+		"the snapshot archive to read",
+	)
+	_ = cmd.MarkFlagRequired("input")
+	cmd.Flags().StringVar(
+		&flags.Config,
+		"config",
+		"",
+		"path to a kind config file with nodes matching the snapshot, defaults to the config recorded in the snapshot",
+	)
+	cmd.Flags().StringVar(
+		&flags.Kubeconfig,
+		"kubeconfig",
+		"",
+		"sets kubeconfig path instead of $KUBECONFIG or $HOME/.kube/config",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, flags *flagpole) error {
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	if err := provider.ImportSnapshot(flags.Input, flags.Name, flags.Config, flags.Kubeconfig); err != nil {
+		return err
+	}
+	logger.V(0).Infof("Imported cluster from %s", flags.Input)
+	return nil
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package initialize implements the `init` command
+package initialize
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
+)
+
+// NewCommand returns a new cobra.Command for interactively generating a config
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.MaximumNArgs(1),
+		Use:   "init [output-file]",
+		Short: "Interactively generates a cluster config",
+		Long: "Asks about the cluster topology and features and writes a validated cluster config " +
+			"to stdout or [output-file] if specified",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runE(logger, streams, args)
+		},
+	}
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, args []string) error {
+	// prompt on stderr so the config can be redirected from stdout
+	a, err := ask(streams.In, streams.ErrOut)
+	if err != nil {
+		return err
+	}
+	raw := render(a)
+	cfg, err := encoding.Parse([]byte(raw))
+	if err != nil {
+		return errors.Wrap(err, "generated an invalid config")
+	}
+	if err := cfg.Validate(); err != nil {
+		return errors.Wrap(err, "generated an invalid config")
+	}
+	if len(args) == 0 {
+		_, err = io.WriteString(streams.Out, raw)
+		return err
+	}
+	if err := os.WriteFile(args[0], []byte(raw), 0644); err != nil {
+		return err
+	}
+	logger.V(0).Infof("Wrote cluster config to %s, create the cluster with:\n\n  kind create cluster --config %s\n", args[0], args[0])
+	return nil
+}
+
+// answers are the choices made when generating a config
+type answers struct {
+	Name           string
+	ControlPlanes  int
+	Workers        int
+	IPFamily       string
+	Image          string
+	RegistryMirror string
+	Ingress        bool
+}
+
+func ask(in io.Reader, out io.Writer) (answers, error) {
+	p := &prompter{in: bufio.NewReader(in), out: out}
+	a := answers{}
+	var err error
+	if a.Name, err = p.String("Cluster name", "kind"); err != nil {
+		return a, err
+	}
+	if a.ControlPlanes, err = p.Int("Number of control-plane nodes (more than one enables HA)", 1, 1); err != nil {
+		return a, err
+	}
+	if a.Workers, err = p.Int("Number of worker nodes", 0, 0); err != nil {
+		return a, err
+	}
+	if a.IPFamily, err = p.Choice("IP family", "ipv4", "ipv4", "ipv6", "dual"); err != nil {
+		return a, err
+	}
+	if a.Image, err = p.String("Node image (blank for the default)", ""); err != nil {
+		return a, err
+	}
+	if a.RegistryMirror, err = p.String("Registry mirror for docker.io, e.g. http://localhost:5000 (blank for none)", ""); err != nil {
+		return a, err
+	}
+	if a.Ingress, err = p.Bool("Expose ports 80 and 443 for an ingress controller", false); err != nil {
+		return a, err
+	}
+	return a, nil
+}
+
+// render writes the answers as a v1alpha4 cluster config
+func render(a answers) string {
+	var b strings.Builder
+	b.WriteString("kind: Cluster\n")
+	b.WriteString("apiVersion: kind.x-k8s.io/v1alpha4\n")
+	fmt.Fprintf(&b, "name: %s\n", a.Name)
+	if a.IPFamily != "ipv4" {
+		b.WriteString("networking:\n")
+		fmt.Fprintf(&b, "  ipFamily: %s\n", a.IPFamily)
+	}
+	if a.RegistryMirror != "" {
+		b.WriteString("registryMirrors:\n")
+		b.WriteString("- registry: docker.io\n")
+		fmt.Fprintf(&b, "  endpoints: [%q]\n", a.RegistryMirror)
+	}
+	b.WriteString("nodes:\n")
+	for i := 0; i < a.ControlPlanes; i++ {
+		writeNode(&b, "control-plane", a.Image)
+		// the ingress controller runs on the first control-plane node
+		if i == 0 && a.Ingress {
+			b.WriteString("  kubeadmConfigPatches:\n")
+			b.WriteString("  - |\n")
+			b.WriteString("    kind: InitConfiguration\n")
+			b.WriteString("    nodeRegistration:\n")
+			b.WriteString("      kubeletExtraArgs:\n")
+			b.WriteString("        node-labels: \"ingress-ready=true\"\n")
+			b.WriteString("  extraPortMappings:\n")
+			for _, port := range []int{80, 443} {
+				fmt.Fprintf(&b, "  - containerPort: %d\n", port)
+				fmt.Fprintf(&b, "    hostPort: %d\n", port)
+				b.WriteString("    protocol: TCP\n")
+			}
+		}
+	}
+	for i := 0; i < a.Workers; i++ {
+		writeNode(&b, "worker", a.Image)
+	}
+	return b.String()
+}
+
+func writeNode(b *strings.Builder, role, image string) {
+	fmt.Fprintf(b, "- role: %s\n", role)
+	if image != "" {
+		fmt.Fprintf(b, "  image: %s\n", image)
+	}
+}
+
+// prompter asks questions on out and reads the answers from in,
+// re-asking until a valid answer is given
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func (p *prompter) line(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	line, err := p.in.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return "", errors.New("unexpected end of input")
+	} else if err != nil && err != io.EOF {
+		return "", err
+	}
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return def, nil
+	}
+	return line, nil
+}
+
+// String asks for a free form answer
+func (p *prompter) String(question, def string) (string, error) {
+	return p.line(question, def)
+}
+
+// Int asks for a number no less than min
+func (p *prompter) Int(question string, def, min int) (int, error) {
+	for {
+		answer, err := p.line(question, strconv.Itoa(def))
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= min {
+			return n, nil
+		}
+		fmt.Fprintf(p.out, "Please enter a number, at least %d\n", min)
+	}
+}
+
+// Choice asks for one of choices
+func (p *prompter) Choice(question, def string, choices ...string) (string, error) {
+	for {
+		answer, err := p.line(fmt.Sprintf("%s (%s)", question, strings.Join(choices, ", ")), def)
+		if err != nil {
+			return "", err
+		}
+		for _, c := range choices {
+			if answer == c {
+				return c, nil
+			}
+		}
+		fmt.Fprintf(p.out, "Please enter one of: %s\n", strings.Join(choices, ", "))
+	}
+}
+
+// Bool asks a yes or no question
+func (p *prompter) Bool(question string, def bool) (bool, error) {
+	d := "n"
+	if def {
+		d = "y"
+	}
+	for {
+		answer, err := p.line(question+" (y/n)", d)
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "Please enter y or n")
+	}
+}
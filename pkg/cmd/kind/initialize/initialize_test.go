@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package initialize
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestAsk(t *testing.T) {
+	t.Parallel()
+	// invalid answers are asked again
+	in := strings.NewReader(strings.Join([]string{
+		"dev",
+		"0",
+		"3",
+		"",
+		"ipv5",
+		"dual",
+		"",
+		"http://localhost:5000",
+		"yes",
+	}, "\n"))
+	var out bytes.Buffer
+	a, err := ask(in, &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assert.DeepEqual(t, answers{
+		Name:           "dev",
+		ControlPlanes:  3,
+		Workers:        0,
+		IPFamily:       "dual",
+		RegistryMirror: "http://localhost:5000",
+		Ingress:        true,
+	}, a)
+
+	if _, err := ask(strings.NewReader("dev\n"), &out); err == nil {
+		t.Errorf("expected an error when input ends early")
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name    string
+		Answers answers
+	}{
+		{
+			Name: "defaults",
+			Answers: answers{
+				Name:          "kind",
+				ControlPlanes: 1,
+				IPFamily:      "ipv4",
+			},
+		},
+		{
+			Name: "everything",
+			Answers: answers{
+				Name:           "dev",
+				ControlPlanes:  3,
+				Workers:        2,
+				IPFamily:       "dual",
+				Image:          "kindest/node:v1.29.0",
+				RegistryMirror: "http://localhost:5000",
+				Ingress:        true,
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := encoding.Parse([]byte(render(tc.Answers)))
+			if err != nil {
+				t.Fatalf("failed to parse rendered config: %v", err)
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Fatalf("rendered config is invalid: %v", err)
+			}
+			assert.StringEqual(t, tc.Answers.Name, cfg.Name)
+			assert.StringEqual(t, tc.Answers.IPFamily, string(cfg.Networking.IPFamily))
+			if len(cfg.Nodes) != tc.Answers.ControlPlanes+tc.Answers.Workers {
+				t.Errorf("expected %d nodes, got %d", tc.Answers.ControlPlanes+tc.Answers.Workers, len(cfg.Nodes))
+			}
+			if tc.Answers.Ingress && len(cfg.Nodes[0].ExtraPortMappings) != 2 {
+				t.Errorf("expected ingress port mappings on the first node")
+			}
+			if tc.Answers.RegistryMirror != "" {
+				assert.DeepEqual(t, []config.RegistryMirror{{Registry: "docker.io", Endpoints: []string{tc.Answers.RegistryMirror}}}, cfg.RegistryMirrors)
+				if len(cfg.ContainerdConfigPatches) != 0 {
+					t.Errorf("expected no containerd patches, registry mirrors use hosts.toml")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package repair implements the `repair` command
+package repair
+
+import (
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name       string
+	Kubeconfig string
+}
+
+// NewCommand returns a new cobra.Command for repairing kubeconfig entries
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "repair",
+		Short: "Points the cluster's kubeconfig entries at its current API server endpoint",
+		Long: "Points the cluster's kubeconfig entries at its current API server endpoint.\n\n" +
+			"The published host port of the API server may change when the container runtime\n" +
+			"restarts, e.g. with Docker Desktop, which breaks existing kubeconfig entries.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	cmd.Flags().StringVar(
+		&flags.Kubeconfig,
+		"kubeconfig",
+		"",
+		"sets kubeconfig path instead of $KUBECONFIG or $HOME/.kube/config",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, flags *flagpole) error {
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	repaired, err := provider.RepairKubeConfig(flags.Name, flags.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	if len(repaired) == 0 {
+		logger.V(0).Infof(`kubeconfig entries for "kind-%s" are up to date`, flags.Name)
+		return nil
+	}
+	for _, path := range repaired {
+		logger.V(0).Infof(`Repaired "kind-%s" in %s`, flags.Name, path)
+	}
+	return nil
+}
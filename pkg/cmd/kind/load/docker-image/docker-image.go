@@ -19,19 +19,18 @@ package load
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
+	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
 	"sigs.k8s.io/kind/pkg/cluster/nodes"
 	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
 	"sigs.k8s.io/kind/pkg/cmd"
 	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/exec"
-	"sigs.k8s.io/kind/pkg/fs"
 	"sigs.k8s.io/kind/pkg/log"
 
 	"sigs.k8s.io/kind/pkg/internal/cli"
@@ -45,6 +44,7 @@ type (
 type flagpole struct {
 	Name  string
 	Nodes []string
+	Roles []string
 }
 
 // NewCommand returns a new cobra.Command for loading an image into a cluster
@@ -61,7 +61,9 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		Short: "Loads docker images from host into nodes",
 		Long:  "Loads docker images from host into all or specified nodes by name",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.OverrideDefaultName(cmd.Flags())
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
 			return runE(logger, flags, args)
 		},
 	}
@@ -78,6 +80,12 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		nil,
 		"comma separated list of nodes to load images into",
 	)
+	cmd.Flags().StringSliceVar(
+		&flags.Roles,
+		"roles",
+		nil,
+		"comma separated list of node roles to load images into, e.g. worker",
+	)
 	return cmd
 }
 
@@ -128,10 +136,16 @@ func runE(logger log.Logger, flags *flagpole, args []string) error {
 			candidateNodes = append(candidateNodes, node)
 		}
 	}
+	candidateNodes, err = selectNodesByRoles(candidateNodes, flags.Roles)
+	if err != nil {
+		return err
+	}
+	if len(candidateNodes) == 0 {
+		return fmt.Errorf("no nodes with roles %q found for cluster %q", strings.Join(flags.Roles, ","), flags.Name)
+	}
 
 	// pick only the nodes that don't have the image
 	selectedNodes := map[string]nodes.Node{}
-	fns := []func() error{}
 	for i, imageName := range imageNames {
 		imageID := imageIDs[i]
 		processed := false
@@ -170,45 +184,70 @@ func runE(logger log.Logger, flags *flagpole, args []string) error {
 		return nil
 	}
 
-	// Setup the tar path where the images will be saved
-	dir, err := fs.TempDir("", "images-tar")
-	if err != nil {
-		return errors.Wrap(err, "failed to create tempdir")
-	}
-	defer os.RemoveAll(dir)
-	imagesTarPath := filepath.Join(dir, "images.tar")
-	// Save the images into a tar
-	err = save(imageNames, imagesTarPath)
-	if err != nil {
-		return err
-	}
-
 	// Load the images on the selected nodes
-	for _, selectedNode := range selectedNodes {
-		selectedNode := selectedNode // capture loop variable
-		fns = append(fns, func() error {
-			return loadImage(imagesTarPath, selectedNode)
-		})
+	loadNodes := make([]nodes.Node, 0, len(selectedNodes))
+	for _, node := range selectedNodes {
+		loadNodes = append(loadNodes, node)
 	}
-	return errors.UntilErrorConcurrent(fns)
+	return loadImages(imageNames, loadNodes)
 }
 
 // TODO: we should consider having a cluster method to load images
 
-// loads an image tarball onto a node
-func loadImage(imageTarName string, node nodes.Node) error {
-	f, err := os.Open(imageTarName)
-	if err != nil {
-		return errors.Wrap(err, "failed to open image")
+// loadImages streams `docker save` of images into all of nodeList
+// concurrently, without writing the archive to disk first
+func loadImages(images []string, nodeList []nodes.Node) error {
+	writers := make([]io.Writer, len(nodeList))
+	pipeWriters := make([]*io.PipeWriter, len(nodeList))
+	fns := make([]func() error, 0, len(nodeList)+1)
+	for i, node := range nodeList {
+		node := node // capture loop variable
+		r, w := io.Pipe()
+		writers[i], pipeWriters[i] = w, w
+		fns = append(fns, func() error {
+			err := nodeutils.LoadImageArchive(node, r)
+			// a node that stopped reading fails the save instead of blocking it
+			_ = r.Close()
+			return errors.Wrapf(err, "failed to load images on node %s", node.String())
+		})
 	}
-	defer f.Close()
-	return nodeutils.LoadImageArchive(node, f)
+	fns = append(fns, func() error {
+		err := exec.Command("docker", append([]string{"save"}, images...)...).
+			SetStdout(io.MultiWriter(writers...)).Run()
+		// end the stream for every node, with the error if the save failed
+		for _, w := range pipeWriters {
+			_ = w.CloseWithError(err)
+		}
+		return errors.Wrap(err, "failed to save images")
+	})
+	return errors.AggregateConcurrent(fns)
 }
 
-// save saves images to dest, as in `docker save`
-func save(images []string, dest string) error {
-	commandArgs := append([]string{"save", "-o", dest}, images...)
-	return exec.Command("docker", commandArgs...).Run()
+// selectNodesByRoles returns the nodes with any of roles, or all nodes if
+// roles is empty
+func selectNodesByRoles(nodeList []nodes.Node, roles []string) ([]nodes.Node, error) {
+	if len(roles) == 0 {
+		return nodeList, nil
+	}
+	for _, role := range roles {
+		if role != constants.ControlPlaneNodeRoleValue && role != constants.WorkerNodeRoleValue {
+			return nil, fmt.Errorf("unknown role: %q, must be one of: %q, %q", role, constants.ControlPlaneNodeRoleValue, constants.WorkerNodeRoleValue)
+		}
+	}
+	selected := []nodes.Node{}
+	for _, node := range nodeList {
+		role, err := node.Role()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range roles {
+			if role == r {
+				selected = append(selected, node)
+				break
+			}
+		}
+	}
+	return selected, nil
 }
 
 // imageID return the Id of the container image
@@ -198,3 +198,66 @@ func Test_checkIfImageReTagRequired(t *testing.T) {
 		})
 	}
 }
+
+type fakeNode struct {
+	nodes.Node
+	name string
+	role string
+}
+
+func (n *fakeNode) String() string        { return n.name }
+func (n *fakeNode) Role() (string, error) { return n.role, nil }
+
+func Test_selectNodesByRoles(t *testing.T) {
+	allNodes := []nodes.Node{
+		&fakeNode{name: "kind-control-plane", role: "control-plane"},
+		&fakeNode{name: "kind-worker", role: "worker"},
+		&fakeNode{name: "kind-worker2", role: "worker"},
+	}
+	tests := []struct {
+		name    string
+		roles   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "no roles",
+			roles: nil,
+			want:  []string{"kind-control-plane", "kind-worker", "kind-worker2"},
+		},
+		{
+			name:  "workers",
+			roles: []string{"worker"},
+			want:  []string{"kind-worker", "kind-worker2"},
+		},
+		{
+			name:  "all roles",
+			roles: []string{"worker", "control-plane"},
+			want:  []string{"kind-control-plane", "kind-worker", "kind-worker2"},
+		},
+		{
+			name:    "unknown role",
+			roles:   []string{"external-load-balancer"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			selected, err := selectNodesByRoles(allNodes, tc.roles)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("selectNodesByRoles() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			got := []string{}
+			for _, n := range selected {
+				got = append(got, n.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("selectNodesByRoles() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -35,8 +35,11 @@ import (
 )
 
 type flagpole struct {
-	Name  string
-	Nodes []string
+	Name         string
+	Nodes        []string
+	OCILayouts   []string
+	IndexName    string
+	FromRegistry []string
 }
 
 // NewCommand returns a new cobra.Command for loading an image into a cluster
@@ -44,8 +47,8 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	flags := &flagpole{}
 	cmd := &cobra.Command{
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return fmt.Errorf("name of image archive is required")
+			if len(args) < 1 && len(flags.OCILayouts) == 0 && len(flags.FromRegistry) == 0 {
+				return fmt.Errorf("name of image archive, --oci-layout or --from-registry is required")
 			}
 			return nil
 		},
@@ -54,11 +57,13 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 				logger.Warn("It is suggested that you save multiple images into a common archive and load that instead of loading multiple archives for better performance")
 			}
 		},
-		Use:   "image-archive <IMAGE.tar>",
+		Use:   "image-archive [IMAGE.tar...]",
 		Short: "Loads docker image from archive into nodes",
-		Long:  "Loads docker image from archive into all or specified nodes by name",
+		Long:  "Loads docker image from archive, OCI image layout or registry into all or specified nodes by name",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cli.OverrideDefaultName(cmd.Flags())
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
 			return runE(logger, flags, args)
 		},
 	}
@@ -75,6 +80,24 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		nil,
 		"comma separated list of nodes to load images into",
 	)
+	cmd.Flags().StringArrayVar(
+		&flags.OCILayouts,
+		"oci-layout",
+		nil,
+		"OCI image layout directory to load, e.g. from buildah, ko or bazel, may be repeated",
+	)
+	cmd.Flags().StringVar(
+		&flags.IndexName,
+		"index-name",
+		"",
+		"image name to load a single --oci-layout as, for layouts whose images are not named by a fully qualified reference",
+	)
+	cmd.Flags().StringArrayVar(
+		&flags.FromRegistry,
+		"from-registry",
+		nil,
+		"image to pull on the host with skopeo and load, using the host registry credentials, may be repeated",
+	)
 	return cmd
 }
 
@@ -89,23 +112,60 @@ func runE(logger log.Logger, flags *flagpole, args []string) error {
 			return err
 		}
 	}
+	indexName := ""
+	if flags.IndexName != "" {
+		if len(flags.OCILayouts) != 1 {
+			return fmt.Errorf("--index-name requires exactly one --oci-layout")
+		}
+		name, err := normalizeReference(flags.IndexName)
+		if err != nil {
+			return err
+		}
+		indexName = name
+	}
+	for _, dir := range flags.OCILayouts {
+		if _, err := os.Stat(dir); err != nil {
+			return err
+		}
+		if indexName == "" {
+			if err := checkLayoutRefs(dir); err != nil {
+				return err
+			}
+		}
+	}
+
+	selectedNodes, err := selectNodes(provider, flags.Name, flags.Nodes)
+	if err != nil {
+		return err
+	}
 
 	for _, imageTarPath := range args {
-		if err := loadArchiveToNodes(logger, provider, flags.Name, flags.Nodes, imageTarPath); err != nil {
+		if err := loadArchiveToNodes(logger, imageTarPath, selectedNodes); err != nil {
+			return err
+		}
+	}
+	for _, dir := range flags.OCILayouts {
+		if err := loadLayoutToNodes(logger, dir, indexName, selectedNodes); err != nil {
+			return err
+		}
+	}
+	for _, ref := range flags.FromRegistry {
+		if err := loadRegistryImageToNodes(logger, ref, selectedNodes); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func loadArchiveToNodes(logger log.Logger, provider *cluster.Provider, clusterName string, nodeNames []string, imageArchivePath string) error {
+// selectNodes returns the named nodes of the cluster, or all of them if none are named
+func selectNodes(provider *cluster.Provider, clusterName string, nodeNames []string) ([]nodes.Node, error) {
 	// Check if the cluster nodes exist
 	nodeList, err := provider.ListInternalNodes(clusterName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(nodeList) == 0 {
-		return fmt.Errorf("no nodes found for cluster %q", clusterName)
+		return nil, fmt.Errorf("no nodes found for cluster %q", clusterName)
 	}
 
 	// map cluster nodes by their name
@@ -124,12 +184,15 @@ func loadArchiveToNodes(logger log.Logger, provider *cluster.Provider, clusterNa
 		for _, name := range nodeNames {
 			node, ok := nodesByName[name]
 			if !ok {
-				return fmt.Errorf("unknown node: %s", name)
+				return nil, fmt.Errorf("unknown node: %s", name)
 			}
 			selectedNodes = append(selectedNodes, node)
 		}
 	}
+	return selectedNodes, nil
+}
 
+func loadArchiveToNodes(logger log.Logger, imageArchivePath string, selectedNodes []nodes.Node) error {
 	// Load the image on the selected nodes
 	fns := []func() error{}
 	for _, selectedNode := range selectedNodes {
@@ -151,3 +214,31 @@ func loadImage(logger log.Logger, imageTarName string, node nodes.Node) error {
 	logger.V(2).Infof("Loading Docker Image from archive %s to node %s", imageTarName, node.String())
 	return nodeutils.LoadImageArchive(node, f)
 }
+
+// loadLayoutToNodes loads the OCI image layout in dir to the selected nodes,
+// naming its image index indexName if it is set
+func loadLayoutToNodes(logger log.Logger, dir, indexName string, selectedNodes []nodes.Node) error {
+	fns := []func() error{}
+	for _, selectedNode := range selectedNodes {
+		selectedNode := selectedNode // capture loop variable
+		fns = append(fns, func() error {
+			logger.V(2).Infof("Loading OCI image layout %s to node %s", dir, selectedNode.String())
+			return nodeutils.LoadOCILayout(selectedNode, dir, indexName)
+		})
+	}
+	return errors.UntilErrorConcurrent(fns)
+}
+
+// loadRegistryImageToNodes pulls ref on the host and loads it to the selected nodes
+func loadRegistryImageToNodes(logger log.Logger, ref string, selectedNodes []nodes.Node) error {
+	dir, err := os.MkdirTemp("", "image-layout")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary directory")
+	}
+	defer os.RemoveAll(dir)
+	logger.V(0).Infof("Pulling image %s", ref)
+	if err := pullToLayout(ref, dir); err != nil {
+		return err
+	}
+	return loadLayoutToNodes(logger, dir, "", selectedNodes)
+}
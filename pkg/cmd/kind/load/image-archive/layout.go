@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package load
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+// refNameAnnotation is the index.json annotation containerd names images by
+const refNameAnnotation = "org.opencontainers.image.ref.name"
+
+// checkLayoutRefs checks that every manifest in the index.json of the OCI
+// image layout in dir is named by a fully qualified reference, as containerd
+// would otherwise import images the kubelet cannot reference
+func checkLayoutRefs(dir string) error {
+	raw, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		return errors.Errorf("%s is not an OCI image layout, it has no index.json", dir)
+	}
+	index := struct {
+		Manifests []struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"manifests"`
+	}{}
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return errors.Wrapf(err, "failed to parse index.json of %s", dir)
+	}
+	if len(index.Manifests) == 0 {
+		return errors.Errorf("OCI image layout %s has no images", dir)
+	}
+	for _, m := range index.Manifests {
+		ref := m.Annotations[refNameAnnotation]
+		if name, err := normalizeReference(ref); ref == "" || err != nil || name != ref {
+			return errors.Errorf("OCI image layout %s has an image without a fully qualified %s annotation (%q), pass --index-name to name it", dir, refNameAnnotation, ref)
+		}
+	}
+	return nil
+}
+
+// pullToLayout pulls ref for all platforms on the host into an OCI image
+// layout in dir, using the registry credentials of the host
+func pullToLayout(ref, dir string) error {
+	name, err := normalizeReference(ref)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("skopeo", "copy", "--all", "docker://"+name, "oci:"+dir+":"+name)
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "failed to pull %s, is skopeo installed?", ref)
+	}
+	return nil
+}
+
+// normalizeReference returns the fully qualified form of the image reference,
+// which is the name containerd and the kubelet know the image by
+func normalizeReference(ref string) (string, error) {
+	if strings.Contains(ref, "@") {
+		return "", errors.Errorf("image %q is referenced by digest, pass a tag instead", ref)
+	}
+	name := ref
+	if i := strings.Index(name, "/"); i == -1 {
+		name = "docker.io/library/" + name
+	} else if domain := name[:i]; !strings.ContainsAny(domain, ".:") && domain != "localhost" {
+		name = "docker.io/" + name
+	}
+	// a tag follows the last colon after the last slash, else a port precedes it
+	if !strings.Contains(name[strings.LastIndex(name, "/"):], ":") {
+		name += ":latest"
+	}
+	return name, nil
+}
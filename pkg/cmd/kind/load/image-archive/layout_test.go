@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package load
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestNormalizeReference(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Ref         string
+		Expected    string
+		ExpectError bool
+	}{
+		{Ref: "nginx", Expected: "docker.io/library/nginx:latest"},
+		{Ref: "nginx:1.25", Expected: "docker.io/library/nginx:1.25"},
+		{Ref: "bitnami/nginx:1.25", Expected: "docker.io/bitnami/nginx:1.25"},
+		{Ref: "ghcr.io/foo/bar", Expected: "ghcr.io/foo/bar:latest"},
+		{Ref: "localhost:5000/foo", Expected: "localhost:5000/foo:latest"},
+		{Ref: "localhost/foo:v1", Expected: "localhost/foo:v1"},
+		{Ref: "nginx@sha256:0123", ExpectError: true},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Ref, func(t *testing.T) {
+			t.Parallel()
+			name, err := normalizeReference(tc.Ref)
+			assert.ExpectError(t, tc.ExpectError, err)
+			assert.StringEqual(t, tc.Expected, name)
+		})
+	}
+}
+
+func TestCheckLayoutRefs(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		Index       string
+		ExpectError bool
+	}{
+		{
+			Name:  "fully qualified ref",
+			Index: `{"manifests":[{"annotations":{"org.opencontainers.image.ref.name":"docker.io/library/foo:v1"}}]}`,
+		},
+		{
+			Name:        "tag only",
+			Index:       `{"manifests":[{"annotations":{"org.opencontainers.image.ref.name":"v1"}}]}`,
+			ExpectError: true,
+		},
+		{
+			Name:        "short name",
+			Index:       `{"manifests":[{"annotations":{"org.opencontainers.image.ref.name":"foo:v1"}}]}`,
+			ExpectError: true,
+		},
+		{
+			Name:        "no annotation",
+			Index:       `{"manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json"}]}`,
+			ExpectError: true,
+		},
+		{
+			Name:        "one of several unnamed",
+			Index:       `{"manifests":[{"annotations":{"org.opencontainers.image.ref.name":"ghcr.io/foo/bar:v1"}},{}]}`,
+			ExpectError: true,
+		},
+		{
+			Name:        "no manifests",
+			Index:       `{}`,
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid index",
+			Index:       `{`,
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "index.json"), []byte(tc.Index), 0644); err != nil {
+				t.Fatal(err)
+			}
+			assert.ExpectError(t, tc.ExpectError, checkLayoutRefs(dir))
+		})
+	}
+
+	if err := checkLayoutRefs(t.TempDir()); err == nil {
+		t.Fatal("expected an error for a directory without index.json")
+	}
+}
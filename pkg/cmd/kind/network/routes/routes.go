@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package routes implements the `routes` command
+package routes
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/cmd/kind/network/routes/install"
+	"sigs.k8s.io/kind/pkg/cmd/kind/network/routes/remove"
+	"sigs.k8s.io/kind/pkg/log"
+)
+
+// NewCommand returns a new cobra.Command for managing host routes
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "routes",
+		Short: "Manages host routes to cluster pods and services, one of [install, remove]",
+		Long: `Manages host routes to the cluster's pod and service subnets via the node containers.
+
+With these routes installed, pod IPs and ClusterIPs can be reached directly
+from the host without port-forwarding. This is only supported on linux and
+typically requires root.
+
+If the host firewall filters forwarded traffic you will also need to allow
+traffic to the pod and service subnets through the kind network bridge, e.g.:
+
+  iptables -I DOCKER-USER -d 10.244.0.0/16 -j ACCEPT
+  iptables -I DOCKER-USER -d 10.96.0.0/16 -j ACCEPT
+
+The routes are recorded on the nodes, and only those are removed when the
+cluster is deleted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := cmd.Help()
+			if err != nil {
+				return err
+			}
+			return errors.New("Subcommand is required")
+		},
+	}
+	cmd.AddCommand(install.NewCommand(logger, streams))
+	cmd.AddCommand(remove.NewCommand(logger, streams))
+	return cmd
+}
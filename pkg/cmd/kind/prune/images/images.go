@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package images implements the `images` command
+package images
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name    string
+	Keep    []string
+	DryRun  bool
+	Confirm cli.ConfirmFlags
+}
+
+// NewCommand returns a new cobra.Command for pruning node images
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "images",
+		Short: "Removes unused images from the cluster's nodes",
+		Long: "Removes the images on each of the cluster's nodes that are not used by any container " +
+			"and reports the disk space reclaimed. Pinned images and kind's own images are always kept.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, streams, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	cmd.Flags().StringSliceVar(
+		&flags.Keep,
+		"keep",
+		nil,
+		"also keep images whose names start with these prefixes, e.g. docker.io/library/",
+	)
+	cmd.Flags().BoolVar(
+		&flags.DryRun,
+		"dry-run",
+		false,
+		"only list the images that would be removed",
+	)
+	flags.Confirm.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
+	if !flags.DryRun {
+		action := fmt.Sprintf("remove the unused images of cluster %q", flags.Name)
+		if err := cli.Confirm(streams.In, streams.ErrOut, flags.Confirm, action); err != nil {
+			return err
+		}
+	}
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	results, err := provider.PruneImages(flags.Name, flags.Keep, flags.DryRun)
+	// report the nodes that were pruned even if some failed
+	for _, r := range results {
+		if r.Node == "" {
+			continue
+		}
+		if flags.DryRun {
+			fmt.Fprintf(streams.Out, "%s: would remove %d images\n", r.Node, len(r.Removed))
+		} else {
+			fmt.Fprintf(streams.Out, "%s: removed %d images, reclaimed %s\n", r.Node, len(r.Removed), cli.FormatBytes(r.ReclaimedBytes))
+		}
+		for _, image := range r.Removed {
+			fmt.Fprintf(streams.Out, "  %s\n", image)
+		}
+	}
+	return err
+}
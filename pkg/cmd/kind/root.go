@@ -23,21 +23,40 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/cmd/kind/apply"
 	"sigs.k8s.io/kind/pkg/cmd/kind/build"
+	"sigs.k8s.io/kind/pkg/cmd/kind/cache"
 	"sigs.k8s.io/kind/pkg/cmd/kind/completion"
 	"sigs.k8s.io/kind/pkg/cmd/kind/create"
 	"sigs.k8s.io/kind/pkg/cmd/kind/delete"
 	"sigs.k8s.io/kind/pkg/cmd/kind/export"
 	"sigs.k8s.io/kind/pkg/cmd/kind/get"
+	"sigs.k8s.io/kind/pkg/cmd/kind/history"
+	"sigs.k8s.io/kind/pkg/cmd/kind/imports"
+	"sigs.k8s.io/kind/pkg/cmd/kind/initialize"
+	"sigs.k8s.io/kind/pkg/cmd/kind/kubeconfig"
 	"sigs.k8s.io/kind/pkg/cmd/kind/load"
+	"sigs.k8s.io/kind/pkg/cmd/kind/network"
+	"sigs.k8s.io/kind/pkg/cmd/kind/pause"
+	"sigs.k8s.io/kind/pkg/cmd/kind/prune"
+	"sigs.k8s.io/kind/pkg/cmd/kind/resume"
+	"sigs.k8s.io/kind/pkg/cmd/kind/scale"
+	"sigs.k8s.io/kind/pkg/cmd/kind/status"
+	"sigs.k8s.io/kind/pkg/cmd/kind/top"
+	"sigs.k8s.io/kind/pkg/cmd/kind/upgrade"
 	"sigs.k8s.io/kind/pkg/cmd/kind/version"
+	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/runtime"
 )
 
 type flagpole struct {
-	LogLevel  string
-	Verbosity int32
-	Quiet     bool
+	LogLevel    string
+	Verbosity   int32
+	Quiet       bool
+	ErrorFormat string
+	Provider    string
 }
 
 // NewCommand returns a new cobra.Command implementing the root command for kind
@@ -77,6 +96,18 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 		false,
 		"silence all stderr output",
 	)
+	cmd.PersistentFlags().StringVar(
+		&flags.ErrorFormat,
+		"error-format",
+		"text",
+		"format of the final error on failure, one of: text, json",
+	)
+	cmd.PersistentFlags().StringVar(
+		&flags.Provider,
+		"provider",
+		"",
+		"node provider to use, one of: auto, docker, podman, nerdctl, finch, nerdctl.lima, overrides KIND_PROVIDER (default auto)",
+	)
 	// add all top level subcommands
 	cmd.AddCommand(build.NewCommand(logger, streams))
 	cmd.AddCommand(completion.NewCommand(logger, streams))
@@ -84,12 +115,33 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	cmd.AddCommand(delete.NewCommand(logger, streams))
 	cmd.AddCommand(export.NewCommand(logger, streams))
 	cmd.AddCommand(get.NewCommand(logger, streams))
+	cmd.AddCommand(imports.NewCommand(logger, streams))
+	cmd.AddCommand(initialize.NewCommand(logger, streams))
 	cmd.AddCommand(version.NewCommand(logger, streams))
 	cmd.AddCommand(load.NewCommand(logger, streams))
+	cmd.AddCommand(network.NewCommand(logger, streams))
+	cmd.AddCommand(kubeconfig.NewCommand(logger, streams))
+	cmd.AddCommand(status.NewCommand(logger, streams))
+	cmd.AddCommand(top.NewCommand(logger, streams))
+	cmd.AddCommand(upgrade.NewCommand(logger, streams))
+	cmd.AddCommand(pause.NewCommand(logger, streams))
+	cmd.AddCommand(resume.NewCommand(logger, streams))
+	cmd.AddCommand(scale.NewCommand(logger, streams))
+	cmd.AddCommand(prune.NewCommand(logger, streams))
+	cmd.AddCommand(apply.NewCommand(logger, streams))
+	cmd.AddCommand(cache.NewCommand(logger, streams))
+	cmd.AddCommand(history.NewCommand(logger, streams))
 	return cmd
 }
 
 func runE(logger log.Logger, flags *flagpole, command *cobra.Command) error {
+	// NOTE: json errors are written by app.Run, we only validate the flag here
+	if flags.ErrorFormat != "text" && flags.ErrorFormat != "json" {
+		return errors.Errorf("invalid --error-format %q, must be one of: text, json", flags.ErrorFormat)
+	}
+	if err := runtime.SetProviderFlag(flags.Provider); err != nil {
+		return err
+	}
 	// handle limited migration for --loglevel
 	setLogLevel := command.Flag("loglevel").Changed
 	setVerbosity := command.Flag("verbosity").Changed
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scale implements the `scale` command
+package scale
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cluster/constants"
+	"sigs.k8s.io/kind/pkg/cluster/nodeutils"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name    string
+	Workers int
+	Config  string
+	Wait    time.Duration
+	Confirm cli.ConfirmFlags
+}
+
+// NewCommand returns a new cobra.Command for scaling a cluster's workers
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "scale",
+		Short: "Adds or removes worker nodes of a cluster",
+		Long: "Adds or removes worker nodes of an existing cluster. New workers are joined with kubeadm, " +
+			"removed workers are drained and deleted, starting with the most recently added",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, streams, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	cmd.Flags().IntVar(
+		&flags.Workers,
+		"workers",
+		0,
+		"the desired number of worker nodes",
+	)
+	_ = cmd.MarkFlagRequired("workers")
+	cmd.Flags().StringVar(
+		&flags.Config,
+		"config",
+		"",
+		"path to the config the cluster was created with, new workers are configured like its last worker",
+	)
+	cmd.Flags().DurationVar(
+		&flags.Wait,
+		"wait",
+		time.Duration(0),
+		"wait for new nodes to be ready (default 0s)",
+	)
+	flags.Confirm.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	// removing workers deletes them along with anything stored on them
+	allNodes, err := provider.ListNodes(flags.Name)
+	if err != nil {
+		return err
+	}
+	workers, err := nodeutils.SelectNodesByRole(allNodes, constants.WorkerNodeRoleValue)
+	if err != nil {
+		return err
+	}
+	if remove := len(workers) - flags.Workers; remove > 0 && flags.Workers >= 0 {
+		action := fmt.Sprintf("remove %d workers of cluster %q", remove, flags.Name)
+		if err := cli.Confirm(streams.In, streams.ErrOut, flags.Confirm, action); err != nil {
+			return err
+		}
+	}
+	return provider.ScaleWorkers(flags.Name, flags.Workers, flags.Config, flags.Wait)
+}
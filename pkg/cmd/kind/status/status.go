@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package status implements the `status` command
+package status
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name       string
+	Kubeconfig string
+	CheckDrift bool
+}
+
+// NewCommand returns a new cobra.Command for reporting cluster status
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "status",
+		Short: "Reports the status of a cluster and detects common problems",
+		Long:  "Reports the status of a cluster and detects common problems, such as stale kubeconfig entries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, streams, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	cmd.Flags().StringVar(
+		&flags.Kubeconfig,
+		"kubeconfig",
+		"",
+		"sets kubeconfig path instead of $KUBECONFIG or $HOME/.kube/config",
+	)
+	cmd.Flags().BoolVar(
+		&flags.CheckDrift,
+		"check-drift",
+		false,
+		"check if the node image references now resolve to different images, this pulls the images",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	n, err := provider.ListNodes(flags.Name)
+	if err != nil {
+		return err
+	}
+	if len(n) == 0 {
+		return errors.Errorf("unknown cluster %q", flags.Name)
+	}
+
+	fmt.Fprintf(streams.Out, "Cluster: %s\n\n", flags.Name)
+	w := tabwriter.NewWriter(streams.Out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tROLE")
+	for _, node := range n {
+		role, err := node.Role()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\t%s\n", node.String(), role)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(streams.Out)
+
+	missing, err := provider.MissingNetwork(flags.Name)
+	if err != nil {
+		return err
+	}
+	if missing == "" {
+		fmt.Fprintln(streams.Out, "network: ok")
+	} else {
+		fmt.Fprintf(streams.Out, "network: %s missing\n", missing)
+		logger.Warnf("WARNING: the network %q was deleted, run `kind resume --name %s` to recreate it", missing, flags.Name)
+	}
+
+	stale, err := provider.StaleKubeConfigs(flags.Name, flags.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		fmt.Fprintln(streams.Out, "kubeconfig: ok")
+	} else {
+		fmt.Fprintf(streams.Out, "kubeconfig: stale in %s\n", strings.Join(stale, ", "))
+		logger.Warnf("WARNING: the API server endpoint changed, run `kind kubeconfig repair --name %s`", flags.Name)
+	}
+
+	if !flags.CheckDrift {
+		return nil
+	}
+	drift, err := provider.CheckImageDrift(flags.Name)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(streams.Out)
+	w = tabwriter.NewWriter(streams.Out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NODE\tIMAGE\tDIGEST\tCURRENT")
+	for _, d := range drift {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", d.Node, d.Image, d.Digest, d.Current)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, d := range drift {
+		if d.Drifted {
+			logger.Warnf("WARNING: %s now resolves to %s, but node %s runs %s", d.Image, d.Current, d.Node, d.Digest)
+		}
+	}
+	return nil
+}
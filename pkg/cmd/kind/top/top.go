@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package top implements the `top` command
+package top
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/env"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name     string
+	Watch    bool
+	Interval time.Duration
+}
+
+// NewCommand returns a new cobra.Command for reporting cluster resource usage
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "top",
+		Short: "Displays the resource usage of a cluster's node containers",
+		Long:  "Displays the resource usage of a cluster's node containers on the host, including the disk usage of their volumes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, streams, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	cmd.Flags().BoolVarP(
+		&flags.Watch,
+		"watch",
+		"w",
+		false,
+		"continuously refresh the resource usage",
+	)
+	cmd.Flags().DurationVar(
+		&flags.Interval,
+		"interval",
+		2*time.Second,
+		"the refresh interval with --watch",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
+	if flags.Interval <= 0 {
+		return errors.Errorf("invalid --interval %s, must be positive", flags.Interval)
+	}
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	for {
+		stats, err := provider.NodeStats(flags.Name)
+		if err != nil {
+			return err
+		}
+		if flags.Watch && env.IsSmartTerminal(streams.Out) {
+			// clear the screen like watch(1)
+			fmt.Fprint(streams.Out, "\x1b[H\x1b[2J")
+		}
+		if err := printStats(streams.Out, stats); err != nil {
+			return err
+		}
+		if !flags.Watch {
+			return nil
+		}
+		time.Sleep(flags.Interval)
+		fmt.Fprintln(streams.Out)
+	}
+}
+
+func printStats(out io.Writer, stats []cluster.NodeStats) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCPU %\tMEMORY\tPIDS\tNET I/O\tBLOCK I/O\tDISK")
+	total := cluster.NodeStats{Name: "TOTAL"}
+	for _, s := range stats {
+		printRow(w, s)
+		total.CPUPercent += s.CPUPercent
+		total.MemoryBytes += s.MemoryBytes
+		total.PIDs += s.PIDs
+		total.NetRxBytes += s.NetRxBytes
+		total.NetTxBytes += s.NetTxBytes
+		total.BlockReadBytes += s.BlockReadBytes
+		total.BlockWriteBytes += s.BlockWriteBytes
+		total.DiskBytes += s.DiskBytes
+	}
+	printRow(w, total)
+	return w.Flush()
+}
+
+func printRow(w io.Writer, s cluster.NodeStats) {
+	fmt.Fprintf(w, "%s\t%.2f%%\t%s\t%d\t%s / %s\t%s / %s\t%s\n",
+		s.Name,
+		s.CPUPercent,
+		cli.FormatBytes(s.MemoryBytes),
+		s.PIDs,
+		cli.FormatBytes(s.NetRxBytes), cli.FormatBytes(s.NetTxBytes),
+		cli.FormatBytes(s.BlockReadBytes), cli.FormatBytes(s.BlockWriteBytes),
+		cli.FormatBytes(s.DiskBytes),
+	)
+}
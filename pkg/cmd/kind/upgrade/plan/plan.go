@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plan implements the `plan` command
+package plan
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/log"
+
+	"sigs.k8s.io/kind/pkg/internal/cli"
+	"sigs.k8s.io/kind/pkg/internal/runtime"
+)
+
+type flagpole struct {
+	Name  string
+	Image string
+}
+
+// NewCommand returns a new cobra.Command for the upgrade plan command
+func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
+	flags := &flagpole{}
+	cmd := &cobra.Command{
+		Args:  cobra.NoArgs,
+		Use:   "plan",
+		Short: "Reports whether a cluster can be upgraded in-place to a node image",
+		Long: "Reports whether a cluster can be upgraded in-place to a node image.\n\n" +
+			"A scratch container of the image is started to compare component versions " +
+			"and run kubeadm upgrade plan against the cluster, the cluster is not modified.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cli.OverrideDefaultName(cmd.Flags()); err != nil {
+				return err
+			}
+			return runE(logger, streams, flags)
+		},
+	}
+	cmd.Flags().StringVarP(
+		&flags.Name,
+		"name",
+		"n",
+		cluster.DefaultName,
+		"the cluster context name",
+	)
+	cmd.Flags().StringVar(
+		&flags.Image,
+		"image",
+		"",
+		"the node image to upgrade to",
+	)
+	return cmd
+}
+
+func runE(logger log.Logger, streams cmd.IOStreams, flags *flagpole) error {
+	if flags.Image == "" {
+		return errors.New("--image is required")
+	}
+	provider := cluster.NewProvider(
+		cluster.ProviderWithLogger(logger),
+		runtime.GetDefault(logger),
+	)
+	plan, err := provider.UpgradePlan(flags.Name, flags.Image)
+	if err != nil {
+		return errors.Wrap(err, "failed to check upgrade")
+	}
+	return printPlan(streams.Out, plan)
+}
+
+func printPlan(out io.Writer, plan *cluster.UpgradePlan) error {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tCURRENT\tTARGET")
+	for _, c := range plan.Components {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", c.Name, c.Current, c.Target)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(out)
+	for _, line := range plan.KubeadmOutput {
+		fmt.Fprintln(out, line)
+	}
+	fmt.Fprintln(out)
+	if plan.Viable {
+		fmt.Fprintf(out, "Upgrading to %s looks viable.\n", plan.Image)
+		return nil
+	}
+	fmt.Fprintf(out, "Upgrading to %s is not viable:\n", plan.Image)
+	for _, r := range plan.Reasons {
+		fmt.Fprintf(out, " • %s\n", r)
+	}
+	return nil
+}
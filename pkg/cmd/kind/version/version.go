@@ -24,27 +24,13 @@ import (
 	"github.com/spf13/cobra"
 
 	"sigs.k8s.io/kind/pkg/cmd"
+	"sigs.k8s.io/kind/pkg/internal/version"
 	"sigs.k8s.io/kind/pkg/log"
 )
 
 // Version returns the kind CLI Semantic Version
 func Version() string {
-	v := versionCore
-	// add pre-release version info if we have it
-	if versionPreRelease != "" {
-		v += "-" + versionPreRelease
-		// If gitCommitCount was set, add to the pre-release version
-		if gitCommitCount != "" {
-			v += "." + gitCommitCount
-		}
-		// if commit was set, add the + <build>
-		// we only do this for pre-release versions
-		if gitCommit != "" {
-			// NOTE: use 14 character short hash, like Kubernetes
-			v += "+" + truncate(gitCommit, 14)
-		}
-	}
-	return v
+	return version.KindVersion()
 }
 
 // DisplayVersion is Version() display formatted, this is what the version
@@ -53,21 +39,6 @@ func DisplayVersion() string {
 	return "kind v" + Version() + " " + runtime.Version() + " " + runtime.GOOS + "/" + runtime.GOARCH
 }
 
-// versionCore is the core portion of the kind CLI version per Semantic Versioning 2.0.0
-const versionCore = "0.25.0"
-
-// versionPreRelease is the base pre-release portion of the kind CLI version per
-// Semantic Versioning 2.0.0
-var versionPreRelease = "alpha"
-
-// gitCommitCount count the commits since the last release.
-// It is injected at build time.
-var gitCommitCount = ""
-
-// gitCommit is the commit used to build the kind binary, if available.
-// It is injected at build time.
-var gitCommit = ""
-
 // NewCommand returns a new cobra.Command for version
 func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	cmd := &cobra.Command{
@@ -88,10 +59,3 @@ func NewCommand(logger log.Logger, streams cmd.IOStreams) *cobra.Command {
 	}
 	return cmd
 }
-
-func truncate(s string, maxLen int) string {
-	if len(s) < maxLen {
-		return s
-	}
-	return s[:maxLen]
-}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+// Category is a stable, machine-readable classification of an error
+type Category string
+
+const (
+	// UnknownCategory is the category of errors without Details
+	UnknownCategory Category = "Unknown"
+	// ConfigCategory is for invalid configuration
+	ConfigCategory Category = "ConfigInvalid"
+	// PreflightCategory is for failed checks before any resources are created
+	PreflightCategory Category = "PreflightFailed"
+	// ProvisioningCategory is for failures creating or configuring nodes
+	ProvisioningCategory Category = "ProvisioningFailed"
+	// KubeadmCategory is for failures running kubeadm on a node
+	KubeadmCategory Category = "KubeadmFailed"
+	// TimeoutCategory is for operations that did not complete in time
+	TimeoutCategory Category = "Timeout"
+)
+
+// exitCodes are the stable process exit codes for each Category
+// 6 is unused
+var exitCodes = map[Category]int{
+	UnknownCategory:      1,
+	ConfigCategory:       2,
+	PreflightCategory:    3,
+	ProvisioningCategory: 4,
+	KubeadmCategory:      5,
+	TimeoutCategory:      7,
+}
+
+// ExitCode returns the stable process exit code for the Category
+func (c Category) ExitCode() int {
+	if code, ok := exitCodes[c]; ok {
+		return code
+	}
+	return exitCodes[UnknownCategory]
+}
+
+// ExitCode returns the process exit code for err, based on its Category
+// If err is nil, ExitCode returns 0
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return DetailsOf(err).Category.ExitCode()
+}
+
+// Details is structured context about an error, for presenting failures
+// to programs without parsing messages
+type Details struct {
+	// Category classifies the failure
+	Category Category
+	// Phase is the step that failed, e.g. kubeadm-init
+	Phase string
+	// Node is the name of the node the failure occurred on, if any
+	Node string
+	// Hint is a human readable suggestion for remediating the failure
+	Hint string
+}
+
+type withDetails struct {
+	error
+	details Details
+}
+
+// Cause implements Causer
+func (w *withDetails) Cause() error {
+	return w.error
+}
+
+// Unwrap implements the standard library's error unwrapping
+func (w *withDetails) Unwrap() error {
+	return w.error
+}
+
+// WithDetails annotates err with details, without changing the message
+// If err is nil, WithDetails returns nil.
+func WithDetails(err error, details Details) error {
+	if err == nil {
+		return nil
+	}
+	return &withDetails{error: err, details: details}
+}
+
+// DetailsOf returns the Details annotating err or any error in its Cause chain
+// Where multiple errors in the chain have details, fields set by outer errors
+// take precedence and unset fields are filled in from inner errors
+func DetailsOf(err error) Details {
+	details := Details{}
+	for err != nil {
+		if d, ok := err.(*withDetails); ok {
+			if details.Category == "" {
+				details.Category = d.details.Category
+			}
+			if details.Phase == "" {
+				details.Phase = d.details.Phase
+			}
+			if details.Node == "" {
+				details.Node = d.details.Node
+			}
+			if details.Hint == "" {
+				details.Hint = d.details.Hint
+			}
+		}
+		causerErr, ok := err.(Causer)
+		if !ok {
+			break
+		}
+		err = causerErr.Cause()
+	}
+	if details.Category == "" {
+		details.Category = UnknownCategory
+	}
+	return details
+}
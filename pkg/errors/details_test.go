@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestDetailsOf(t *testing.T) {
+	t.Parallel()
+	t.Run("no details", func(t *testing.T) {
+		t.Parallel()
+		assert.DeepEqual(t, Details{Category: UnknownCategory}, DetailsOf(New("foo")))
+	})
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		assert.DeepEqual(t, Details{Category: UnknownCategory}, DetailsOf(nil))
+		if WithDetails(nil, Details{Phase: "foo"}) != nil {
+			t.Errorf("expected WithDetails(nil, ...) to be nil")
+		}
+	})
+	t.Run("wrapped chain", func(t *testing.T) {
+		t.Parallel()
+		inner := WithDetails(New("kubeadm failed"), Details{
+			Category: KubeadmCategory,
+			Node:     "kind-control-plane",
+			Hint:     "inner hint",
+		})
+		outer := WithDetails(Wrap(inner, "action failed"), Details{
+			Category: ProvisioningCategory,
+			Phase:    "kubeadm-init",
+		})
+		assert.StringEqual(t, "action failed: kubeadm failed", outer.Error())
+		assert.DeepEqual(t, Details{
+			Category: ProvisioningCategory,
+			Phase:    "kubeadm-init",
+			Node:     "kind-control-plane",
+			Hint:     "inner hint",
+		}, DetailsOf(outer))
+	})
+}
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name     string
+		Err      error
+		Expected int
+	}{
+		{Name: "nil", Err: nil, Expected: 0},
+		{Name: "no details", Err: New("foo"), Expected: 1},
+		{Name: "config", Err: WithDetails(New("foo"), Details{Category: ConfigCategory}), Expected: 2},
+		{Name: "preflight", Err: WithDetails(New("foo"), Details{Category: PreflightCategory}), Expected: 3},
+		{Name: "provisioning", Err: WithDetails(New("foo"), Details{Category: ProvisioningCategory}), Expected: 4},
+		{Name: "kubeadm", Err: Wrap(WithDetails(New("foo"), Details{Category: KubeadmCategory}), "bar"), Expected: 5},
+		{Name: "timeout", Err: WithDetails(New("foo"), Details{Category: TimeoutCategory}), Expected: 7},
+		{Name: "unknown category", Err: WithDetails(New("foo"), Details{Category: "Bogus"}), Expected: 1},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			if code := ExitCode(tc.Err); code != tc.Expected {
+				t.Errorf("expected exit code %d but got %d", tc.Expected, code)
+			}
+		})
+	}
+}
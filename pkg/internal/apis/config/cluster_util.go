@@ -16,6 +16,8 @@ limitations under the License.
 
 package config
 
+import "time"
+
 // ClusterHasIPv6 returns true if the cluster should have IPv6 enabled due to either
 // being IPv6 cluster family or Dual Stack
 func ClusterHasIPv6(c *Cluster) bool {
@@ -24,6 +26,9 @@ func ClusterHasIPv6(c *Cluster) bool {
 
 // ClusterHasImplicitLoadBalancer returns true if this cluster has an implicit api-server LoadBalancer
 func ClusterHasImplicitLoadBalancer(c *Cluster) bool {
+	if c.LoadBalancer.Always {
+		return true
+	}
 	controlPlanes := 0
 	for _, node := range c.Nodes {
 		if node.Role == ControlPlaneRole {
@@ -32,3 +37,30 @@ func ClusterHasImplicitLoadBalancer(c *Cluster) bool {
 	}
 	return controlPlanes > 1
 }
+
+// IngressReadyLabelKey is the node label selecting the node the ingress
+// controller is scheduled to
+const IngressReadyLabelKey = "ingress-ready"
+
+// IngressNode returns the node the ingress controller is scheduled to, the
+// node labeled ingress-ready=true or else the first control-plane node
+func IngressNode(c *Cluster) *Node {
+	for i := range c.Nodes {
+		if c.Nodes[i].Labels[IngressReadyLabelKey] == "true" {
+			return &c.Nodes[i]
+		}
+	}
+	for i := range c.Nodes {
+		if c.Nodes[i].Role == ControlPlaneRole {
+			return &c.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// TimeoutDuration returns the duration of a validated ClusterTimeouts value,
+// zero means no timeout
+func TimeoutDuration(timeout string) time.Duration {
+	d, _ := time.ParseDuration(timeout)
+	return d
+}
@@ -136,3 +136,37 @@ func TestClusterHasImplicitLoadBalancer(t *testing.T) {
 		})
 	}
 }
+
+func TestIngressNode(t *testing.T) {
+	t.Parallel()
+	c := &Cluster{
+		Ingress: Ingress{Controller: NginxIngress},
+		Nodes: []Node{
+			{Role: WorkerRole},
+			{
+				Role: ControlPlaneRole,
+				ExtraPortMappings: []PortMapping{
+					{ContainerPort: 80, HostPort: 8080},
+				},
+			},
+		},
+	}
+	SetDefaultsCluster(c)
+	node := IngressNode(c)
+	assert.BoolEqual(t, true, node == &c.Nodes[1])
+	assert.StringEqual(t, "true", node.Labels[IngressReadyLabelKey])
+	assert.DeepEqual(t, []PortMapping{
+		{ContainerPort: 80, HostPort: 8080},
+		{ContainerPort: 443, HostPort: 443, Protocol: PortMappingProtocolTCP},
+	}, node.ExtraPortMappings)
+
+	// an explicitly labeled node is preferred, and defaulting is idempotent
+	c.Nodes[0].Labels = map[string]string{IngressReadyLabelKey: "true"}
+	SetDefaultsCluster(c)
+	SetDefaultsCluster(c)
+	assert.BoolEqual(t, true, IngressNode(c) == &c.Nodes[0])
+	assert.DeepEqual(t, []PortMapping{
+		{ContainerPort: 80, HostPort: 80, Protocol: PortMappingProtocolTCP},
+		{ContainerPort: 443, HostPort: 443, Protocol: PortMappingProtocolTCP},
+	}, c.Nodes[0].ExtraPortMappings)
+}
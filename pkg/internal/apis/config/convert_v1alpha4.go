@@ -30,8 +30,45 @@ func Convertv1alpha4(in *v1alpha4.Cluster) *Cluster {
 		RuntimeConfig:                   in.RuntimeConfig,
 		KubeadmConfigPatches:            in.KubeadmConfigPatches,
 		KubeadmConfigPatchesJSON6902:    make([]PatchJSON6902, len(in.KubeadmConfigPatchesJSON6902)),
+		KubeadmConfigPatchDirectories:   in.KubeadmConfigPatchDirectories,
 		ContainerdConfigPatches:         in.ContainerdConfigPatches,
 		ContainerdConfigPatchesJSON6902: in.ContainerdConfigPatchesJSON6902,
+		RegistryMirrors:                 make([]RegistryMirror, len(in.RegistryMirrors)),
+		LocalRegistry:                   LocalRegistry(in.LocalRegistry),
+		CrashCapture:                    in.CrashCapture,
+		SharedOCILayout:                 in.SharedOCILayout,
+		ImageCache:                      in.ImageCache,
+		ImageBundle:                     in.ImageBundle,
+		Timeouts:                        ClusterTimeouts(in.Timeouts),
+		DevicePlugin: DevicePlugin{
+			Type:  DevicePluginType(in.DevicePlugin.Type),
+			Image: in.DevicePlugin.Image,
+		},
+		Ingress: Ingress{
+			Controller: IngressController(in.Ingress.Controller),
+		},
+		Audit: Audit(in.Audit),
+		OIDC: OIDC{
+			IssuerURL:            in.OIDC.IssuerURL,
+			ClientID:             in.OIDC.ClientID,
+			UsernameClaim:        in.OIDC.UsernameClaim,
+			GroupsClaim:          in.OIDC.GroupsClaim,
+			CertificateAuthority: in.OIDC.CertificateAuthority,
+			Provider:             OIDCProvider(in.OIDC.Provider),
+			Image:                in.OIDC.Image,
+		},
+		ProxyHelper: ProxyHelper{
+			Auth:  ProxyAuth(in.ProxyHelper.Auth),
+			Image: in.ProxyHelper.Image,
+		},
+		DNSServer: DNSServer{
+			Enabled: in.DNSServer.Enabled,
+			Image:   in.DNSServer.Image,
+		},
+		ServiceLoadBalancer: ServiceLoadBalancer{
+			Type:      ServiceLoadBalancerType(in.ServiceLoadBalancer.Type),
+			Addresses: in.ServiceLoadBalancer.Addresses,
+		},
 	}
 
 	for i := range in.Nodes {
@@ -40,10 +77,28 @@ func Convertv1alpha4(in *v1alpha4.Cluster) *Cluster {
 
 	convertv1alpha4Networking(&in.Networking, &out.Networking)
 
+	convertv1alpha4StorageClass(&in.StorageClass, &out.StorageClass)
+
+	convertv1alpha4LoadBalancer(&in.LoadBalancer, &out.LoadBalancer)
+
+	out.DNS = DNS{
+		NodesPerReplica: in.DNS.NodesPerReplica,
+		MinReplicas:     in.DNS.MinReplicas,
+		Resources:       DNSResources(in.DNS.Resources),
+	}
+
+	for i := range in.RegistryMirrors {
+		convertv1alpha4RegistryMirror(&in.RegistryMirrors[i], &out.RegistryMirrors[i])
+	}
+
 	for i := range in.KubeadmConfigPatchesJSON6902 {
 		convertv1alpha4PatchJSON6902(&in.KubeadmConfigPatchesJSON6902[i], &out.KubeadmConfigPatchesJSON6902[i])
 	}
 
+	for _, r := range in.DNSServer.Records {
+		out.DNSServer.Records = append(out.DNSServer.Records, DNSRecord(r))
+	}
+
 	return out
 }
 
@@ -53,8 +108,25 @@ func convertv1alpha4Node(in *v1alpha4.Node, out *Node) {
 
 	out.Labels = in.Labels
 	out.KubeadmConfigPatches = in.KubeadmConfigPatches
+	out.IPv4Address = in.IPv4Address
+	out.IPv6Address = in.IPv6Address
+	out.Networks = in.Networks
+	out.Devices = in.Devices
+	out.Sysctls = in.Sysctls
+	out.Tmpfs = in.Tmpfs
+	out.UserNS = in.UserNS
+	out.KernelModules = in.KernelModules
+	convertv1alpha4IOLimits(&in.IOLimits, &out.IOLimits)
+	out.Resources = NodeResources{
+		CPUs:   in.Resources.CPUs,
+		Memory: in.Resources.Memory,
+		PIDs:   in.Resources.PIDs,
+	}
+	out.Logging = NodeLogging(in.Logging)
+	out.RegistryMirrors = make([]RegistryMirror, len(in.RegistryMirrors))
 	out.ExtraMounts = make([]Mount, len(in.ExtraMounts))
 	out.ExtraPortMappings = make([]PortMapping, len(in.ExtraPortMappings))
+	out.HostAliases = make([]HostAlias, len(in.HostAliases))
 	out.KubeadmConfigPatchesJSON6902 = make([]PatchJSON6902, len(in.KubeadmConfigPatchesJSON6902))
 
 	for i := range in.ExtraMounts {
@@ -65,11 +137,38 @@ func convertv1alpha4Node(in *v1alpha4.Node, out *Node) {
 		convertv1alpha4PortMapping(&in.ExtraPortMappings[i], &out.ExtraPortMappings[i])
 	}
 
+	for i := range in.HostAliases {
+		convertv1alpha4HostAlias(&in.HostAliases[i], &out.HostAliases[i])
+	}
+
+	for i := range in.RegistryMirrors {
+		convertv1alpha4RegistryMirror(&in.RegistryMirrors[i], &out.RegistryMirrors[i])
+	}
+
 	for i := range in.KubeadmConfigPatchesJSON6902 {
 		convertv1alpha4PatchJSON6902(&in.KubeadmConfigPatchesJSON6902[i], &out.KubeadmConfigPatchesJSON6902[i])
 	}
 }
 
+func convertv1alpha4IOLimits(in *v1alpha4.IOLimits, out *IOLimits) {
+	out.DeviceReadBPS = in.DeviceReadBPS
+	out.DeviceWriteBPS = in.DeviceWriteBPS
+	out.DeviceReadIOPS = in.DeviceReadIOPS
+	out.DeviceWriteIOPS = in.DeviceWriteIOPS
+	out.EgressRate = in.EgressRate
+}
+
+func convertv1alpha4HostAlias(in *v1alpha4.HostAlias, out *HostAlias) {
+	out.IP = in.IP
+	out.Hostnames = in.Hostnames
+}
+
+func convertv1alpha4RegistryMirror(in *v1alpha4.RegistryMirror, out *RegistryMirror) {
+	out.Registry = in.Registry
+	out.Endpoints = in.Endpoints
+	out.Auth = RegistryAuth(in.Auth)
+}
+
 func convertv1alpha4PatchJSON6902(in *v1alpha4.PatchJSON6902, out *PatchJSON6902) {
 	out.Group = in.Group
 	out.Version = in.Version
@@ -81,10 +180,17 @@ func convertv1alpha4Networking(in *v1alpha4.Networking, out *Networking) {
 	out.IPFamily = ClusterIPFamily(in.IPFamily)
 	out.APIServerPort = in.APIServerPort
 	out.APIServerAddress = in.APIServerAddress
+	out.APIServerSocket = in.APIServerSocket
 	out.PodSubnet = in.PodSubnet
 	out.KubeProxyMode = ProxyMode(in.KubeProxyMode)
 	out.ServiceSubnet = in.ServiceSubnet
+	out.NodeSubnet = in.NodeSubnet
+	out.NodeGateway = in.NodeGateway
+	out.NodeMTU = in.NodeMTU
+	out.ClusterDomain = in.ClusterDomain
 	out.DisableDefaultCNI = in.DisableDefaultCNI
+	out.CNI = CNI(in.CNI)
+	out.CNIVersion = in.CNIVersion
 	out.DNSSearch = in.DNSSearch
 }
 
@@ -102,3 +208,33 @@ func convertv1alpha4PortMapping(in *v1alpha4.PortMapping, out *PortMapping) {
 	out.ListenAddress = in.ListenAddress
 	out.Protocol = PortMappingProtocol(in.Protocol)
 }
+
+func convertv1alpha4StorageClass(in *v1alpha4.StorageClass, out *StorageClass) {
+	out.DisableDefault = in.DisableDefault
+	out.ProvisionerImage = in.ProvisionerImage
+	out.Name = in.Name
+}
+
+func convertv1alpha4LoadBalancer(in *v1alpha4.LoadBalancer, out *LoadBalancer) {
+	out.Timeouts = LoadBalancerTimeouts(in.Timeouts)
+	out.MaxConnections = in.MaxConnections
+	out.HealthCheck = LoadBalancerHealthCheck(in.HealthCheck)
+	out.SessionAffinity = LoadBalancerSessionAffinity(in.SessionAffinity)
+	out.Always = in.Always
+	out.Image = in.Image
+	out.Algorithm = LoadBalancerAlgorithm(in.Algorithm)
+	out.Stats = LoadBalancerStats(in.Stats)
+	if in.ExtraBackends == nil {
+		return
+	}
+	out.ExtraBackends = make([]LoadBalancerBackend, len(in.ExtraBackends))
+	for i, b := range in.ExtraBackends {
+		out.ExtraBackends[i] = LoadBalancerBackend{
+			Name:        b.Name,
+			Port:        b.Port,
+			BackendPort: b.BackendPort,
+			Role:        NodeRole(b.Role),
+			HostPort:    b.HostPort,
+		}
+	}
+}
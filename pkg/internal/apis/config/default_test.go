@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestSetDefaultsAuditMounts(t *testing.T) {
+	t.Parallel()
+	c := &Cluster{
+		Name:  "audit",
+		Audit: Audit{Enabled: true, HostPath: "/tmp/kind-audit"},
+		Nodes: []Node{
+			{Role: ControlPlaneRole},
+			{Role: WorkerRole},
+			{Role: ControlPlaneRole},
+			{
+				Role: ControlPlaneRole,
+				ExtraMounts: []Mount{
+					{ContainerPath: AuditLogDir, HostPath: "/srv/audit"},
+				},
+			},
+		},
+	}
+	// defaulting is idempotent
+	SetDefaultsCluster(c)
+	SetDefaultsCluster(c)
+	assert.DeepEqual(t, []Mount{
+		{ContainerPath: AuditLogDir, HostPath: "/tmp/kind-audit/audit-control-plane"},
+	}, c.Nodes[0].ExtraMounts)
+	assert.DeepEqual(t, []Mount(nil), c.Nodes[1].ExtraMounts)
+	assert.DeepEqual(t, []Mount{
+		{ContainerPath: AuditLogDir, HostPath: "/tmp/kind-audit/audit-control-plane2"},
+	}, c.Nodes[2].ExtraMounts)
+	assert.DeepEqual(t, []Mount{
+		{ContainerPath: AuditLogDir, HostPath: "/srv/audit"},
+	}, c.Nodes[3].ExtraMounts)
+}
@@ -18,6 +18,7 @@ package encoding
 
 import (
 	"bytes"
+	"io"
 	"os"
 
 	yaml "gopkg.in/yaml.v3"
@@ -80,6 +81,30 @@ func Parse(raw []byte) (*config.Cluster, error) {
 	return nil, errors.Errorf("unknown apiVersion: %s", tm.APIVersion)
 }
 
+// SplitDocuments splits raw (yaml) bytes into its non-empty documents,
+// e.g. a config file with multiple Cluster documents
+func SplitDocuments(raw []byte) ([][]byte, error) {
+	docs := [][]byte{}
+	d := yaml.NewDecoder(bytes.NewReader(raw))
+	for {
+		doc := yaml.Node{}
+		if err := d.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "could not split config documents")
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Tag == "!!null" {
+			continue
+		}
+		out, err := yaml.Marshal(&doc)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not split config documents")
+		}
+		docs = append(docs, out)
+	}
+	return docs, nil
+}
+
 // basically metav1.TypeMeta, but with yaml tags
 type typeMeta struct {
 	Kind       string `yaml:"kind,omitempty"`
@@ -123,3 +123,39 @@ func TestLoadCurrent(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitDocuments(t *testing.T) {
+	t.Parallel()
+	raw := []byte(`---
+kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+name: east
+---
+---
+kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+name: west
+nodes:
+- role: control-plane
+- role: worker
+`)
+	docs, err := SplitDocuments(raw)
+	if err != nil {
+		t.Fatalf("unexpected error splitting documents: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents but got %d", len(docs))
+	}
+	for i, name := range []string{"east", "west"} {
+		cfg, err := Parse(docs[i])
+		if err != nil {
+			t.Fatalf("unexpected error parsing document %d: %v", i, err)
+		}
+		if cfg.Name != name {
+			t.Errorf("expected document %d to be cluster %q but got %q", i, name, cfg.Name)
+		}
+	}
+	if _, err := SplitDocuments([]byte("kind: [")); err == nil {
+		t.Errorf("expected an error splitting invalid yaml")
+	}
+}
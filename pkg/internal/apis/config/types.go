@@ -39,6 +39,16 @@ type Cluster struct {
 	// Networking contains cluster wide network settings
 	Networking Networking
 
+	// StorageClass configures the default storage provisioner and StorageClass
+	StorageClass StorageClass
+
+	// LoadBalancer configures the external load balancer provisioned in front
+	// of the control plane nodes when there are multiple control planes
+	LoadBalancer LoadBalancer
+
+	// DNS configures the size of the cluster DNS (CoreDNS) deployment
+	DNS DNS
+
 	// FeatureGates contains a map of Kubernetes feature gates to whether they
 	// are enabled. The feature gates specified here are passed to all Kubernetes components as flags or in config.
 	//
@@ -60,6 +70,10 @@ type Cluster struct {
 	// as patchesJson6902 to `kustomize build`
 	KubeadmConfigPatchesJSON6902 []PatchJSON6902
 
+	// KubeadmConfigPatchDirectories are directories of kubeadm config patch
+	// files, applied after the inline cluster-level patches
+	KubeadmConfigPatchDirectories []string
+
 	// ContainerdConfigPatches are applied to every node's containerd config
 	// in the order listed.
 	// These should be toml stringsto be applied as merge patches
@@ -69,6 +83,67 @@ type Cluster struct {
 	// in the order listed.
 	// These should be YAML or JSON formatting RFC 6902 JSON patches
 	ContainerdConfigPatchesJSON6902 []string
+
+	// RegistryMirrors configures containerd on every node to pull images
+	// through mirrors
+	RegistryMirrors []RegistryMirror
+
+	// LocalRegistry configures a local image registry container for the cluster
+	LocalRegistry LocalRegistry
+
+	// CrashCapture configures the nodes to persist kernel OOM events,
+	// kubelet / containerd panics and core dumps under /var/log/kind/crash
+	CrashCapture bool
+
+	// SharedOCILayout is the path on the host to an OCI image layout directory
+	// that is mounted read-only into every node and imported into containerd
+	SharedOCILayout string
+
+	// ImageCache starts the shared pull-through registry caches and uses
+	// them as mirrors on every node
+	ImageCache bool
+
+	// ImageBundle is the path on the host to an image archive that is loaded
+	// into the host container runtime and every node
+	ImageBundle string
+
+	// Timeouts configures the maximum duration of each provisioning phase
+	Timeouts ClusterTimeouts
+
+	// DevicePlugin installs a Kubernetes device plugin at create time
+	DevicePlugin DevicePlugin
+
+	// Ingress installs an ingress controller at create time
+	Ingress Ingress
+
+	// Audit enables audit logging on the API server
+	Audit Audit
+
+	// OIDC configures OpenID Connect authentication on the API server
+	OIDC OIDC
+
+	// ProxyHelper runs a relay authenticating to the host's proxy for the nodes
+	ProxyHelper ProxyHelper
+
+	// DNSServer runs a DNS server with custom records for the nodes
+	DNSServer DNSServer
+
+	// ServiceLoadBalancer installs an implementation of Services of type
+	// LoadBalancer at create time
+	ServiceLoadBalancer ServiceLoadBalancer
+
+	// Labels are recorded on the node containers and the in-cluster kind
+	// ConfigMap, and may be used to select clusters.
+	// These are set from create options rather than the config file.
+	Labels map[string]string
+
+	// Annotations are recorded on the node containers and the in-cluster kind
+	// ConfigMap. These are set from create options rather than the config file.
+	Annotations map[string]string
+
+	// AllowMixedNodeImages allows nodes to run images with different digests.
+	// This is set from create options rather than the config file.
+	AllowMixedNodeImages bool
 }
 
 // Node contains settings for a node in the `kind` Cluster.
@@ -98,6 +173,48 @@ type Node struct {
 	// binded to a host Port
 	ExtraPortMappings []PortMapping
 
+	// HostAliases are extra entries written to the node's /etc/hosts
+	// These are also used by containerd when pulling images on the node
+	HostAliases []HostAlias
+
+	// IPv4Address and IPv6Address are static addresses for the node on the
+	// kind network, they must be within the network's subnets
+	IPv4Address string
+	IPv6Address string
+
+	// Networks are extra container networks to attach the node to
+	Networks []string
+
+	// Devices are host devices to expose in the node container, in the form
+	// `hostPath[:containerPath[:permissions]]`
+	Devices []string
+
+	// Sysctls are namespaced kernel parameters to set in the node container
+	Sysctls map[string]string
+
+	// Tmpfs are extra tmpfs mounts for the node container, in the form
+	// `containerPath[:options]`
+	Tmpfs []string
+
+	// UserNS is the user namespace mode for the node container
+	UserNS string
+
+	// KernelModules are host kernel modules to load from the node
+	KernelModules []string
+
+	// IOLimits throttle the node container's block device and network IO
+	IOLimits IOLimits
+
+	// Resources limit the CPU, memory and processes of the node container
+	Resources NodeResources
+
+	// Logging sets the log verbosity of the node's kubelet and containerd
+	Logging NodeLogging
+
+	// RegistryMirrors configures containerd on this node only, merged over
+	// the cluster-level RegistryMirrors by registry
+	RegistryMirrors []RegistryMirror
+
 	// KubeadmConfigPatches are applied to the generated kubeadm config as
 	// strategic merge patches to `kustomize build` internally
 	// https://github.com/kubernetes/community/blob/a9cf5c8f3380bb52ebe57b1e2dbdec136d8dd484/contributors/devel/sig-api-machinery/strategic-merge-patch.md
@@ -122,6 +239,143 @@ const (
 	WorkerRole NodeRole = "worker"
 )
 
+// StorageClass configures the default storage provisioner and StorageClass
+type StorageClass struct {
+	// If DisableDefault is true, kind will not install the default storage
+	// provisioner and StorageClass.
+	DisableDefault bool
+	// ProvisionerImage overrides the image of the default storage provisioner
+	ProvisionerImage string
+	// Name is the name of the default StorageClass
+	Name string
+}
+
+// DNS configures the size of the cluster DNS (CoreDNS) deployment
+type DNS struct {
+	// NodesPerReplica scales CoreDNS to one replica per NodesPerReplica nodes,
+	// like the linear mode of cluster-proportional-autoscaler
+	// If unset the kubeadm default of 2 replicas is kept
+	NodesPerReplica int32
+	// MinReplicas is the minimum number of replicas when scaling by NodesPerReplica
+	MinReplicas int32
+	// Resources overrides the resources of the CoreDNS containers
+	Resources DNSResources
+}
+
+// DNSResources are resource quantities for the CoreDNS containers,
+// e.g. "100m" or "70Mi". Unset quantities keep the kubeadm defaults
+type DNSResources struct {
+	CPURequest    string
+	MemoryRequest string
+	MemoryLimit   string
+}
+
+// LoadBalancer configures the external control plane load balancer
+type LoadBalancer struct {
+	// Always creates the load balancer even with a single control-plane node
+	Always bool
+
+	// Image is the haproxy image to run the load balancer with,
+	// empty selects kind's default image
+	Image string
+
+	// ExtraBackends are additional ports forwarded by the load balancer
+	// to the cluster's nodes, in addition to the API server
+	ExtraBackends []LoadBalancerBackend
+
+	// Timeouts configures the load balancer's connection timeouts
+	Timeouts LoadBalancerTimeouts
+
+	// MaxConnections is the maximum number of concurrent connections
+	MaxConnections int32
+
+	// HealthCheck configures the active health checks of the backends
+	HealthCheck LoadBalancerHealthCheck
+
+	// SessionAffinity may be "None" or "ClientIP", in which case connections
+	// from the same client address are always forwarded to the same backend
+	SessionAffinity LoadBalancerSessionAffinity
+
+	// Algorithm is how connections are balanced between backends
+	Algorithm LoadBalancerAlgorithm
+
+	// Stats configures the load balancer's stats and health endpoint
+	Stats LoadBalancerStats
+}
+
+// LoadBalancerStats configures the load balancer's stats and health endpoint
+type LoadBalancerStats struct {
+	// Port is the load balancer port serving the endpoint, zero disables it
+	Port int32
+	// HostPort optionally publishes Port on the host
+	HostPort int32
+}
+
+// LoadBalancerTimeouts configures the load balancer's connection timeouts
+// Timeouts are durations, e.g. "5s" or "1m30s"
+type LoadBalancerTimeouts struct {
+	// Connect is the maximum time to wait for a connection to a backend
+	Connect string
+	// Client is the maximum inactivity time on the client side
+	Client string
+	// Server is the maximum inactivity time on the backend side
+	Server string
+}
+
+// LoadBalancerHealthCheck configures the active health checks of the backends
+type LoadBalancerHealthCheck struct {
+	// Interval is the time between two health checks, e.g. "2s"
+	Interval string
+	// Rise is the number of consecutive successful checks before
+	// a backend is considered up
+	Rise int32
+	// Fall is the number of consecutive failed checks before
+	// a backend is considered down
+	Fall int32
+}
+
+// LoadBalancerSessionAffinity is the session affinity of the load balancer
+type LoadBalancerSessionAffinity string
+
+const (
+	// NoSessionAffinity balances each connection independently
+	NoSessionAffinity LoadBalancerSessionAffinity = "None"
+	// ClientIPSessionAffinity forwards connections from the same client
+	// address to the same backend
+	ClientIPSessionAffinity LoadBalancerSessionAffinity = "ClientIP"
+)
+
+// LoadBalancerAlgorithm is the balancing algorithm of the load balancer
+type LoadBalancerAlgorithm string
+
+const (
+	// RoundRobinAlgorithm uses each backend in turn
+	RoundRobinAlgorithm LoadBalancerAlgorithm = "roundrobin"
+	// LeastConnAlgorithm uses the backend with the fewest connections
+	LeastConnAlgorithm LoadBalancerAlgorithm = "leastconn"
+	// RandomAlgorithm uses a random backend
+	RandomAlgorithm LoadBalancerAlgorithm = "random"
+)
+
+// LoadBalancerBackend is a port forwarded by the load balancer to a set of nodes
+type LoadBalancerBackend struct {
+	// Name identifies the backend in the load balancer config
+	Name string
+	// Port is the port the load balancer listens on
+	Port int32
+	// BackendPort is the port on the nodes to forward to
+	//
+	// Defaults to Port
+	BackendPort int32
+	// Role selects the nodes to forward to
+	//
+	// Defaults to control-plane
+	Role NodeRole
+	// HostPort additionally publishes Port on the host, listening on
+	// networking.apiServerAddress, if set
+	HostPort int32
+}
+
 // Networking contains cluster wide network settings
 type Networking struct {
 	// IPFamily is the network cluster model, currently it can be ipv4 or ipv6
@@ -139,15 +393,34 @@ type Networking struct {
 	//
 	// Defaults to 127.0.0.1
 	APIServerAddress string
+	// APIServerSocket is a host path at which the Kubernetes API Server will
+	// additionally be exposed as a unix socket, via a relay container.
+	//
+	// This is an experimental feature, it is only supported with linux hosts.
+	APIServerSocket string
 	// PodSubnet is the CIDR used for pod IPs
 	// kind will select a default if unspecified
 	PodSubnet string
 	// ServiceSubnet is the CIDR used for services VIPs
 	// kind will select a default if unspecified
 	ServiceSubnet string
+	// NodeSubnet is the CIDR(s) of the container network the nodes are
+	// attached to, at most one per IP family, used when kind creates it
+	NodeSubnet string
+	// NodeGateway is the gateway address(es) of the node network
+	NodeGateway string
+	// NodeMTU is the MTU of the node network, 0 uses the provider default
+	NodeMTU int32
+	// ClusterDomain is the DNS domain used by services in the cluster
+	ClusterDomain string
 	// If DisableDefaultCNI is true, kind will not install the default CNI setup.
 	// Instead the user should install their own CNI after creating the cluster.
 	DisableDefaultCNI bool
+	// CNI is the CNI kind installs, none when DisableDefaultCNI is set
+	CNI CNI
+	// CNIVersion pins the version of a third party CNI, empty for the
+	// version tested with kind
+	CNIVersion string
 	// KubeProxyMode defines if kube-proxy should operate in iptables, ipvs or nftables mode
 	KubeProxyMode ProxyMode
 	// DNSSearch defines the DNS search domain to use for nodes. If not set, this will be inherited from the host.
@@ -180,6 +453,215 @@ const (
 	NoneProxyMode ProxyMode = "none"
 )
 
+// CNI is a CNI kind knows how to install
+type CNI string
+
+const (
+	// KindnetCNI is kind's default CNI, shipped in the node image
+	KindnetCNI CNI = "kindnet"
+	// CalicoCNI is Calico
+	CalicoCNI CNI = "calico"
+	// CiliumCNI is Cilium
+	CiliumCNI CNI = "cilium"
+	// FlannelCNI is Flannel
+	FlannelCNI CNI = "flannel"
+	// NoCNI installs no CNI, the user installs their own
+	NoCNI CNI = "none"
+)
+
+// ClusterTimeouts configures the maximum duration of each provisioning phase
+// Timeouts are durations, a zero duration waits without a limit
+type ClusterTimeouts struct {
+	// ImagePull is the maximum time to ensure each node image is present
+	ImagePull string
+	// NodeProvision is the maximum time to wait for each node container to boot
+	NodeProvision string
+	// KubeadmInit is the maximum time for `kubeadm init`
+	KubeadmInit string
+	// Join is the maximum time for `kubeadm join` on each node and,
+	// when it is not zero, for that node to then become Ready
+	Join string
+	// CNIReady is the time to wait for the default CNI to be rolled out,
+	// zero does not wait
+	CNIReady string
+	// DevicePluginReady is the time to wait for device plugin devices to be
+	// allocatable, zero does not wait
+	DevicePluginReady string
+	// IngressReady is the time to wait for the ingress controller to be
+	// rolled out, zero does not wait
+	IngressReady string
+	// ServiceLoadBalancerReady is the time to wait for the service load
+	// balancer to be rolled out
+	ServiceLoadBalancerReady string
+	// ControlPlaneEndpointReady is the time to wait for the exported API
+	// server endpoint to serve the cluster CA and report ready
+	ControlPlaneEndpointReady string
+}
+
+// DevicePlugin configures the device plugin installed at create time
+type DevicePlugin struct {
+	// Type is the device plugin to install, empty for none
+	Type DevicePluginType
+	// Image overrides the pinned device plugin image
+	Image string
+}
+
+// DevicePluginType is a device plugin kind knows how to install
+type DevicePluginType string
+
+const (
+	// NVIDIADevicePlugin is the NVIDIA device plugin, exposing nvidia.com/gpu
+	NVIDIADevicePlugin DevicePluginType = "nvidia"
+)
+
+// Ingress configures the ingress controller installed at create time
+type Ingress struct {
+	// Controller is the ingress controller to install, empty for none
+	Controller IngressController
+}
+
+// Audit configures API server audit logging
+type Audit struct {
+	// Enabled turns on audit logging
+	Enabled bool
+	// Policy replaces the default audit policy
+	Policy string
+	// HostPath is a host directory to collect the audit logs in
+	HostPath string
+}
+
+const (
+	// AuditPolicyPath is where the audit policy is written on control-plane nodes
+	AuditPolicyPath = "/etc/kubernetes/audit/policy.yaml"
+	// AuditLogDir is where kube-apiserver writes the audit log on control-plane nodes
+	AuditLogDir = "/var/log/kubernetes/audit"
+)
+
+// OIDC configures OpenID Connect authentication on the API server
+type OIDC struct {
+	// IssuerURL is the https URL of the provider
+	IssuerURL string
+	// ClientID is the client ID all tokens must be issued for
+	ClientID string
+	// UsernameClaim is the token claim to use as the user name
+	UsernameClaim string
+	// GroupsClaim is the token claim to use as the user's groups
+	GroupsClaim string
+	// CertificateAuthority is the PEM encoded CA of the provider
+	CertificateAuthority string
+	// Provider is the OIDC provider kind runs for the cluster
+	Provider OIDCProvider
+	// Image overrides the image of the OIDC provider
+	Image string
+}
+
+// OIDCProvider is an OIDC provider kind knows how to run
+type OIDCProvider string
+
+const (
+	// DexOIDCProvider runs dex with a static user
+	DexOIDCProvider OIDCProvider = "dex"
+)
+
+// OIDCCertificateAuthorityPath is where the OIDC provider's CA is written
+// on control-plane nodes
+const OIDCCertificateAuthorityPath = "/etc/kubernetes/oidc/ca.crt"
+
+// IngressController is an ingress controller kind knows how to install
+type IngressController string
+
+const (
+	// NginxIngress is the Ingress NGINX controller
+	NginxIngress IngressController = "nginx"
+	// ContourIngress is the Contour ingress controller
+	ContourIngress IngressController = "contour"
+	// TraefikIngress is the Traefik ingress controller
+	TraefikIngress IngressController = "traefik"
+)
+
+// ProxyHelper configures the authenticating proxy relay
+type ProxyHelper struct {
+	// Auth is the authentication scheme of the proxy, empty for no relay
+	Auth ProxyAuth
+	// Image overrides the relay image
+	Image string
+}
+
+// ProxyAuth is a proxy authentication scheme
+type ProxyAuth string
+
+const (
+	// NTLMProxyAuth is NTLM authentication
+	NTLMProxyAuth ProxyAuth = "ntlm"
+	// NegotiateProxyAuth is SPNEGO authentication, using Kerberos or NTLM
+	NegotiateProxyAuth ProxyAuth = "negotiate"
+)
+
+// DNSServer configures the DNS server run for the cluster
+type DNSServer struct {
+	// Enabled runs the DNS server
+	Enabled bool
+	// Records are the address records the server answers
+	Records []DNSRecord
+	// Image overrides the DNS server image
+	Image string
+	// Address is the IP of the DNS server on the node network, the nodes use
+	// it as their resolver. This is set when the server is created rather
+	// than from the config file.
+	Address string
+}
+
+// DNSRecord is an address record served by the DNS server
+type DNSRecord struct {
+	// Name is the domain name of the record, a leading "*." matches the
+	// domain and all of its subdomains
+	Name string
+	// IP is the address the name resolves to
+	IP string
+}
+
+// ServiceLoadBalancer configures the implementation of Services of type
+// LoadBalancer installed at create time
+type ServiceLoadBalancer struct {
+	// Type is the implementation to install, empty for none
+	Type ServiceLoadBalancerType
+	// Addresses are the address ranges allocated to Services, as CIDRs or
+	// first-last ranges, empty for a range at the end of the node subnets
+	Addresses []string
+}
+
+// ServiceLoadBalancerType is a service load balancer kind knows how to install
+type ServiceLoadBalancerType string
+
+const (
+	// MetalLBServiceLoadBalancer is MetalLB in layer 2 mode
+	MetalLBServiceLoadBalancer ServiceLoadBalancerType = "metallb"
+)
+
+// LocalRegistry configures a local image registry
+type LocalRegistry struct {
+	// Enabled creates the registry container, or reuses it if it exists
+	Enabled bool
+	// Port is the host port the registry is published on
+	Port int32
+}
+
+// RegistryMirror configures the mirrors for an image registry
+type RegistryMirror struct {
+	// Registry is the mirrored registry host
+	Registry string
+	// Endpoints are the mirror URLs, tried in order before the registry itself
+	Endpoints []string
+	// Auth are the credentials sent to the registry and its mirrors
+	Auth RegistryAuth
+}
+
+// RegistryAuth configures basic authentication to an image registry
+type RegistryAuth struct {
+	Username string
+	Password string
+}
+
 // PatchJSON6902 represents an inline kustomize json 6902 patch
 // https://tools.ietf.org/html/rfc6902
 type PatchJSON6902 struct {
@@ -246,6 +728,44 @@ type PortMapping struct {
 	Protocol PortMappingProtocol
 }
 
+// HostAlias specifies an extra /etc/hosts entry for a node.
+type HostAlias struct {
+	// IP address of the host entry.
+	IP string
+	// Hostnames for the above IP address.
+	Hostnames []string
+}
+
+// IOLimits throttle the IO of a node container.
+type IOLimits struct {
+	// DeviceReadBPS, DeviceWriteBPS, DeviceReadIOPS and DeviceWriteIOPS
+	// limit a host block device, in the form `devicePath:rate`
+	DeviceReadBPS   []string
+	DeviceWriteBPS  []string
+	DeviceReadIOPS  []string
+	DeviceWriteIOPS []string
+	// EgressRate shapes the node's egress on the kind network, in tc rate units
+	EgressRate string
+}
+
+// NodeResources limit the resources of a node container.
+type NodeResources struct {
+	// CPUs is the number of host CPUs the node may use
+	CPUs string
+	// Memory is the memory limit in bytes with an optional k, m or g suffix
+	Memory string
+	// PIDs is the maximum number of processes in the node
+	PIDs int64
+}
+
+// NodeLogging configures the log levels of the services on a node
+type NodeLogging struct {
+	// KubeletVerbosity is the kubelet `--v` log verbosity
+	KubeletVerbosity int32
+	// ContainerdLevel is the containerd `--log-level`
+	ContainerdLevel string
+}
+
 // MountPropagation represents an "enum" for mount propagation options,
 // see also Mount.
 type MountPropagation string
@@ -17,12 +17,20 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
+	"encoding/pem"
 	"fmt"
 	"net"
+	"net/url"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	"sigs.k8s.io/kind/pkg/cluster/constants"
 	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/labels"
 	"sigs.k8s.io/kind/pkg/internal/sets"
 )
 
@@ -32,6 +40,10 @@ import (
 // https://godoc.org/github.com/docker/docker/daemon/names#pkg-constants
 var validNameRE = regexp.MustCompile(`^[a-z0-9.-]+$`)
 
+// a lowercase RFC 1123 DNS subdomain, as used for the cluster DNS domain
+// and object names
+var validDomainRE = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
 // Validate returns a ConfigErrors with an entry for each problem
 // with the config, or nil if there are none
 func (c *Cluster) Validate() error {
@@ -67,12 +79,67 @@ func (c *Cluster) Validate() error {
 		errs = append(errs, errors.Errorf("invalid service subnet %v", err))
 	}
 
+	// clusterDomain should be a valid DNS name
+	if !validDomainRE.MatchString(c.Networking.ClusterDomain) {
+		errs = append(errs, errors.Errorf("invalid clusterDomain: %q is not a valid DNS name", c.Networking.ClusterDomain))
+	}
+
+	// the API server socket must name a file, not a directory
+	if c.Networking.APIServerSocket != "" && strings.HasSuffix(c.Networking.APIServerSocket, "/") {
+		errs = append(errs, errors.Errorf("invalid apiServerSocket: %q must be a file path", c.Networking.APIServerSocket))
+	}
+
+	// the default StorageClass name should be a valid object name
+	if !validDomainRE.MatchString(c.StorageClass.Name) {
+		errs = append(errs, errors.Errorf("invalid storageClass name: %q is not a valid DNS subdomain", c.StorageClass.Name))
+	}
+	if c.StorageClass.DisableDefault && c.StorageClass.ProvisionerImage != "" {
+		errs = append(errs, errors.New("storageClass provisionerImage conflicts with disableDefault"))
+	}
+
+	// extra load balancer backends must not conflict with each other or the API server
+	errs = append(errs, validateLoadBalancer(c)...)
+	errs = append(errs, validateDNS(&c.DNS)...)
+	errs = append(errs, validateNodeAddresses(c.Nodes, &c.Networking)...)
+	errs = append(errs, validateNodeNetwork(&c.Networking)...)
+	errs = append(errs, validateRegistryMirrors(c.RegistryMirrors)...)
+	errs = append(errs, validateTimeouts(&c.Timeouts)...)
+	errs = append(errs, validateDevicePlugin(&c.DevicePlugin)...)
+	errs = append(errs, validateIngress(&c.Ingress)...)
+	errs = append(errs, validateAudit(&c.Audit)...)
+	errs = append(errs, validateOIDC(&c.OIDC)...)
+	errs = append(errs, validateProxyHelper(&c.ProxyHelper)...)
+	errs = append(errs, validateDNSServer(&c.DNSServer)...)
+	errs = append(errs, validateServiceLoadBalancer(&c.ServiceLoadBalancer)...)
+
+	// the local registry is published on a host port
+	if c.LocalRegistry.Port < 1 || c.LocalRegistry.Port > 65535 {
+		errs = append(errs, errors.Errorf("invalid localRegistry port: %d", c.LocalRegistry.Port))
+	}
+
 	// KubeProxyMode should be iptables or ipvs
 	if c.Networking.KubeProxyMode != IPTablesProxyMode && c.Networking.KubeProxyMode != IPVSProxyMode &&
 		c.Networking.KubeProxyMode != NoneProxyMode && c.Networking.KubeProxyMode != NFTablesProxyMode {
 		errs = append(errs, errors.Errorf("invalid kubeProxyMode: %s", c.Networking.KubeProxyMode))
 	}
 
+	errs = append(errs, validateCNI(&c.Networking)...)
+
+	// labels and annotations must be valid Kubernetes metadata
+	for key, value := range c.Labels {
+		if err := labels.ValidateKey(key); err != nil {
+			errs = append(errs, errors.Wrap(err, "invalid label"))
+		}
+		if err := labels.ValidateValue(value); err != nil {
+			errs = append(errs, errors.Wrapf(err, "invalid label %q", key))
+		}
+	}
+	for key := range c.Annotations {
+		if err := labels.ValidateKey(key); err != nil {
+			errs = append(errs, errors.Wrap(err, "invalid annotation"))
+		}
+	}
+
 	// validate nodes
 	numByRole := make(map[NodeRole]int32)
 	// All nodes in the config should be valid
@@ -134,6 +201,72 @@ func (n *Node) Validate() error {
 		errs = append(errs, errors.Wrapf(err, "invalid portMapping"))
 	}
 
+	// validate extra /etc/hosts entries
+	for _, alias := range n.HostAliases {
+		if net.ParseIP(alias.IP) == nil {
+			errs = append(errs, errors.Errorf("invalid hostAlias ip: %q", alias.IP))
+		}
+		if len(alias.Hostnames) == 0 {
+			errs = append(errs, errors.Errorf("hostAlias for %q has no hostnames", alias.IP))
+		}
+		for _, hostname := range alias.Hostnames {
+			if !validDomainRE.MatchString(hostname) {
+				errs = append(errs, errors.Errorf("invalid hostAlias hostname: %q", hostname))
+			}
+		}
+	}
+
+	// static addresses must be of the matching IP family
+	if n.IPv4Address != "" {
+		if ip := net.ParseIP(n.IPv4Address); ip == nil || ip.To4() == nil {
+			errs = append(errs, errors.Errorf("invalid ipv4Address: %q", n.IPv4Address))
+		}
+	}
+	if n.IPv6Address != "" {
+		if ip := net.ParseIP(n.IPv6Address); ip == nil || ip.To4() != nil {
+			errs = append(errs, errors.Errorf("invalid ipv6Address: %q", n.IPv6Address))
+		}
+	}
+
+	// extra networks must be valid and distinct network names
+	seenNetworks := map[string]bool{}
+	for _, network := range n.Networks {
+		if !validNetworkNameRE.MatchString(network) {
+			errs = append(errs, errors.Errorf("invalid network: %q is not a valid network name", network))
+		}
+		if seenNetworks[network] {
+			errs = append(errs, errors.Errorf("duplicate network: %q", network))
+		}
+		seenNetworks[network] = true
+	}
+
+	// validate extra container runtime options
+	for _, device := range n.Devices {
+		if err := validateDevice(device); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for key := range n.Sysctls {
+		if !validSysctlRE.MatchString(key) {
+			errs = append(errs, errors.Errorf("invalid sysctl: %q", key))
+		}
+	}
+	for _, tmpfs := range n.Tmpfs {
+		if path := strings.SplitN(tmpfs, ":", 2)[0]; !strings.HasPrefix(path, "/") {
+			errs = append(errs, errors.Errorf("invalid tmpfs: %q, the path must be absolute", tmpfs))
+		}
+	}
+	for _, module := range n.KernelModules {
+		if !validKernelModuleRE.MatchString(module) {
+			errs = append(errs, errors.Errorf("invalid kernelModules module: %q", module))
+		}
+	}
+
+	errs = append(errs, validateRegistryMirrors(n.RegistryMirrors)...)
+	errs = append(errs, validateIOLimits(&n.IOLimits)...)
+	errs = append(errs, validateNodeResources(&n.Resources)...)
+	errs = append(errs, validateNodeLogging(&n.Logging)...)
+
 	if len(errs) > 0 {
 		return errors.NewAggregate(errs)
 	}
@@ -141,6 +274,599 @@ func (n *Node) Validate() error {
 	return nil
 }
 
+// validateIOLimits checks the device limits are `devicePath:rate` with a
+// byte size or count rate, and the egress rate is a tc rate
+func validateIOLimits(l *IOLimits) []error {
+	errs := []error{}
+	check := func(field string, limits []string, rateRE *regexp.Regexp) {
+		for _, limit := range limits {
+			parts := strings.SplitN(limit, ":", 2)
+			if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") || !rateRE.MatchString(parts[1]) {
+				errs = append(errs, errors.Errorf("invalid ioLimits %s: %q must be devicePath:rate", field, limit))
+			}
+		}
+	}
+	check("deviceReadBPS", l.DeviceReadBPS, validByteRateRE)
+	check("deviceWriteBPS", l.DeviceWriteBPS, validByteRateRE)
+	check("deviceReadIOPS", l.DeviceReadIOPS, validCountRateRE)
+	check("deviceWriteIOPS", l.DeviceWriteIOPS, validCountRateRE)
+	if l.EgressRate != "" && !validTCRateRE.MatchString(l.EgressRate) {
+		errs = append(errs, errors.Errorf("invalid ioLimits egressRate: %q, expected a rate such as 100mbit", l.EgressRate))
+	}
+	return errs
+}
+
+// validateNodeResources checks the node resources are positive quantities
+func validateNodeResources(r *NodeResources) []error {
+	errs := []error{}
+	if r.CPUs != "" {
+		if cpus, err := strconv.ParseFloat(r.CPUs, 64); err != nil || cpus <= 0 {
+			errs = append(errs, errors.Errorf("invalid resources cpus: %q, expected a positive number of CPUs such as 1.5", r.CPUs))
+		}
+	}
+	if r.Memory != "" {
+		if _, err := ParseMemory(r.Memory); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if r.PIDs < 0 {
+		errs = append(errs, errors.Errorf("invalid resources pids: %d, must be positive", r.PIDs))
+	}
+	return errs
+}
+
+// validContainerdLogLevels are the containerd --log-level values
+var validContainerdLogLevels = map[string]bool{
+	"trace": true, "debug": true, "info": true, "warn": true,
+	"error": true, "fatal": true, "panic": true,
+}
+
+// validateNodeLogging checks the node log levels are supported
+func validateNodeLogging(l *NodeLogging) []error {
+	errs := []error{}
+	if l.KubeletVerbosity < 0 || l.KubeletVerbosity > 10 {
+		errs = append(errs, errors.Errorf("invalid logging kubeletVerbosity: %d, must be between 0 and 10", l.KubeletVerbosity))
+	}
+	if l.ContainerdLevel != "" && !validContainerdLogLevels[l.ContainerdLevel] {
+		errs = append(errs, errors.Errorf("invalid logging containerdLevel: %q, expected one of trace, debug, info, warn, error, fatal or panic", l.ContainerdLevel))
+	}
+	return errs
+}
+
+// validMemoryRE matches memory quantities as container runtimes accept them,
+// e.g. 512m or 2gb
+var validMemoryRE = regexp.MustCompile(`^([1-9][0-9]*)([kmgKMG]?)[bB]?$`)
+
+// ParseMemory parses a node resources memory quantity into bytes
+func ParseMemory(memory string) (int64, error) {
+	match := validMemoryRE.FindStringSubmatch(memory)
+	if match == nil {
+		return 0, errors.Errorf("invalid resources memory: %q, expected bytes with an optional k, m or g suffix such as 2g", memory)
+	}
+	n, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid resources memory: %q", memory)
+	}
+	shift := map[string]uint{"": 0, "k": 10, "m": 20, "g": 30}[strings.ToLower(match[2])]
+	if n > (1<<63-1)>>shift {
+		return 0, errors.Errorf("invalid resources memory: %q is too large", memory)
+	}
+	return n << shift, nil
+}
+
+// validateRegistryMirrors checks each mirrored registry is a unique
+// host[:port] with at least one http(s) mirror endpoint, or credentials
+func validateRegistryMirrors(mirrors []RegistryMirror) []error {
+	errs := []error{}
+	seen := sets.NewString()
+	for _, m := range mirrors {
+		if seen.Has(m.Registry) {
+			errs = append(errs, errors.Errorf("duplicate registryMirrors registry: %q", m.Registry))
+		}
+		seen.Insert(m.Registry)
+		if m.Registry != "_default" && !validRegistryHost(m.Registry) {
+			errs = append(errs, errors.Errorf("invalid registryMirrors registry: %q must be a host[:port]", m.Registry))
+		}
+		if len(m.Endpoints) == 0 && m.Auth == (RegistryAuth{}) {
+			errs = append(errs, errors.Errorf("registryMirrors registry %q has no endpoints", m.Registry))
+		}
+		if m.Auth != (RegistryAuth{}) && m.Auth.Username == "" {
+			errs = append(errs, errors.Errorf("registryMirrors registry %q auth has no username", m.Registry))
+		}
+		for _, endpoint := range m.Endpoints {
+			u, err := url.Parse(endpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, errors.Errorf("invalid registryMirrors endpoint: %q must be an http(s) URL", endpoint))
+			}
+		}
+	}
+	return errs
+}
+
+// validRegistryHost returns true if host is a valid host[:port]
+func validRegistryHost(host string) bool {
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return false
+		}
+		host = h
+	}
+	return validDomainRE.MatchString(host)
+}
+
+// validSysctlRE matches kernel parameter names, e.g. net.ipv4.ip_forward
+var validSysctlRE = regexp.MustCompile(`^[a-zA-Z0-9_-]+([./][a-zA-Z0-9_-]+)*$`)
+
+// validKernelModuleRE matches kernel module names, e.g. nf_conntrack or sctp
+var validKernelModuleRE = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// validNetworkNameRE matches container network names, as docker and podman
+// allow them
+var validNetworkNameRE = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// validByteRateRE matches byte rates as container runtimes accept them, e.g. 50mb
+var validByteRateRE = regexp.MustCompile(`^[1-9][0-9]*([kmgKMG][bB]?|[bB])?$`)
+
+// validCountRateRE matches operation rates, e.g. 1000
+var validCountRateRE = regexp.MustCompile(`^[1-9][0-9]*$`)
+
+// validTCRateRE matches tc rates, e.g. 100mbit or 12.5mbps
+var validTCRateRE = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?([kmgt]?bit|[kmgt]?bps)$`)
+
+// validDevicePermissionsRE matches cgroup device permissions
+var validDevicePermissionsRE = regexp.MustCompile(`^[rwm]{1,3}$`)
+
+// validateDevice validates a `hostPath[:containerPath[:permissions]]` device
+func validateDevice(device string) error {
+	parts := strings.Split(device, ":")
+	valid := len(parts) <= 3 && strings.HasPrefix(parts[0], "/")
+	switch len(parts) {
+	case 2:
+		valid = valid && (strings.HasPrefix(parts[1], "/") || validDevicePermissionsRE.MatchString(parts[1]))
+	case 3:
+		valid = valid && strings.HasPrefix(parts[1], "/") && validDevicePermissionsRE.MatchString(parts[2])
+	}
+	if !valid {
+		return errors.Errorf("invalid device: %q, expected hostPath[:containerPath[:permissions]]", device)
+	}
+	return nil
+}
+
+// the haproxy frontend and backend names used for the API server
+var reservedLoadBalancerNames = sets.NewString("control-plane", "kube-apiservers")
+
+func validateLoadBalancer(c *Cluster) []error {
+	errs := validateLoadBalancerTuning(&c.LoadBalancer)
+	errs = append(errs, validateLoadBalancerStats(c)...)
+	if len(c.LoadBalancer.ExtraBackends) == 0 {
+		return errs
+	}
+	if !ClusterHasImplicitLoadBalancer(c) {
+		errs = append(errs, errors.New("loadBalancer.extraBackends requires multiple control-plane nodes or loadBalancer.always"))
+	}
+	names := sets.NewString()
+	ports := map[int32]bool{
+		constants.APIServerInternalPort: true,
+	}
+	if c.LoadBalancer.Stats.Port != 0 {
+		ports[c.LoadBalancer.Stats.Port] = true
+	}
+	for _, b := range c.LoadBalancer.ExtraBackends {
+		if !validDomainRE.MatchString(b.Name) || reservedLoadBalancerNames.Has(b.Name) {
+			errs = append(errs, errors.Errorf("invalid loadBalancer backend name: %q", b.Name))
+		} else if names.Has(b.Name) {
+			errs = append(errs, errors.Errorf("duplicate loadBalancer backend name: %q", b.Name))
+		}
+		names.Insert(b.Name)
+		if b.Port < 1 || b.Port > 65535 {
+			errs = append(errs, errors.Errorf("invalid port for loadBalancer backend %q: %d", b.Name, b.Port))
+		} else if ports[b.Port] {
+			errs = append(errs, errors.Errorf("port %d for loadBalancer backend %q is already in use", b.Port, b.Name))
+		}
+		ports[b.Port] = true
+		if b.BackendPort < 1 || b.BackendPort > 65535 {
+			errs = append(errs, errors.Errorf("invalid backendPort for loadBalancer backend %q: %d", b.Name, b.BackendPort))
+		}
+		if b.Role != ControlPlaneRole && b.Role != WorkerRole {
+			errs = append(errs, errors.Errorf("invalid role for loadBalancer backend %q: %q", b.Name, b.Role))
+		}
+		if err := validatePort(b.HostPort); err != nil {
+			errs = append(errs, errors.Wrapf(err, "invalid hostPort for loadBalancer backend %q", b.Name))
+		}
+	}
+	return errs
+}
+
+func validateLoadBalancerStats(c *Cluster) []error {
+	stats := c.LoadBalancer.Stats
+	if stats.Port == 0 {
+		if stats.HostPort != 0 {
+			return []error{errors.New("loadBalancer.stats.hostPort requires loadBalancer.stats.port")}
+		}
+		return nil
+	}
+	errs := []error{}
+	if !ClusterHasImplicitLoadBalancer(c) {
+		errs = append(errs, errors.New("loadBalancer.stats requires multiple control-plane nodes or loadBalancer.always"))
+	}
+	if stats.Port < 1 || stats.Port > 65535 {
+		errs = append(errs, errors.Errorf("invalid loadBalancer stats port: %d", stats.Port))
+	} else if stats.Port == constants.APIServerInternalPort {
+		errs = append(errs, errors.Errorf("loadBalancer stats port %d is already in use", stats.Port))
+	}
+	if err := validatePort(stats.HostPort); err != nil {
+		errs = append(errs, errors.Wrap(err, "invalid loadBalancer stats hostPort"))
+	}
+	return errs
+}
+
+// resource quantities such as 100m, 0.5 or 70Mi
+var validQuantityRE = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(m|k|M|G|T|Ki|Mi|Gi|Ti)?$`)
+
+// validateNodeAddresses checks that no two nodes have the same static address
+func validateNodeAddresses(nodes []Node, networking *Networking) []error {
+	errs := []error{}
+	// with a requested node network, addresses can be checked before the
+	// network exists, otherwise the provider checks the existing network
+	nodeSubnets := []*net.IPNet{}
+	for _, s := range strings.Split(networking.NodeSubnet, ",") {
+		if _, cidr, err := net.ParseCIDR(s); err == nil {
+			nodeSubnets = append(nodeSubnets, cidr)
+		}
+	}
+	gateways := map[string]bool{}
+	for _, g := range strings.Split(networking.NodeGateway, ",") {
+		if ip := net.ParseIP(g); ip != nil {
+			gateways[ip.String()] = true
+		}
+	}
+	seen := map[string]bool{}
+	for _, n := range nodes {
+		for _, address := range []string{n.IPv4Address, n.IPv6Address} {
+			ip := net.ParseIP(address)
+			if ip == nil {
+				continue
+			}
+			if seen[ip.String()] {
+				errs = append(errs, errors.Errorf("duplicate node address: %s", address))
+			}
+			seen[ip.String()] = true
+			if gateways[ip.String()] {
+				errs = append(errs, errors.Errorf("invalid node address %s: it is the nodeGateway", address))
+			}
+			// a single stack nodeSubnet does not constrain the other family
+			if subnets := sameFamilySubnets(nodeSubnets, ip); len(subnets) > 0 && !subnetsContain(subnets, ip) {
+				errs = append(errs, errors.Errorf("invalid node address %s: not within nodeSubnet %q", address, networking.NodeSubnet))
+			}
+		}
+	}
+	return errs
+}
+
+// sameFamilySubnets returns the subnets of the same IP family as ip
+func sameFamilySubnets(subnets []*net.IPNet, ip net.IP) []*net.IPNet {
+	isIPv4 := ip.To4() != nil
+	same := []*net.IPNet{}
+	for _, cidr := range subnets {
+		if (cidr.IP.To4() != nil) == isIPv4 {
+			same = append(same, cidr)
+		}
+	}
+	return same
+}
+
+func subnetsContain(subnets []*net.IPNet, ip net.IP) bool {
+	for _, cidr := range subnets {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNodeNetwork checks the subnets, gateways and MTU requested for the
+// node network, the node network is dual stack capable regardless of ipFamily
+func validateNodeNetwork(n *Networking) []error {
+	errs := []error{}
+	if n.NodeMTU != 0 && (n.NodeMTU < 1280 || n.NodeMTU > 65535) {
+		errs = append(errs, errors.Errorf("invalid nodeMTU: %d must be between 1280 and 65535", n.NodeMTU))
+	}
+	if n.NodeSubnet == "" {
+		if n.NodeGateway != "" {
+			errs = append(errs, errors.New("invalid nodeGateway: requires nodeSubnet"))
+		}
+		return errs
+	}
+	subnets := []*net.IPNet{}
+	families := map[bool]bool{}
+	for _, s := range strings.Split(n.NodeSubnet, ",") {
+		_, cidr, err := net.ParseCIDR(s)
+		if err != nil {
+			errs = append(errs, errors.Errorf("invalid nodeSubnet: failed to parse cidr value: %q", s))
+			continue
+		}
+		if families[cidr.IP.To4() == nil] {
+			errs = append(errs, errors.Errorf("invalid nodeSubnet: expected at most one CIDR per IP family, got %q", n.NodeSubnet))
+		}
+		families[cidr.IP.To4() == nil] = true
+		for _, other := range []string{n.PodSubnet, n.ServiceSubnet} {
+			for _, o := range strings.Split(other, ",") {
+				if _, ocidr, err := net.ParseCIDR(o); err == nil && (ocidr.Contains(cidr.IP) || cidr.Contains(ocidr.IP)) {
+					errs = append(errs, errors.Errorf("invalid nodeSubnet: %s overlaps with %s", cidr, ocidr))
+				}
+			}
+		}
+		subnets = append(subnets, cidr)
+	}
+	if n.NodeGateway == "" {
+		return errs
+	}
+	gatewayFamilies := map[bool]bool{}
+	for _, g := range strings.Split(n.NodeGateway, ",") {
+		ip := net.ParseIP(g)
+		if ip == nil {
+			errs = append(errs, errors.Errorf("invalid nodeGateway: %q is not an IP address", g))
+			continue
+		}
+		if gatewayFamilies[ip.To4() == nil] {
+			errs = append(errs, errors.Errorf("invalid nodeGateway: expected at most one address per IP family, got %q", n.NodeGateway))
+		}
+		gatewayFamilies[ip.To4() == nil] = true
+		if !subnetsContain(subnets, ip) {
+			errs = append(errs, errors.Errorf("invalid nodeGateway: %s is not within nodeSubnet %q", ip, n.NodeSubnet))
+		}
+	}
+	return errs
+}
+
+func validateDNS(dns *DNS) []error {
+	errs := []error{}
+	if dns.NodesPerReplica < 0 {
+		errs = append(errs, errors.Errorf("invalid dns nodesPerReplica: %d", dns.NodesPerReplica))
+	}
+	if dns.MinReplicas < 1 {
+		errs = append(errs, errors.Errorf("invalid dns minReplicas: %d", dns.MinReplicas))
+	}
+	for _, q := range []struct{ name, value string }{
+		{"cpuRequest", dns.Resources.CPURequest},
+		{"memoryRequest", dns.Resources.MemoryRequest},
+		{"memoryLimit", dns.Resources.MemoryLimit},
+	} {
+		if q.value != "" && !validQuantityRE.MatchString(q.value) {
+			errs = append(errs, errors.Errorf("invalid dns resources %s: %q", q.name, q.value))
+		}
+	}
+	return errs
+}
+
+func validateTimeouts(t *ClusterTimeouts) []error {
+	errs := []error{}
+	for _, d := range []struct{ name, value string }{
+		{"imagePull", t.ImagePull},
+		{"nodeProvision", t.NodeProvision},
+		{"kubeadmInit", t.KubeadmInit},
+		{"join", t.Join},
+		{"cniReady", t.CNIReady},
+		{"devicePluginReady", t.DevicePluginReady},
+		{"ingressReady", t.IngressReady},
+		{"serviceLoadBalancerReady", t.ServiceLoadBalancerReady},
+		{"controlPlaneEndpointReady", t.ControlPlaneEndpointReady},
+	} {
+		if parsed, err := time.ParseDuration(d.value); err != nil || parsed < 0 {
+			errs = append(errs, errors.Errorf("invalid timeouts %s: %q", d.name, d.value))
+		}
+	}
+	return errs
+}
+
+func validateDevicePlugin(d *DevicePlugin) []error {
+	errs := []error{}
+	switch d.Type {
+	case "", NVIDIADevicePlugin:
+	default:
+		errs = append(errs, errors.Errorf("invalid devicePlugin type: %q, must be one of: %q", d.Type, NVIDIADevicePlugin))
+	}
+	if d.Image != "" && d.Type == "" {
+		errs = append(errs, errors.New("devicePlugin image requires a devicePlugin type"))
+	}
+	return errs
+}
+
+func validateIngress(i *Ingress) []error {
+	switch i.Controller {
+	case "", NginxIngress, ContourIngress, TraefikIngress:
+		return nil
+	}
+	return []error{errors.Errorf(
+		"invalid ingress controller: %q, must be one of: %q, %q, %q",
+		i.Controller, NginxIngress, ContourIngress, TraefikIngress,
+	)}
+}
+
+func validateAudit(a *Audit) []error {
+	errs := []error{}
+	if !a.Enabled && (a.Policy != "" || a.HostPath != "") {
+		errs = append(errs, errors.New("audit policy and hostPath require audit to be enabled"))
+	}
+	if a.HostPath != "" && !filepath.IsAbs(a.HostPath) {
+		errs = append(errs, errors.Errorf("invalid audit hostPath: %q must be an absolute path", a.HostPath))
+	}
+	return errs
+}
+
+func validateOIDC(o *OIDC) []error {
+	errs := []error{}
+	switch o.Provider {
+	case "":
+		if o.Image != "" {
+			errs = append(errs, errors.New("oidc image requires an oidc provider"))
+		}
+	case DexOIDCProvider:
+		// kind fills these in for the provider it runs
+		if o.IssuerURL != "" || o.CertificateAuthority != "" {
+			errs = append(errs, errors.Errorf("oidc issuerURL and certificateAuthority are set by kind for the %q provider", o.Provider))
+		}
+		return errs
+	default:
+		return append(errs, errors.Errorf("invalid oidc provider: %q, must be one of: %q", o.Provider, DexOIDCProvider))
+	}
+	// without a provider, the remaining fields configure an existing issuer
+	if o.IssuerURL == "" && o.ClientID == "" && o.UsernameClaim == "" && o.GroupsClaim == "" && o.CertificateAuthority == "" {
+		return errs
+	}
+	if u, err := url.Parse(o.IssuerURL); err != nil || u.Scheme != "https" || u.Host == "" {
+		errs = append(errs, errors.Errorf("invalid oidc issuerURL: %q must be an https URL", o.IssuerURL))
+	}
+	if o.ClientID == "" {
+		errs = append(errs, errors.New("oidc clientID is required with an issuerURL"))
+	}
+	if o.CertificateAuthority != "" {
+		if block, _ := pem.Decode([]byte(o.CertificateAuthority)); block == nil || block.Type != "CERTIFICATE" {
+			errs = append(errs, errors.New("invalid oidc certificateAuthority: must be a PEM encoded certificate"))
+		}
+	}
+	return errs
+}
+
+func validateProxyHelper(p *ProxyHelper) []error {
+	errs := []error{}
+	switch p.Auth {
+	case "", NTLMProxyAuth, NegotiateProxyAuth:
+	default:
+		errs = append(errs, errors.Errorf("invalid proxyHelper auth: %q, must be one of: %q, %q", p.Auth, NTLMProxyAuth, NegotiateProxyAuth))
+	}
+	if p.Image != "" && p.Auth == "" {
+		errs = append(errs, errors.New("proxyHelper image requires a proxyHelper auth"))
+	}
+	return errs
+}
+
+func validateDNSServer(d *DNSServer) []error {
+	errs := []error{}
+	if !d.Enabled && (len(d.Records) > 0 || d.Image != "") {
+		errs = append(errs, errors.New("dnsServer records and image require dnsServer enabled"))
+	}
+	for _, r := range d.Records {
+		if !validDomainRE.MatchString(strings.TrimPrefix(r.Name, "*.")) {
+			errs = append(errs, errors.Errorf("invalid dnsServer record name: %q is not a valid DNS name", r.Name))
+		}
+		if net.ParseIP(r.IP) == nil {
+			errs = append(errs, errors.Errorf("invalid dnsServer record %q ip: %q", r.Name, r.IP))
+		}
+	}
+	return errs
+}
+
+// validCNIVersionRE matches release versions, e.g. v3.27.3 or 1.15.5, the
+// v prefix is normalized for each CNI when it is installed
+var validCNIVersionRE = regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+$`)
+
+func validateCNI(n *Networking) []error {
+	errs := []error{}
+	switch n.CNI {
+	case KindnetCNI, CalicoCNI, CiliumCNI, FlannelCNI, NoCNI:
+	default:
+		errs = append(errs, errors.Errorf("invalid cni: %q, must be one of: %q, %q, %q, %q, %q", n.CNI, KindnetCNI, CalicoCNI, CiliumCNI, FlannelCNI, NoCNI))
+	}
+	// the third party manifests are only configured for IPv4 pod networks
+	switch n.CNI {
+	case CalicoCNI, CiliumCNI, FlannelCNI:
+		if n.IPFamily != IPv4Family {
+			errs = append(errs, errors.Errorf("cni %q requires ipFamily %q", n.CNI, IPv4Family))
+		}
+	}
+	if n.DisableDefaultCNI && n.CNI != NoCNI {
+		errs = append(errs, errors.Errorf("disableDefaultCNI conflicts with cni: %q", n.CNI))
+	}
+	if n.CNIVersion != "" {
+		switch n.CNI {
+		case CalicoCNI, CiliumCNI, FlannelCNI:
+			if !validCNIVersionRE.MatchString(n.CNIVersion) {
+				errs = append(errs, errors.Errorf("invalid cniVersion: %q, must be a release version like v1.2.3", n.CNIVersion))
+			}
+		default:
+			errs = append(errs, errors.Errorf("cniVersion requires cni to be one of: %q, %q, %q", CalicoCNI, CiliumCNI, FlannelCNI))
+		}
+	}
+	return errs
+}
+
+func validateServiceLoadBalancer(s *ServiceLoadBalancer) []error {
+	errs := []error{}
+	switch s.Type {
+	case "", MetalLBServiceLoadBalancer:
+	default:
+		errs = append(errs, errors.Errorf("invalid serviceLoadBalancer type: %q, must be one of: %q", s.Type, MetalLBServiceLoadBalancer))
+	}
+	if len(s.Addresses) > 0 && s.Type == "" {
+		errs = append(errs, errors.New("serviceLoadBalancer addresses require a serviceLoadBalancer type"))
+	}
+	for _, a := range s.Addresses {
+		if !validAddressRange(a) {
+			errs = append(errs, errors.Errorf("invalid serviceLoadBalancer address range %q, must be a CIDR or a first-last range", a))
+		}
+	}
+	return errs
+}
+
+// validAddressRange returns true if r is a CIDR or a range of two addresses
+// of the same family, with the first not after the last
+func validAddressRange(r string) bool {
+	if _, _, err := net.ParseCIDR(r); err == nil {
+		return true
+	}
+	parts := strings.Split(r, "-")
+	if len(parts) != 2 {
+		return false
+	}
+	first, last := net.ParseIP(strings.TrimSpace(parts[0])), net.ParseIP(strings.TrimSpace(parts[1]))
+	if first == nil || last == nil || (first.To4() == nil) != (last.To4() == nil) {
+		return false
+	}
+	if first.To4() != nil {
+		first, last = first.To4(), last.To4()
+	}
+	return bytes.Compare(first, last) <= 0
+}
+
+func validateLoadBalancerTuning(lb *LoadBalancer) []error {
+	errs := []error{}
+	for _, d := range []struct{ name, value string }{
+		{"timeouts.connect", lb.Timeouts.Connect},
+		{"timeouts.client", lb.Timeouts.Client},
+		{"timeouts.server", lb.Timeouts.Server},
+		{"healthCheck.interval", lb.HealthCheck.Interval},
+	} {
+		if parsed, err := time.ParseDuration(d.value); err != nil || parsed <= 0 {
+			errs = append(errs, errors.Errorf("invalid loadBalancer %s: %q", d.name, d.value))
+		}
+	}
+	if lb.MaxConnections < 1 {
+		errs = append(errs, errors.Errorf("invalid loadBalancer maxConnections: %d", lb.MaxConnections))
+	}
+	if lb.HealthCheck.Rise < 1 {
+		errs = append(errs, errors.Errorf("invalid loadBalancer healthCheck.rise: %d", lb.HealthCheck.Rise))
+	}
+	if lb.HealthCheck.Fall < 1 {
+		errs = append(errs, errors.Errorf("invalid loadBalancer healthCheck.fall: %d", lb.HealthCheck.Fall))
+	}
+	switch lb.SessionAffinity {
+	case NoSessionAffinity, ClientIPSessionAffinity:
+	default:
+		errs = append(errs, errors.Errorf("invalid loadBalancer sessionAffinity: %q", lb.SessionAffinity))
+	}
+	switch lb.Algorithm {
+	case RoundRobinAlgorithm, LeastConnAlgorithm, RandomAlgorithm:
+	default:
+		errs = append(errs, errors.Errorf("invalid loadBalancer algorithm: %q", lb.Algorithm))
+	}
+	// ClientIP affinity is implemented by balancing on the client address
+	if lb.SessionAffinity == ClientIPSessionAffinity && lb.Algorithm != RoundRobinAlgorithm {
+		errs = append(errs, errors.Errorf("loadBalancer algorithm %q conflicts with sessionAffinity %q", lb.Algorithm, lb.SessionAffinity))
+	}
+	return errs
+}
+
 func validatePortMappings(portMappings []PortMapping) error {
 	errMsg := "port mapping with same listen address, port and protocol already configured"
 
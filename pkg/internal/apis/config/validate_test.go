@@ -77,6 +77,624 @@ func TestClusterValidate(t *testing.T) {
 			}(),
 			ExpectErrors: 1,
 		},
+		{
+			Name: "custom clusterDomain",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Networking.ClusterDomain = "cluster-a.local"
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus clusterDomain",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Networking.ClusterDomain = "Cluster_A."
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "apiServerSocket",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Networking.APIServerSocket = "/tmp/kind/api.sock"
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "apiServerSocket directory",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Networking.APIServerSocket = "/tmp/kind/"
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "loadBalancer extraBackends",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Nodes = []Node{{Role: ControlPlaneRole}, {Role: ControlPlaneRole}, {Role: WorkerRole}}
+				c.LoadBalancer.ExtraBackends = []LoadBalancerBackend{
+					{Name: "webhooks", Port: 8443},
+					{Name: "ingress", Port: 9443, Role: WorkerRole, HostPort: 9443},
+				}
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "loadBalancer extraBackends without load balancer",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.LoadBalancer.ExtraBackends = []LoadBalancerBackend{{Name: "webhooks", Port: 8443}}
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "loadBalancer extraBackends with an always created load balancer",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.LoadBalancer.Always = true
+				c.LoadBalancer.Image = "registry.example.com/haproxy:v1"
+				c.LoadBalancer.ExtraBackends = []LoadBalancerBackend{{Name: "ingress", Port: 443, Role: WorkerRole}}
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus loadBalancer extraBackends",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Nodes = []Node{{Role: ControlPlaneRole}, {Role: ControlPlaneRole}}
+				c.LoadBalancer.ExtraBackends = []LoadBalancerBackend{
+					{Name: "kube-apiservers", Port: 8443},
+					{Name: "api", Port: 6443},
+					{Name: "other", Port: 8080, Role: "etcd"},
+				}
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 3,
+		},
+		{
+			Name: "loadBalancer tuning",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Nodes = []Node{{Role: ControlPlaneRole}, {Role: ControlPlaneRole}}
+				c.LoadBalancer.Timeouts.Server = "1m30s"
+				c.LoadBalancer.HealthCheck.Interval = "500ms"
+				c.LoadBalancer.HealthCheck.Fall = 1
+				c.LoadBalancer.SessionAffinity = ClientIPSessionAffinity
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "loadBalancer algorithm",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.LoadBalancer.Algorithm = LeastConnAlgorithm
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus loadBalancer algorithm",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.LoadBalancer.Algorithm = "first"
+				c.LoadBalancer.SessionAffinity = ClientIPSessionAffinity
+				return c
+			}(),
+			// invalid, and conflicts with ClientIP affinity
+			ExpectErrors: 2,
+		},
+		{
+			Name: "bogus loadBalancer tuning",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.LoadBalancer.Timeouts.Connect = "5"
+				c.LoadBalancer.Timeouts.Client = "-1s"
+				c.LoadBalancer.MaxConnections = -1
+				c.LoadBalancer.HealthCheck.Rise = -1
+				c.LoadBalancer.SessionAffinity = "Cookie"
+				return c
+			}(),
+			ExpectErrors: 5,
+		},
+		{
+			Name: "loadBalancer stats",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Nodes = []Node{{Role: ControlPlaneRole}, {Role: ControlPlaneRole}}
+				c.LoadBalancer.Stats = LoadBalancerStats{Port: 8404, HostPort: 8404}
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus loadBalancer stats",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.LoadBalancer.Stats = LoadBalancerStats{Port: 6443, HostPort: -2}
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 3,
+		},
+		{
+			Name: "phase timeouts",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Timeouts.KubeadmInit = "10m"
+				c.Timeouts.CNIReady = "1m30s"
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus phase timeouts",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Timeouts.ImagePull = "5"
+				c.Timeouts.Join = "-1s"
+				return c
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			Name: "dual stack node network with gateway and mtu",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Networking.NodeSubnet = "172.30.0.0/16,fc00:30::/64"
+				c.Networking.NodeGateway = "172.30.0.1"
+				c.Networking.NodeMTU = 1400
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus node network",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				// two IPv4 subnets, one overlapping the pod subnet, and a gateway outside them
+				c.Networking.NodeSubnet = "172.30.0.0/16,10.244.0.0/24"
+				c.Networking.NodeGateway = "172.31.0.1"
+				c.Networking.NodeMTU = 100
+				return c
+			}(),
+			ExpectErrors: 4,
+		},
+		{
+			Name: "node gateway without subnet",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Networking.NodeGateway = "172.30.0.1"
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "nvidia device plugin",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.DevicePlugin = DevicePlugin{Type: NVIDIADevicePlugin, Image: "registry.example.com/k8s-device-plugin:v0.15.0"}
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus device plugin",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.DevicePlugin = DevicePlugin{Type: "amd"}
+				c.Timeouts.DevicePluginReady = "soon"
+				return c
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			Name: "device plugin image without type",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.DevicePlugin.Image = "registry.example.com/k8s-device-plugin:v0.15.0"
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "ingress controller",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Ingress.Controller = ContourIngress
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus ingress controller",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Ingress.Controller = "haproxy"
+				c.Timeouts.IngressReady = "-1m"
+				return c
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			Name: "audit logs collected on the host",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Audit = Audit{Enabled: true, HostPath: "/tmp/kind-audit"}
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "audit hostPath relative and not enabled",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Audit = Audit{HostPath: "kind-audit"}
+				return c
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			Name: "oidc issuer",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.OIDC = OIDC{IssuerURL: "https://accounts.example.com", ClientID: "kind", GroupsClaim: "groups"}
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "oidc without client, over http, with a bogus CA",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.OIDC = OIDC{IssuerURL: "http://accounts.example.com", CertificateAuthority: "not a certificate"}
+				return c
+			}(),
+			ExpectErrors: 3,
+		},
+		{
+			Name: "dex oidc provider",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.OIDC.Provider = DexOIDCProvider
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "dex oidc provider with an issuer",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.OIDC = OIDC{Provider: DexOIDCProvider, IssuerURL: "https://accounts.example.com"}
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "bogus oidc provider",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.OIDC = OIDC{Provider: "keycloak"}
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "ntlm proxy helper",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.ProxyHelper = ProxyHelper{Auth: NTLMProxyAuth, Image: "registry.example.com/px:0.9.2"}
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus proxy helper",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.ProxyHelper = ProxyHelper{Auth: "basic"}
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "proxy helper image without auth",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.ProxyHelper.Image = "registry.example.com/px:0.9.2"
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "dns server with records",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.DNSServer = DNSServer{
+					Enabled: true,
+					Records: []DNSRecord{
+						{Name: "*.local.test", IP: "172.18.0.100"},
+						{Name: "metadata.google.internal", IP: "169.254.169.254"},
+						{Name: "v6.local.test", IP: "fd00::100"},
+					},
+				}
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "dns server records without enabled",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.DNSServer.Records = []DNSRecord{{Name: "local.test", IP: "172.18.0.100"}}
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "dns server bogus records",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.DNSServer = DNSServer{
+					Enabled: true,
+					Records: []DNSRecord{
+						{Name: "foo.*.test", IP: "172.18.0.100"},
+						{Name: "local.test", IP: "bogus"},
+					},
+				}
+				return c
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			Name: "metallb service load balancer",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.ServiceLoadBalancer = ServiceLoadBalancer{
+					Type:      MetalLBServiceLoadBalancer,
+					Addresses: []string{"172.18.255.200-172.18.255.250", "fc00:f853:ccd:e793::ff00/120"},
+				}
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus service load balancer",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.ServiceLoadBalancer = ServiceLoadBalancer{
+					Type:      "cloud",
+					Addresses: []string{"172.18.255.250-172.18.255.200", "172.18.255.200-fc00::1", "bogus"},
+				}
+				return c
+			}(),
+			ExpectErrors: 4,
+		},
+		{
+			Name: "service load balancer addresses without type",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.ServiceLoadBalancer.Addresses = []string{"172.18.255.200/29"}
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "calico cni with version",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Networking.CNI = CalicoCNI
+				c.Networking.CNIVersion = "v3.27.3"
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "disableDefaultCNI defaults cni to none",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Networking.DisableDefaultCNI = true
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "cni conflicts",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Networking.CNI = CiliumCNI
+				c.Networking.CNIVersion = "latest"
+				c.Networking.DisableDefaultCNI = true
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			Name: "flannel cni on ipv6",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Networking.CNI = FlannelCNI
+				c.Networking.IPFamily = IPv6Family
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "bogus cni, and version for kindnet",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Networking.CNI = "weave"
+				c.Networking.CNIVersion = "v2.8.1"
+				return c
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			Name: "dns scaling",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.DNS.NodesPerReplica = 16
+				c.DNS.Resources = DNSResources{CPURequest: "200m", MemoryRequest: "140Mi", MemoryLimit: "340Mi"}
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus dns",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.DNS.NodesPerReplica = -1
+				c.DNS.MinReplicas = 0
+				c.DNS.Resources.CPURequest = "lots"
+				return c
+			}(),
+			ExpectErrors: 3,
+		},
+		{
+			Name: "registry mirrors",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.RegistryMirrors = []RegistryMirror{
+					{Registry: "docker.io", Endpoints: []string{"http://kind-registry:5000", "https://mirror.gcr.io"}},
+					{Registry: "registry.example.com:5000", Endpoints: []string{"http://10.0.0.5:5000"}},
+					{Registry: "_default", Endpoints: []string{"https://cache.example.com"}},
+				}
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus registry mirrors",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.RegistryMirrors = []RegistryMirror{
+					{Registry: "docker.io", Endpoints: []string{"kind-registry:5000"}},
+					{Registry: "docker.io", Endpoints: []string{"https://mirror.gcr.io"}},
+					{Registry: "Registry.Example.com:99999"},
+				}
+				return c
+			}(),
+			ExpectErrors: 4,
+		},
+		{
+			Name: "bogus local registry port",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.LocalRegistry = LocalRegistry{Enabled: true, Port: 65536}
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "valid labels and annotations",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Labels = map[string]string{"pr": "1234", "example.com/owner": "alice"}
+				c.Annotations = map[string]string{"example.com/description": "preview environment for #1234"}
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "bogus labels",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.Labels = map[string]string{"-pr": "1234", "owner": "not valid"}
+				return c
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			Name: "bogus storageClass name",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.StorageClass.Name = "Fast SSD"
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "storageClass provisionerImage with disableDefault",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				SetDefaultsCluster(&c)
+				c.StorageClass.DisableDefault = true
+				c.StorageClass.ProvisionerImage = "registry.example.com/local-path-provisioner:dev"
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "CNI and storage disabled independently",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.StorageClass.DisableDefault = true
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
 		{
 			Name: "bogus apiServerPort",
 			Cluster: func() Cluster {
@@ -246,6 +864,64 @@ func TestClusterValidate(t *testing.T) {
 			}(),
 			ExpectErrors: 1,
 		},
+		{
+			Name: "duplicate node addresses",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Nodes = []Node{
+					{Role: ControlPlaneRole, IPv4Address: "172.18.0.10"},
+					{Role: WorkerRole, IPv4Address: "172.18.0.11", IPv6Address: "fc00:f853:ccd:e793::11"},
+					{Role: WorkerRole, IPv4Address: "172.18.0.10"},
+				}
+				SetDefaultsCluster(&c)
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
+		{
+			Name: "node addresses outside the node subnet",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Nodes = []Node{
+					{Role: ControlPlaneRole, IPv4Address: "172.30.0.10"},
+					{Role: WorkerRole, IPv4Address: "172.30.0.1"},
+					{Role: WorkerRole, IPv4Address: "172.18.0.10"},
+				}
+				SetDefaultsCluster(&c)
+				c.Networking.NodeSubnet = "172.30.0.0/16"
+				c.Networking.NodeGateway = "172.30.0.1"
+				return c
+			}(),
+			// the gateway address, and the address outside the subnet
+			ExpectErrors: 2,
+		},
+		{
+			Name: "ipv6 node address with an ipv4 node subnet",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Nodes = []Node{
+					{Role: ControlPlaneRole, IPv4Address: "172.30.0.10", IPv6Address: "fc00:f853:ccd:e793::10"},
+				}
+				SetDefaultsCluster(&c)
+				c.Networking.NodeSubnet = "172.30.0.0/16"
+				return c
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			Name: "ipv6 node address outside a dual stack node subnet",
+			Cluster: func() Cluster {
+				c := Cluster{}
+				c.Nodes = []Node{
+					{Role: ControlPlaneRole, IPv4Address: "172.30.0.10", IPv6Address: "fc00:f853:ccd:e793::10"},
+				}
+				SetDefaultsCluster(&c)
+				c.Networking.IPFamily = DualStackFamily
+				c.Networking.NodeSubnet = "172.30.0.0/16,fc00:30::/64"
+				return c
+			}(),
+			ExpectErrors: 1,
+		},
 		{
 			Name: "bogus node",
 			Cluster: func() Cluster {
@@ -401,6 +1077,193 @@ func TestNodeValidate(t *testing.T) {
 			}(),
 			ExpectErrors: 0,
 		},
+		{
+			TestName: "Valid HostAliases",
+			Node: func() Node {
+				cfg := newDefaultedNode(ControlPlaneRole)
+				cfg.HostAliases = []HostAlias{
+					{
+						IP:        "192.168.1.10",
+						Hostnames: []string{"registry.lab", "registry"},
+					},
+					{
+						IP:        "fd00::10",
+						Hostnames: []string{"registry.lab"},
+					},
+				}
+				return cfg
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			TestName: "Valid static addresses",
+			Node: func() Node {
+				cfg := newDefaultedNode(ControlPlaneRole)
+				cfg.IPv4Address = "172.18.0.10"
+				cfg.IPv6Address = "fc00:f853:ccd:e793::10"
+				return cfg
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			TestName: "Invalid static addresses",
+			Node: func() Node {
+				cfg := newDefaultedNode(ControlPlaneRole)
+				cfg.IPv4Address = "fc00:f853:ccd:e793::10"
+				cfg.IPv6Address = "kind-control-plane"
+				return cfg
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			TestName: "Valid extra networks",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.Networks = []string{"storage", "kind-mesh_2"}
+				return cfg
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			TestName: "Invalid extra networks",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.Networks = []string{"storage", "-bad", "storage"}
+				return cfg
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			TestName: "Valid IO limits",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.IOLimits = IOLimits{
+					DeviceReadBPS:   []string{"/dev/sda:50mb"},
+					DeviceWriteBPS:  []string{"/dev/sda:10485760"},
+					DeviceReadIOPS:  []string{"/dev/nvme0n1:2000"},
+					DeviceWriteIOPS: []string{"/dev/nvme0n1:1000"},
+					EgressRate:      "100mbit",
+				}
+				return cfg
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			TestName: "Invalid IO limits",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.IOLimits = IOLimits{
+					DeviceReadBPS:  []string{"sda:50mb"},
+					DeviceWriteBPS: []string{"/dev/sda"},
+					DeviceReadIOPS: []string{"/dev/sda:1k"},
+					EgressRate:     "fast",
+				}
+				return cfg
+			}(),
+			ExpectErrors: 4,
+		},
+		{
+			TestName: "Valid resources",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.Resources = NodeResources{CPUs: "0.5", Memory: "512m", PIDs: 4096}
+				return cfg
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			TestName: "Invalid resources",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.Resources = NodeResources{CPUs: "0", Memory: "2 gigs", PIDs: -1}
+				return cfg
+			}(),
+			ExpectErrors: 3,
+		},
+		{
+			TestName: "Valid logging",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.Logging = NodeLogging{KubeletVerbosity: 6, ContainerdLevel: "debug"}
+				return cfg
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			TestName: "Invalid logging",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.Logging = NodeLogging{KubeletVerbosity: 11, ContainerdLevel: "verbose"}
+				return cfg
+			}(),
+			ExpectErrors: 2,
+		},
+		{
+			TestName: "Valid runtime options",
+			Node: func() Node {
+				cfg := newDefaultedNode(ControlPlaneRole)
+				cfg.Devices = []string{"/dev/kvm", "/dev/fuse:rwm", "/dev/sda:/dev/xvda:r"}
+				cfg.Sysctls = map[string]string{"net.ipv4.ip_forward": "1", "kernel/shm_rmid_forced": "1"}
+				cfg.Tmpfs = []string{"/scratch", "/cache:size=64m,mode=1777"}
+				cfg.UserNS = "host"
+				cfg.KernelModules = []string{"sctp", "nf_conntrack", "ip_vs_rr"}
+				return cfg
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			TestName: "Invalid runtime options",
+			Node: func() Node {
+				cfg := newDefaultedNode(ControlPlaneRole)
+				cfg.Devices = []string{"dev/kvm", "/dev/fuse:rwx", "/dev/sda:/dev/xvda:r:extra"}
+				cfg.Sysctls = map[string]string{"net ipv4": "1"}
+				cfg.Tmpfs = []string{"scratch:size=1g"}
+				cfg.KernelModules = []string{"sctp; reboot"}
+				return cfg
+			}(),
+			ExpectErrors: 6,
+		},
+		{
+			TestName: "Invalid HostAliases",
+			Node: func() Node {
+				cfg := newDefaultedNode(ControlPlaneRole)
+				cfg.HostAliases = []HostAlias{
+					{
+						IP:        "registry.lab",
+						Hostnames: []string{"Not_A_Host"},
+					},
+					{
+						IP: "192.168.1.10",
+					},
+				}
+				return cfg
+			}(),
+			ExpectErrors: 3,
+		},
+		{
+			TestName: "Node registry mirrors",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.RegistryMirrors = []RegistryMirror{
+					{Registry: "registry.example.com", Auth: RegistryAuth{Username: "ci", Password: "secret"}},
+					{Registry: "docker.io", Endpoints: []string{"https://mirror.example.com"}, Auth: RegistryAuth{Username: "ci"}},
+				}
+				return cfg
+			}(),
+			ExpectErrors: 0,
+		},
+		{
+			TestName: "Invalid node registry mirrors",
+			Node: func() Node {
+				cfg := newDefaultedNode(WorkerRole)
+				cfg.RegistryMirrors = []RegistryMirror{
+					{Registry: "registry.example.com", Auth: RegistryAuth{Password: "secret"}},
+					{Registry: "docker.io"},
+				}
+				return cfg
+			}(),
+			ExpectErrors: 2,
+		},
 	}
 
 	for _, tc := range cases {
@@ -590,3 +1453,41 @@ func TestValidatePortMappings(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMemory(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Memory      string
+		Expected    int64
+		ExpectError bool
+	}{
+		{Memory: "1048576", Expected: 1 << 20},
+		{Memory: "512k", Expected: 512 << 10},
+		{Memory: "512m", Expected: 512 << 20},
+		{Memory: "2G", Expected: 2 << 30},
+		{Memory: "2gb", Expected: 2 << 30},
+		{Memory: "0", ExpectError: true},
+		{Memory: "1.5g", ExpectError: true},
+		{Memory: "2t", ExpectError: true},
+		{Memory: "99999999999999g", ExpectError: true},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Memory, func(t *testing.T) {
+			t.Parallel()
+			bytes, err := ParseMemory(tc.Memory)
+			if tc.ExpectError {
+				if err == nil {
+					t.Errorf("expected an error for %q", tc.Memory)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bytes != tc.Expected {
+				t.Errorf("expected %d bytes but got %d", tc.Expected, bytes)
+			}
+		})
+	}
+}
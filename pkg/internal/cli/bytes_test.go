@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"testing"
+)
+
+func TestFormatBytes(t *testing.T) {
+	t.Parallel()
+	cases := map[int64]string{
+		0:                       "0B",
+		1023:                    "1023B",
+		1536:                    "1.5KiB",
+		612 << 20:               "612.0MiB",
+		3 << 30:                 "3.0GiB",
+		(5 << 40) + (512 << 30): "5.5TiB",
+	}
+	for b, expected := range cases {
+		if actual := FormatBytes(b); actual != expected {
+			t.Errorf("FormatBytes(%d): expected %q but got %q", b, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kind/pkg/errors"
+	"sigs.k8s.io/kind/pkg/internal/env"
+)
+
+// ConfirmFlags are the flags of commands that ask for confirmation before
+// deleting things that cannot be recreated by running kind again
+type ConfirmFlags struct {
+	Yes            bool
+	NonInteractive bool
+}
+
+// AddFlags adds --yes and --non-interactive to fs
+func (c *ConfirmFlags) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(
+		&c.Yes,
+		"yes",
+		"y",
+		false,
+		"do not ask for confirmation",
+	)
+	fs.BoolVar(
+		&c.NonInteractive,
+		"non-interactive",
+		false,
+		"never prompt, fail instead if --yes is not set",
+	)
+}
+
+// Confirm asks whether to proceed with action, which reads like
+// "delete clusters foo, bar", on out, reading the answer from in
+//
+// It returns nil without asking if --yes is set or if in is not a terminal,
+// so scripts and CI keep working. Otherwise it is an error if
+// --non-interactive is set or if the answer is no
+func Confirm(in io.Reader, out io.Writer, flags ConfirmFlags, action string) error {
+	f, isFile := in.(*os.File)
+	return confirm(in, out, flags, action, isFile && env.IsTerminal(f))
+}
+
+func confirm(in io.Reader, out io.Writer, flags ConfirmFlags, action string, interactive bool) error {
+	if flags.Yes {
+		return nil
+	}
+	if flags.NonInteractive {
+		return errors.Errorf("refusing to %s without confirmation, re-run with --yes", action)
+	}
+	if !interactive {
+		return nil
+	}
+	fmt.Fprintf(out, "Are you sure you want to %s? [y/N]: ", action)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return errors.Wrap(err, "failed to read confirmation")
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return errors.Errorf("did not %s, not confirmed", action)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Name        string
+		Flags       ConfirmFlags
+		Interactive bool
+		Answer      string
+		Prompted    bool
+		ExpectError bool
+	}{
+		{
+			Name:  "yes without a terminal",
+			Flags: ConfirmFlags{Yes: true},
+		},
+		{
+			Name:        "yes wins over non-interactive",
+			Flags:       ConfirmFlags{Yes: true, NonInteractive: true},
+			Interactive: true,
+		},
+		{
+			Name: "no terminal",
+		},
+		{
+			Name:        "non-interactive without a terminal",
+			Flags:       ConfirmFlags{NonInteractive: true},
+			ExpectError: true,
+		},
+		{
+			Name:        "non-interactive terminal",
+			Flags:       ConfirmFlags{NonInteractive: true},
+			Interactive: true,
+			ExpectError: true,
+		},
+		{
+			Name:        "confirmed",
+			Interactive: true,
+			Answer:      "Y\n",
+			Prompted:    true,
+		},
+		{
+			Name:        "declined by default",
+			Interactive: true,
+			Answer:      "\n",
+			Prompted:    true,
+			ExpectError: true,
+		},
+		{
+			Name:        "end of input",
+			Interactive: true,
+			Prompted:    true,
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			err := confirm(strings.NewReader(tc.Answer), &out, tc.Flags, "delete clusters foo", tc.Interactive)
+			assert.ExpectError(t, tc.ExpectError, err)
+			prompt := ""
+			if tc.Prompted {
+				prompt = "Are you sure you want to delete clusters foo? [y/N]: "
+			}
+			assert.StringEqual(t, prompt, out.String())
+		})
+	}
+}
@@ -4,15 +4,34 @@ import (
 	"os"
 
 	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kind/pkg/errors"
 )
 
 // OverrideDefaultName conditionally allows overriding the default cluster name
 // by setting the KIND_CLUSTER_NAME environment variable
 // only if --name wasn't set explicitly
-func OverrideDefaultName(fs *pflag.FlagSet) {
+// The name AutoName is replaced with the name of the current workspace
+func OverrideDefaultName(fs *pflag.FlagSet) error {
+	_, err := OverrideDefaultNameWithWorkspace(fs)
+	return err
+}
+
+// OverrideDefaultNameWithWorkspace is OverrideDefaultName, returning the
+// workspace root if the name was derived from it, or the empty string
+func OverrideDefaultNameWithWorkspace(fs *pflag.FlagSet) (string, error) {
 	if !fs.Changed("name") {
 		if name := os.Getenv("KIND_CLUSTER_NAME"); name != "" {
 			_ = fs.Set("name", name)
 		}
 	}
+	if flag := fs.Lookup("name"); flag == nil || flag.Value.String() != AutoName {
+		return "", nil
+	}
+	root, err := Workspace()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to find the workspace for --name %s", AutoName)
+	}
+	_ = fs.Set("name", WorkspaceName(root))
+	return root, nil
 }
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kind/pkg/log"
+)
+
+// PrefixLogger wraps l to prefix every message with prefix, for telling
+// apart the output of concurrent operations, e.g. creating multiple clusters
+// Status objects for the returned logger log each step as a plain line
+// rather than sharing a spinner
+func PrefixLogger(l log.Logger, prefix string) log.Logger {
+	return &prefixLogger{logger: l, prefix: prefix}
+}
+
+type prefixLogger struct {
+	logger log.Logger
+	prefix string
+}
+
+func (p *prefixLogger) Warn(message string) {
+	p.logger.Warn(p.prefix + message)
+}
+
+func (p *prefixLogger) Warnf(format string, args ...interface{}) {
+	p.logger.Warn(p.prefix + fmt.Sprintf(format, args...))
+}
+
+func (p *prefixLogger) Error(message string) {
+	p.logger.Error(p.prefix + message)
+}
+
+func (p *prefixLogger) Errorf(format string, args ...interface{}) {
+	p.logger.Error(p.prefix + fmt.Sprintf(format, args...))
+}
+
+func (p *prefixLogger) V(level log.Level) log.InfoLogger {
+	return prefixInfoLogger{logger: p.logger.V(level), prefix: p.prefix}
+}
+
+type prefixInfoLogger struct {
+	logger log.InfoLogger
+	prefix string
+}
+
+func (p prefixInfoLogger) Enabled() bool {
+	return p.logger.Enabled()
+}
+
+func (p prefixInfoLogger) Info(message string) {
+	p.logger.Info(p.prefix + message)
+}
+
+func (p prefixInfoLogger) Infof(format string, args ...interface{}) {
+	p.logger.Info(p.prefix + fmt.Sprintf(format, args...))
+}
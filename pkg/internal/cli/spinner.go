@@ -55,8 +55,14 @@ type Spinner struct {
 	ticker  *time.Ticker // signals that it is time to write a frame
 	prefix  string
 	suffix  string
+	// additional status lines rendered below the spinner, see SetLines
+	lines []string
+	// number of additional lines currently drawn below the spinner
+	drawnLines int
 	// format string used to write a frame, depends on the host OS / terminal
 	frameFormat string
+	// whether additional lines can be drawn, depends on the host OS / terminal
+	multiLine bool
 }
 
 // spinner implements writer
@@ -66,11 +72,13 @@ var _ io.Writer = &Spinner{}
 // NOTE: w should be os.Stderr or similar, and it should be a Terminal
 func NewSpinner(w io.Writer) *Spinner {
 	frameFormat := "\x1b[?7l\r%s%s%s\x1b[?7h"
+	multiLine := true
 	// toggling wrapping seems to behave poorly on windows
 	// in general only the simplest escape codes behave well at the moment,
 	// and only in newer shells
 	if runtime.GOOS == "windows" {
 		frameFormat = "\r%s%s%s"
+		multiLine = false
 	}
 	return &Spinner{
 		stop:        make(chan struct{}, 1),
@@ -78,6 +86,7 @@ func NewSpinner(w io.Writer) *Spinner {
 		mu:          &sync.Mutex{},
 		writer:      w,
 		frameFormat: frameFormat,
+		multiLine:   multiLine,
 	}
 }
 
@@ -95,6 +104,15 @@ func (s *Spinner) SetSuffix(suffix string) {
 	s.suffix = suffix
 }
 
+// SetLines sets additional status lines to render below the spinner,
+// these are used to display concurrent phases
+// Lines are not rendered on terminals with limited escape code support
+func (s *Spinner) SetLines(lines []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lines = append([]string{}, lines...)
+}
+
 // Start starts the spinner running
 func (s *Spinner) Start() {
 	s.mu.Lock()
@@ -119,6 +137,7 @@ func (s *Spinner) Start() {
 						s.mu.Lock()
 						defer s.mu.Unlock()
 						s.ticker.Stop()         // free up the ticker
+						s.clearLines()          // erase any additional lines
 						s.running = false       // mark as stopped (it's fine to start now)
 						s.stopped <- struct{}{} // tell Stop() that we're done
 					}()
@@ -129,6 +148,7 @@ func (s *Spinner) Start() {
 						s.mu.Lock()
 						defer s.mu.Unlock()
 						fmt.Fprintf(s.writer, s.frameFormat, s.prefix, frame, s.suffix)
+						s.drawLines()
 					}()
 				}
 			}
@@ -164,5 +184,34 @@ func (s *Spinner) Write(p []byte) (n int, err error) {
 	if _, err := s.writer.Write([]byte("\r")); err != nil {
 		return 0, err
 	}
+	s.clearLines()
 	return s.writer.Write(p)
 }
+
+// drawLines draws the additional lines below the current frame, leaving the
+// cursor on the frame line, it must be called with mu held
+func (s *Spinner) drawLines() {
+	if !s.multiLine || (len(s.lines) == 0 && s.drawnLines == 0) {
+		return
+	}
+	// clear everything below the frame, then draw the current lines
+	fmt.Fprint(s.writer, "\x1b[J")
+	for _, line := range s.lines {
+		fmt.Fprintf(s.writer, "\n\x1b[?7l%s\x1b[?7h", line)
+	}
+	// move back up to the frame line
+	if len(s.lines) > 0 {
+		fmt.Fprintf(s.writer, "\x1b[%dA\r", len(s.lines))
+	}
+	s.drawnLines = len(s.lines)
+}
+
+// clearLines erases any additional lines drawn below the current frame,
+// it must be called with mu held
+func (s *Spinner) clearLines() {
+	if s.drawnLines == 0 {
+		return
+	}
+	fmt.Fprint(s.writer, "\r\x1b[J")
+	s.drawnLines = 0
+}
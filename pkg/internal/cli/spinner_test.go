@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestSpinnerDrawLines(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	s := NewSpinner(&buf)
+	s.multiLine = true
+
+	s.SetLines([]string{"   • kind-worker", "   • kind-worker2"})
+	s.drawLines()
+	assert.StringEqual(t, "\x1b[J\n\x1b[?7l   • kind-worker\x1b[?7h\n\x1b[?7l   • kind-worker2\x1b[?7h\x1b[2A\r", buf.String())
+
+	// fewer lines erase the ones no longer drawn
+	buf.Reset()
+	s.SetLines([]string{"   • kind-worker2"})
+	s.drawLines()
+	assert.StringEqual(t, "\x1b[J\n\x1b[?7l   • kind-worker2\x1b[?7h\x1b[1A\r", buf.String())
+
+	buf.Reset()
+	s.clearLines()
+	assert.StringEqual(t, "\r\x1b[J", buf.String())
+
+	// nothing is left to erase
+	buf.Reset()
+	s.SetLines(nil)
+	s.drawLines()
+	s.clearLines()
+	assert.StringEqual(t, "", buf.String())
+}
+
+func TestSpinnerDrawLinesSingleLine(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	s := NewSpinner(&buf)
+	s.multiLine = false
+	s.SetLines([]string{"   • kind-worker"})
+	s.drawLines()
+	s.clearLines()
+	assert.StringEqual(t, "", buf.String())
+}
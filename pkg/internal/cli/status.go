@@ -18,6 +18,7 @@ package cli
 
 import (
 	"fmt"
+	"sync"
 
 	"sigs.k8s.io/kind/pkg/log"
 )
@@ -31,6 +32,15 @@ type Status struct {
 	// for controlling coloring etc
 	successFormat string
 	failureFormat string
+	// phases are concurrent sub-steps of the current status, see StartPhase
+	phasesMu sync.Mutex
+	phases   []*Phase
+}
+
+// Phase is one of possibly many concurrently running sub-steps of a Status
+type Phase struct {
+	status *Status
+	name   string
 }
 
 // StatusForLogger returns a new status object for the logger l,
@@ -88,3 +98,66 @@ func (s *Status) End(success bool) {
 
 	s.status = ""
 }
+
+// StartPhase starts a new phase of the current status, phases may run
+// concurrently with each other. If attached to a terminal all running phases
+// are displayed below the spinner, otherwise they are logged sequentially
+func (s *Status) StartPhase(name string) *Phase {
+	p := &Phase{status: s, name: name}
+	s.phasesMu.Lock()
+	defer s.phasesMu.Unlock()
+	s.phases = append(s.phases, p)
+	if s.spinner != nil {
+		s.renderPhases()
+	} else {
+		s.logger.V(0).Infof("   • %s  ...\n", name)
+	}
+	return p
+}
+
+// End completes the phase, marking it as success or failure
+func (p *Phase) End(success bool) {
+	s := p.status
+	s.phasesMu.Lock()
+	defer s.phasesMu.Unlock()
+	found := false
+	for i := range s.phases {
+		if s.phases[i] == p {
+			s.phases = append(s.phases[:i], s.phases[i+1:]...)
+			found = true
+			break
+		}
+	}
+	// ending a phase twice is a no-op
+	if !found {
+		return
+	}
+	if s.spinner != nil {
+		s.renderPhases()
+	}
+	if success {
+		s.logger.V(0).Infof("  "+s.successFormat, p.name)
+	} else {
+		s.logger.V(0).Infof("  "+s.failureFormat, p.name)
+	}
+}
+
+// WithPhase wraps fn to run as a phase of the current status named name
+func (s *Status) WithPhase(name string, fn func() error) func() error {
+	return func() error {
+		p := s.StartPhase(name)
+		err := fn()
+		p.End(err == nil)
+		return err
+	}
+}
+
+// renderPhases updates the spinner with the running phases,
+// it must be called with phasesMu held
+func (s *Status) renderPhases() {
+	lines := make([]string, 0, len(s.phases))
+	for _, p := range s.phases {
+		lines = append(lines, fmt.Sprintf("   • %s", p.name))
+	}
+	s.spinner.SetLines(lines)
+}
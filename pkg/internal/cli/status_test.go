@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestStatusPhases(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	s := StatusForLogger(NewLogger(&buf, 0))
+	s.Start("Preparing nodes")
+	worker := s.StartPhase("kind-worker")
+	worker2 := s.StartPhase("kind-worker2")
+	// phases may end in any order, and ending one twice is a no-op
+	worker2.End(false)
+	worker.End(true)
+	worker.End(false)
+	s.End(true)
+	assert.StringEqual(t, ` • Preparing nodes  ...
+   • kind-worker  ...
+   • kind-worker2  ...
+   ✗ kind-worker2
+   ✓ kind-worker
+ ✓ Preparing nodes
+`, buf.String())
+}
+
+func TestStatusPhasesSpinnerLines(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	spinner := NewSpinner(&buf)
+	s := StatusForLogger(NewLogger(spinner, 0))
+	worker := s.StartPhase("kind-worker")
+	assert.DeepEqual(t, []string{"   • kind-worker"}, spinner.lines)
+	err := s.WithPhase("kind-worker2", func() error {
+		assert.DeepEqual(t, []string{"   • kind-worker", "   • kind-worker2"}, spinner.lines)
+		return nil
+	})()
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []string{"   • kind-worker"}, spinner.lines)
+	worker.End(true)
+	assert.DeepEqual(t, []string{}, spinner.lines)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/exec"
+)
+
+const (
+	// AutoName is the cluster name that is replaced with a name derived
+	// from the current workspace, see WorkspaceName
+	AutoName = "auto"
+	// WorkspaceAnnotation records the workspace a cluster named with
+	// AutoName was created from
+	WorkspaceAnnotation = "kind.x-k8s.io/workspace"
+
+	// maxWorkspaceNameLength leaves room for the node name suffixes
+	maxWorkspaceNameLength = 40
+)
+
+// Workspace returns the root of the current workspace, which is the git
+// repository containing the working directory, or the working directory
+func Workspace() (string, error) {
+	if lines, err := exec.OutputLines(exec.Command("git", "rev-parse", "--show-toplevel")); err == nil && len(lines) == 1 {
+		return filepath.Clean(lines[0]), nil
+	}
+	return os.Getwd()
+}
+
+var invalidNameCharsRE = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// WorkspaceName returns the cluster name for the workspace at root, which is
+// its directory name made a valid cluster name, e.g. My_App -> my-app
+func WorkspaceName(root string) string {
+	name := invalidNameCharsRE.ReplaceAllString(strings.ToLower(filepath.Base(root)), "-")
+	if len(name) > maxWorkspaceNameLength {
+		name = name[:maxWorkspaceNameLength]
+	}
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "kind"
+	}
+	return name
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestWorkspaceName(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Root     string
+		Expected string
+	}{
+		{Root: "/home/user/src/my-app", Expected: "my-app"},
+		{Root: "/home/user/src/My_App.v2", Expected: "my-app-v2"},
+		{Root: "/home/user/src/--api--", Expected: "api"},
+		{Root: "/", Expected: "kind"},
+		{Root: "/src/" + "a-very-long-project-name-that-keeps-going-on-and-on", Expected: "a-very-long-project-name-that-keeps-goin"},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Root, func(t *testing.T) {
+			t.Parallel()
+			assert.StringEqual(t, tc.Expected, WorkspaceName(tc.Root))
+		})
+	}
+}
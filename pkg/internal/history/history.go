@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package history implements a small local log of cluster operations,
+// stored as one JSON object per line
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// Entry is a single recorded cluster operation
+type Entry struct {
+	// Time is when the operation started
+	Time time.Time `json:"time"`
+	// User is the local user that ran the operation
+	User string `json:"user,omitempty"`
+	// Command is the kind command path, e.g. "create cluster"
+	Command string `json:"command"`
+	// Cluster is the cluster name the operation targeted, if any
+	Cluster string `json:"cluster,omitempty"`
+	// ConfigHash is the sha256 of the --config file, if any
+	ConfigHash string `json:"configHash,omitempty"`
+	// Duration is how long the operation took
+	Duration time.Duration `json:"duration"`
+	// Result is either "success" or "failure"
+	Result string `json:"result"`
+	// Error is the error message the operation failed with, if any
+	Error string `json:"error,omitempty"`
+}
+
+const (
+	// ResultSuccess is the Entry.Result of an operation that succeeded
+	ResultSuccess = "success"
+	// ResultFailure is the Entry.Result of an operation that failed
+	ResultFailure = "failure"
+)
+
+// DefaultPath returns the path of the history file, this is $KIND_HISTORY_FILE
+// if set, otherwise ~/.kind/history.jsonl
+func DefaultPath() (string, error) {
+	if path := os.Getenv("KIND_HISTORY_FILE"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to locate home directory")
+	}
+	return filepath.Join(home, ".kind", "history.jsonl"), nil
+}
+
+// Record appends entry to the history file at path, creating it if necessary
+func Record(path string, entry Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create history directory")
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode history entry")
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed to open history file")
+	}
+	// a single write of a short line is atomic with O_APPEND, so concurrent
+	// kind invocations will not interleave entries
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to write history entry")
+	}
+	return f.Close()
+}
+
+// Read returns all entries in the history file at path, oldest first
+// A missing file has no entries, malformed lines are skipped
+func Read(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return []Entry{}, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to open history file")
+	}
+	defer f.Close()
+	entries := []Entry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read history file")
+	}
+	return entries, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestRecordRead(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nested", "history.jsonl")
+
+	entries, err := Read(path)
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, []Entry{}, entries)
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	expected := []Entry{
+		{
+			Time:       start,
+			User:       "alice",
+			Command:    "create cluster",
+			Cluster:    "kind",
+			ConfigHash: "sha256:abc",
+			Duration:   42 * time.Second,
+			Result:     ResultSuccess,
+		},
+		{
+			Time:     start.Add(time.Hour),
+			Command:  "delete cluster",
+			Cluster:  "kind",
+			Duration: time.Second,
+			Result:   ResultFailure,
+			Error:    "boom",
+		},
+	}
+	for _, e := range expected {
+		assert.ExpectError(t, false, Record(path, e))
+	}
+
+	// malformed lines are skipped
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.ExpectError(t, false, err)
+	_, err = f.WriteString("not json\n")
+	assert.ExpectError(t, false, err)
+	assert.ExpectError(t, false, f.Close())
+
+	entries, err = Read(path)
+	assert.ExpectError(t, false, err)
+	assert.DeepEqual(t, expected, entries)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package labels implements validation of Kubernetes style labels and
+// simple equality based label selectors.
+//
+// This is a small subset of k8s.io/apimachinery/pkg/labels, which we avoid
+// importing to keep kind easy to embed in other projects.
+package labels
+
+import (
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+const nameFmt = `([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]`
+const prefixFmt = `[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*`
+
+var nameRE = regexp.MustCompile(`^` + nameFmt + `$`)
+var prefixRE = regexp.MustCompile(`^` + prefixFmt + `$`)
+
+// ValidateKey returns an error if key is not a valid label or annotation key
+// Keys are an optional DNS subdomain prefix and "/", followed by a name of at
+// most 63 alphanumeric, '-', '_' or '.' characters
+func ValidateKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i != -1 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if len(prefix) > 253 || !prefixRE.MatchString(prefix) {
+			return errors.Errorf("invalid key %q: prefix must be a DNS subdomain", key)
+		}
+	}
+	if len(name) > 63 || !nameRE.MatchString(name) {
+		return errors.Errorf("invalid key %q: name must be 63 characters or less and match `%s`", key, nameFmt)
+	}
+	return nil
+}
+
+// ValidateValue returns an error if value is not a valid label value
+func ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > 63 || !nameRE.MatchString(value) {
+		return errors.Errorf("invalid value %q: must be 63 characters or less and match `%s`", value, nameFmt)
+	}
+	return nil
+}
+
+// Selector matches a set of labels
+type Selector interface {
+	// Matches returns true if the labels match the selector
+	Matches(labels map[string]string) bool
+	// String returns the selector in the format it was parsed from
+	String() string
+}
+
+type operator string
+
+const (
+	equals       operator = "="
+	notEquals    operator = "!="
+	exists       operator = ""
+	doesNotExist operator = "!"
+)
+
+type requirement struct {
+	key   string
+	op    operator
+	value string
+}
+
+func (r requirement) matches(labels map[string]string) bool {
+	value, ok := labels[r.key]
+	switch r.op {
+	case equals:
+		return ok && value == r.value
+	case notEquals:
+		return !ok || value != r.value
+	case exists:
+		return ok
+	case doesNotExist:
+		return !ok
+	}
+	return false
+}
+
+func (r requirement) String() string {
+	if r.op == doesNotExist {
+		return "!" + r.key
+	}
+	return r.key + string(r.op) + r.value
+}
+
+type selector []requirement
+
+func (s selector) Matches(labels map[string]string) bool {
+	for _, r := range s {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s selector) String() string {
+	parts := make([]string, 0, len(s))
+	for _, r := range s {
+		parts = append(parts, r.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// Parse parses a comma separated list of requirements into a Selector
+// Supported requirements are `key=value`, `key==value`, `key!=value`,
+// `key` (exists) and `!key` (does not exist)
+// The empty selector matches everything
+func Parse(raw string) (Selector, error) {
+	s := selector{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		r := requirement{}
+		switch {
+		case strings.HasPrefix(part, "!"):
+			r.key, r.op = strings.TrimSpace(part[1:]), doesNotExist
+		case strings.Contains(part, "!="):
+			kv := strings.SplitN(part, "!=", 2)
+			r.key, r.op, r.value = strings.TrimSpace(kv[0]), notEquals, strings.TrimSpace(kv[1])
+		case strings.Contains(part, "="):
+			kv := strings.SplitN(part, "=", 2)
+			r.key, r.op, r.value = strings.TrimSpace(kv[0]), equals, strings.TrimSpace(strings.TrimPrefix(kv[1], "="))
+		default:
+			r.key, r.op = part, exists
+		}
+		if err := ValidateKey(r.key); err != nil {
+			return nil, errors.Wrapf(err, "invalid selector %q", raw)
+		}
+		if err := ValidateValue(r.value); err != nil {
+			return nil, errors.Wrapf(err, "invalid selector %q", raw)
+		}
+		s = append(s, r)
+	}
+	return s, nil
+}
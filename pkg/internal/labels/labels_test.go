@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package labels
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestValidateKey(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		Key         string
+		ExpectError bool
+	}{
+		{Key: "pr"},
+		{Key: "example.com/owner"},
+		{Key: "app.kubernetes.io/name"},
+		{Key: "", ExpectError: true},
+		{Key: "-pr", ExpectError: true},
+		{Key: "Example.com/pr", ExpectError: true},
+		{Key: "example.com/", ExpectError: true},
+		{Key: "this-name-is-way-too-long-to-be-a-valid-label-key-so-it-is-rejected", ExpectError: true},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Key, func(t *testing.T) {
+			t.Parallel()
+			assert.ExpectError(t, tc.ExpectError, ValidateKey(tc.Key))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	labels := map[string]string{
+		"pr":    "1234",
+		"owner": "alice",
+	}
+	cases := []struct {
+		Name        string
+		Selector    string
+		Matches     bool
+		ExpectError bool
+	}{
+		{
+			Name:     "empty",
+			Selector: "",
+			Matches:  true,
+		},
+		{
+			Name:     "equals",
+			Selector: "pr=1234",
+			Matches:  true,
+		},
+		{
+			Name:     "double equals",
+			Selector: "pr==1234",
+			Matches:  true,
+		},
+		{
+			Name:     "multiple",
+			Selector: "pr=1234, owner=alice",
+			Matches:  true,
+		},
+		{
+			Name:     "mismatch",
+			Selector: "pr=1234,owner=bob",
+			Matches:  false,
+		},
+		{
+			Name:     "not equals",
+			Selector: "owner!=bob",
+			Matches:  true,
+		},
+		{
+			Name:     "not equals missing key",
+			Selector: "team!=infra",
+			Matches:  true,
+		},
+		{
+			Name:     "exists",
+			Selector: "pr",
+			Matches:  true,
+		},
+		{
+			Name:     "does not exist",
+			Selector: "!pr",
+			Matches:  false,
+		},
+		{
+			Name:        "invalid value",
+			Selector:    "pr=not valid",
+			ExpectError: true,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			s, err := Parse(tc.Selector)
+			assert.ExpectError(t, tc.ExpectError, err)
+			if err != nil {
+				return
+			}
+			assert.BoolEqual(t, tc.Matches, s.Matches(labels))
+		})
+	}
+}
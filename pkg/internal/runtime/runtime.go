@@ -4,11 +4,36 @@ import (
 	"os"
 
 	"sigs.k8s.io/kind/pkg/cluster"
+	"sigs.k8s.io/kind/pkg/errors"
 	"sigs.k8s.io/kind/pkg/log"
 )
 
-// GetDefault selected the default runtime from the environment override
+// providerFlag is the value of the global --provider flag, if set
+var providerFlag string
+
+// SetProviderFlag records the value of the global --provider flag, which takes
+// precedence over the environment, "" and "auto" mean auto-detect
+func SetProviderFlag(p string) error {
+	if p != "" && p != "auto" && !knownProvider(p) {
+		return errors.Errorf("invalid --provider %q, must be one of: auto, docker, podman, nerdctl, finch, nerdctl.lima", p)
+	}
+	providerFlag = p
+	return nil
+}
+
+// GetDefault selected the default runtime from the --provider flag or the
+// environment overrides, in that order, nil means auto-detect
 func GetDefault(logger log.Logger) cluster.ProviderOption {
+	if providerFlag != "" {
+		return selected(logger, providerFlag, "--provider")
+	}
+	if p := os.Getenv("KIND_PROVIDER"); p != "" {
+		if p != "auto" && !knownProvider(p) {
+			logger.Warnf("ignoring unknown value %q for KIND_PROVIDER", p)
+			return nil
+		}
+		return selected(logger, p, "KIND_PROVIDER")
+	}
 	switch p := os.Getenv("KIND_EXPERIMENTAL_PROVIDER"); p {
 	case "":
 		return nil
@@ -26,3 +51,27 @@ func GetDefault(logger log.Logger) cluster.ProviderOption {
 		return nil
 	}
 }
+
+// selected returns the option for the known provider p, chosen by source
+func selected(logger log.Logger, p, source string) cluster.ProviderOption {
+	logger.V(1).Infof("using %s due to %s", p, source)
+	switch p {
+	case "podman":
+		return cluster.ProviderWithPodman()
+	case "docker":
+		return cluster.ProviderWithDocker()
+	case "nerdctl", "finch", "nerdctl.lima":
+		return cluster.ProviderWithNerdctl(p)
+	default:
+		// auto
+		return nil
+	}
+}
+
+func knownProvider(p string) bool {
+	switch p {
+	case "docker", "podman", "nerdctl", "finch", "nerdctl.lima":
+		return true
+	}
+	return false
+}
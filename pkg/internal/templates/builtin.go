@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+// BuiltinSource is the Source of built-in templates
+const BuiltinSource = "built-in"
+
+// builtin templates, these are parsed and validated in the unit tests
+var builtin = []Template{
+	{
+		Name:        "dual-stack",
+		Source:      BuiltinSource,
+		Description: "single node cluster with IPv4 and IPv6 networking",
+		Config: []byte(`kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+networking:
+  ipFamily: dual
+`),
+	},
+	{
+		Name:        "ha",
+		Source:      BuiltinSource,
+		Description: "three control-plane nodes behind a load balancer and two workers",
+		Config: []byte(`kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+nodes:
+- role: control-plane
+- role: control-plane
+- role: control-plane
+- role: worker
+- role: worker
+`),
+	},
+	{
+		Name:        "ingress",
+		Source:      BuiltinSource,
+		Description: "control-plane labeled ingress-ready=true with host ports 80 and 443 mapped",
+		Config: []byte(`kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+nodes:
+- role: control-plane
+  kubeadmConfigPatches:
+  - |
+    kind: InitConfiguration
+    nodeRegistration:
+      kubeletExtraArgs:
+        node-labels: "ingress-ready=true"
+  extraPortMappings:
+  - containerPort: 80
+    hostPort: 80
+    protocol: TCP
+  - containerPort: 443
+    hostPort: 443
+    protocol: TCP
+`),
+	},
+	{
+		Name:        "ipv6",
+		Source:      BuiltinSource,
+		Description: "single node cluster with IPv6 only networking",
+		Config: []byte(`kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+networking:
+  ipFamily: ipv6
+`),
+	},
+	{
+		Name:        "large-worker",
+		Source:      BuiltinSource,
+		Description: "control-plane and one worker allowing up to 250 pods",
+		Config: []byte(`kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+nodes:
+- role: control-plane
+- role: worker
+  kubeadmConfigPatches:
+  - |
+    kind: JoinConfiguration
+    nodeRegistration:
+      kubeletExtraArgs:
+        max-pods: "250"
+`),
+	},
+	{
+		Name:        "registry",
+		Source:      BuiltinSource,
+		Description: "containerd configured to read registry config from /etc/containerd/certs.d, see https://kind.sigs.k8s.io/docs/user/local-registry/",
+		Config: []byte(`kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+containerdConfigPatches:
+- |-
+  [plugins."io.containerd.grpc.v1.cri".registry]
+    config_path = "/etc/containerd/certs.d"
+`),
+	},
+}
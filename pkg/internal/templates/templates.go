@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package templates implements named cluster configuration templates, both
+// built-in and from user template directories
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kind/pkg/errors"
+)
+
+// Extension is the file extension of user templates
+const Extension = ".yaml"
+
+// Template is a named cluster configuration
+type Template struct {
+	// Name is used to select the template, e.g. --template=ha
+	Name string
+	// Source is "built-in" or the path to the user template file
+	Source string
+	// Description is a short summary, only set for built-in templates
+	Description string
+	// Config is the raw v1alpha4 cluster config
+	Config []byte
+}
+
+// Dirs returns the user template directories, in order of precedence
+// These are the entries of $KIND_TEMPLATE_PATH, followed by the kind
+// templates directory in the user config directory
+func Dirs() []string {
+	dirs := filepath.SplitList(os.Getenv("KIND_TEMPLATE_PATH"))
+	if configDir, err := os.UserConfigDir(); err == nil {
+		dirs = append(dirs, filepath.Join(configDir, "kind", "templates"))
+	}
+	return dirs
+}
+
+// Get returns the template named name, user templates in dirs take precedence
+// over built-in templates of the same name
+func Get(name string, dirs []string) (*Template, error) {
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name+Extension)
+		raw, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to read template %q", path)
+		}
+		return &Template{Name: name, Source: path, Config: raw}, nil
+	}
+	for _, t := range builtin {
+		if t.Name == name {
+			t := t
+			return &t, nil
+		}
+	}
+	return nil, errors.Errorf("unknown template %q, see `kind get templates`", name)
+}
+
+// List returns all templates available, sorted by name
+// User templates in dirs shadow templates of the same name in later
+// dirs and built-in templates
+func List(dirs []string) ([]Template, error) {
+	byName := map[string]Template{}
+	for _, t := range builtin {
+		byName[t.Name] = t
+	}
+	// visit dirs in reverse so earlier dirs take precedence
+	for i := len(dirs) - 1; i >= 0; i-- {
+		entries, err := os.ReadDir(dirs[i])
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to list templates in %q", dirs[i])
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), Extension) {
+				continue
+			}
+			name := strings.TrimSuffix(entry.Name(), Extension)
+			byName[name] = Template{Name: name, Source: filepath.Join(dirs[i], entry.Name())}
+		}
+	}
+	templates := make([]Template, 0, len(byName))
+	for _, t := range byName {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kind/pkg/internal/apis/config"
+	"sigs.k8s.io/kind/pkg/internal/apis/config/encoding"
+	"sigs.k8s.io/kind/pkg/internal/assert"
+)
+
+func TestBuiltinTemplatesAreValid(t *testing.T) {
+	t.Parallel()
+	for _, tc := range builtin {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := encoding.Parse(tc.Config)
+			if err != nil {
+				t.Fatalf("failed to parse template: %v", err)
+			}
+			// the name is defaulted during cluster creation
+			config.SetDefaultsCluster(cfg)
+			if err := cfg.Validate(); err != nil {
+				t.Fatalf("invalid template: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetAndList(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ha.yaml"), []byte("kind: Cluster\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "mine.yaml"), []byte("kind: Cluster\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a template\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	dirs := []string{filepath.Join(dir, "missing"), dir}
+
+	// user templates shadow built-in templates
+	ha, err := Get("ha", dirs)
+	assert.ExpectError(t, false, err)
+	assert.StringEqual(t, filepath.Join(dir, "ha.yaml"), ha.Source)
+
+	ipv6, err := Get("ipv6", dirs)
+	assert.ExpectError(t, false, err)
+	assert.StringEqual(t, BuiltinSource, ipv6.Source)
+
+	_, err = Get("missing", dirs)
+	assert.ExpectError(t, true, err)
+
+	templates, err := List(dirs)
+	assert.ExpectError(t, false, err)
+	names := []string{}
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+	assert.DeepEqual(t, []string{"dual-stack", "ha", "ingress", "ipv6", "large-worker", "mine", "registry"}, names)
+}
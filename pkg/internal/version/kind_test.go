@@ -135,8 +135,8 @@ func TestVersion(t *testing.T) {
 			defer func() {
 				versionPreRelease = versionPreReleaseBackup
 			}()
-			if got := Version(); got != tt.want {
-				t.Errorf("Version() = %v, want %v", got, tt.want)
+			if got := KindVersion(); got != tt.want {
+				t.Errorf("KindVersion() = %v, want %v", got, tt.want)
 			}
 		})
 	}